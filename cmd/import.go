@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/manager"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	importYes              bool
+	importQualityProfileID int32
+)
+
+// importCmd imports files from a directory into the library by hand
+var importCmd = &cobra.Command{
+	Use:   "import <dir>",
+	Short: "Import files from a directory into the library",
+	Long: `Scan a directory for video files and propose the movie or episode each one is.
+
+Each match is confirmed before it's imported. Answer y or press enter to import the file as proposed, n to skip it,
+"movie <tmdb id>" to import it as another movie or "episode <series id> <season> <episode>..." to import it as other
+episodes of a library series. Use --yes to import every proposed match without asking.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.Get()
+		ctx := logger.WithCtx(cmd.Context(), log)
+
+		cfg, err := config.New(viper.GetViper())
+		if err != nil {
+			log.Fatal("failed to read configurations", zap.Error(err))
+		}
+
+		m, err := newMediaManager(ctx, cfg)
+		if err != nil {
+			log.Fatal("failed to create media manager", zap.Error(err))
+		}
+
+		files, err := m.ScanImport(ctx, args[0])
+		if err != nil {
+			log.Fatal("failed to scan directory", zap.Error(err))
+		}
+
+		in := bufio.NewScanner(cmd.InOrStdin())
+		var confirmed []manager.ImportFile
+		for _, f := range files {
+			fmt.Printf("%s\n  %s\n", f.Path, describeImportMatch(f))
+			if confirmImport(in, cmd.OutOrStdout(), f) {
+				confirmed = append(confirmed, *f)
+			}
+		}
+
+		if len(confirmed) == 0 {
+			fmt.Println("nothing to import")
+			return
+		}
+
+		imported, err := m.ImportFiles(ctx, manager.ImportRequest{Files: confirmed, QualityProfileID: importQualityProfileID})
+		if err != nil {
+			log.Fatal("failed to import files", zap.Error(err))
+		}
+
+		failed := 0
+		for _, f := range imported {
+			if f.Error != "" {
+				failed++
+				fmt.Printf("failed %s: %s\n", f.Path, f.Error)
+				continue
+			}
+			fmt.Printf("imported %s -> %s\n", f.Path, f.To)
+		}
+		fmt.Printf("imported %d files, %d failed\n", len(imported)-failed, failed)
+	},
+}
+
+// describeImportMatch is the movie or episodes a file is matched to, or why it isn't matched
+func describeImportMatch(f *manager.ImportFile) string {
+	switch f.MediaType {
+	case "movie":
+		if f.Year != nil {
+			return fmt.Sprintf("movie: %s (%d) [tmdb %d]", f.Title, *f.Year, f.TMDBID)
+		}
+		return fmt.Sprintf("movie: %s [tmdb %d]", f.Title, f.TMDBID)
+	case "episode":
+		episodes := make([]string, 0, len(f.EpisodeNumbers))
+		for _, n := range f.EpisodeNumbers {
+			episodes = append(episodes, fmt.Sprintf("E%02d", n))
+		}
+		return fmt.Sprintf("episode: %s S%02d%s [series %d]", f.Title, f.SeasonNumber, strings.Join(episodes, ""), f.SeriesID)
+	default:
+		return fmt.Sprintf("no match: %s", f.Error)
+	}
+}
+
+// confirmImport asks whether to import the file until it gets an answer it understands. An override changes the
+// file's match. Without --yes, files are skipped once there are no more answers.
+func confirmImport(in *bufio.Scanner, out io.Writer, f *manager.ImportFile) bool {
+	if importYes {
+		return f.MediaType != ""
+	}
+
+	for {
+		fmt.Fprint(out, "  import? [Y/n, movie <tmdb id>, episode <series id> <season> <episode>...]: ")
+		if !in.Scan() {
+			fmt.Fprintln(out)
+			return false
+		}
+
+		ok, err := parseImportAnswer(in.Text(), f)
+		if err != nil {
+			fmt.Fprintf(out, "  %s\n", err)
+			continue
+		}
+		return ok
+	}
+}
+
+// parseImportAnswer applies an answer to the file's match and reports whether to import it
+func parseImportAnswer(answer string, f *manager.ImportFile) (bool, error) {
+	fields := strings.Fields(strings.ToLower(answer))
+	if len(fields) == 0 {
+		return f.MediaType != "", nil
+	}
+
+	switch fields[0] {
+	case "y", "yes":
+		if f.MediaType == "" {
+			return false, fmt.Errorf("the file isn't matched, give the movie or episode to import it as")
+		}
+		return true, nil
+	case "n", "no":
+		return false, nil
+	case "movie":
+		if len(fields) != 2 {
+			return false, fmt.Errorf("usage: movie <tmdb id>")
+		}
+		id, err := strconv.Atoi(fields[1])
+		if err != nil || id <= 0 {
+			return false, fmt.Errorf("invalid tmdb id %q", fields[1])
+		}
+
+		*f = manager.ImportFile{Path: f.Path, Size: f.Size, MediaType: "movie", TMDBID: id}
+		return true, nil
+	case "episode":
+		if len(fields) < 4 {
+			return false, fmt.Errorf("usage: episode <series id> <season> <episode>...")
+		}
+
+		numbers := make([]int32, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			n, err := strconv.ParseInt(field, 10, 32)
+			if err != nil || n < 0 {
+				return false, fmt.Errorf("invalid number %q", field)
+			}
+			numbers = append(numbers, int32(n))
+		}
+
+		*f = manager.ImportFile{
+			Path:           f.Path,
+			Size:           f.Size,
+			MediaType:      "episode",
+			SeriesID:       numbers[0],
+			SeasonNumber:   numbers[1],
+			EpisodeNumbers: numbers[2:],
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown answer %q", answer)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().BoolVarP(&importYes, "yes", "y", false, "Import every proposed match without asking")
+	importCmd.Flags().Int32Var(&importQualityProfileID, "quality-profile", 0, "Quality profile for movies that aren't in the library yet")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/download"
+	"github.com/kasuboski/mediaz/pkg/indexer"
+	mio "github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/manager"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite"
+	"github.com/kasuboski/mediaz/pkg/tmdb"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// libraryCmd groups commands that manage the media libraries
+var libraryCmd = &cobra.Command{
+	Use:   "library",
+	Short: "Manage the media libraries",
+}
+
+var organizeApply bool
+
+// libraryOrganizeCmd moves library files to match the naming formats
+var libraryOrganizeCmd = &cobra.Command{
+	Use:   "organize",
+	Short: "Rename library files to match the naming formats",
+	Long: `Compute where every tracked movie and episode file belongs according to the configured naming formats.
+
+By default only the moves are listed. Use --apply to move the files and update their paths.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.Get()
+		ctx := logger.WithCtx(cmd.Context(), log)
+
+		cfg, err := config.New(viper.GetViper())
+		if err != nil {
+			log.Fatal("failed to read configurations", zap.Error(err))
+		}
+
+		m, err := newMediaManager(ctx, cfg)
+		if err != nil {
+			log.Fatal("failed to create media manager", zap.Error(err))
+		}
+
+		result, err := m.OrganizeLibrary(ctx, !organizeApply)
+		if err != nil {
+			log.Fatal("failed to organize library", zap.Error(err))
+		}
+
+		failed := 0
+		for _, r := range result.Renames {
+			fmt.Printf("%s: %s -> %s\n", r.MediaType, r.From, r.To)
+			if r.Error != "" {
+				failed++
+				fmt.Printf("  error: %s\n", r.Error)
+			}
+		}
+
+		switch {
+		case len(result.Renames) == 0:
+			fmt.Println("library is already organized")
+		case result.DryRun:
+			fmt.Printf("%d files would be moved, run with --apply to move them\n", len(result.Renames))
+		default:
+			fmt.Printf("moved %d files, %d failed\n", len(result.Renames)-failed, failed)
+		}
+	},
+}
+
+// newMediaManager creates a media manager for the configured storage and libraries
+func newMediaManager(ctx context.Context, cfg config.Config) (manager.MediaManager, error) {
+	tmdbClient, err := tmdb.New(cfg.TMDB.URI, cfg.TMDB.APIKey, tmdb.WithHTTPClient(newTMDBHTTPClient(cfg.TMDB)))
+	if err != nil {
+		return manager.MediaManager{}, fmt.Errorf("failed to create tmdb client: %w", err)
+	}
+
+	store, err := sqlite.New(ctx, cfg.Storage.FilePath)
+	if err != nil {
+		return manager.MediaManager{}, fmt.Errorf("failed to create storage connection: %w", err)
+	}
+
+	schemas, err := storage.GetSchemas()
+	if err != nil {
+		return manager.MediaManager{}, err
+	}
+
+	err = store.Init(ctx, schemas...)
+	if err != nil {
+		return manager.MediaManager{}, fmt.Errorf("failed to init database: %w", err)
+	}
+
+	lib := library.New(
+		library.FileSystem{
+			Path: cfg.Library.MovieDir,
+			FS:   os.DirFS(cfg.Library.MovieDir),
+		},
+		library.FileSystem{
+			Path: cfg.Library.TVDir,
+			FS:   os.DirFS(cfg.Library.TVDir),
+		},
+		&mio.MediaFileSystem{},
+		cfg.Library.UseHardlinks,
+		library.WithExtraFileExtensions(cfg.Library.ExtraFileExtensions),
+		library.WithMinimumFreeSpace(cfg.Library.MinimumFreeSpace<<20),
+	)
+
+	factory := download.NewDownloadClientFactory(cfg.Library.DownloadMountDir)
+	return manager.New(tmdbClient, indexer.NewIndexerSourceFactory(), lib, store, factory, cfg.Manager, cfg), nil
+}
+
+func init() {
+	rootCmd.AddCommand(libraryCmd)
+	libraryCmd.AddCommand(libraryOrganizeCmd)
+
+	libraryOrganizeCmd.Flags().BoolVar(&organizeApply, "apply", false, "Move the files instead of only listing the moves")
+}
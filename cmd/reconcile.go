@@ -83,6 +83,8 @@ var reconcileMoviesCmd = &cobra.Command{
 			},
 			mediaFileSystem,
 			cfg.Library.UseHardlinks,
+			library.WithExtraFileExtensions(cfg.Library.ExtraFileExtensions),
+			library.WithMinimumFreeSpace(cfg.Library.MinimumFreeSpace<<20),
 		)
 
 		// Create MediaManager
@@ -154,6 +156,8 @@ var reconcileSeriesCmd = &cobra.Command{
 			},
 			mediaFileSystem,
 			cfg.Library.UseHardlinks,
+			library.WithExtraFileExtensions(cfg.Library.ExtraFileExtensions),
+			library.WithMinimumFreeSpace(cfg.Library.MinimumFreeSpace<<20),
 		)
 
 		// Create MediaManager
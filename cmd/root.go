@@ -7,6 +7,7 @@ import (
 
 	"github.com/kasuboski/mediaz/config"
 	mhttp "github.com/kasuboski/mediaz/pkg/http"
+	"github.com/kasuboski/mediaz/pkg/library"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -73,6 +74,16 @@ func initConfig() {
 	viper.SetDefault("library.tv", "")
 	viper.SetDefault("library.movie", "")
 	viper.SetDefault("library.useHardlinks", true)
+	viper.SetDefault("library.recycleBin", "")
+	viper.SetDefault("library.recycleBinRetentionDays", 7)
+	viper.SetDefault("library.movieFolderFormat", library.DefaultMovieFolderFormat)
+	viper.SetDefault("library.movieFileFormat", library.DefaultMovieFileFormat)
+	viper.SetDefault("library.seriesFolderFormat", library.DefaultSeriesFolderFormat)
+	viper.SetDefault("library.seasonFolderFormat", library.DefaultSeasonFolderFormat)
+	viper.SetDefault("library.episodeFileFormat", library.DefaultEpisodeFileFormat)
+	viper.SetDefault("library.extraFileExtensions", library.DefaultExtraFileExtensions)
+	viper.SetDefault("library.extractDir", "")
+	viper.SetDefault("library.minimumFreeSpace", 100)
 
 	viper.SetDefault("storage.filePath", "mediaz.sqlite")
 	viper.SetDefault("storage.schemas", []string{"./pkg/storage/sqlite/schema/schema.sql"})
@@ -85,7 +96,15 @@ func initConfig() {
 	viper.SetDefault("manager.jobs.seriesReconcile", defaultReconcileJobInterval)
 
 	viper.SetDefault("manager.jobs.indexerSync", "1h")
+	viper.SetDefault("manager.jobs.recycleBinCleanup", "24h")
+	viper.SetDefault("manager.jobs.upgradeSearch", "12h")
 
 	viper.SetDefault("manager.jobs.jobScheduleInterval", "10s")
 	viper.SetDefault("manager.jobs.minJobsToKeep", 10)
+
+	viper.SetDefault("manager.upgradePropers", false)
+	viper.SetDefault("manager.releases.minimumSeeders", 1)
+	viper.SetDefault("manager.releases.maximumAge", 0)
+	viper.SetDefault("manager.releases.retentionDays", 0)
+	viper.SetDefault("manager.import.minimumSizePerMinute", 2)
 }
@@ -66,6 +66,8 @@ var serveCmd = &cobra.Command{
 			},
 			&mio.MediaFileSystem{},
 			cfg.Library.UseHardlinks,
+			library.WithExtraFileExtensions(cfg.Library.ExtraFileExtensions),
+			library.WithMinimumFreeSpace(cfg.Library.MinimumFreeSpace<<20),
 		)
 
 		factory := download.NewDownloadClientFactory(cfg.Library.DownloadMountDir)
@@ -32,6 +32,25 @@ type Library struct {
 	TVDir            string `json:"tv" yaml:"tv" mapstructure:"tv"`
 	DownloadMountDir string `json:"downloadMountDir" yaml:"downloadMountDir" mapstructure:"downloadMountDir"`
 	UseHardlinks     bool   `json:"useHardlinks" yaml:"useHardlinks" mapstructure:"useHardlinks"`
+	// RecycleBin is where deleted files and files replaced by an upgrade are moved instead of being deleted. Files are
+	// deleted if it's empty.
+	RecycleBin string `json:"recycleBin" yaml:"recycleBin" mapstructure:"recycleBin"`
+	// RecycleBinRetentionDays purges files from the recycle bin this many days after they were moved there. Files are
+	// kept until they're removed by hand if it's 0.
+	RecycleBinRetentionDays int `json:"recycleBinRetentionDays" yaml:"recycleBinRetentionDays" mapstructure:"recycleBinRetentionDays"`
+	// Naming formats for imported files, see library.FormatName for the tokens. Empty formats use the library defaults.
+	MovieFolderFormat  string `json:"movieFolderFormat" yaml:"movieFolderFormat" mapstructure:"movieFolderFormat"`
+	MovieFileFormat    string `json:"movieFileFormat" yaml:"movieFileFormat" mapstructure:"movieFileFormat"`
+	SeriesFolderFormat string `json:"seriesFolderFormat" yaml:"seriesFolderFormat" mapstructure:"seriesFolderFormat"`
+	SeasonFolderFormat string `json:"seasonFolderFormat" yaml:"seasonFolderFormat" mapstructure:"seasonFolderFormat"`
+	EpisodeFileFormat  string `json:"episodeFileFormat" yaml:"episodeFileFormat" mapstructure:"episodeFileFormat"`
+	// ExtraFileExtensions are the companion files, e.g. subtitles, imported along with the video file they're named after
+	ExtraFileExtensions []string `json:"extraFileExtensions" yaml:"extraFileExtensions" mapstructure:"extraFileExtensions"`
+	// ExtractDir is where archives in completed downloads are extracted to before they're imported. Archives are
+	// extracted next to themselves if it's empty.
+	ExtractDir string `json:"extractDir" yaml:"extractDir" mapstructure:"extractDir"`
+	// MinimumFreeSpace is the MB a grab or an import has to leave free on the download or library file system
+	MinimumFreeSpace int64 `json:"minimumFreeSpace" yaml:"minimumFreeSpace" mapstructure:"minimumFreeSpace"`
 }
 
 // Storage configuration is assumed to be for sqlite database only currently
@@ -44,6 +63,58 @@ type Storage struct {
 // Manager houses configuration related to the manager and reconcillation
 type Manager struct {
 	Jobs Jobs `json:"jobs" yaml:"jobs" mapstructure:"jobs"`
+	// UpgradePropers grabs a PROPER or REPACK of the quality already downloaded and replaces the file when it is imported
+	UpgradePropers bool `json:"upgradePropers" yaml:"upgradePropers" mapstructure:"upgradePropers"`
+	// Releases rejects releases that are unlikely to finish downloading
+	Releases ReleaseFilters `json:"releases" yaml:"releases" mapstructure:"releases"`
+	// DelayProfiles wait before grabbing a release in case a better one shows up. The first profile that applies to a
+	// quality profile is used.
+	DelayProfiles []DelayProfile `json:"delayProfiles" yaml:"delayProfiles" mapstructure:"delayProfiles"`
+	// Import decides which of a completed download's files are imported
+	Import ImportFilters `json:"import" yaml:"import" mapstructure:"import"`
+}
+
+// ImportFilters skip the files of a completed download that aren't the media. Samples, trailers and featurettes are
+// always skipped. A zero value disables the filter.
+type ImportFilters struct {
+	// MinimumSizePerMinute skips video files smaller than this many MB per minute of the movie's or episode's runtime
+	MinimumSizePerMinute float64 `json:"minimumSizePerMinute" yaml:"minimumSizePerMinute" mapstructure:"minimumSizePerMinute"`
+}
+
+// DelayProfile is how long to wait after the first acceptable release for a movie, episode or season is found before
+// grabbing the best one
+type DelayProfile struct {
+	// QualityProfiles are the names of the quality profiles the delay applies to. Empty applies to all of them.
+	QualityProfiles []string `json:"qualityProfiles" yaml:"qualityProfiles" mapstructure:"qualityProfiles"`
+	// Usenet and Torrent are the delays for releases of each protocol
+	Usenet  time.Duration `json:"usenet" yaml:"usenet" mapstructure:"usenet"`
+	Torrent time.Duration `json:"torrent" yaml:"torrent" mapstructure:"torrent"`
+	// PreferredProtocol, usenet or torrent, ranks releases of the protocol above otherwise equal releases of the other
+	PreferredProtocol string `json:"preferredProtocol" yaml:"preferredProtocol" mapstructure:"preferredProtocol"`
+	// BypassIfCutoffMet grabs a release that meets the quality profile's cutoff without waiting
+	BypassIfCutoffMet bool `json:"bypassIfCutoffMet" yaml:"bypassIfCutoffMet" mapstructure:"bypassIfCutoffMet"`
+}
+
+// ReleaseFilters apply to releases from every indexer. A zero value disables the filter.
+type ReleaseFilters struct {
+	// MinimumSeeders rejects torrents with fewer seeders
+	MinimumSeeders int32 `json:"minimumSeeders" yaml:"minimumSeeders" mapstructure:"minimumSeeders"`
+	// MaximumAge rejects releases published longer ago
+	MaximumAge time.Duration `json:"maximumAge" yaml:"maximumAge" mapstructure:"maximumAge"`
+	// RetentionDays is the usenet provider's retention. Older NZBs are rejected since they've likely expired.
+	RetentionDays int32 `json:"retentionDays" yaml:"retentionDays" mapstructure:"retentionDays"`
+	// RequiredIndexerFlags rejects torrents that don't have all of the flags, e.g. freeleech
+	RequiredIndexerFlags []string `json:"requiredIndexerFlags" yaml:"requiredIndexerFlags" mapstructure:"requiredIndexerFlags"`
+	// Indexers override the filters for releases from an indexer, keyed by the indexer name
+	Indexers map[string]IndexerReleaseFilters `json:"indexers" yaml:"indexers" mapstructure:"indexers"`
+}
+
+// IndexerReleaseFilters override the global release filters for an indexer. Unset fields use the global value.
+type IndexerReleaseFilters struct {
+	MinimumSeeders       *int32         `json:"minimumSeeders" yaml:"minimumSeeders" mapstructure:"minimumSeeders"`
+	MaximumAge           *time.Duration `json:"maximumAge" yaml:"maximumAge" mapstructure:"maximumAge"`
+	RetentionDays        *int32         `json:"retentionDays" yaml:"retentionDays" mapstructure:"retentionDays"`
+	RequiredIndexerFlags []string       `json:"requiredIndexerFlags" yaml:"requiredIndexerFlags" mapstructure:"requiredIndexerFlags"`
 }
 
 type Jobs struct {
@@ -52,6 +123,8 @@ type Jobs struct {
 	SeriesReconcile     time.Duration `json:"seriesReconcile" yaml:"seriesReconcile" mapstructure:"seriesReconcile"`
 	SeriesIndex         time.Duration `json:"seriesIndex" yaml:"seriesIndex" mapstructure:"seriesIndex"`
 	IndexerSync         time.Duration `json:"indexerSync" yaml:"indexerSync" mapstructure:"indexerSync"`
+	RecycleBinCleanup   time.Duration `json:"recycleBinCleanup" yaml:"recycleBinCleanup" mapstructure:"recycleBinCleanup"`
+	UpgradeSearch       time.Duration `json:"upgradeSearch" yaml:"upgradeSearch" mapstructure:"upgradeSearch"`
 	JobScheduleInterval time.Duration `json:"JobScheduleInterval" yaml:"JobScheduleInterval" mapstructure:"JobScheduleInterval"`
 	MinJobsToKeep       int           `json:"minJobsToKeep" yaml:"minJobsToKeep" mapstructure:"minJobsToKeep"`
 }
@@ -85,4 +85,59 @@ func TestNew(t *testing.T) {
 			t.Errorf("TestNew() config = %+v, want %+v", c, wantConfig)
 		}
 	})
+
+	t.Run("release filters with indexer overrides", func(t *testing.T) {
+		cu := viper.New()
+		cu.SetConfigFile("./testing/releases.yaml")
+		c, err := New(cu)
+		if err != nil {
+			t.Errorf("TestNew() err = %v, want %v", err, nil)
+		}
+
+		minimumSeeders := int32(0)
+		maximumAge := time.Hour * 720
+		wantReleases := ReleaseFilters{
+			MinimumSeeders: 2,
+			MaximumAge:     time.Hour * 8760,
+			RetentionDays:  3000,
+			Indexers: map[string]IndexerReleaseFilters{
+				// viper lower cases keys
+				"private tracker": {
+					MinimumSeeders:       &minimumSeeders,
+					MaximumAge:           &maximumAge,
+					RequiredIndexerFlags: []string{"freeleech"},
+				},
+			},
+		}
+
+		if !reflect.DeepEqual(c.Manager.Releases, wantReleases) {
+			t.Errorf("TestNew() releases = %+v, want %+v", c.Manager.Releases, wantReleases)
+		}
+	})
+
+	t.Run("delay profiles", func(t *testing.T) {
+		cu := viper.New()
+		cu.SetConfigFile("./testing/delay.yaml")
+		c, err := New(cu)
+		if err != nil {
+			t.Errorf("TestNew() err = %v, want %v", err, nil)
+		}
+
+		wantDelays := []DelayProfile{
+			{
+				QualityProfiles:   []string{"Ultra HD"},
+				Usenet:            time.Hour * 6,
+				Torrent:           time.Hour * 12,
+				PreferredProtocol: "usenet",
+				BypassIfCutoffMet: true,
+			},
+			{
+				Torrent: time.Minute * 30,
+			},
+		}
+
+		if !reflect.DeepEqual(c.Manager.DelayProfiles, wantDelays) {
+			t.Errorf("TestNew() delay profiles = %+v, want %+v", c.Manager.DelayProfiles, wantDelays)
+		}
+	})
 }
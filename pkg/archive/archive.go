@@ -0,0 +1,176 @@
+// Package archive extracts the zip and RAR archives releases are often packed in
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	mio "github.com/kasuboski/mediaz/pkg/io"
+	"github.com/nwaples/rardecode/v2"
+)
+
+// ErrUnsafePath is returned for an archive entry that would be extracted outside of the destination directory
+var ErrUnsafePath = errors.New("archive entry is outside of the destination directory")
+
+var (
+	// partVolumeRe matches the new style volume names of a RAR set, e.g. "release.part01.rar"
+	partVolumeRe = regexp.MustCompile(`(?i)\.part(\d+)\.rar$`)
+	// oldVolumeRe matches the old style volume names that follow the first ".rar" volume of a set, e.g. "release.r00"
+	oldVolumeRe = regexp.MustCompile(`(?i)\.[rs]\d{2,3}$`)
+)
+
+// IsArchive reports whether the file is an archive to extract: a zip or the first volume of a RAR set. The other volumes
+// are read along with the first one.
+func IsArchive(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	switch filepath.Ext(name) {
+	case ".zip":
+		return true
+	case ".rar":
+		m := partVolumeRe.FindStringSubmatch(name)
+		if m == nil {
+			return true
+		}
+		n, err := strconv.Atoi(m[1])
+		return err == nil && n == 1
+	}
+
+	return false
+}
+
+// IsPart reports whether the file belongs to an archive, i.e. it's an archive or any volume of a RAR set
+func IsPart(path string) bool {
+	name := filepath.Base(path)
+	return IsArchive(name) || partVolumeRe.MatchString(name) || oldVolumeRe.MatchString(name)
+}
+
+// Extract extracts the archive at path into dir with fileIO and returns the paths of the extracted files. The volumes of a
+// RAR set are found next to its first volume. Directories in the archive are kept, links are skipped and an entry that
+// would end up outside of dir fails the extraction with ErrUnsafePath.
+func Extract(ctx context.Context, fileIO mio.FileIO, path, dir string) ([]string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		return extractZip(ctx, fileIO, path, dir)
+	}
+
+	return extractRar(ctx, fileIO, path, dir)
+}
+
+func extractZip(ctx context.Context, fileIO mio.FileIO, path, dir string) ([]string, error) {
+	f, err := fileIO.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip: %w", err)
+	}
+
+	r, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip: %w", err)
+	}
+
+	var files []string
+	for _, f := range r.File {
+		if err := ctx.Err(); err != nil {
+			return files, err
+		}
+		if f.FileInfo().IsDir() || !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return files, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+
+		target, err := writeFile(fileIO, dir, f.Name, rc)
+		rc.Close()
+		if err != nil {
+			return files, err
+		}
+		files = append(files, target)
+	}
+
+	return files, nil
+}
+
+func extractRar(ctx context.Context, fileIO mio.FileIO, path, dir string) ([]string, error) {
+	r, err := rardecode.OpenReader(path, rardecode.FileSystem(volumeFS{fileIO}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rar: %w", err)
+	}
+	defer r.Close()
+
+	var files []string
+	for {
+		if err := ctx.Err(); err != nil {
+			return files, err
+		}
+
+		h, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return files, fmt.Errorf("failed to read rar: %w", err)
+		}
+		if h.IsDir || h.LinkType != 0 {
+			continue
+		}
+
+		target, err := writeFile(fileIO, dir, h.Name, r)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, target)
+	}
+}
+
+// volumeFS opens the volumes of a RAR set with a FileIO. Volumes are opened by the same paths os.Open would be given.
+type volumeFS struct {
+	fileIO mio.FileIO
+}
+
+func (v volumeFS) Open(name string) (fs.File, error) {
+	return v.fileIO.Open(name)
+}
+
+// writeFile writes an archive entry named name, with '/' separators, into dir
+func writeFile(fileIO mio.FileIO, dir, name string, r io.Reader) (string, error) {
+	local := filepath.FromSlash(name)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+
+	target := filepath.Join(dir, local)
+	err := fileIO.MkdirAll(filepath.Dir(target), fs.ModePerm)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := fileIO.Create(target)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mio "github.com/kasuboski/mediaz/pkg/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsArchive(t *testing.T) {
+	tests := []struct {
+		path    string
+		archive bool
+		part    bool
+	}{
+		{path: "/downloads/release.zip", archive: true, part: true},
+		{path: "/downloads/release.RAR", archive: true, part: true},
+		{path: "/downloads/release.part1.rar", archive: true, part: true},
+		{path: "/downloads/release.part01.rar", archive: true, part: true},
+		{path: "/downloads/release.part02.rar", archive: false, part: true},
+		{path: "/downloads/release.r00", archive: false, part: true},
+		{path: "/downloads/release.s01", archive: false, part: true},
+		{path: "/downloads/release.mkv", archive: false, part: false},
+		{path: "/downloads/release.nfo", archive: false, part: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			assert.Equal(t, tt.archive, IsArchive(tt.path))
+			assert.Equal(t, tt.part, IsPart(tt.path))
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		archive string
+		want    map[string]string
+	}{
+		{
+			name:    "rar",
+			archive: "movie.rar",
+			want: map[string]string{
+				"Movie.2020.1080p.BluRay.x264-GRP.mkv":    "movie",
+				"Movie.2020.1080p.BluRay.x264-GRP.en.srt": "subtitle",
+			},
+		},
+		{
+			name:    "rar set",
+			archive: "episode.part1.rar",
+			want: map[string]string{
+				"Series.S01E01.720p.HDTV.x264-GRP.mkv": "episode split across volumes",
+			},
+		},
+		{
+			name:    "zip with a directory",
+			archive: "movie.zip",
+			want: map[string]string{
+				"Movie.2020.1080p.WEB-DL/Movie.2020.1080p.WEB-DL.mkv": "movie",
+				"Movie.2020.1080p.WEB-DL/Movie.2020.1080p.WEB-DL.nfo": "nfo",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			files, err := Extract(ctx, &mio.MediaFileSystem{}, filepath.Join("testdata", tt.archive), dir)
+			require.NoError(t, err)
+
+			got := make(map[string]string, len(files))
+			for _, f := range files {
+				rel, err := filepath.Rel(dir, f)
+				require.NoError(t, err)
+				b, err := os.ReadFile(f)
+				require.NoError(t, err)
+				got[filepath.ToSlash(rel)] = string(b)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("entry outside of the directory", func(t *testing.T) {
+		parent := t.TempDir()
+		dir := filepath.Join(parent, "staging")
+
+		_, err := Extract(ctx, &mio.MediaFileSystem{}, filepath.Join("testdata", "unsafe.zip"), dir)
+		assert.ErrorIs(t, err, ErrUnsafePath)
+		assert.NoFileExists(t, filepath.Join(parent, "escaped.mkv"))
+	})
+
+	t.Run("missing archive", func(t *testing.T) {
+		_, err := Extract(ctx, &mio.MediaFileSystem{}, filepath.Join("testdata", "missing.rar"), t.TempDir())
+		assert.Error(t, err)
+	})
+}
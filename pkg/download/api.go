@@ -10,6 +10,10 @@ import (
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 )
 
+// ErrDownloadNotFound is returned when the download client no longer knows about a download,
+// e.g. because it was removed manually
+var ErrDownloadNotFound = errors.New("download not found")
+
 type DownloadClient interface {
 	Add(ctx context.Context, request AddRequest) (Status, error)
 	Get(ctx context.Context, request GetRequest) (Status, error)
@@ -68,4 +72,6 @@ type Status struct {
 	Speed     int64    `json:"speed"`     // assumed mb/s
 	Size      int64    `json:"size"`      // assumed mb
 	Done      bool     `json:"done"`
+	Failed    bool     `json:"failed"`          // the download client gave up on the download
+	Error     string   `json:"error,omitempty"` // reason reported by the download client when failed
 }
@@ -14,6 +14,7 @@ import (
 
 	mhttp "github.com/kasuboski/mediaz/pkg/http"
 	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/size"
 	"go.uber.org/zap"
 )
 
@@ -154,7 +155,19 @@ func (c *SabnzbdClient) Get(ctx context.Context, request GetRequest) (Status, er
 		}
 	}
 
-	return status, errors.New("no download found")
+	// finished and failed downloads are no longer in the queue
+	history, err := c.history(ctx, request.ID)
+	if err != nil {
+		return status, err
+	}
+
+	for _, h := range history.History.Slots {
+		if h.NzoID == request.ID {
+			return historyToStatus(h, c.mountPrefix), nil
+		}
+	}
+
+	return status, fmt.Errorf("no download found: %w", ErrDownloadNotFound)
 }
 
 func (c *SabnzbdClient) List(ctx context.Context) ([]Status, error) {
@@ -314,6 +327,33 @@ func queueToStatus(queue Queue, history History, mountPrefix string) ([]Status,
 	return stats, nil
 }
 
+const (
+	sabnzbdHistoryCompleted = "Completed"
+	sabnzbdHistoryFailed    = "Failed"
+)
+
+// historyToStatus converts a finished history slot to a status
+func historyToStatus(slot HistorySlot, mountPrefix string) Status {
+	s := Status{
+		ID:     slot.NzoID,
+		Name:   slot.Name,
+		Size:   size.BytesToMB(slot.Bytes),
+		Done:   slot.Status == sabnzbdHistoryCompleted,
+		Failed: slot.Status == sabnzbdHistoryFailed,
+		Error:  slot.FailMessage,
+	}
+
+	if s.Done {
+		s.Progress = 100
+	}
+
+	if slot.Storage != "" {
+		s.FilePaths = []string{filepath.Join(mountPrefix, slot.Storage)}
+	}
+
+	return s
+}
+
 func (c *SabnzbdClient) do(ctx context.Context, url *url.URL) ([]byte, error) {
 	log := logger.FromCtx(ctx)
 	if c.http == nil {
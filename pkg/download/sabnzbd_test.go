@@ -365,7 +365,15 @@ func TestSabnzbdClient_Get(t *testing.T) {
 			Body:       io.NopCloser(bytes.NewBuffer(historyResponseBody)),
 		}, nil)
 
-		gomock.InOrder(queueMock, historyMock)
+		emptyHistoryBody, err := json.Marshal(HistoryResponse{})
+		require.NoError(t, err)
+
+		finishedHistoryMock := mockHttp.EXPECT().Do(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(emptyHistoryBody)),
+		}, nil)
+
+		gomock.InOrder(queueMock, historyMock, finishedHistoryMock)
 
 		getRequest := GetRequest{
 			ID: "1",
@@ -374,8 +382,93 @@ func TestSabnzbdClient_Get(t *testing.T) {
 		status, err := client.Get(ctx, getRequest)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no download found")
+		assert.ErrorIs(t, err, ErrDownloadNotFound)
 		assert.Equal(t, Status{}, status)
 	})
+
+	t.Run("finished download found in history", func(t *testing.T) {
+		mockHttp := httpMock.NewMockHTTPClient(ctrl)
+		client := NewSabnzbdClient(mockHttp, "http", "localhost", "/mnt", "secret")
+		ctx := context.Background()
+
+		queueResponseBody, err := json.Marshal(QueueResponse{Queue: Queue{Speed: "0"}})
+		require.NoError(t, err)
+
+		queueMock := mockHttp.EXPECT().Do(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(queueResponseBody)),
+		}, nil)
+
+		queueHistoryBody, err := json.Marshal(HistoryResponse{})
+		require.NoError(t, err)
+
+		queueHistoryMock := mockHttp.EXPECT().Do(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(queueHistoryBody)),
+		}, nil)
+
+		historyResponse := HistoryResponse{
+			History: History{
+				Slots: []HistorySlot{
+					{
+						NzoID:       "SABnzbd_nzo_failed",
+						Name:        "TV.Show.S04E12.720p.HDTV.x264",
+						Status:      "Failed",
+						FailMessage: "Aborted, cannot be completed",
+						Bytes:       2097152,
+					},
+					{
+						NzoID:   "SABnzbd_nzo_done",
+						Name:    "TV.Show.S04E13.720p.HDTV.x264",
+						Status:  "Completed",
+						Storage: "/downloads/TV.Show.S04E13.720p.HDTV.x264",
+						Bytes:   1048576,
+					},
+				},
+			},
+		}
+
+		historyResponseBody, err := json.Marshal(historyResponse)
+		require.NoError(t, err)
+
+		historyMock := mockHttp.EXPECT().Do(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(historyResponseBody)),
+		}, nil)
+
+		gomock.InOrder(queueMock, queueHistoryMock, historyMock)
+
+		status, err := client.Get(ctx, GetRequest{ID: "SABnzbd_nzo_done"})
+		require.NoError(t, err)
+
+		expectedStatus := Status{
+			ID:        "SABnzbd_nzo_done",
+			Name:      "TV.Show.S04E13.720p.HDTV.x264",
+			Progress:  100,
+			Size:      1,
+			Done:      true,
+			FilePaths: []string{"/mnt/downloads/TV.Show.S04E13.720p.HDTV.x264"},
+		}
+		assert.Equal(t, expectedStatus, status)
+	})
+}
+
+func TestHistoryToStatus(t *testing.T) {
+	status := historyToStatus(HistorySlot{
+		NzoID:       "SABnzbd_nzo_failed",
+		Name:        "TV.Show.S04E12.720p.HDTV.x264",
+		Status:      "Failed",
+		FailMessage: "Aborted, cannot be completed",
+		Bytes:       2097152,
+	}, "")
+
+	assert.Equal(t, Status{
+		ID:     "SABnzbd_nzo_failed",
+		Name:   "TV.Show.S04E12.720p.HDTV.x264",
+		Size:   2,
+		Failed: true,
+		Error:  "Aborted, cannot be completed",
+	}, status)
 }
 
 func TestSabnzbdClient_List(t *testing.T) {
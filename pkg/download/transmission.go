@@ -93,6 +93,8 @@ type TransmissionTorrent struct {
 	DoneDate            int64                     `json:"doneDate"`
 	AddedDate           int64                     `json:"addedDate"`
 	Status              float64                   `json:"status"`
+	Error               int                       `json:"error"`
+	ErrorString         string                    `json:"errorString"`
 	UploadRatio         float64                   `json:"uploadRatio"`
 	DownloadLimited     bool                      `json:"downloadLimited"`
 	UploadLimited       bool                      `json:"uploadLimited"`
@@ -112,6 +114,11 @@ func (t *TransmissionTorrent) ToStatus(mountPrefix string) Status {
 		Speed:     size.BytesToMB(t.RateDownload),
 		FilePaths: paths,
 		Done:      t.Status > transmissionStatusSeeding || t.PercentDone == 100.0,
+		Failed:    t.Error == transmissionErrorLocal,
+	}
+
+	if t.Error != transmissionErrorNone {
+		s.Error = t.ErrorString
 	}
 
 	return s
@@ -298,7 +305,7 @@ func (c *TransmissionClient) Get(ctx context.Context, request GetRequest) (Statu
 
 	torrents := response.ToTorrents(c.mountPrefix)
 	if len(torrents) == 0 {
-		return status, fmt.Errorf("no torrent found for %s", request.ID)
+		return status, fmt.Errorf("no torrent found for %s: %w", request.ID, ErrDownloadNotFound)
 	}
 
 	return torrents[0], nil
@@ -417,6 +424,11 @@ const (
 	// transmissionStatusSeeding is the Transmission torrent status value above which
 	// a torrent is considered done downloading (4 = downloading, 5+ = seeding/queued).
 	transmissionStatusSeeding = 4
+
+	// transmissionErrorNone means the torrent has no error. Errors 1 and 2 are tracker
+	// warnings/errors which are usually transient, 3 is a local error that stops the torrent.
+	transmissionErrorNone  = 0
+	transmissionErrorLocal = 3
 )
 
 func (c *TransmissionClient) do(ctx context.Context, url *url.URL, body []byte, retry ...bool) ([]byte, error) {
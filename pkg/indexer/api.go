@@ -2,6 +2,8 @@ package indexer
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
@@ -18,20 +20,60 @@ type IndexerSource interface {
 }
 
 type SourceIndexer struct {
-	ID         int32
-	Name       string
-	URI        string
-	Priority   int32
-	Categories []prowlarr.IndexerCategory
-	Status     *prowlarr.IndexerStatusResource
+	ID                int32
+	Name              string
+	URI               string
+	Priority          int32
+	Categories        []prowlarr.IndexerCategory
+	MovieSearchParams []prowlarr.MovieSearchParam
+	TvSearchParams    []prowlarr.TvSearchParam
+	Status            *prowlarr.IndexerStatusResource
 }
 
 type SearchOptions struct {
 	Query   string
 	Season  *int32
 	Episode *int32
+	// AbsoluteEpisode is the episode number across the whole series, used by anime releases
+	AbsoluteEpisode *int32
+	// AirDate searches daily shows by the day the episode aired
+	AirDate *time.Time
 	Type    *string
 	TmdbID  *int32
+	ImdbID  *string
+	TvdbID  *int32
+}
+
+// SearchOptions drops the external ids the indexer can't search by, so it falls back to the text query
+func (s SourceIndexer) SearchOptions(opts SearchOptions) SearchOptions {
+	supported := opts
+	supported.TmdbID, supported.ImdbID, supported.TvdbID = nil, nil, nil
+
+	if opts.Type == nil {
+		return supported
+	}
+
+	switch *opts.Type {
+	case TypeMovie:
+		if slices.Contains(s.MovieSearchParams, prowlarr.MovieSearchParamTmdbID) {
+			supported.TmdbID = opts.TmdbID
+		}
+		if slices.Contains(s.MovieSearchParams, prowlarr.MovieSearchParamImdbID) {
+			supported.ImdbID = opts.ImdbID
+		}
+	case TypeTV:
+		if slices.Contains(s.TvSearchParams, prowlarr.TmdbID) {
+			supported.TmdbID = opts.TmdbID
+		}
+		if slices.Contains(s.TvSearchParams, prowlarr.ImdbID) {
+			supported.ImdbID = opts.ImdbID
+		}
+		if slices.Contains(s.TvSearchParams, prowlarr.TvdbID) {
+			supported.TvdbID = opts.TvdbID
+		}
+	}
+
+	return supported
 }
 
 type Factory interface {
@@ -0,0 +1,59 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/indexer"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceIndexer_SearchOptions(t *testing.T) {
+	opts := func(mediaType string) indexer.SearchOptions {
+		return indexer.SearchOptions{
+			Query:  "Title",
+			Type:   ptr.To(mediaType),
+			TmdbID: ptr.To(int32(1)),
+			ImdbID: ptr.To("tt1"),
+			TvdbID: ptr.To(int32(2)),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		indexer indexer.SourceIndexer
+		opts    indexer.SearchOptions
+		want    indexer.SearchOptions
+	}{
+		{
+			name: "drops ids without capabilities",
+			opts: opts(indexer.TypeMovie),
+			want: indexer.SearchOptions{Query: "Title", Type: ptr.To(indexer.TypeMovie)},
+		},
+		{
+			name:    "keeps supported movie ids",
+			indexer: indexer.SourceIndexer{MovieSearchParams: []prowlarr.MovieSearchParam{prowlarr.MovieSearchParamQ, prowlarr.MovieSearchParamImdbID}},
+			opts:    opts(indexer.TypeMovie),
+			want:    indexer.SearchOptions{Query: "Title", Type: ptr.To(indexer.TypeMovie), ImdbID: ptr.To("tt1")},
+		},
+		{
+			name:    "keeps supported tv ids",
+			indexer: indexer.SourceIndexer{TvSearchParams: []prowlarr.TvSearchParam{prowlarr.Q, prowlarr.TvdbID, prowlarr.TmdbID}},
+			opts:    opts(indexer.TypeTV),
+			want:    indexer.SearchOptions{Query: "Title", Type: ptr.To(indexer.TypeTV), TmdbID: ptr.To(int32(1)), TvdbID: ptr.To(int32(2))},
+		},
+		{
+			name:    "movie capabilities don't apply to tv",
+			indexer: indexer.SourceIndexer{MovieSearchParams: []prowlarr.MovieSearchParam{prowlarr.MovieSearchParamImdbID}},
+			opts:    opts(indexer.TypeTV),
+			want:    indexer.SearchOptions{Query: "Title", Type: ptr.To(indexer.TypeTV)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.indexer.SearchOptions(tt.opts))
+		})
+	}
+}
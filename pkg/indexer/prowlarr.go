@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/kasuboski/mediaz/pkg/logger"
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
@@ -99,13 +100,18 @@ func (p *ProwlarrIndexerSource) ListIndexers(ctx context.Context) ([]SourceIndex
 			categories = cats
 		}
 
+		movieSearchParams, _ := pi.Capabilities.MovieSearchParams.Get()
+		tvSearchParams, _ := pi.Capabilities.TvSearchParams.Get()
+
 		indexers = append(indexers, SourceIndexer{
-			ID:         *pi.ID,
-			Name:       name,
-			URI:        uri,
-			Priority:   *pi.Priority,
-			Categories: categories,
-			Status:     pi.Status,
+			ID:                *pi.ID,
+			Name:              name,
+			URI:               uri,
+			Priority:          *pi.Priority,
+			Categories:        categories,
+			MovieSearchParams: movieSearchParams,
+			TvSearchParams:    tvSearchParams,
+			Status:            pi.Status,
 		})
 	}
 
@@ -115,13 +121,7 @@ func (p *ProwlarrIndexerSource) ListIndexers(ctx context.Context) ([]SourceIndex
 func (p *ProwlarrIndexerSource) Search(ctx context.Context, indexerID int32, categories []int32, opts SearchOptions) ([]*prowlarr.ReleaseResource, error) {
 	log := logger.FromCtx(ctx)
 
-	query := opts.Query
-	if opts.Season != nil {
-		query = fmt.Sprintf("%s S%02d", query, *opts.Season)
-		if opts.Episode != nil {
-			query = fmt.Sprintf("%sE%02d", query, *opts.Episode)
-		}
-	}
+	query, searchType := prowlarrQuery(opts)
 
 	log.Debug("searching indexer", zap.Int32("indexer_id", indexerID), zap.String("query", query))
 
@@ -130,7 +130,7 @@ func (p *ProwlarrIndexerSource) Search(ctx context.Context, indexerID int32, cat
 		Query:      &query,
 		Categories: &categories,
 		Limit:      ptr.To(int32(100)),
-		Type:       opts.Type,
+		Type:       searchType,
 	})
 	if err != nil {
 		return nil, err
@@ -153,3 +153,74 @@ func (p *ProwlarrIndexerSource) Search(ctx context.Context, indexerID int32, cat
 
 	return releases, nil
 }
+
+// prowlarrQuery builds the search query and type. Searches with external ids use Prowlarr's
+// id syntax, e.g. {ImdbId:tt0111161}, with the matching movie or tv search type.
+func prowlarrQuery(opts SearchOptions) (string, *string) {
+	if terms, searchType := prowlarrIDTerms(opts); len(terms) > 0 {
+		return strings.Join(terms, " "), &searchType
+	}
+
+	query := opts.Query
+	if opts.AbsoluteEpisode != nil {
+		return fmt.Sprintf("%s %02d", query, *opts.AbsoluteEpisode), opts.Type
+	}
+
+	if opts.AirDate != nil {
+		return fmt.Sprintf("%s %s", query, opts.AirDate.Format("2006 01 02")), opts.Type
+	}
+
+	if opts.Season != nil {
+		query = fmt.Sprintf("%s S%02d", query, *opts.Season)
+		if opts.Episode != nil {
+			query = fmt.Sprintf("%sE%02d", query, *opts.Episode)
+		}
+	}
+
+	return query, opts.Type
+}
+
+func prowlarrIDTerms(opts SearchOptions) ([]string, string) {
+	if opts.Type == nil {
+		return nil, ""
+	}
+
+	var terms []string
+	switch *opts.Type {
+	case TypeMovie:
+		if opts.ImdbID != nil {
+			terms = append(terms, fmt.Sprintf("{ImdbId:%s}", *opts.ImdbID))
+		}
+		if opts.TmdbID != nil {
+			terms = append(terms, fmt.Sprintf("{TmdbId:%d}", *opts.TmdbID))
+		}
+		return terms, "movie"
+	case TypeTV:
+		if opts.TvdbID != nil {
+			terms = append(terms, fmt.Sprintf("{TvdbId:%d}", *opts.TvdbID))
+		}
+		if opts.ImdbID != nil {
+			terms = append(terms, fmt.Sprintf("{ImdbId:%s}", *opts.ImdbID))
+		}
+		if opts.TmdbID != nil {
+			terms = append(terms, fmt.Sprintf("{TmdbId:%d}", *opts.TmdbID))
+		}
+		if len(terms) == 0 {
+			return nil, ""
+		}
+		// daily episodes are searched with the year as the season and month/day as the episode
+		if opts.AirDate != nil {
+			terms = append(terms, fmt.Sprintf("{Season:%s}", opts.AirDate.Format("2006")), fmt.Sprintf("{Episode:%s}", opts.AirDate.Format("01/02")))
+			return terms, "tvsearch"
+		}
+		if opts.Season != nil {
+			terms = append(terms, fmt.Sprintf("{Season:%02d}", *opts.Season))
+			if opts.Episode != nil {
+				terms = append(terms, fmt.Sprintf("{Episode:%02d}", *opts.Episode))
+			}
+		}
+		return terms, "tvsearch"
+	default:
+		return nil, ""
+	}
+}
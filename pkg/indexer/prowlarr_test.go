@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/kasuboski/mediaz/pkg/indexer"
 	"github.com/kasuboski/mediaz/pkg/indexer/mocks"
@@ -327,6 +328,136 @@ func TestProwlarrIndexerSource_Search(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("formats absolute episode in query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		src, mockClient := newSourceWithMock(ctrl)
+
+		body, err := json.Marshal([]*prowlarr.ReleaseResource{})
+		require.NoError(t, err)
+
+		season := int32(2)
+		episode := int32(3)
+		absolute := int32(31)
+		mockClient.EXPECT().GetAPIV1Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, params *prowlarr.GetAPIV1SearchParams, _ ...interface{}) (*http.Response, error) {
+				assert.Equal(t, "Show 31", *params.Query)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			},
+		)
+
+		_, err = src.Search(ctx, 1, nil, indexer.SearchOptions{
+			Query:           "Show",
+			Season:          &season,
+			Episode:         &episode,
+			AbsoluteEpisode: &absolute,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("searches movies by ids", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		src, mockClient := newSourceWithMock(ctrl)
+
+		body, err := json.Marshal([]*prowlarr.ReleaseResource{})
+		require.NoError(t, err)
+
+		mockClient.EXPECT().GetAPIV1Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, params *prowlarr.GetAPIV1SearchParams, _ ...interface{}) (*http.Response, error) {
+				assert.Equal(t, "{ImdbId:tt0111161} {TmdbId:278}", *params.Query)
+				assert.Equal(t, "movie", *params.Type)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			},
+		)
+
+		_, err = src.Search(ctx, 1, nil, indexer.SearchOptions{
+			Query:  "The Shawshank Redemption",
+			Type:   ptr.To(indexer.TypeMovie),
+			TmdbID: ptr.To(int32(278)),
+			ImdbID: ptr.To("tt0111161"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("searches episodes by ids", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		src, mockClient := newSourceWithMock(ctrl)
+
+		body, err := json.Marshal([]*prowlarr.ReleaseResource{})
+		require.NoError(t, err)
+
+		mockClient.EXPECT().GetAPIV1Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, params *prowlarr.GetAPIV1SearchParams, _ ...interface{}) (*http.Response, error) {
+				assert.Equal(t, "{TvdbId:81189} {Season:03} {Episode:07}", *params.Query)
+				assert.Equal(t, "tvsearch", *params.Type)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+				}, nil
+			},
+		)
+
+		_, err = src.Search(ctx, 1, nil, indexer.SearchOptions{
+			Query:   "Breaking Bad",
+			Type:    ptr.To(indexer.TypeTV),
+			Season:  ptr.To(int32(3)),
+			Episode: ptr.To(int32(7)),
+			TvdbID:  ptr.To(int32(81189)),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("searches daily episodes by air date", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		src, mockClient := newSourceWithMock(ctrl)
+
+		body, err := json.Marshal([]*prowlarr.ReleaseResource{})
+		require.NoError(t, err)
+
+		airDate := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+		gomock.InOrder(
+			mockClient.EXPECT().GetAPIV1Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params *prowlarr.GetAPIV1SearchParams, _ ...interface{}) (*http.Response, error) {
+					assert.Equal(t, "The Daily Show 2026 10 15", *params.Query)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewReader(body)),
+					}, nil
+				},
+			),
+			mockClient.EXPECT().GetAPIV1Search(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params *prowlarr.GetAPIV1SearchParams, _ ...interface{}) (*http.Response, error) {
+					assert.Equal(t, "{TvdbId:71256} {Season:2026} {Episode:10/15}", *params.Query)
+					assert.Equal(t, "tvsearch", *params.Type)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewReader(body)),
+					}, nil
+				},
+			),
+		)
+
+		_, err = src.Search(ctx, 1, nil, indexer.SearchOptions{
+			Query:   "The Daily Show",
+			Type:    ptr.To(indexer.TypeTV),
+			AirDate: &airDate,
+		})
+		require.NoError(t, err)
+
+		_, err = src.Search(ctx, 1, nil, indexer.SearchOptions{
+			Query:   "The Daily Show",
+			Type:    ptr.To(indexer.TypeTV),
+			AirDate: &airDate,
+			TvdbID:  ptr.To(int32(71256)),
+		})
+		require.NoError(t, err)
+	})
+
 	t.Run("returns error on non-200 status", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		src, mockClient := newSourceWithMock(ctrl)
@@ -19,4 +19,11 @@ type FileIO interface {
 	MkdirAll(name string, perm os.FileMode) error
 	Remove(name string) error
 	RemoveAll(path string) error
+	DiskUsage(path string) (DiskUsage, error)
+}
+
+// DiskUsage is the space of a file system in bytes
+type DiskUsage struct {
+	Total int64 `json:"total"`
+	Free  int64 `json:"free"`
 }
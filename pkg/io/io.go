@@ -70,9 +70,33 @@ func (o *MediaFileSystem) Copy(source, target string) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	defer targetFile.Close()
 
-	return io.Copy(targetFile, sourceFile)
+	n, err := io.Copy(targetFile, sourceFile)
+	if closeErr := targetFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// a partial copy isn't left behind, e.g. when the disk fills up
+		_ = os.Remove(target)
+		return n, err
+	}
+
+	return n, nil
+}
+
+// DiskUsage returns the total and free space, in bytes, of the file system a path is on. Free is the space available
+// to unprivileged users.
+func (o *MediaFileSystem) DiskUsage(path string) (DiskUsage, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+
+	return DiskUsage{
+		Total: int64(stat.Blocks) * int64(stat.Bsize),
+		Free:  int64(stat.Bavail) * int64(stat.Bsize),
+	}, nil
 }
 
 // IsSameFileSystem checks if a source and target are on the same file system. If a file does not exist, it is considered to be on a different file system.
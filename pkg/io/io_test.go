@@ -69,3 +69,19 @@ func TestMediaFileSystem_IsSameFileSystem(t *testing.T) {
 		}
 	})
 }
+
+func TestMediaFileSystem_DiskUsage(t *testing.T) {
+	mfs := &MediaFileSystem{}
+
+	t.Run("reports the file system's space", func(t *testing.T) {
+		usage, err := mfs.DiskUsage(t.TempDir())
+		assert.NoError(t, err)
+		assert.Positive(t, usage.Total)
+		assert.LessOrEqual(t, usage.Free, usage.Total)
+	})
+
+	t.Run("non-existent path", func(t *testing.T) {
+		_, err := mfs.DiskUsage("/non/existent/path")
+		assert.Error(t, err)
+	})
+}
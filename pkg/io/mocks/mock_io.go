@@ -10,11 +10,12 @@
 package mocks
 
 import (
-	io "io"
+	io0 "io"
 	fs "io/fs"
 	os "os"
 	reflect "reflect"
 
+	io "github.com/kasuboski/mediaz/pkg/io"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -57,10 +58,10 @@ func (mr *MockFileIOMockRecorder) Copy(arg0, arg1 any) *gomock.Call {
 }
 
 // Create mocks base method.
-func (m *MockFileIO) Create(arg0 string) (io.WriteCloser, error) {
+func (m *MockFileIO) Create(arg0 string) (io0.WriteCloser, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", arg0)
-	ret0, _ := ret[0].(io.WriteCloser)
+	ret0, _ := ret[0].(io0.WriteCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -71,6 +72,21 @@ func (mr *MockFileIOMockRecorder) Create(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFileIO)(nil).Create), arg0)
 }
 
+// DiskUsage mocks base method.
+func (m *MockFileIO) DiskUsage(arg0 string) (io.DiskUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiskUsage", arg0)
+	ret0, _ := ret[0].(io.DiskUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiskUsage indicates an expected call of DiskUsage.
+func (mr *MockFileIOMockRecorder) DiskUsage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskUsage", reflect.TypeOf((*MockFileIO)(nil).DiskUsage), arg0)
+}
+
 // Hardlink mocks base method.
 func (m *MockFileIO) Hardlink(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -2,28 +2,33 @@ package library
 
 import (
 	"context"
-	"regexp"
-)
+	"time"
 
-const (
-	moviePattern = `^((?:\w|\s|')+)(\(\d+\))?\s*({tmdb-\w+})?([[:print:]])*\.?\w*$`
-	showPattern  = `^([^/\\]+?)(\([^)]+\))*\s*(\(\d+\))*\s*({tmdb-\d+})?[-.\s]*([sS]\d{1,2}[eE]\d{1,2})?[-.\s]*([^/\\]*?)(\(\d+\))?\.?\w*$`
+	"github.com/kasuboski/mediaz/pkg/io"
 )
 
-var (
-	movieRegex      = regexp.MustCompile(moviePattern)
-	showRegex       = regexp.MustCompile(showPattern)
-	videoExtensions = []string{".mp4", ".avi", ".mkv", ".m4v", ".iso", ".ts", ".m2ts"}
-)
+var videoExtensions = []string{".mp4", ".avi", ".mkv", ".m4v", ".iso", ".ts", ".m2ts"}
 
 type Library interface {
 	FindMovies(ctx context.Context) ([]MovieFile, error)
-	AddMovie(ctx context.Context, title, sourcePath string) (MovieFile, error)
+	AddMovie(ctx context.Context, name, sourcePath, edition string) (MovieFile, error)
 	DeleteMovieFile(ctx context.Context, relativePath string) error
 	DeleteMovieDirectory(ctx context.Context, relativePath string) error
+	MoveMovieFile(ctx context.Context, from, to string) error
+	RecycleMovieFile(ctx context.Context, relativePath, recycleBin string) (string, error)
+	RecycleMovieDirectory(ctx context.Context, relativePath, recycleBin string) (string, error)
 
-	AddEpisode(ctx context.Context, seriesTitle string, seasonNumber int32, sourcePath string) (EpisodeFile, error)
+	AddEpisode(ctx context.Context, name, sourcePath string) (EpisodeFile, error)
 	FindEpisodes(ctx context.Context) ([]EpisodeFile, error)
 	DeleteSeriesFile(ctx context.Context, relativePath string) error
 	DeleteSeriesDirectory(ctx context.Context, relativePath string) error
+	MoveSeriesFile(ctx context.Context, from, to string) error
+	RecycleSeriesFile(ctx context.Context, relativePath, recycleBin string) (string, error)
+	RecycleSeriesDirectory(ctx context.Context, relativePath, recycleBin string) (string, error)
+
+	ListRecycleBin(ctx context.Context, recycleBin string) ([]RecycledFile, error)
+	RestoreRecycledFile(ctx context.Context, recycleBin, id string) (string, error)
+	PurgeRecycleBin(ctx context.Context, recycleBin string, before time.Time) (int, error)
+
+	DiskUsage(ctx context.Context, path string) (io.DiskUsage, error)
 }
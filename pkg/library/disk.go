@@ -0,0 +1,52 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/size"
+	"go.uber.org/zap"
+)
+
+// ErrInsufficientDiskSpace is returned when copying a file would leave less than the minimum free space
+var ErrInsufficientDiskSpace = errors.New("insufficient disk space")
+
+// WithMinimumFreeSpace refuses to copy a file into the library if it would leave the file system with less than this
+// many bytes free. Renamed and hardlinked files don't take up space and aren't checked.
+func WithMinimumFreeSpace(bytes int64) Option {
+	return func(l *MediaLibrary) {
+		l.minimumFreeSpace = max(bytes, 0)
+	}
+}
+
+// DiskUsage returns the total and free space of the file system a path is on
+func (l *MediaLibrary) DiskUsage(ctx context.Context, path string) (io.DiskUsage, error) {
+	return l.io.DiskUsage(path)
+}
+
+// ensureFreeSpace returns ErrInsufficientDiskSpace if copying the source file into a directory would leave less than
+// the minimum free space. The copy goes ahead if the free space can't be determined.
+func (l *MediaLibrary) ensureFreeSpace(ctx context.Context, sourcePath, dir string) error {
+	log := logger.FromCtx(ctx)
+
+	info, err := l.io.Stat(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	usage, err := l.io.DiskUsage(dir)
+	if err != nil {
+		log.Debug("failed to get free disk space, copying anyway", zap.String("path", dir), zap.Error(err))
+		return nil
+	}
+
+	if usage.Free-info.Size() < l.minimumFreeSpace {
+		return fmt.Errorf("%w: copying %s (%d MB) to %s would leave less than %d MB of its %d MB free",
+			ErrInsufficientDiskSpace, sourcePath, size.BytesToMB(info.Size()), dir, size.BytesToMB(l.minimumFreeSpace), size.BytesToMB(usage.Free))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package library
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/io/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMediaLibrary_AddMovie_MinimumFreeSpace(t *testing.T) {
+	ctx := context.Background()
+
+	// newLibrary copies files into a movie library on a file system with the free space
+	newLibrary := func(t *testing.T, free int64) (Library, *mocks.MockFileIO) {
+		ctrl := gomock.NewController(t)
+		mockfs := mocks.NewMockFileIO(ctrl)
+
+		mockFileInfo := mocks.NewMockFileInfo(ctrl)
+		mockFileInfo.EXPECT().Size().Return(int64(1 << 30)).AnyTimes()
+
+		mockfs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Return(nil)
+		mockfs.EXPECT().IsSameFileSystem(gomock.Any(), gomock.Any()).Return(false, nil)
+		mockfs.EXPECT().Stat(gomock.Any()).Return(mockFileInfo, nil).AnyTimes()
+		mockfs.EXPECT().DiskUsage(filepath.Join("movies", "Movie (2020)")).Return(io.DiskUsage{Total: 1 << 40, Free: free}, nil)
+
+		return New(FileSystem{Path: "movies"}, FileSystem{}, mockfs, false, WithMinimumFreeSpace(100<<20)), mockfs
+	}
+
+	t.Run("copies a file that leaves the minimum free", func(t *testing.T) {
+		lib, mockfs := newLibrary(t, 2<<30)
+		mockfs.EXPECT().Copy("/downloads/movie.mkv", filepath.Join("movies", "Movie (2020)", "Movie (2020).mkv")).Return(int64(1<<30), nil)
+
+		_, err := lib.AddMovie(ctx, "Movie (2020)/Movie (2020)", "/downloads/movie.mkv", "")
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses a file that would leave less than the minimum free", func(t *testing.T) {
+		lib, _ := newLibrary(t, 1<<30+50<<20)
+
+		_, err := lib.AddMovie(ctx, "Movie (2020)/Movie (2020)", "/downloads/movie.mkv", "")
+		assert.ErrorIs(t, err, ErrInsufficientDiskSpace)
+	})
+}
+
+func TestMediaLibrary_DiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	lib := New(FileSystem{FS: os.DirFS(dir), Path: dir}, FileSystem{}, &io.MediaFileSystem{}, false)
+
+	usage, err := lib.DiskUsage(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Positive(t, usage.Total)
+}
@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/kasuboski/mediaz/pkg/parser"
 )
 
 type EpisodeFile struct {
@@ -12,32 +15,23 @@ type EpisodeFile struct {
 	Size         int64
 	RelativePath string
 	AbsolutePath string
+	// ExtraFiles are the companion files imported along with the episode file
+	ExtraFiles []ExtraFile
 
 	SeriesName    string
 	SeasonNumber  int
 	EpisodeNumber int
+	// EpisodeNumbers is set for multi-episode files like "Show.S01E01E02" and lists every episode,
+	// starting with EpisodeNumber
+	EpisodeNumbers []int
+	// AbsoluteEpisodeNumber is set for anime style names like "Show - 123 [1080p]"
+	// that number episodes across the whole series instead of per season
+	AbsoluteEpisodeNumber int
+	// AirDate is set for daily show names like "Show.2026.10.15.Guest.Name"
+	AirDate *time.Time
 }
 
-var (
-	seasonDirRe    = regexp.MustCompile(`(?i)^season\s*(\d+)`)
-	seasonPatterns = []*regexp.Regexp{
-		regexp.MustCompile(`(?i)s(\d+)e\d+`),
-		regexp.MustCompile(`(?i)(\d+)x\d+`),
-	}
-	// Episode number extraction patterns, ordered by preference
-	episodePatterns = []*regexp.Regexp{
-		// S01E05 or s01e05 format
-		regexp.MustCompile(`(?i)s\d+e(\d+)`),
-		// 1x05 format
-		regexp.MustCompile(`(?i)\d+x(\d+)`),
-		// Episode 5 or Ep 5 format
-		regexp.MustCompile(`(?i)(?:episode?|ep)\s*(\d+)`),
-		// E05 format (standalone E followed by digits)
-		regexp.MustCompile(`(?i)(?:^|[^a-z])e(\d+)(?:[^a-z]|$)`),
-		// - 05 - format (episode number between dashes)
-		regexp.MustCompile(`-\s*(\d+)\s*-`),
-	}
-)
+var seasonDirRe = regexp.MustCompile(`(?i)^season\s*(\d+)`)
 
 func EpisodeFileFromPath(path string, libraryRoot ...string) EpisodeFile {
 	name := sanitizeName(filepath.Base(path))
@@ -56,8 +50,13 @@ func EpisodeFileFromPath(path string, libraryRoot ...string) EpisodeFile {
 		series = sanitizeName(dirName(filepath.Dir(path)))
 	}
 
-	season := extractSeasonNumber(name, dirName(path))
-	episode := extractEpisodeNumber(name)
+	parsed := parser.Parse(name)
+	season := seasonNumber(parsed, dirName(path))
+	episode := episodeNumber(parsed)
+	episodes := parsed.Episodes
+	if len(episodes) < 2 {
+		episodes = nil
+	}
 
 	var absolutePath string
 	if len(libraryRoot) > 0 {
@@ -71,25 +70,38 @@ func EpisodeFileFromPath(path string, libraryRoot ...string) EpisodeFile {
 		SeriesName:    series,
 		SeasonNumber:  season,
 		EpisodeNumber: episode,
+
+		EpisodeNumbers:        episodes,
+		AbsoluteEpisodeNumber: parsed.AbsoluteEpisode,
+		AirDate:               parsed.AirDate,
+	}
+}
+
+// Episodes returns every episode number in the file, or nil if no episode number was found
+func (e EpisodeFile) Episodes() []int {
+	if len(e.EpisodeNumbers) > 0 {
+		return e.EpisodeNumbers
 	}
+	if e.EpisodeNumber > 0 {
+		return []int{e.EpisodeNumber}
+	}
+	return nil
 }
 
 func extractSeasonNumber(filename, dirName string) int {
+	return seasonNumber(parser.Parse(filename), dirName)
+}
+
+// seasonNumber prefers the season directory a file is in over the season in its name
+func seasonNumber(parsed parser.Release, dirName string) int {
 	if m := seasonDirRe.FindStringSubmatch(dirName); len(m) == 2 {
 		if n, err := strconv.Atoi(strings.TrimLeft(m[1], "0")); err == nil {
 			return n
 		}
 	}
 
-	name := strings.TrimSuffix(filename, filepath.Ext(filename))
-	for _, pattern := range seasonPatterns {
-		if matches := pattern.FindStringSubmatch(name); len(matches) >= 2 {
-			if season, err := strconv.Atoi(strings.TrimLeft(matches[1], "0")); err == nil {
-				if season > 0 {
-					return season
-				}
-			}
-		}
+	if len(parsed.Seasons) > 0 {
+		return parsed.Seasons[0]
 	}
 
 	return 0
@@ -97,18 +109,12 @@ func extractSeasonNumber(filename, dirName string) int {
 
 // extractEpisodeNumber extracts episode number from filename using various patterns
 func extractEpisodeNumber(filename string) int {
-	// Remove file extension for cleaner matching
-	name := strings.TrimSuffix(filename, filepath.Ext(filename))
-
-	for _, pattern := range episodePatterns {
-		if matches := pattern.FindStringSubmatch(name); len(matches) >= 2 {
-			if episode, err := strconv.Atoi(strings.TrimLeft(matches[1], "0")); err == nil {
-				if episode > 0 { // Episode 0 is typically not valid
-					return episode
-				}
-			}
-		}
-	}
+	return episodeNumber(parser.Parse(filename))
+}
 
-	return 0 // No episode number found
+func episodeNumber(parsed parser.Release) int {
+	if len(parsed.Episodes) > 0 {
+		return parsed.Episodes[0]
+	}
+	return 0
 }
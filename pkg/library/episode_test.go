@@ -1,6 +1,7 @@
 package library
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -264,3 +265,31 @@ func TestEpisodeFileFromPathWithEpisodeNumbers(t *testing.T) {
 		})
 	}
 }
+
+func TestEpisodeFileFromPath_Absolute(t *testing.T) {
+	ef := EpisodeFileFromPath("Frieren/[SubsPlease] Frieren - 30 (1080p).mkv")
+	if ef.AbsoluteEpisodeNumber != 30 {
+		t.Errorf("AbsoluteEpisodeNumber = %d, want 30", ef.AbsoluteEpisodeNumber)
+	}
+	if ef.SeasonNumber != 0 || ef.EpisodeNumber != 0 {
+		t.Errorf("expected no season or episode, got S%dE%d", ef.SeasonNumber, ef.EpisodeNumber)
+	}
+}
+
+func TestEpisodeFileFromPath_MultiEpisode(t *testing.T) {
+	ef := EpisodeFileFromPath("Show/Season 01/Show.S01E01E02.1080p.mkv")
+	if ef.EpisodeNumber != 1 {
+		t.Errorf("EpisodeNumber = %d, want 1", ef.EpisodeNumber)
+	}
+	if !reflect.DeepEqual(ef.EpisodeNumbers, []int{1, 2}) {
+		t.Errorf("EpisodeNumbers = %v, want [1 2]", ef.EpisodeNumbers)
+	}
+
+	ef = EpisodeFileFromPath("Show/Season 01/Show - 1x05 - Pilot.mkv")
+	if ef.EpisodeNumbers != nil {
+		t.Errorf("EpisodeNumbers = %v, want nil", ef.EpisodeNumbers)
+	}
+	if !reflect.DeepEqual(ef.Episodes(), []int{5}) {
+		t.Errorf("Episodes() = %v, want [5]", ef.Episodes())
+	}
+}
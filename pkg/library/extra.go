@@ -0,0 +1,127 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultExtraFileExtensions are the companion files imported along with a video file: subtitles, nfo files and artwork
+var DefaultExtraFileExtensions = []string{".srt", ".ass", ".ssa", ".sub", ".idx", ".nfo", ".jpg", ".jpeg", ".png"}
+
+// ExtraFile is a companion file imported along with a movie or episode file, e.g. a subtitle, nfo file or artwork
+type ExtraFile struct {
+	RelativePath string `json:"path"`
+	AbsolutePath string `json:"absolutePath"`
+	Size         int64  `json:"size"`
+}
+
+// Option configures a MediaLibrary
+type Option func(*MediaLibrary)
+
+// WithExtraFileExtensions imports the files with these extensions that are named after a video file along with it.
+// Nothing but the video file is imported if it's empty.
+func WithExtraFileExtensions(extensions []string) Option {
+	return func(l *MediaLibrary) {
+		l.extraFileExtensions = nil
+		for _, ext := range extensions {
+			ext = strings.ToLower(strings.TrimSpace(ext))
+			if ext == "" {
+				continue
+			}
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			l.extraFileExtensions = append(l.extraFileExtensions, ext)
+		}
+	}
+}
+
+// extraFile is a companion file next to a video file and what follows the video's name in its name, e.g. ".en.forced.srt"
+type extraFile struct {
+	path   string
+	suffix string
+}
+
+// findExtraFiles lists the files in the video file's directory that are named after it and have one of the extra file
+// extensions. Subdirectories aren't searched.
+func (l *MediaLibrary) findExtraFiles(sourcePath string) ([]extraFile, error) {
+	if len(l.extraFileExtensions) == 0 {
+		return nil, nil
+	}
+
+	dir := filepath.Dir(sourcePath)
+	videoName := filepath.Base(sourcePath)
+	stem := strings.TrimSuffix(videoName, filepath.Ext(videoName))
+
+	var extras []extraFile
+	err := l.io.WalkDir(os.DirFS(dir), ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p == "." {
+				return nil
+			}
+			return fs.SkipDir
+		}
+
+		name := d.Name()
+		if name == videoName || len(name) <= len(stem) || !strings.EqualFold(name[:len(stem)], stem) || name[len(stem)] != '.' {
+			return nil
+		}
+		if !slices.Contains(l.extraFileExtensions, strings.ToLower(filepath.Ext(name))) {
+			return nil
+		}
+
+		extras = append(extras, extraFile{path: filepath.Join(dir, name), suffix: name[len(stem):]})
+		return nil
+	})
+
+	return extras, err
+}
+
+// importExtraFiles moves the companion files of the video file at sourcePath next to where it was imported to at
+// targetPath, renamed to match it. What follows the video's name is kept, so "Movie.2005.1080p.en.forced.srt" is imported
+// as "Movie (2005).en.forced.srt". folder is the target's directory relative to the library. A companion file that can't
+// be imported is skipped.
+func (l *MediaLibrary) importExtraFiles(ctx context.Context, sourcePath, targetPath, folder, libraryRoot string) []ExtraFile {
+	log := logger.FromCtx(ctx).With("source path", sourcePath)
+
+	found, err := l.findExtraFiles(sourcePath)
+	if err != nil {
+		log.Warn("failed to find extra files", zap.Error(err))
+		return nil
+	}
+
+	targetName := filepath.Base(targetPath)
+	targetStem := strings.TrimSuffix(targetName, filepath.Ext(targetName))
+
+	var extras []ExtraFile
+	for _, f := range found {
+		target := filepath.Join(filepath.Dir(targetPath), targetStem+f.suffix)
+
+		info, actualTarget, err := l.moveFileToLibrary(ctx, f.path, target, libraryRoot)
+		if err != nil && !errors.Is(err, io.ErrFileExists) {
+			log.Warn("failed to import extra file", zap.String("extra file", f.path), zap.Error(err))
+			continue
+		}
+
+		extras = append(extras, ExtraFile{
+			RelativePath: path.Join(filepath.ToSlash(folder), sanitizeName(filepath.Base(actualTarget))),
+			AbsolutePath: actualTarget,
+			Size:         info.Size(),
+		})
+	}
+
+	return extras
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/kasuboski/mediaz/pkg/io"
 	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/parser"
 
 	"go.uber.org/zap"
 )
@@ -28,30 +31,45 @@ type MediaLibrary struct {
 	movies       FileSystem
 	tv           FileSystem
 	useHardlinks bool
+	// extraFileExtensions are the companion files imported along with a video file, see WithExtraFileExtensions
+	extraFileExtensions []string
+	// now is when files are recycled, see recycleFolder
+	now func() time.Time
+	// minimumFreeSpace is the bytes a copy into the library has to leave free, see WithMinimumFreeSpace
+	minimumFreeSpace int64
 }
 
 // New creates a new library
-func New(movies FileSystem, tv FileSystem, io io.FileIO, useHardlinks bool) Library {
-	return &MediaLibrary{
+func New(movies FileSystem, tv FileSystem, io io.FileIO, useHardlinks bool, opts ...Option) Library {
+	l := &MediaLibrary{
 		movies:       movies,
 		tv:           tv,
 		io:           io,
 		useHardlinks: useHardlinks,
+		now:          time.Now,
 	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
 }
 
-// AddMovie adds a movie file from an absolute path to the movie library.
-// If a directory does not exist for the movie, it will be created using the title provided.
+// AddMovie adds a movie file from an absolute path to the movie library as name, its path relative to the library
+// without the file extension, e.g. "Batman Begins (2005)/Batman Begins (2005)". Missing directories are created.
 // This assumes the source path is not already relative to the library, i.e it was downloaded or discoverd outside of the library.
-func (l *MediaLibrary) AddMovie(ctx context.Context, title, sourcePath string) (MovieFile, error) {
+// A non-empty edition is kept in the file name, see movieFilename. Companion files named after the source file are
+// imported along with it, see importExtraFiles.
+func (l *MediaLibrary) AddMovie(ctx context.Context, name, sourcePath, edition string) (MovieFile, error) {
 	log := logger.FromCtx(ctx)
-	log = log.With("source path", sourcePath, "movie library path", l.movies.Path, "title", title)
+	log = log.With("source path", sourcePath, "movie library path", l.movies.Path, "name", name)
 
 	var movieFile MovieFile
 
-	// downloads/file.mp4 -> /library/movies/batman begins/file.mp4
-	targetDir := filepath.Join(l.movies.Path, title)
-	targetPath := filepath.Join(targetDir, filepath.Base(sourcePath))
+	// downloads/file.mp4 -> /library/movies/Batman Begins (2005)/Batman Begins (2005).mp4
+	folder := filepath.Dir(name)
+	targetPath := filepath.Join(l.movies.Path, filepath.FromSlash(MovieFilePath(name, filepath.Ext(sourcePath), edition)))
 
 	fileInfo, actualTargetPath, err := l.moveFileToLibrary(ctx, sourcePath, targetPath, l.movies.Path)
 	if err != nil {
@@ -63,12 +81,29 @@ func (l *MediaLibrary) AddMovie(ctx context.Context, title, sourcePath string) (
 
 	movieFile.Name = sanitizeName(filepath.Base(actualTargetPath))
 	movieFile.Size = fileInfo.Size()
-	movieFile.RelativePath = fmt.Sprintf("%s/%s", title, movieFile.Name)
+	movieFile.RelativePath = path.Join(filepath.ToSlash(folder), movieFile.Name)
 	movieFile.AbsolutePath = actualTargetPath
+	movieFile.ExtraFiles = l.importExtraFiles(ctx, sourcePath, actualTargetPath, folder, l.movies.Path)
 
 	return movieFile, err
 }
 
+// MovieFilePath is the path relative to the movie library that AddMovie adds a file named name with the extension ext at
+func MovieFilePath(name, ext, edition string) string {
+	return path.Join(filepath.ToSlash(filepath.Dir(name)), sanitizeFilename(movieFilename(filepath.Base(name)+ext, edition)))
+}
+
+// movieFilename adds the edition to a movie's file name as a {edition-Name} tag, unless the name already says which
+// edition it is
+func movieFilename(name, edition string) string {
+	if edition == "" || strings.EqualFold(parser.Parse(name).Edition, parser.NormalizeEdition(edition)) {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	return fmt.Sprintf("%s {edition-%s}%s", strings.TrimSuffix(name, ext), edition, ext)
+}
+
 // moveFileToLibrary is a common helper that handles the file system operations
 // for moving files from downloads to library locations. Returns the file info and the actual target path used.
 // if the file already exists, ErrFileExists is returned along with the file info.
@@ -132,6 +167,12 @@ func (l *MediaLibrary) moveFile(ctx context.Context, libraryRoot, sourcePath, ta
 		return l.renameFile(ctx, sourcePath, targetPath)
 	}
 
+	err = l.ensureFreeSpace(ctx, sourcePath, filepath.Dir(targetPath))
+	if err != nil {
+		log.Warn("not copying file into library", zap.Error(err))
+		return err
+	}
+
 	return l.copyFile(ctx, sourcePath, targetPath)
 }
 
@@ -154,21 +195,18 @@ func (l *MediaLibrary) copyFile(ctx context.Context, sourcePath, targetPath stri
 	return err
 }
 
-// AddEpisode adds an episode file from an absolute path to the TV library.
-// The episode will be organized into the proper series/season directory structure.
-// This assumes the source path is not already relative to the library.
-func (l *MediaLibrary) AddEpisode(ctx context.Context, seriesTitle string, seasonNumber int32, sourcePath string) (EpisodeFile, error) {
+// AddEpisode adds an episode file from an absolute path to the TV library as name, its path relative to the library
+// without the file extension, e.g. "Series Name/Season 01/Series Name - S01E01". Missing directories are created.
+// This assumes the source path is not already relative to the library. Companion files are imported like AddMovie does.
+func (l *MediaLibrary) AddEpisode(ctx context.Context, name, sourcePath string) (EpisodeFile, error) {
 	log := logger.FromCtx(ctx)
-	log = log.With("source path", sourcePath, "tv library path", l.tv.Path, "series", seriesTitle, "season", seasonNumber)
+	log = log.With("source path", sourcePath, "tv library path", l.tv.Path, "name", name)
 
 	var episodeFile EpisodeFile
 
 	// downloads/episode.mp4 -> /library/tv/Series Name/Season XX/Episode Title.mp4
-	seasonDirectory := formatSeasonDirectory(seasonNumber)
-	seriesDir := filepath.Join(l.tv.Path, sanitizeName(seriesTitle))
-	seasonDir := filepath.Join(seriesDir, seasonDirectory)
-	filename := formatEpisodeFilename(filepath.Base(sourcePath))
-	targetPath := filepath.Join(seasonDir, filename)
+	folder := filepath.Dir(name)
+	targetPath := filepath.Join(l.tv.Path, filepath.FromSlash(EpisodeFilePath(name, filepath.Ext(sourcePath))))
 
 	fileInfo, actualTargetPath, err := l.moveFileToLibrary(ctx, sourcePath, targetPath, l.tv.Path)
 	if err != nil {
@@ -180,22 +218,16 @@ func (l *MediaLibrary) AddEpisode(ctx context.Context, seriesTitle string, seaso
 
 	episodeFile.Name = sanitizeName(filepath.Base(actualTargetPath))
 	episodeFile.Size = fileInfo.Size()
-	episodeFile.RelativePath = fmt.Sprintf("%s/%s/%s", seriesTitle, seasonDirectory, episodeFile.Name)
+	episodeFile.RelativePath = path.Join(filepath.ToSlash(folder), episodeFile.Name)
 	episodeFile.AbsolutePath = actualTargetPath
+	episodeFile.ExtraFiles = l.importExtraFiles(ctx, sourcePath, actualTargetPath, folder, l.tv.Path)
 
 	return episodeFile, err
 }
 
-// formatSeasonDirectory formats season number as "Season XX"
-func formatSeasonDirectory(seasonNumber int32) string {
-	return fmt.Sprintf("Season %02d", seasonNumber)
-}
-
-func formatEpisodeFilename(filePath string) string {
-	ext := filepath.Ext(filePath)
-	base := strings.TrimSuffix(filepath.Base(filePath), ext)
-	name := fmt.Sprintf("%s%s", base, ext)
-	return sanitizeFilename(name)
+// EpisodeFilePath is the path relative to the TV library that AddEpisode adds a file named name with the extension ext at
+func EpisodeFilePath(name, ext string) string {
+	return path.Join(filepath.ToSlash(filepath.Dir(name)), sanitizeFilename(filepath.Base(name)+ext))
 }
 
 // FindMovies lists media in the movie library
@@ -220,7 +252,7 @@ func (l *MediaLibrary) FindMovies(ctx context.Context) ([]MovieFile, error) {
 			return nil
 		}
 
-		if !match || nesting == 0 || !isVideoFile(path) {
+		if !match || nesting == 0 || !IsVideoFile(path) {
 			return nil
 		}
 
@@ -274,7 +306,7 @@ func (l *MediaLibrary) FindEpisodes(ctx context.Context) ([]EpisodeFile, error)
 			log.Debugw("skipping file", "path", path, "reason", "nesting is 0")
 			return nil
 		}
-		if !isVideoFile(path) {
+		if !IsVideoFile(path) {
 			log.Debugw("skipping file", "path", path, "reason", "not a video file")
 			return nil
 		}
@@ -299,15 +331,19 @@ func levelsOfNesting(path string) int {
 	return strings.Count(path, "/")
 }
 
+// matchMovie reports whether a file name has a title to look the movie up by
 func matchMovie(name string) bool {
-	return movieRegex.MatchString(sanitizeName(name))
+	return parser.Parse(sanitizeName(name)).Title != ""
 }
 
+// matchEpisode reports whether a file name has a series title or numbers the episode
 func matchEpisode(name string) bool {
-	return showRegex.MatchString(sanitizeName(name))
+	parsed := parser.Parse(sanitizeName(name))
+	return parsed.Title != "" || len(parsed.Episodes) > 0 || parsed.AbsoluteEpisode > 0 || parsed.AirDate != nil
 }
 
-func isVideoFile(name string) bool {
+// IsVideoFile reports whether the file has one of the video extensions the library imports
+func IsVideoFile(name string) bool {
 	ext := filepath.Ext(name)
 	return slices.Contains(videoExtensions, strings.ToLower(ext))
 }
@@ -376,6 +412,41 @@ func (l *MediaLibrary) DeleteSeriesDirectory(ctx context.Context, relativePath s
 	return l.deleteDirectory(ctx, l.tv.Path, relativePath)
 }
 
+// MoveMovieFile moves a movie file to another path in the movie library. Both paths are relative to the library and
+// missing directories are created. ErrFileExists is returned if there's already a file at the new path.
+func (l *MediaLibrary) MoveMovieFile(ctx context.Context, from, to string) error {
+	return l.relocateFile(ctx, l.movies.Path, from, to)
+}
+
+// MoveSeriesFile moves a TV episode file to another path in the TV library. Both paths are relative to the library and
+// missing directories are created. ErrFileExists is returned if there's already a file at the new path.
+func (l *MediaLibrary) MoveSeriesFile(ctx context.Context, from, to string) error {
+	return l.relocateFile(ctx, l.tv.Path, from, to)
+}
+
+// relocateFile is a helper that renames a file within a library
+func (l *MediaLibrary) relocateFile(ctx context.Context, rootPath, from, to string) error {
+	sourcePath := filepath.Join(rootPath, filepath.FromSlash(from))
+	targetPath := filepath.Join(rootPath, filepath.FromSlash(to))
+
+	log := logger.FromCtx(ctx).With("path", sourcePath, "target path", targetPath)
+	log.Info("moving file in library")
+
+	err := l.io.MkdirAll(filepath.Dir(targetPath), os.ModePerm)
+	if err != nil {
+		log.Warn("failed to create target directory", zap.Error(err))
+		return err
+	}
+
+	err = l.renameFile(ctx, sourcePath, targetPath)
+	if err != nil {
+		log.Warn("failed to move file", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 // deleteFile is a helper that removes a single file from the library
 func (l *MediaLibrary) deleteFile(ctx context.Context, rootPath, relativePath string) error {
 	log := logger.FromCtx(ctx)
@@ -14,6 +14,7 @@ import (
 	"github.com/kasuboski/mediaz/pkg/io"
 	"github.com/kasuboski/mediaz/pkg/io/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -329,7 +330,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 		library := New(FileSystem{}, fileSystem, mockfs, true)
 
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{}
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "expected testing error")
@@ -363,7 +364,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 		ctx := context.Background()
 
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         "",
 			Size:         0,
@@ -409,7 +410,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 		library := New(fileSystem, FileSystem{}, mockfs, true)
 
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         filepath.Base(tmpFile.Name()),
 			Size:         1024,
@@ -451,7 +452,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 
 		ctx := context.Background()
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         filepath.Base(tmpFile.Name()),
 			Size:         1024,
@@ -495,7 +496,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 		library := New(fileSystem, FileSystem{}, mockfs, true)
 
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         filepath.Base(tmpFile.Name()),
 			Size:         1024,
@@ -538,7 +539,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 
 		ctx := context.Background()
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         filepath.Base(tmpFile.Name()),
 			Size:         1024,
@@ -583,7 +584,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 		library := New(fileSystem, FileSystem{}, mockfs, false)
 
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         filepath.Base(tmpFile.Name()),
 			Size:         1024,
@@ -612,8 +613,9 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 
 		mockfs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 		mockfs.EXPECT().IsSameFileSystem(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+		mockfs.EXPECT().DiskUsage(gomock.Any()).Times(1).Return(io.DiskUsage{Total: 1 << 40, Free: 1 << 40}, nil)
 		mockfs.EXPECT().Copy(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
-		mockfs.EXPECT().Stat(gomock.Any()).Times(1).Return(mockFileInfo, nil)
+		mockfs.EXPECT().Stat(gomock.Any()).Times(2).Return(mockFileInfo, nil)
 
 		fs, _ := MovieFSFromFile(t, "./testing/test_movies.txt")
 
@@ -626,7 +628,7 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 
 		ctx := context.Background()
 		title := "Batman Begins"
-		movieFile, err := library.AddMovie(ctx, title, movieToAdd)
+		movieFile, err := library.AddMovie(ctx, filepath.Join(title, trimExt(filepath.Base(tmpFile.Name()))), movieToAdd, "")
 		wantMovieFile := MovieFile{
 			Name:         filepath.Base(tmpFile.Name()),
 			Size:         1024,
@@ -639,6 +641,27 @@ func TestMediaLibrary_AddMovie(t *testing.T) {
 	})
 }
 
+func TestMovieFilename(t *testing.T) {
+	tests := []struct {
+		name    string
+		edition string
+		want    string
+	}{
+		{name: "Movie.2020.1080p.BluRay.mkv", want: "Movie.2020.1080p.BluRay.mkv"},
+		{name: "Movie.2020.1080p.BluRay.mkv", edition: "Extended", want: "Movie.2020.1080p.BluRay {edition-Extended}.mkv"},
+		{name: "Movie.2020.Extended.Cut.1080p.BluRay.mkv", edition: "Extended", want: "Movie.2020.Extended.Cut.1080p.BluRay.mkv"},
+		{name: "Movie.2020.Directors.Cut.1080p.mkv", edition: "Director's Cut", want: "Movie.2020.Directors.Cut.1080p.mkv"},
+		{name: "Movie (2020) {edition-Final Cut}.mkv", edition: "Final Cut", want: "Movie (2020) {edition-Final Cut}.mkv"},
+		{name: "Movie.2020.IMAX.1080p.mkv", edition: "Extended", want: "Movie.2020.IMAX.1080p {edition-Extended}.mkv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, movieFilename(tt.name, tt.edition))
+		})
+	}
+}
+
 func TestMediaLibrary_AddEpisode(t *testing.T) {
 	t.Run("same file system - success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -673,7 +696,7 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 
 		seriesTitle := "Series Name"
 		var seasonNumber int32 = 1
-		episodeFile, err := library.AddEpisode(ctx, seriesTitle, seasonNumber, episodeToAdd)
+		episodeFile, err := library.AddEpisode(ctx, filepath.Join(seriesTitle, fmt.Sprintf("Season %02d", seasonNumber), trimExt(filepath.Base(tmpFile.Name()))), episodeToAdd)
 
 		expectedFilename := filepath.Base(tmpFile.Name())
 		wantEpisodeFile := EpisodeFile{
@@ -720,7 +743,7 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 
 		seriesTitle := "Series Name"
 		var seasonNumber int32 = 1
-		episodeFile, err := library.AddEpisode(ctx, seriesTitle, seasonNumber, episodeToAdd)
+		episodeFile, err := library.AddEpisode(ctx, filepath.Join(seriesTitle, fmt.Sprintf("Season %02d", seasonNumber), trimExt(filepath.Base(tmpFile.Name()))), episodeToAdd)
 
 		expectedFilename := filepath.Base(tmpFile.Name())
 		wantEpisodeFile := EpisodeFile{
@@ -767,7 +790,7 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 
 		seriesTitle := "Series Name"
 		var seasonNumber int32 = 1
-		episodeFile, err := library.AddEpisode(ctx, seriesTitle, seasonNumber, episodeToAdd)
+		episodeFile, err := library.AddEpisode(ctx, filepath.Join(seriesTitle, fmt.Sprintf("Season %02d", seasonNumber), trimExt(filepath.Base(tmpFile.Name()))), episodeToAdd)
 
 		expectedFilename := filepath.Base(tmpFile.Name())
 		wantEpisodeFile := EpisodeFile{
@@ -815,7 +838,7 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 
 		seriesTitle := "Series Name"
 		var seasonNumber int32 = 1
-		episodeFile, err := library.AddEpisode(ctx, seriesTitle, seasonNumber, episodeToAdd)
+		episodeFile, err := library.AddEpisode(ctx, filepath.Join(seriesTitle, fmt.Sprintf("Season %02d", seasonNumber), trimExt(filepath.Base(tmpFile.Name()))), episodeToAdd)
 
 		expectedFilename := filepath.Base(tmpFile.Name())
 		wantEpisodeFile := EpisodeFile{
@@ -848,8 +871,9 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 
 		mockfs.EXPECT().MkdirAll(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 		mockfs.EXPECT().IsSameFileSystem(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+		mockfs.EXPECT().DiskUsage(gomock.Any()).Times(1).Return(io.DiskUsage{Total: 1 << 40, Free: 1 << 40}, nil)
 		mockfs.EXPECT().Copy(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
-		mockfs.EXPECT().Stat(gomock.Any()).Times(1).Return(mockFileInfo, nil)
+		mockfs.EXPECT().Stat(gomock.Any()).Times(2).Return(mockFileInfo, nil)
 
 		fs, _ := TVFSFromFile(t, "./testing/test_episodes.txt")
 
@@ -862,7 +886,7 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 
 		seriesTitle := "Series Name"
 		var seasonNumber int32 = 1
-		episodeFile, err := library.AddEpisode(ctx, seriesTitle, seasonNumber, episodeToAdd)
+		episodeFile, err := library.AddEpisode(ctx, filepath.Join(seriesTitle, fmt.Sprintf("Season %02d", seasonNumber), trimExt(filepath.Base(tmpFile.Name()))), episodeToAdd)
 
 		expectedFilename := filepath.Base(tmpFile.Name())
 		wantEpisodeFile := EpisodeFile{
@@ -876,3 +900,108 @@ func TestMediaLibrary_AddEpisode(t *testing.T) {
 		assert.Equal(t, wantEpisodeFile, episodeFile)
 	})
 }
+
+func TestMediaLibrary_MoveMovieFile(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (Library, string) {
+		movieDir := t.TempDir()
+
+		err := os.MkdirAll(filepath.Join(movieDir, "Batman Begins"), os.ModePerm)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(movieDir, "Batman Begins", "batman.begins.mkv"), []byte("movie"), 0o644)
+		require.NoError(t, err)
+
+		lib := New(FileSystem{FS: os.DirFS(movieDir), Path: movieDir}, FileSystem{}, &io.MediaFileSystem{}, false)
+		return lib, movieDir
+	}
+
+	t.Run("moves the file into a new folder", func(t *testing.T) {
+		lib, movieDir := setup(t)
+
+		err := lib.MoveMovieFile(ctx, "Batman Begins/batman.begins.mkv", "Batman Begins (2005)/Batman Begins (2005).mkv")
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, filepath.Join(movieDir, "Batman Begins", "batman.begins.mkv"))
+		b, err := os.ReadFile(filepath.Join(movieDir, "Batman Begins (2005)", "Batman Begins (2005).mkv"))
+		require.NoError(t, err)
+		assert.Equal(t, "movie", string(b))
+	})
+
+	t.Run("doesn't replace an existing file", func(t *testing.T) {
+		lib, movieDir := setup(t)
+
+		err := os.WriteFile(filepath.Join(movieDir, "Batman Begins", "Batman Begins.mkv"), []byte("other"), 0o644)
+		require.NoError(t, err)
+
+		err = lib.MoveMovieFile(ctx, "Batman Begins/batman.begins.mkv", "Batman Begins/Batman Begins.mkv")
+		assert.ErrorIs(t, err, io.ErrFileExists)
+		assert.FileExists(t, filepath.Join(movieDir, "Batman Begins", "batman.begins.mkv"))
+	})
+}
+
+func TestMediaLibrary_AddMovie_ExtraFiles(t *testing.T) {
+	ctx := context.Background()
+
+	downloadDir := t.TempDir()
+	movieDir := t.TempDir()
+	for name, content := range map[string]string{
+		"Batman.Begins.2005.1080p.mkv":             "movie",
+		"Batman.Begins.2005.1080p.en.forced.srt":   "subtitle",
+		"Batman.Begins.2005.1080p.NFO":             "nfo",
+		"Batman.Begins.2005.1080p.txt":             "not an extra",
+		"Other.Movie.2005.1080p.srt":               "another movie's subtitle",
+		"Subs/Batman.Begins.2005.1080p.en.srt":     "nested subtitle",
+		"Batman.Begins.2005.1080p.sample.mkv.part": "partial",
+	} {
+		p := filepath.Join(downloadDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+
+	lib := New(FileSystem{FS: os.DirFS(movieDir), Path: movieDir}, FileSystem{}, &io.MediaFileSystem{}, false, WithExtraFileExtensions([]string{".srt", "nfo"}))
+	movieFile, err := lib.AddMovie(ctx, "Batman Begins (2005)/Batman Begins (2005)", filepath.Join(downloadDir, "Batman.Begins.2005.1080p.mkv"), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Batman Begins (2005)/Batman Begins (2005).mkv", movieFile.RelativePath)
+	assert.Equal(t, []ExtraFile{
+		{
+			RelativePath: "Batman Begins (2005)/Batman Begins (2005).NFO",
+			AbsolutePath: filepath.Join(movieDir, "Batman Begins (2005)", "Batman Begins (2005).NFO"),
+			Size:         3,
+		},
+		{
+			RelativePath: "Batman Begins (2005)/Batman Begins (2005).en.forced.srt",
+			AbsolutePath: filepath.Join(movieDir, "Batman Begins (2005)", "Batman Begins (2005).en.forced.srt"),
+			Size:         8,
+		},
+	}, movieFile.ExtraFiles)
+
+	assert.FileExists(t, filepath.Join(downloadDir, "Batman.Begins.2005.1080p.txt"), "only the configured extensions are imported")
+	assert.FileExists(t, filepath.Join(downloadDir, "Other.Movie.2005.1080p.srt"), "files named after another video aren't imported")
+	assert.FileExists(t, filepath.Join(downloadDir, "Subs", "Batman.Begins.2005.1080p.en.srt"), "subdirectories aren't searched")
+
+	t.Run("without extensions only the video is imported", func(t *testing.T) {
+		downloadDir := t.TempDir()
+		tvDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(downloadDir, "Series.S01E01.mkv"), []byte("episode"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(downloadDir, "Series.S01E01.srt"), []byte("subtitle"), 0o644))
+
+		lib := New(FileSystem{}, FileSystem{FS: os.DirFS(tvDir), Path: tvDir}, &io.MediaFileSystem{}, false)
+		episodeFile, err := lib.AddEpisode(ctx, "Series/Season 01/Series - S01E01", filepath.Join(downloadDir, "Series.S01E01.mkv"))
+		require.NoError(t, err)
+		assert.Empty(t, episodeFile.ExtraFiles)
+		assert.FileExists(t, filepath.Join(downloadDir, "Series.S01E01.srt"))
+	})
+}
+
+func TestMovieFilePath(t *testing.T) {
+	assert.Equal(t, "Movie (2020)/Movie (2020).mkv", MovieFilePath("Movie (2020)/Movie (2020)", ".mkv", ""))
+	assert.Equal(t, "Movie (2020)/Movie (2020) {edition-Extended}.mkv", MovieFilePath("Movie (2020)/Movie (2020)", ".mkv", "Extended"))
+	assert.Equal(t, "Series/Season 01/Series - S01E01.mkv", EpisodeFilePath(filepath.Join("Series", "Season 01", "Series - S01E01"), ".mkv"))
+}
+
+// trimExt is the library name a file is added with when it keeps its downloaded name
+func trimExt(name string) string {
+	return name[:len(name)-len(filepath.Ext(name))]
+}
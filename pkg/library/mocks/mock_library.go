@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	io "github.com/kasuboski/mediaz/pkg/io"
 	library "github.com/kasuboski/mediaz/pkg/library"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,33 +43,33 @@ func (m *MockLibrary) EXPECT() *MockLibraryMockRecorder {
 }
 
 // AddEpisode mocks base method.
-func (m *MockLibrary) AddEpisode(arg0 context.Context, arg1 string, arg2 int32, arg3 string) (library.EpisodeFile, error) {
+func (m *MockLibrary) AddEpisode(arg0 context.Context, arg1, arg2 string) (library.EpisodeFile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddEpisode", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "AddEpisode", arg0, arg1, arg2)
 	ret0, _ := ret[0].(library.EpisodeFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddEpisode indicates an expected call of AddEpisode.
-func (mr *MockLibraryMockRecorder) AddEpisode(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockLibraryMockRecorder) AddEpisode(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEpisode", reflect.TypeOf((*MockLibrary)(nil).AddEpisode), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEpisode", reflect.TypeOf((*MockLibrary)(nil).AddEpisode), arg0, arg1, arg2)
 }
 
 // AddMovie mocks base method.
-func (m *MockLibrary) AddMovie(arg0 context.Context, arg1, arg2 string) (library.MovieFile, error) {
+func (m *MockLibrary) AddMovie(arg0 context.Context, arg1, arg2, arg3 string) (library.MovieFile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddMovie", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "AddMovie", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(library.MovieFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddMovie indicates an expected call of AddMovie.
-func (mr *MockLibraryMockRecorder) AddMovie(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockLibraryMockRecorder) AddMovie(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovie", reflect.TypeOf((*MockLibrary)(nil).AddMovie), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovie", reflect.TypeOf((*MockLibrary)(nil).AddMovie), arg0, arg1, arg2, arg3)
 }
 
 // DeleteMovieDirectory mocks base method.
@@ -126,6 +128,21 @@ func (mr *MockLibraryMockRecorder) DeleteSeriesFile(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeriesFile", reflect.TypeOf((*MockLibrary)(nil).DeleteSeriesFile), arg0, arg1)
 }
 
+// DiskUsage mocks base method.
+func (m *MockLibrary) DiskUsage(arg0 context.Context, arg1 string) (io.DiskUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiskUsage", arg0, arg1)
+	ret0, _ := ret[0].(io.DiskUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiskUsage indicates an expected call of DiskUsage.
+func (mr *MockLibraryMockRecorder) DiskUsage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskUsage", reflect.TypeOf((*MockLibrary)(nil).DiskUsage), arg0, arg1)
+}
+
 // FindEpisodes mocks base method.
 func (m *MockLibrary) FindEpisodes(arg0 context.Context) ([]library.EpisodeFile, error) {
 	m.ctrl.T.Helper()
@@ -155,3 +172,136 @@ func (mr *MockLibraryMockRecorder) FindMovies(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMovies", reflect.TypeOf((*MockLibrary)(nil).FindMovies), arg0)
 }
+
+// ListRecycleBin mocks base method.
+func (m *MockLibrary) ListRecycleBin(arg0 context.Context, arg1 string) ([]library.RecycledFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecycleBin", arg0, arg1)
+	ret0, _ := ret[0].([]library.RecycledFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecycleBin indicates an expected call of ListRecycleBin.
+func (mr *MockLibraryMockRecorder) ListRecycleBin(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecycleBin", reflect.TypeOf((*MockLibrary)(nil).ListRecycleBin), arg0, arg1)
+}
+
+// MoveMovieFile mocks base method.
+func (m *MockLibrary) MoveMovieFile(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveMovieFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveMovieFile indicates an expected call of MoveMovieFile.
+func (mr *MockLibraryMockRecorder) MoveMovieFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMovieFile", reflect.TypeOf((*MockLibrary)(nil).MoveMovieFile), arg0, arg1, arg2)
+}
+
+// MoveSeriesFile mocks base method.
+func (m *MockLibrary) MoveSeriesFile(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveSeriesFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveSeriesFile indicates an expected call of MoveSeriesFile.
+func (mr *MockLibraryMockRecorder) MoveSeriesFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveSeriesFile", reflect.TypeOf((*MockLibrary)(nil).MoveSeriesFile), arg0, arg1, arg2)
+}
+
+// PurgeRecycleBin mocks base method.
+func (m *MockLibrary) PurgeRecycleBin(arg0 context.Context, arg1 string, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRecycleBin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRecycleBin indicates an expected call of PurgeRecycleBin.
+func (mr *MockLibraryMockRecorder) PurgeRecycleBin(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRecycleBin", reflect.TypeOf((*MockLibrary)(nil).PurgeRecycleBin), arg0, arg1, arg2)
+}
+
+// RecycleMovieDirectory mocks base method.
+func (m *MockLibrary) RecycleMovieDirectory(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecycleMovieDirectory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecycleMovieDirectory indicates an expected call of RecycleMovieDirectory.
+func (mr *MockLibraryMockRecorder) RecycleMovieDirectory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecycleMovieDirectory", reflect.TypeOf((*MockLibrary)(nil).RecycleMovieDirectory), arg0, arg1, arg2)
+}
+
+// RecycleMovieFile mocks base method.
+func (m *MockLibrary) RecycleMovieFile(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecycleMovieFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecycleMovieFile indicates an expected call of RecycleMovieFile.
+func (mr *MockLibraryMockRecorder) RecycleMovieFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecycleMovieFile", reflect.TypeOf((*MockLibrary)(nil).RecycleMovieFile), arg0, arg1, arg2)
+}
+
+// RecycleSeriesDirectory mocks base method.
+func (m *MockLibrary) RecycleSeriesDirectory(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecycleSeriesDirectory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecycleSeriesDirectory indicates an expected call of RecycleSeriesDirectory.
+func (mr *MockLibraryMockRecorder) RecycleSeriesDirectory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecycleSeriesDirectory", reflect.TypeOf((*MockLibrary)(nil).RecycleSeriesDirectory), arg0, arg1, arg2)
+}
+
+// RecycleSeriesFile mocks base method.
+func (m *MockLibrary) RecycleSeriesFile(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecycleSeriesFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecycleSeriesFile indicates an expected call of RecycleSeriesFile.
+func (mr *MockLibraryMockRecorder) RecycleSeriesFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecycleSeriesFile", reflect.TypeOf((*MockLibrary)(nil).RecycleSeriesFile), arg0, arg1, arg2)
+}
+
+// RestoreRecycledFile mocks base method.
+func (m *MockLibrary) RestoreRecycledFile(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRecycledFile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRecycledFile indicates an expected call of RestoreRecycledFile.
+func (mr *MockLibraryMockRecorder) RestoreRecycledFile(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRecycledFile", reflect.TypeOf((*MockLibrary)(nil).RestoreRecycledFile), arg0, arg1, arg2)
+}
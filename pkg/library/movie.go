@@ -9,6 +9,8 @@ type MovieFile struct {
 	RelativePath string `json:"path"`
 	AbsolutePath string `json:"absolutePath"`
 	Size         int64  `json:"size"`
+	// ExtraFiles are the companion files imported along with the movie file
+	ExtraFiles []ExtraFile `json:"extraFiles,omitempty"`
 }
 
 func (mf MovieFile) String() string {
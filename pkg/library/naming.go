@@ -0,0 +1,103 @@
+package library
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Default naming formats keep the downloaded file name in a folder named after the movie or series
+const (
+	DefaultMovieFolderFormat  = "{Title}"
+	DefaultMovieFileFormat    = "{Original}"
+	DefaultSeriesFolderFormat = "{Title}"
+	DefaultSeasonFolderFormat = "Season {Season:00}"
+	DefaultEpisodeFileFormat  = "{Original}"
+)
+
+// NameTokens are the values a naming format is filled in with. Zero values render as nothing.
+type NameTokens struct {
+	Title   string
+	Year    int32
+	TMDBID  int32
+	Quality string
+	Edition string
+	Season  int32
+	// Episodes are the episode numbers in the file, a multi-episode file renders them like 01-E02
+	Episodes     []int32
+	EpisodeTitle string
+	// Original is the downloaded file name without its extension
+	Original string
+}
+
+var (
+	nameTokenRegex  = regexp.MustCompile(`\{([A-Za-z][A-Za-z-]*)(?::(0+))?\}`)
+	emptyGroupRegex = regexp.MustCompile(`\(\s*\)|\[\s*\]|\{\s*\}`)
+	spacesRegex     = regexp.MustCompile(`\s{2,}`)
+)
+
+// FormatName fills in a naming format like "{Title} ({Year})" or "Season {Season:00}". A number token is zero padded
+// to the number of zeros after the colon. Unknown tokens are kept as written, and brackets left empty by a missing
+// value are removed. Values can't add path separators or characters that aren't allowed in file names.
+func FormatName(format string, tokens NameTokens) string {
+	name := nameTokenRegex.ReplaceAllStringFunc(format, func(token string) string {
+		match := nameTokenRegex.FindStringSubmatch(token)
+		value, ok := tokens.value(strings.ToLower(match[1]), len(match[2]))
+		if !ok {
+			return token
+		}
+		return sanitizeNameValue(value)
+	})
+
+	name = emptyGroupRegex.ReplaceAllString(name, "")
+	name = spacesRegex.ReplaceAllString(name, " ")
+	return strings.Trim(name, " -._")
+}
+
+func (t NameTokens) value(token string, pad int) (string, bool) {
+	switch token {
+	case "title":
+		return t.Title, true
+	case "year":
+		return formatNameNumber(t.Year, pad), true
+	case "tmdb-id":
+		if t.TMDBID == 0 {
+			return "", true
+		}
+		return fmt.Sprintf("{tmdb-%d}", t.TMDBID), true
+	case "quality":
+		return t.Quality, true
+	case "edition":
+		return t.Edition, true
+	case "season":
+		return fmt.Sprintf("%0*d", pad, t.Season), true
+	case "episode":
+		episodes := make([]string, len(t.Episodes))
+		for i, e := range t.Episodes {
+			episodes[i] = fmt.Sprintf("%0*d", pad, e)
+		}
+		return strings.Join(episodes, "-E"), true
+	case "episodetitle":
+		return t.EpisodeTitle, true
+	case "original":
+		return t.Original, true
+	default:
+		return "", false
+	}
+}
+
+func formatNameNumber(n int32, pad int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%0*d", pad, n)
+}
+
+// sanitizeNameValue makes a value safe to use in a file name, e.g. "Face/Off" becomes "Face-Off" and
+// "Star Wars: A New Hope" becomes "Star Wars - A New Hope"
+func sanitizeNameValue(value string) string {
+	value = strings.ReplaceAll(value, ": ", " - ")
+	return strings.NewReplacer(
+		"/", "-", "\\", "-", ":", "-", "*", "-", "?", "", "\"", "'", "<", "", ">", "", "|", "-",
+	).Replace(value)
+}
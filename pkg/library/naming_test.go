@@ -0,0 +1,49 @@
+package library
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatName(t *testing.T) {
+	movie := NameTokens{
+		Title:    "Star Wars: A New Hope",
+		Year:     1977,
+		TMDBID:   11,
+		Quality:  "Bluray-1080p",
+		Original: "Star.Wars.1977.1080p.BluRay-GRP",
+	}
+	episode := NameTokens{
+		Title:        "Series",
+		Season:       1,
+		Episodes:     []int32{1, 2},
+		EpisodeTitle: "Pilot",
+		Quality:      "WEBDL-720p",
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		tokens NameTokens
+		want   string
+	}{
+		{name: "movie folder", format: "{Title} ({Year}) {tmdb-id}", tokens: movie, want: "Star Wars - A New Hope (1977) {tmdb-11}"},
+		{name: "movie file", format: "{Title} ({Year}) [{Quality}] {Edition}", tokens: movie, want: "Star Wars - A New Hope (1977) [Bluray-1080p]"},
+		{name: "original name", format: "{Original}", tokens: movie, want: "Star.Wars.1977.1080p.BluRay-GRP"},
+		{name: "tokens are case insensitive", format: "{title} {YEAR}", tokens: movie, want: "Star Wars - A New Hope 1977"},
+		{name: "missing year drops its brackets", format: "{Title} ({Year})", tokens: NameTokens{Title: "Movie"}, want: "Movie"},
+		{name: "season folder", format: "Season {Season:00}", tokens: episode, want: "Season 01"},
+		{name: "unpadded season", format: "Season {Season}", tokens: episode, want: "Season 1"},
+		{name: "multi-episode file", format: "{Title} - S{Season:00}E{Episode:00} - {EpisodeTitle} [{Quality}]", tokens: episode, want: "Series - S01E01-E02 - Pilot [WEBDL-720p]"},
+		{name: "missing episode title", format: "{Title} - S{Season:00}E{Episode:00} - {EpisodeTitle}", tokens: NameTokens{Title: "Series", Season: 2, Episodes: []int32{5}}, want: "Series - S02E05"},
+		{name: "unknown token is kept", format: "{Title} {Unknown}", tokens: movie, want: "Star Wars - A New Hope {Unknown}"},
+		{name: "path separators are replaced", format: "{Title}", tokens: NameTokens{Title: "Face/Off"}, want: "Face-Off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatName(tt.format, tt.tokens))
+		})
+	}
+}
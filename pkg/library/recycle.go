@@ -0,0 +1,329 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// The recycle bin has a folder for each library. Files recycled together are moved into a folder named after when
+// they were recycled, keeping their path relative to the library, e.g.
+// "movies/20240102T150405Z/Batman Begins (2005)/Batman Begins (2005).mkv".
+const (
+	RecycleBinMovies = "movies"
+	RecycleBinTV     = "tv"
+
+	recycleTimeLayout = "20060102T150405Z"
+)
+
+// ErrInvalidRecycledFile is returned for an id that isn't the path of a file in the recycle bin
+var ErrInvalidRecycledFile = errors.New("invalid recycle bin file")
+
+// RecycledFile is a file in the recycle bin
+type RecycledFile struct {
+	// ID is the file's path relative to the recycle bin
+	ID string `json:"id"`
+	// Library is the library the file was recycled from, RecycleBinMovies or RecycleBinTV
+	Library string `json:"library"`
+	// Path is the file's path relative to its library, where it's restored to
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// RecycleMovieFile moves a single movie file from the library into the recycle bin, keeping its path relative to the library.
+// It returns the path the file was moved to.
+func (l *MediaLibrary) RecycleMovieFile(ctx context.Context, relativePath, recycleBin string) (string, error) {
+	return l.recycleFile(ctx, l.movies.Path, relativePath, l.recycleFolder(recycleBin, RecycleBinMovies))
+}
+
+// RecycleSeriesFile moves a single TV episode file from the library into the recycle bin, keeping its path relative to the library.
+// It returns the path the file was moved to.
+func (l *MediaLibrary) RecycleSeriesFile(ctx context.Context, relativePath, recycleBin string) (string, error) {
+	return l.recycleFile(ctx, l.tv.Path, relativePath, l.recycleFolder(recycleBin, RecycleBinTV))
+}
+
+// RecycleMovieDirectory moves a movie directory and all its contents from the library into the recycle bin.
+// It returns the path the directory was moved to.
+func (l *MediaLibrary) RecycleMovieDirectory(ctx context.Context, relativePath, recycleBin string) (string, error) {
+	return l.recycleDirectory(ctx, l.movies.Path, relativePath, l.recycleFolder(recycleBin, RecycleBinMovies))
+}
+
+// RecycleSeriesDirectory moves a series directory and all its contents from the library into the recycle bin.
+// It returns the path the directory was moved to.
+func (l *MediaLibrary) RecycleSeriesDirectory(ctx context.Context, relativePath, recycleBin string) (string, error) {
+	return l.recycleDirectory(ctx, l.tv.Path, relativePath, l.recycleFolder(recycleBin, RecycleBinTV))
+}
+
+// recycleFolder is the folder in the recycle bin that files recycled from a library now are moved to
+func (l *MediaLibrary) recycleFolder(recycleBin, library string) string {
+	return filepath.Join(recycleBin, library, l.now().UTC().Format(recycleTimeLayout))
+}
+
+// recycleFile is a helper that moves a single file from the library into a recycle bin folder.
+// A file already in the folder at the same path is replaced.
+func (l *MediaLibrary) recycleFile(ctx context.Context, rootPath, relativePath, binPath string) (string, error) {
+	sourcePath := filepath.Join(rootPath, relativePath)
+	targetPath := filepath.Join(binPath, relativePath)
+
+	log := logger.FromCtx(ctx).With("path", sourcePath, "recycle bin path", targetPath)
+	log.Info("moving file to recycle bin")
+
+	err := l.io.MkdirAll(filepath.Dir(targetPath), os.ModePerm)
+	if err != nil {
+		log.Warn("failed to create recycle bin directory", zap.Error(err))
+		return "", err
+	}
+
+	if _, err := l.io.Stat(targetPath); err == nil {
+		err = l.io.Remove(targetPath)
+		if err != nil {
+			log.Warn("failed to remove file already in recycle bin", zap.Error(err))
+			return "", err
+		}
+	}
+
+	err = l.transferFile(ctx, sourcePath, targetPath)
+	if err != nil {
+		log.Warn("failed to move file to recycle bin", zap.Error(err))
+		return "", err
+	}
+
+	return targetPath, nil
+}
+
+// recycleDirectory is a helper that moves every file in a library directory into a recycle bin folder and then removes
+// the directory. A directory that doesn't exist has nothing to recycle.
+func (l *MediaLibrary) recycleDirectory(ctx context.Context, rootPath, relativePath, binPath string) (string, error) {
+	sourcePath := filepath.Join(rootPath, relativePath)
+	targetPath := filepath.Join(binPath, relativePath)
+
+	log := logger.FromCtx(ctx).With("path", sourcePath, "recycle bin path", targetPath)
+	log.Info("moving directory to recycle bin")
+
+	if _, err := l.io.Stat(sourcePath); errors.Is(err, fs.ErrNotExist) {
+		log.Debug("directory doesn't exist, nothing to recycle")
+		return targetPath, nil
+	}
+
+	var files []string
+	err := l.io.WalkDir(os.DirFS(sourcePath), ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn("failed to list directory", zap.Error(err))
+		return "", err
+	}
+
+	for _, f := range files {
+		_, err := l.recycleFile(ctx, rootPath, filepath.Join(relativePath, filepath.FromSlash(f)), binPath)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = l.io.RemoveAll(sourcePath)
+	if err != nil {
+		log.Warn("failed to remove directory after recycling its files", zap.Error(err))
+		return "", err
+	}
+
+	return targetPath, nil
+}
+
+// transferFile renames a file if the target is on the same file system, otherwise it's copied and the source removed
+func (l *MediaLibrary) transferFile(ctx context.Context, sourcePath, targetPath string) error {
+	ok, err := l.io.IsSameFileSystem(sourcePath, filepath.Dir(targetPath))
+	if err != nil {
+		logger.FromCtx(ctx).Debug("failed to determine if source and target share a file system", zap.Error(err))
+		return err
+	}
+
+	if ok {
+		return l.renameFile(ctx, sourcePath, targetPath)
+	}
+
+	err = l.copyFile(ctx, sourcePath, targetPath)
+	if err != nil {
+		return err
+	}
+
+	return l.io.Remove(sourcePath)
+}
+
+// ListRecycleBin lists the files in the recycle bin, most recently deleted first. Files that aren't in a folder named
+// after when they were recycled aren't listed.
+func (l *MediaLibrary) ListRecycleBin(ctx context.Context, recycleBin string) ([]RecycledFile, error) {
+	log := logger.FromCtx(ctx)
+
+	files := make([]RecycledFile, 0)
+	for _, library := range []string{RecycleBinMovies, RecycleBinTV} {
+		root := filepath.Join(recycleBin, library)
+		if _, err := l.io.Stat(root); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		err := l.io.WalkDir(os.DirFS(root), ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			folder, relativePath, ok := strings.Cut(p, "/")
+			deletedAt, err := time.Parse(recycleTimeLayout, folder)
+			if !ok || err != nil {
+				log.Debug("skipping file outside of a recycle bin folder", zap.String("path", path.Join(library, p)))
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			files = append(files, RecycledFile{
+				ID:        path.Join(library, p),
+				Library:   library,
+				Path:      relativePath,
+				Size:      info.Size(),
+				DeletedAt: deletedAt,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list recycle bin: %w", err)
+		}
+	}
+
+	slices.SortStableFunc(files, func(a, b RecycledFile) int {
+		return b.DeletedAt.Compare(a.DeletedAt)
+	})
+
+	return files, nil
+}
+
+// RestoreRecycledFile moves a file from the recycle bin back to its path in its library and returns that path.
+// io.ErrFileExists is returned if there's already a file at the path. Recycle bin folders left empty are removed.
+func (l *MediaLibrary) RestoreRecycledFile(ctx context.Context, recycleBin, id string) (string, error) {
+	id = path.Clean(filepath.ToSlash(id))
+	if !fs.ValidPath(id) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidRecycledFile, id)
+	}
+
+	library, rest, _ := strings.Cut(id, "/")
+	folder, relativePath, ok := strings.Cut(rest, "/")
+	if _, err := time.Parse(recycleTimeLayout, folder); !ok || err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidRecycledFile, id)
+	}
+
+	var rootPath string
+	switch library {
+	case RecycleBinMovies:
+		rootPath = l.movies.Path
+	case RecycleBinTV:
+		rootPath = l.tv.Path
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidRecycledFile, id)
+	}
+
+	sourcePath := filepath.Join(recycleBin, filepath.FromSlash(id))
+	targetPath := filepath.Join(rootPath, filepath.FromSlash(relativePath))
+
+	log := logger.FromCtx(ctx).With("recycle bin path", sourcePath, "path", targetPath)
+	log.Info("restoring file from recycle bin")
+
+	info, err := l.io.Stat(sourcePath)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%w: %s is a directory", ErrInvalidRecycledFile, id)
+	}
+
+	err = l.io.MkdirAll(filepath.Dir(targetPath), os.ModePerm)
+	if err != nil {
+		log.Warn("failed to create library directory", zap.Error(err))
+		return "", err
+	}
+
+	err = l.transferFile(ctx, sourcePath, targetPath)
+	if err != nil {
+		log.Warn("failed to restore file", zap.Error(err))
+		return "", err
+	}
+
+	// removing a folder that isn't empty fails, which stops at the first one still holding recycled files
+	stop := filepath.Join(recycleBin, library)
+	for dir := filepath.Dir(sourcePath); dir != stop && strings.HasPrefix(dir, stop); dir = filepath.Dir(dir) {
+		if l.io.Remove(dir) != nil {
+			break
+		}
+	}
+
+	return relativePath, nil
+}
+
+// PurgeRecycleBin permanently removes the recycle bin folders of files recycled before a time and returns how many
+// folders were removed
+func (l *MediaLibrary) PurgeRecycleBin(ctx context.Context, recycleBin string, before time.Time) (int, error) {
+	log := logger.FromCtx(ctx)
+
+	purged := 0
+	for _, library := range []string{RecycleBinMovies, RecycleBinTV} {
+		root := filepath.Join(recycleBin, library)
+		if _, err := l.io.Stat(root); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		err := l.io.WalkDir(os.DirFS(root), ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if p == "." {
+				return nil
+			}
+			if !d.IsDir() {
+				return nil
+			}
+
+			deletedAt, err := time.Parse(recycleTimeLayout, p)
+			if err != nil || !deletedAt.Before(before) {
+				return fs.SkipDir
+			}
+
+			folder := filepath.Join(root, p)
+			err = l.io.RemoveAll(folder)
+			if err != nil {
+				log.Warn("failed to purge recycle bin folder", zap.String("path", folder), zap.Error(err))
+				return fs.SkipDir
+			}
+
+			log.Info("purged recycle bin folder", zap.String("path", folder))
+			purged++
+			return fs.SkipDir
+		})
+		if err != nil {
+			return purged, fmt.Errorf("failed to purge recycle bin: %w", err)
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,198 @@
+package library
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRecycleLibrary creates a library in temporary directories that recycles files at a fixed time
+func newRecycleLibrary(t *testing.T, now time.Time) (*MediaLibrary, string, string, string) {
+	movieDir := t.TempDir()
+	tvDir := t.TempDir()
+	binDir := t.TempDir()
+
+	lib := New(FileSystem{FS: os.DirFS(movieDir), Path: movieDir}, FileSystem{FS: os.DirFS(tvDir), Path: tvDir}, &io.MediaFileSystem{}, false).(*MediaLibrary)
+	lib.now = func() time.Time { return now }
+	return lib, movieDir, tvDir, binDir
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestMediaLibrary_RecycleMovieFile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	t.Run("moves the file into the recycle bin", func(t *testing.T) {
+		lib, movieDir, _, binDir := newRecycleLibrary(t, now)
+		writeTestFile(t, filepath.Join(movieDir, "Batman Begins", "Batman Begins.mkv"), "old")
+
+		path, err := lib.RecycleMovieFile(ctx, "Batman Begins/Batman Begins.mkv", binDir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(binDir, "movies", "20240102T150405Z", "Batman Begins", "Batman Begins.mkv"), path)
+
+		assert.NoFileExists(t, filepath.Join(movieDir, "Batman Begins", "Batman Begins.mkv"))
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(b))
+	})
+
+	t.Run("replaces a file recycled at the same time", func(t *testing.T) {
+		lib, movieDir, _, binDir := newRecycleLibrary(t, now)
+		writeTestFile(t, filepath.Join(movieDir, "Batman Begins", "Batman Begins.mkv"), "old")
+		writeTestFile(t, filepath.Join(binDir, "movies", "20240102T150405Z", "Batman Begins", "Batman Begins.mkv"), "older")
+
+		path, err := lib.RecycleMovieFile(ctx, "Batman Begins/Batman Begins.mkv", binDir)
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(b))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		lib, _, _, binDir := newRecycleLibrary(t, now)
+
+		_, err := lib.RecycleMovieFile(ctx, "Batman Begins/missing.mkv", binDir)
+		assert.Error(t, err)
+	})
+}
+
+func TestMediaLibrary_RecycleSeriesDirectory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	t.Run("moves every file of the directory into the recycle bin", func(t *testing.T) {
+		lib, _, tvDir, binDir := newRecycleLibrary(t, now)
+		writeTestFile(t, filepath.Join(tvDir, "Show", "Season 01", "Show - S01E01.mkv"), "episode")
+		writeTestFile(t, filepath.Join(tvDir, "Show", "Season 01", "Show - S01E01.srt"), "subtitle")
+
+		path, err := lib.RecycleSeriesDirectory(ctx, "Show", binDir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(binDir, "tv", "20240102T150405Z", "Show"), path)
+
+		assert.NoDirExists(t, filepath.Join(tvDir, "Show"))
+		assert.FileExists(t, filepath.Join(path, "Season 01", "Show - S01E01.mkv"))
+		assert.FileExists(t, filepath.Join(path, "Season 01", "Show - S01E01.srt"))
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		lib, _, _, binDir := newRecycleLibrary(t, now)
+
+		_, err := lib.RecycleSeriesDirectory(ctx, "Missing", binDir)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMediaLibrary_ListRecycleBin(t *testing.T) {
+	ctx := context.Background()
+	lib, _, _, binDir := newRecycleLibrary(t, time.Now())
+
+	writeTestFile(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Movie", "Movie.mkv"), "movie")
+	writeTestFile(t, filepath.Join(binDir, "tv", "20240102T000000Z", "Show", "Season 01", "Show - S01E01.mkv"), "episode")
+	writeTestFile(t, filepath.Join(binDir, "movies", "Old Layout", "Old Layout.mkv"), "old")
+
+	files, err := lib.ListRecycleBin(ctx, binDir)
+	require.NoError(t, err)
+	assert.Equal(t, []RecycledFile{
+		{
+			ID:        "tv/20240102T000000Z/Show/Season 01/Show - S01E01.mkv",
+			Library:   RecycleBinTV,
+			Path:      "Show/Season 01/Show - S01E01.mkv",
+			Size:      7,
+			DeletedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "movies/20240101T000000Z/Movie/Movie.mkv",
+			Library:   RecycleBinMovies,
+			Path:      "Movie/Movie.mkv",
+			Size:      5,
+			DeletedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, files)
+
+	t.Run("empty recycle bin", func(t *testing.T) {
+		files, err := lib.ListRecycleBin(ctx, t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
+func TestMediaLibrary_RestoreRecycledFile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("moves the file back into the library", func(t *testing.T) {
+		lib, movieDir, _, binDir := newRecycleLibrary(t, time.Now())
+		writeTestFile(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Movie", "Movie.mkv"), "movie")
+		writeTestFile(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Other", "Other.mkv"), "other")
+
+		path, err := lib.RestoreRecycledFile(ctx, binDir, "movies/20240101T000000Z/Movie/Movie.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, "Movie/Movie.mkv", path)
+
+		b, err := os.ReadFile(filepath.Join(movieDir, "Movie", "Movie.mkv"))
+		require.NoError(t, err)
+		assert.Equal(t, "movie", string(b))
+
+		assert.NoDirExists(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Movie"))
+		assert.FileExists(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Other", "Other.mkv"))
+	})
+
+	t.Run("removes the folder once it's empty", func(t *testing.T) {
+		lib, _, tvDir, binDir := newRecycleLibrary(t, time.Now())
+		writeTestFile(t, filepath.Join(binDir, "tv", "20240101T000000Z", "Show", "Show - S01E01.mkv"), "episode")
+
+		_, err := lib.RestoreRecycledFile(ctx, binDir, "tv/20240101T000000Z/Show/Show - S01E01.mkv")
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(tvDir, "Show", "Show - S01E01.mkv"))
+		assert.NoDirExists(t, filepath.Join(binDir, "tv", "20240101T000000Z"))
+		assert.DirExists(t, filepath.Join(binDir, "tv"))
+	})
+
+	t.Run("file already in the library", func(t *testing.T) {
+		lib, movieDir, _, binDir := newRecycleLibrary(t, time.Now())
+		writeTestFile(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Movie", "Movie.mkv"), "movie")
+		writeTestFile(t, filepath.Join(movieDir, "Movie", "Movie.mkv"), "new")
+
+		_, err := lib.RestoreRecycledFile(ctx, binDir, "movies/20240101T000000Z/Movie/Movie.mkv")
+		assert.ErrorIs(t, err, io.ErrFileExists)
+	})
+
+	t.Run("invalid ids", func(t *testing.T) {
+		lib, _, _, binDir := newRecycleLibrary(t, time.Now())
+
+		for _, id := range []string{"", "movies", "movies/20240101T000000Z", "movies/not-a-time/Movie.mkv", "music/20240101T000000Z/Song.mp3", "../movies/20240101T000000Z/Movie.mkv", "/etc/passwd"} {
+			_, err := lib.RestoreRecycledFile(ctx, binDir, id)
+			assert.ErrorIs(t, err, ErrInvalidRecycledFile, id)
+		}
+	})
+}
+
+func TestMediaLibrary_PurgeRecycleBin(t *testing.T) {
+	ctx := context.Background()
+	lib, _, _, binDir := newRecycleLibrary(t, time.Now())
+
+	writeTestFile(t, filepath.Join(binDir, "movies", "20240101T000000Z", "Movie", "Movie.mkv"), "movie")
+	writeTestFile(t, filepath.Join(binDir, "movies", "20240110T000000Z", "Newer", "Newer.mkv"), "newer")
+	writeTestFile(t, filepath.Join(binDir, "tv", "20240102T000000Z", "Show", "Show - S01E01.mkv"), "episode")
+	writeTestFile(t, filepath.Join(binDir, "movies", "Old Layout", "Old Layout.mkv"), "old")
+
+	purged, err := lib.PurgeRecycleBin(ctx, binDir, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	assert.NoDirExists(t, filepath.Join(binDir, "movies", "20240101T000000Z"))
+	assert.NoDirExists(t, filepath.Join(binDir, "tv", "20240102T000000Z"))
+	assert.FileExists(t, filepath.Join(binDir, "movies", "20240110T000000Z", "Newer", "Newer.mkv"))
+	assert.FileExists(t, filepath.Join(binDir, "movies", "Old Layout", "Old Layout.mkv"))
+}
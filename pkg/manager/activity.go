@@ -279,6 +279,10 @@ func calculateDurations(entries []*storage.HistoryEntry) []*HistoryEntry {
 			metadata = &TransitionMetadata{
 				DownloadClient: nil,
 				DownloadID:     entry.Metadata.DownloadID,
+				ReleaseTitle:   entry.Metadata.ReleaseTitle,
+				IsUpgrade:      entry.Metadata.IsUpgrade,
+				UpgradeReason:  entry.Metadata.UpgradeReason,
+				ReplacedFile:   entry.Metadata.ReplacedFile,
 			}
 			if entry.Metadata.DownloadClient != nil {
 				metadata.DownloadClient = &DownloadClientInfo{
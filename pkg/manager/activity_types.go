@@ -3,9 +3,10 @@ package manager
 import "time"
 
 type ActiveActivityResponse struct {
-	Movies []*ActiveMovie  `json:"movies"`
-	Series []*ActiveSeries `json:"series"`
-	Jobs   []*ActiveJob    `json:"jobs"`
+	Movies  []*ActiveMovie    `json:"movies"`
+	Series  []*ActiveSeries   `json:"series"`
+	Jobs    []*ActiveJob      `json:"jobs"`
+	Pending []*PendingRelease `json:"pending"`
 }
 
 type ActiveMovie struct {
@@ -35,6 +36,19 @@ type ActiveSeries struct {
 	CurrentEpisode *EpisodeInfo        `json:"currentEpisode,omitempty"`
 }
 
+// PendingRelease is a release found for a movie, episode or season pack that is waiting out a delay profile
+type PendingRelease struct {
+	ID        int64     `json:"id"`
+	MovieID   *int32    `json:"movieId,omitempty"`
+	EpisodeID *int32    `json:"episodeId,omitempty"`
+	SeasonID  *int32    `json:"seasonId,omitempty"`
+	Title     string    `json:"title"`
+	Protocol  string    `json:"protocol"`
+	Indexer   string    `json:"indexer,omitempty"`
+	FirstSeen time.Time `json:"firstSeen"`
+	GrabAfter time.Time `json:"grabAfter"`
+}
+
 type ActiveJob struct {
 	ID        int64     `json:"id"`
 	Type      string    `json:"type"`
@@ -132,4 +146,8 @@ type HistoryEntry struct {
 type TransitionMetadata struct {
 	DownloadClient *DownloadClientInfo `json:"downloadClient,omitempty"`
 	DownloadID     string              `json:"downloadID,omitempty"`
+	ReleaseTitle   string              `json:"releaseTitle,omitempty"`
+	IsUpgrade      bool                `json:"isUpgrade,omitempty"`
+	UpgradeReason  string              `json:"upgradeReason,omitempty"`
+	ReplacedFile   string              `json:"replacedFile,omitempty"`
 }
@@ -0,0 +1,247 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/download"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+)
+
+const (
+	BlocklistReasonManual          = "manual"
+	BlocklistReasonDownloadFailed  = "download failed"
+	BlocklistReasonDownloadRemoved = "download removed"
+)
+
+type BlocklistService struct {
+	blocklistStorage storage.BlocklistStorage
+}
+
+func NewBlocklistService(blocklistStorage storage.BlocklistStorage) *BlocklistService {
+	return &BlocklistService{
+		blocklistStorage: blocklistStorage,
+	}
+}
+
+type AddBlocklistEntryRequest struct {
+	MovieID   *int32  `json:"movieId"`
+	EpisodeID *int32  `json:"episodeId"`
+	GUID      *string `json:"guid"`
+	InfoHash  *string `json:"infoHash"`
+	Title     string  `json:"title"`
+	Protocol  *string `json:"protocol"`
+	Indexer   *string `json:"indexer"`
+	Reason    string  `json:"reason"`
+}
+
+// ListBlocklist lists blocklisted releases, optionally filtered to a movie or episode
+func (bs BlocklistService) ListBlocklist(ctx context.Context, movieID, episodeID *int32) ([]*model.ReleaseBlocklist, error) {
+	where := make([]sqlite.BoolExpression, 0)
+	if movieID != nil {
+		where = append(where, table.ReleaseBlocklist.MovieID.EQ(sqlite.Int32(*movieID)))
+	}
+	if episodeID != nil {
+		where = append(where, table.ReleaseBlocklist.EpisodeID.EQ(sqlite.Int32(*episodeID)))
+	}
+
+	if len(where) == 0 {
+		return bs.blocklistStorage.ListBlocklistEntries(ctx)
+	}
+
+	return bs.blocklistStorage.ListBlocklistEntries(ctx, sqlite.AND(where...))
+}
+
+// AddBlocklistEntry manually blocklists a release for a movie or episode
+func (bs BlocklistService) AddBlocklistEntry(ctx context.Context, request AddBlocklistEntryRequest) (*model.ReleaseBlocklist, error) {
+	if (request.MovieID == nil) == (request.EpisodeID == nil) {
+		return nil, fmt.Errorf("%w: exactly one of movieId or episodeId is required", ErrValidation)
+	}
+
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrValidation)
+	}
+
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		reason = BlocklistReasonManual
+	}
+
+	entry := model.ReleaseBlocklist{
+		MovieID:   request.MovieID,
+		EpisodeID: request.EpisodeID,
+		GUID:      request.GUID,
+		InfoHash:  request.InfoHash,
+		Title:     title,
+		Protocol:  request.Protocol,
+		Indexer:   request.Indexer,
+		Reason:    reason,
+	}
+
+	id, err := bs.blocklistStorage.CreateBlocklistEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.blocklistStorage.GetBlocklistEntry(ctx, id)
+}
+
+// DeleteBlocklistEntry removes a release from the blocklist so it can be grabbed again
+func (bs BlocklistService) DeleteBlocklistEntry(ctx context.Context, id int64) error {
+	_, err := bs.blocklistStorage.GetBlocklistEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return bs.blocklistStorage.DeleteBlocklistEntry(ctx, id)
+}
+
+// blocklistMovieRelease blocklists the release a movie was downloading
+func (bs BlocklistService) blocklistMovieRelease(ctx context.Context, movie *storage.Movie, reason string) error {
+	entry, ok := blocklistEntryFromTransition(movie.ReleaseGUID, movie.ReleaseTitle, movie.ReleaseInfoHash, reason)
+	if !ok {
+		return nil
+	}
+
+	entry.MovieID = &movie.ID
+	_, err := bs.blocklistStorage.CreateBlocklistEntry(ctx, entry)
+	return err
+}
+
+// blocklistEpisodeRelease blocklists the release an episode was downloading
+func (bs BlocklistService) blocklistEpisodeRelease(ctx context.Context, episode *storage.Episode, reason string) error {
+	entry, ok := blocklistEntryFromTransition(episode.ReleaseGUID, episode.ReleaseTitle, episode.ReleaseInfoHash, reason)
+	if !ok {
+		return nil
+	}
+
+	entry.EpisodeID = &episode.ID
+	_, err := bs.blocklistStorage.CreateBlocklistEntry(ctx, entry)
+	return err
+}
+
+func (bs BlocklistService) listMovieBlocklist(ctx context.Context, movieID int32) ([]*model.ReleaseBlocklist, error) {
+	return bs.blocklistStorage.ListBlocklistEntries(ctx, table.ReleaseBlocklist.MovieID.EQ(sqlite.Int32(movieID)))
+}
+
+func (bs BlocklistService) listEpisodesBlocklist(ctx context.Context, episodes []*storage.Episode) ([]*model.ReleaseBlocklist, error) {
+	if len(episodes) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]sqlite.Expression, len(episodes))
+	for i, e := range episodes {
+		ids[i] = sqlite.Int32(e.ID)
+	}
+
+	return bs.blocklistStorage.ListBlocklistEntries(ctx, table.ReleaseBlocklist.EpisodeID.IN(ids...))
+}
+
+// blocklistEntryFromTransition builds an entry from the release stored when the download was requested.
+// Downloads requested before releases were tracked have nothing to blocklist.
+func blocklistEntryFromTransition(guid, title, infoHash *string, reason string) (model.ReleaseBlocklist, bool) {
+	if title == nil || *title == "" {
+		return model.ReleaseBlocklist{}, false
+	}
+
+	return model.ReleaseBlocklist{
+		GUID:     guid,
+		InfoHash: infoHash,
+		Title:    *title,
+		Reason:   reason,
+	}, true
+}
+
+// releaseTransitionMetadata captures the identity of a release so it can be blocklisted later
+func releaseTransitionMetadata(r *prowlarr.ReleaseResource) storage.TransitionStateMetadata {
+	var metadata storage.TransitionStateMetadata
+	if r == nil {
+		return metadata
+	}
+
+	if guid, err := r.GUID.Get(); err == nil && guid != "" {
+		metadata.ReleaseGUID = &guid
+	}
+	if title, err := r.Title.Get(); err == nil && title != "" {
+		metadata.ReleaseTitle = &title
+	}
+	if infoHash, err := r.InfoHash.Get(); err == nil && infoHash != "" {
+		metadata.ReleaseInfoHash = &infoHash
+	}
+
+	return metadata
+}
+
+// releaseBlocklist matches releases against blocklist entries by guid, info hash, or title
+type releaseBlocklist struct {
+	guids      map[string]struct{}
+	infoHashes map[string]struct{}
+	titles     map[string]struct{}
+}
+
+func newReleaseBlocklist(entries []*model.ReleaseBlocklist) releaseBlocklist {
+	b := releaseBlocklist{
+		guids:      make(map[string]struct{}),
+		infoHashes: make(map[string]struct{}),
+		titles:     make(map[string]struct{}),
+	}
+
+	for _, e := range entries {
+		if e == nil {
+			continue
+		}
+		if e.GUID != nil && *e.GUID != "" {
+			b.guids[*e.GUID] = struct{}{}
+		}
+		if e.InfoHash != nil && *e.InfoHash != "" {
+			b.infoHashes[strings.ToLower(*e.InfoHash)] = struct{}{}
+		}
+		if title := normalizeBlocklistTitle(e.Title); title != "" {
+			b.titles[title] = struct{}{}
+		}
+	}
+
+	return b
+}
+
+func (b releaseBlocklist) matches(r *prowlarr.ReleaseResource) bool {
+	if r == nil {
+		return false
+	}
+
+	if guid, err := r.GUID.Get(); err == nil && guid != "" {
+		if _, ok := b.guids[guid]; ok {
+			return true
+		}
+	}
+
+	if infoHash, err := r.InfoHash.Get(); err == nil && infoHash != "" {
+		if _, ok := b.infoHashes[strings.ToLower(infoHash)]; ok {
+			return true
+		}
+	}
+
+	if title, err := r.Title.Get(); err == nil {
+		if _, ok := b.titles[normalizeBlocklistTitle(title)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeBlocklistTitle(title string) string {
+	return strings.ToLower(strings.TrimSpace(normalizeSeparators(strings.TrimSpace(title))))
+}
+
+// isDownloadGone reports whether the download client no longer knows about a download
+func isDownloadGone(err error) bool {
+	return errors.Is(err, download.ErrDownloadNotFound)
+}
@@ -0,0 +1,173 @@
+package manager
+
+import (
+	"regexp"
+
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+)
+
+// releaseAttributes are the parts of a release title custom format specifications match against
+type releaseAttributes struct {
+	title        string
+	releaseGroup string
+	source       string
+	resolution   string
+	codec        string
+	edition      string
+	languages    []string
+}
+
+func parseReleaseAttributes(title string) releaseAttributes {
+	parsed := parser.Parse(title)
+	return releaseAttributes{
+		title:        title,
+		releaseGroup: parsed.ReleaseGroup,
+		source:       parsed.Source,
+		resolution:   parsed.Resolution,
+		codec:        parsed.Codec,
+		edition:      parsed.Edition,
+		languages:    parsed.Languages,
+	}
+}
+
+// values returns the attribute a specification type is matched against
+func (a releaseAttributes) values(specType string) []string {
+	switch specType {
+	case storage.CustomFormatSpecTitle:
+		return []string{a.title}
+	case storage.CustomFormatSpecReleaseGroup:
+		return []string{a.releaseGroup}
+	case storage.CustomFormatSpecSource:
+		return []string{a.source}
+	case storage.CustomFormatSpecResolution:
+		return []string{a.resolution}
+	case storage.CustomFormatSpecCodec:
+		return []string{a.codec}
+	case storage.CustomFormatSpecEdition:
+		return []string{a.edition}
+	case storage.CustomFormatSpecLanguage:
+		if len(a.languages) == 0 {
+			return []string{""}
+		}
+		return a.languages
+	default:
+		return nil
+	}
+}
+
+type compiledSpecification struct {
+	storage.CustomFormatSpecification
+	pattern *regexp.Regexp
+}
+
+func (s compiledSpecification) matches(attrs releaseAttributes) bool {
+	matched := false
+	if s.pattern != nil {
+		for _, v := range attrs.values(s.Type) {
+			if v != "" && s.pattern.MatchString(v) {
+				matched = true
+				break
+			}
+		}
+	}
+
+	return matched != s.Negate
+}
+
+type scoredFormat struct {
+	name           string
+	score          int32
+	specifications []compiledSpecification
+}
+
+// matches reports whether a release matches the format. Every required specification has to match,
+// and at least one of the others if there are any.
+func (f scoredFormat) matches(attrs releaseAttributes) bool {
+	if len(f.specifications) == 0 {
+		return false
+	}
+
+	optional, optionalMatched := 0, false
+	for _, s := range f.specifications {
+		matched := s.matches(attrs)
+		if s.Required {
+			if !matched {
+				return false
+			}
+			continue
+		}
+
+		optional++
+		optionalMatched = optionalMatched || matched
+	}
+
+	return optional == 0 || optionalMatched
+}
+
+// formatScorer scores releases with the custom formats of a quality profile
+type formatScorer struct {
+	formats  []scoredFormat
+	minScore int32
+}
+
+func newFormatScorer(profile storage.QualityProfile) formatScorer {
+	scorer := formatScorer{minScore: profile.MinFormatScore}
+
+	for _, pf := range profile.Formats {
+		format := scoredFormat{name: pf.Format.Name, score: pf.Score}
+		for _, spec := range pf.Format.Specifications {
+			// specifications are validated when saved, one that doesn't compile never matches
+			pattern, _ := regexp.Compile("(?i)" + spec.Value)
+			format.specifications = append(format.specifications, compiledSpecification{CustomFormatSpecification: spec, pattern: pattern})
+		}
+		scorer.formats = append(scorer.formats, format)
+	}
+
+	return scorer
+}
+
+// score sums the scores of every custom format the release title matches
+func (f formatScorer) score(title string) int32 {
+	score, _ := f.evaluate(title)
+	return score
+}
+
+// evaluate returns the total score of a release title and the names of the formats it matched
+func (f formatScorer) evaluate(title string) (int32, []string) {
+	if len(f.formats) == 0 {
+		return 0, nil
+	}
+
+	attrs := parseReleaseAttributes(title)
+
+	var score int32
+	var matched []string
+	for _, format := range f.formats {
+		if format.matches(attrs) {
+			score += format.score
+			matched = append(matched, format.name)
+		}
+	}
+
+	return score, matched
+}
+
+func (f formatScorer) releaseScore(r *prowlarr.ReleaseResource) int32 {
+	if r == nil {
+		return 0
+	}
+
+	title, err := r.Title.Get()
+	if err != nil {
+		return 0
+	}
+
+	return f.score(title)
+}
+
+// accepts reports whether a release reaches the profile's minimum custom format score
+func (f formatScorer) accepts(r *prowlarr.ReleaseResource) bool {
+	return f.releaseScore(r) >= f.minScore
+}
@@ -0,0 +1,165 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReleaseAttributes(t *testing.T) {
+	tests := []struct {
+		title string
+		want  releaseAttributes
+	}{
+		{
+			title: "Movie.2019.2160p.UHD.BluRay.REMUX.HDR.HEVC.Atmos-FraMeSToR",
+			want: releaseAttributes{
+				title:        "Movie.2019.2160p.UHD.BluRay.REMUX.HDR.HEVC.Atmos-FraMeSToR",
+				releaseGroup: "FraMeSToR",
+				source:       "Remux",
+				resolution:   "2160p",
+				codec:        "x265",
+			},
+		},
+		{
+			title: "Movie 2019 Directors Cut FRENCH 1080p WEB-DL x264-GRP.mkv",
+			want: releaseAttributes{
+				title:        "Movie 2019 Directors Cut FRENCH 1080p WEB-DL x264-GRP.mkv",
+				releaseGroup: "GRP",
+				source:       "WEB-DL",
+				resolution:   "1080p",
+				codec:        "x264",
+				edition:      "Director's Cut",
+				languages:    []string{"fr"},
+			},
+		},
+		{
+			title: "Movie (2019) {edition-Extended} [Bluray-1080p]",
+			want: releaseAttributes{
+				title:      "Movie (2019) {edition-Extended} [Bluray-1080p]",
+				source:     "BluRay",
+				resolution: "1080p",
+				edition:    "Extended",
+			},
+		},
+		{
+			title: "Show.S01E01.HDTV.x264",
+			want: releaseAttributes{
+				title:  "Show.S01E01.HDTV.x264",
+				source: "HDTV",
+				codec:  "x264",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseReleaseAttributes(tt.title))
+		})
+	}
+}
+
+func TestFormatScorer(t *testing.T) {
+	hdr := storage.CustomFormat{
+		Name: "HDR",
+		Specifications: []storage.CustomFormatSpecification{
+			{Type: storage.CustomFormatSpecTitle, Value: `\bHDR(10)?\b`},
+			{Type: storage.CustomFormatSpecTitle, Value: `\b(DV|DoVi)\b`},
+		},
+	}
+	x265 := storage.CustomFormat{
+		Name: "x265 not from YIFY",
+		Specifications: []storage.CustomFormatSpecification{
+			{Type: storage.CustomFormatSpecCodec, Value: `^(x|h\.?)265|hevc$`, Required: true},
+			{Type: storage.CustomFormatSpecReleaseGroup, Value: `^yify$`, Negate: true, Required: true},
+		},
+	}
+	cam := storage.CustomFormat{
+		Name: "CAM",
+		Specifications: []storage.CustomFormatSpecification{
+			{Type: storage.CustomFormatSpecSource, Value: `^(cam|hdcam|ts|hdts|telesync)$`},
+		},
+	}
+
+	profile := storage.QualityProfile{
+		MinFormatScore: 0,
+		Formats: []storage.QualityProfileFormat{
+			{Format: hdr, Score: 100},
+			{Format: x265, Score: 50},
+			{Format: cam, Score: -1000},
+		},
+	}
+	scorer := newFormatScorer(profile)
+
+	tests := []struct {
+		title string
+		want  int32
+	}{
+		{"Movie.2019.1080p.WEB-DL.x264-GRP", 0},
+		{"Movie.2019.2160p.WEB-DL.HDR.x265-GRP", 150},
+		{"Movie.2019.2160p.WEB-DL.DV.HEVC-GRP", 150},
+		{"Movie.2019.1080p.BluRay.x265-YIFY", 0},
+		{"Movie.2019.HDCAM.x264-GRP", -1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, scorer.score(tt.title))
+		})
+	}
+
+	t.Run("no formats", func(t *testing.T) {
+		assert.Equal(t, int32(0), newFormatScorer(storage.QualityProfile{}).score("Movie.2019.2160p.HDR-GRP"))
+	})
+}
+
+func TestCustomFormatRejectAndSort(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities:      []storage.QualityDefinition{{Name: "WEBDL-1080p", MinSize: 0, MaxSize: 1000}},
+		MinFormatScore: 0,
+		Formats: []storage.QualityProfileFormat{
+			{
+				Format: storage.CustomFormat{Name: "HDR", Specifications: []storage.CustomFormatSpecification{{Type: storage.CustomFormatSpecTitle, Value: `\bHDR\b`}}},
+				Score:  100,
+			},
+			{
+				Format: storage.CustomFormat{Name: "Banned group", Specifications: []storage.CustomFormatSpecification{{Type: storage.CustomFormatSpecReleaseGroup, Value: `^BAD$`}}},
+				Score:  -1000,
+			},
+		},
+	}
+
+	release := func(title string, seeders int32) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue(title),
+			Size:     ptr.To(int64(1024 * 1024 * 1024)),
+			Seeders:  nullable.NewNullableWithValue(seeders),
+			Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+	}
+
+	params := ReleaseFilterParams{Title: "Movie", Runtime: 120}
+	reject := RejectMovieReleaseFunc(context.Background(), params, profile, map[string]struct{}{"torrent": {}})
+
+	plain := release("Movie.2019.1080p.WEB-DL-GRP", 500)
+	hdr := release("Movie.2019.1080p.WEB-DL.HDR-GRP", 10)
+	banned := release("Movie.2019.1080p.WEB-DL.HDR-BAD", 1000)
+
+	assert.False(t, reject(plain))
+	assert.False(t, reject(hdr))
+	assert.True(t, reject(banned), "negative score is below the minimum")
+
+	strict := profile
+	strict.MinFormatScore = 100
+	assert.True(t, RejectMovieReleaseFunc(context.Background(), params, strict, map[string]struct{}{"torrent": {}})(plain))
+
+	releases := []*prowlarr.ReleaseResource{hdr, plain, release("Movie.2019.1080p.WEB-DL-OTHER", 20)}
+	ranked := newReleaseRanker(profile, 120, nil).rank(releases)
+	assert.Equal(t, hdr, ranked[0].Release, "custom format score outranks seeders")
+	assert.Equal(t, []string{"HDR"}, ranked[0].Score.Formats)
+	assert.Equal(t, plain, ranked[1].Release, "seeders break ties")
+}
@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/size"
+	"go.uber.org/zap"
+)
+
+// DirectoryDiskSpace is the space of the file system a movie, TV or download directory is on, in bytes. Error is why
+// it couldn't be determined.
+type DirectoryDiskSpace struct {
+	Name         string `json:"name"`
+	Path         string `json:"path"`
+	Total        int64  `json:"total"`
+	Free         int64  `json:"free"`
+	MinimumFree  int64  `json:"minimumFree"`
+	BelowMinimum bool   `json:"belowMinimum"`
+	Error        string `json:"error,omitempty"`
+}
+
+// GetDiskSpace reports the total and free space for the movie, TV and download directories that are configured
+func (m MediaManager) GetDiskSpace(ctx context.Context) ([]DirectoryDiskSpace, error) {
+	log := logger.FromCtx(ctx)
+
+	dirs := []struct{ name, path string }{
+		{"movies", m.config.Library.MovieDir},
+		{"tv", m.config.Library.TVDir},
+		{"downloads", m.config.Library.DownloadMountDir},
+	}
+
+	minimum := m.minimumFreeSpace()
+	spaces := make([]DirectoryDiskSpace, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir.path == "" {
+			continue
+		}
+
+		space := DirectoryDiskSpace{Name: dir.name, Path: dir.path, MinimumFree: minimum}
+		usage, err := m.library.DiskUsage(ctx, dir.path)
+		if err != nil {
+			log.Warn("failed to get disk space", zap.String("path", dir.path), zap.Error(err))
+			space.Error = err.Error()
+			spaces = append(spaces, space)
+			continue
+		}
+
+		space.Total = usage.Total
+		space.Free = usage.Free
+		space.BelowMinimum = usage.Free < minimum
+		spaces = append(spaces, space)
+	}
+
+	return spaces, nil
+}
+
+// ensureDownloadSpace returns library.ErrInsufficientDiskSpace if downloading the release would leave less than the
+// minimum free space in the download directory. The release is grabbed if the space can't be determined.
+func (m MediaManager) ensureDownloadSpace(ctx context.Context, release *prowlarr.ReleaseResource) error {
+	dir := m.config.Library.DownloadMountDir
+	if dir == "" || release.Size == nil {
+		return nil
+	}
+
+	usage, err := m.library.DiskUsage(ctx, dir)
+	if err != nil {
+		logger.FromCtx(ctx).Debug("failed to get free disk space, grabbing anyway", zap.String("path", dir), zap.Error(err))
+		return nil
+	}
+
+	minimum := m.minimumFreeSpace()
+	if usage.Free-*release.Size < minimum {
+		title, _ := release.Title.Get()
+		return fmt.Errorf("%w: downloading %s (%d MB) to %s would leave less than %d MB of its %d MB free",
+			library.ErrInsufficientDiskSpace, title, size.BytesToMB(*release.Size), dir, size.BytesToMB(minimum), size.BytesToMB(usage.Free))
+	}
+
+	return nil
+}
+
+// minimumFreeSpace is the configured minimum free space in bytes
+func (m MediaManager) minimumFreeSpace() int64 {
+	return max(m.config.Library.MinimumFreeSpace, 0) << 20
+}
@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/library"
+	mockLibrary "github.com/kasuboski/mediaz/pkg/library/mocks"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMediaManager_requestReleaseDownload_DiskSpace(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	lib := mockLibrary.NewMockLibrary(ctrl)
+	lib.EXPECT().DiskUsage(ctx, "/downloads").Return(io.DiskUsage{Total: 100 << 30, Free: 5<<30 + 50<<20}, nil)
+
+	m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{DownloadMountDir: "/downloads", MinimumFreeSpace: 100}})
+	snapshot := newReconcileSnapshot(nil, []*model.DownloadClient{{ID: 1, Type: "torrent"}})
+	release := &prowlarr.ReleaseResource{
+		Title:    nullable.NewNullableWithValue("Movie.2020.2160p.WEB-DL"),
+		Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		Size:     ptr.To(int64(5 << 30)),
+	}
+
+	_, _, err := m.requestReleaseDownload(ctx, snapshot.withIndexers(nil), release)
+	assert.ErrorIs(t, err, library.ErrInsufficientDiskSpace)
+	assert.ErrorIs(t, snapshot.DiskSpaceError(), library.ErrInsufficientDiskSpace)
+}
+
+func TestMediaManager_ensureDownloadSpace(t *testing.T) {
+	ctx := context.Background()
+	release := &prowlarr.ReleaseResource{Size: ptr.To(int64(1 << 30))}
+
+	t.Run("leaves the minimum free", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lib := mockLibrary.NewMockLibrary(ctrl)
+		lib.EXPECT().DiskUsage(ctx, "/downloads").Return(io.DiskUsage{Total: 100 << 30, Free: 2 << 30}, nil)
+
+		m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{DownloadMountDir: "/downloads", MinimumFreeSpace: 100}})
+		assert.NoError(t, m.ensureDownloadSpace(ctx, release))
+	})
+
+	t.Run("free space can't be determined", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lib := mockLibrary.NewMockLibrary(ctrl)
+		lib.EXPECT().DiskUsage(ctx, "/downloads").Return(io.DiskUsage{}, errors.New("no such file or directory"))
+
+		m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{DownloadMountDir: "/downloads", MinimumFreeSpace: 100}})
+		assert.NoError(t, m.ensureDownloadSpace(ctx, release))
+	})
+
+	t.Run("no download directory", func(t *testing.T) {
+		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{})
+		assert.NoError(t, m.ensureDownloadSpace(ctx, release))
+	})
+}
+
+func TestReconcileSnapshot_DiskSpaceError(t *testing.T) {
+	snapshot := newReconcileSnapshot(nil, nil)
+	require.NoError(t, snapshot.DiskSpaceError())
+
+	err := errors.New("download client unavailable")
+	assert.Equal(t, err, snapshot.trackDiskSpace(err))
+	require.NoError(t, snapshot.DiskSpaceError())
+
+	refused := errors.Join(library.ErrInsufficientDiskSpace, errors.New("copying movie.mkv"))
+	snapshot.trackDiskSpace(refused)
+	assert.Equal(t, refused, snapshot.DiskSpaceError())
+
+	snapshot.withIndexers(nil).trackDiskSpace(refused)
+	err = snapshot.DiskSpaceError()
+	assert.ErrorIs(t, err, library.ErrInsufficientDiskSpace)
+	assert.Contains(t, err.Error(), "and 1 more grabs or imports were refused")
+}
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// recordExtraFiles tracks the companion files imported along with a movie or episode file so they're removed with it.
+// owner links them to the file. A companion file that can't be recorded is only logged since the video was imported.
+func (m MediaManager) recordExtraFiles(ctx context.Context, owner model.ExtraFile, extras []library.ExtraFile) {
+	log := logger.FromCtx(ctx)
+
+	for _, extra := range extras {
+		file := owner
+		file.RelativePath = extra.RelativePath
+		file.Size = extra.Size
+
+		_, err := m.extraFileStorage.CreateExtraFile(ctx, file)
+		if err != nil {
+			log.Warn("failed to record extra file", zap.String("path", extra.RelativePath), zap.Error(err))
+			continue
+		}
+
+		log.Debug("recorded extra file", zap.String("path", extra.RelativePath))
+	}
+}
+
+// discardExtraFiles removes the companion files of a movie or episode file an upgrade replaced from the library, see
+// discardLibraryFile. A companion file the new file's import took over, i.e. one that is also tracked for another file,
+// is kept. The records are removed along with the movie or episode file's.
+func (m MediaManager) discardExtraFiles(ctx context.Context, owner sqlite.BoolExpression, remove func(context.Context, string) error, recycle func(context.Context, string, string) (string, error)) error {
+	extras, err := m.extraFileStorage.ListExtraFiles(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, extra := range extras {
+		shared, err := m.extraFileStorage.ListExtraFiles(ctx, table.ExtraFile.RelativePath.EQ(sqlite.String(extra.RelativePath)).
+			AND(table.ExtraFile.ID.NOT_EQ(sqlite.Int32(extra.ID))))
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if len(shared) > 0 {
+			continue
+		}
+
+		err = m.discardLibraryFile(ctx, extra.RelativePath, remove, recycle)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,161 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/kasuboski/mediaz/pkg/archive"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// extractedMarker is written into a staging directory once its archive is fully extracted. It lists the extracted
+// files, one per line relative to the staging directory.
+const extractedMarker = ".mediaz-extracted"
+
+// extractDownload extracts the archives among a completed download's files so they can be imported. The files to import
+// are the download's files with its archives, and the other volumes of a RAR set, replaced by the video files extracted
+// from them. Each archive is extracted into its own staging directory, in the configured extract directory or next to
+// the archive, which cleanup removes once the files have been imported. Until then an archive that's already been
+// extracted isn't extracted again, so a failed import is retried with the files extracted before.
+func (m MediaManager) extractDownload(ctx context.Context, filePaths []string) ([]string, func(), error) {
+	log := logger.FromCtx(ctx)
+
+	var files, staging []string
+	cleanup := func() {
+		for _, dir := range staging {
+			err := m.fs.RemoveAll(dir)
+			if err != nil {
+				log.Warn("failed to remove staging directory", zap.String("dir", dir), zap.Error(err))
+			}
+		}
+	}
+
+	for _, p := range filePaths {
+		if !archive.IsPart(p) {
+			files = append(files, p)
+			continue
+		}
+		if !archive.IsArchive(p) {
+			continue
+		}
+
+		dir := m.stagingDir(p)
+		staging = append(staging, dir)
+
+		extracted, err := m.extractedFiles(dir)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+
+		if extracted != nil {
+			log.Debug("archive was already extracted", zap.String("archive", p), zap.Int("files", len(extracted)))
+		} else {
+			extracted, err = m.extractArchive(ctx, p, dir)
+			if err != nil {
+				cleanup()
+				return nil, nil, fmt.Errorf("failed to extract %s: %w", p, err)
+			}
+			log.Info("extracted archive", zap.String("archive", p), zap.Int("files", len(extracted)))
+		}
+
+		for _, f := range extracted {
+			if library.IsVideoFile(f) {
+				files = append(files, f)
+			}
+		}
+	}
+
+	return files, cleanup, nil
+}
+
+// stagingDir is the directory to extract the archive into. It's named after the archive's path so the same archive is
+// always extracted into the same directory.
+func (m MediaManager) stagingDir(archivePath string) string {
+	parent := m.config.Library.ExtractDir
+	if parent == "" {
+		parent = filepath.Dir(archivePath)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(archivePath))
+
+	return filepath.Join(parent, fmt.Sprintf(".mediaz-extract-%x", h.Sum64()))
+}
+
+// extractArchive extracts the archive into the staging directory, replacing anything left from an extraction that
+// didn't finish, and marks the directory extracted
+func (m MediaManager) extractArchive(ctx context.Context, archivePath, dir string) ([]string, error) {
+	err := m.fs.RemoveAll(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear staging directory: %w", err)
+	}
+
+	err = m.fs.MkdirAll(dir, fs.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	extracted, err := archive.Extract(ctx, m.fs, archivePath, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(extracted))
+	for _, f := range extracted {
+		rel, err := filepath.Rel(dir, f)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, filepath.ToSlash(rel))
+	}
+
+	w, err := m.fs.Create(filepath.Join(dir, extractedMarker))
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark archive extracted: %w", err)
+	}
+	_, err = io.WriteString(w, strings.Join(names, "\n"))
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark archive extracted: %w", err)
+	}
+
+	return extracted, nil
+}
+
+// extractedFiles are the files extracted into the staging directory before, or nil if the directory isn't marked
+// extracted
+func (m MediaManager) extractedFiles(dir string) ([]string, error) {
+	f, err := m.fs.Open(filepath.Join(dir, extractedMarker))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extracted files: %w", err)
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extracted files: %w", err)
+	}
+
+	files := make([]string, 0)
+	for _, name := range strings.Split(string(b), "\n") {
+		if name != "" {
+			files = append(files, filepath.Join(dir, filepath.FromSlash(name)))
+		}
+	}
+
+	return files, nil
+}
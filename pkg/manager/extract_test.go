@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kasuboski/mediaz/config"
+	mio "github.com/kasuboski/mediaz/pkg/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaManager_extractDownload(t *testing.T) {
+	ctx := context.Background()
+
+	// writeZip creates a zip in dir with the files, by name
+	writeZip := func(t *testing.T, dir, name string, files map[string]string) string {
+		p := filepath.Join(dir, name)
+		f, err := os.Create(p)
+		require.NoError(t, err)
+		defer f.Close()
+
+		w := zip.NewWriter(f)
+		for name, content := range files {
+			fw, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = fw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return p
+	}
+
+	t.Run("replaces archives with their extracted video files", func(t *testing.T) {
+		downloadDir := t.TempDir()
+		archivePath := writeZip(t, downloadDir, "movie.2020.1080p.zip", map[string]string{
+			"movie.2020.1080p.mkv":    "movie",
+			"movie.2020.1080p.en.srt": "subtitle",
+		})
+
+		m := MediaManager{fs: &mio.MediaFileSystem{}}
+		files, cleanup, err := m.extractDownload(ctx, []string{
+			archivePath,
+			filepath.Join(downloadDir, "movie.2020.1080p.r00"),
+			filepath.Join(downloadDir, "movie.2020.1080p.sample.mkv"),
+		})
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+
+		assert.Equal(t, filepath.Join(downloadDir, "movie.2020.1080p.sample.mkv"), files[1], "files that aren't archives are kept")
+
+		extracted := files[0]
+		assert.Equal(t, "movie.2020.1080p.mkv", filepath.Base(extracted))
+		assert.Equal(t, downloadDir, filepath.Dir(filepath.Dir(extracted)), "archives are extracted next to themselves")
+		assert.FileExists(t, filepath.Join(filepath.Dir(extracted), "movie.2020.1080p.en.srt"), "companion files are extracted along with the video")
+
+		cleanup()
+		assert.NoDirExists(t, filepath.Dir(extracted))
+		assert.FileExists(t, archivePath)
+	})
+
+	t.Run("extracts into the configured directory", func(t *testing.T) {
+		downloadDir := t.TempDir()
+		extractDir := filepath.Join(t.TempDir(), "extract")
+		archivePath := writeZip(t, downloadDir, "episode.zip", map[string]string{"series.s01e01.mkv": "episode"})
+
+		m := MediaManager{fs: &mio.MediaFileSystem{}, config: config.Config{Library: config.Library{ExtractDir: extractDir}}}
+		files, cleanup, err := m.extractDownload(ctx, []string{archivePath})
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.Len(t, files, 1)
+		assert.Equal(t, extractDir, filepath.Dir(filepath.Dir(files[0])))
+	})
+
+	t.Run("an extracted archive isn't extracted again until cleanup", func(t *testing.T) {
+		downloadDir := t.TempDir()
+		archivePath := writeZip(t, downloadDir, "movie.zip", map[string]string{"movie.2020.1080p.mkv": "movie"})
+
+		m := MediaManager{fs: &mio.MediaFileSystem{}}
+		files, _, err := m.extractDownload(ctx, []string{archivePath})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		// the import failed, so the next reconcile gets the same files without the archive being read
+		require.NoError(t, os.WriteFile(archivePath, []byte("not an archive anymore"), 0o644))
+		again, cleanup, err := m.extractDownload(ctx, []string{archivePath})
+		require.NoError(t, err)
+		assert.Equal(t, files, again)
+		assert.FileExists(t, again[0])
+
+		cleanup()
+		assert.NoDirExists(t, filepath.Dir(files[0]))
+	})
+
+	t.Run("an extraction that didn't finish is redone", func(t *testing.T) {
+		downloadDir := t.TempDir()
+		archivePath := writeZip(t, downloadDir, "movie.zip", map[string]string{"movie.2020.1080p.mkv": "movie"})
+
+		m := MediaManager{fs: &mio.MediaFileSystem{}}
+		dir := m.stagingDir(archivePath)
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "movie.2020.1080p.mkv"), []byte("mov"), 0o644))
+
+		files, cleanup, err := m.extractDownload(ctx, []string{archivePath})
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.Len(t, files, 1)
+		b, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, "movie", string(b))
+	})
+
+	t.Run("failed extraction cleans up", func(t *testing.T) {
+		downloadDir := t.TempDir()
+		archivePath := filepath.Join(downloadDir, "broken.rar")
+		require.NoError(t, os.WriteFile(archivePath, []byte("not an archive"), 0o644))
+
+		m := MediaManager{fs: &mio.MediaFileSystem{}}
+		_, _, err := m.extractDownload(ctx, []string{archivePath})
+		assert.Error(t, err)
+
+		entries, err := os.ReadDir(downloadDir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "the staging directory is removed")
+	})
+}
@@ -0,0 +1,136 @@
+package manager
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/size"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// junkWordRe matches the words that mark a downloaded video file that isn't the media itself
+var junkWordRe = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(sample|trailer|featurette)s?(?:[^a-z0-9]|$)`)
+
+// importFile is a downloaded video file that may be imported. size is -1 if the file couldn't be read.
+type importFile struct {
+	path string
+	size int64
+}
+
+// downloadedVideoFiles are a completed download's video files that aren't samples, trailers or featurettes, judged by
+// their name and the name of the folder they're in. A junk word that's part of the media's title doesn't count. Each
+// skipped file is logged with the reason.
+func (m MediaManager) downloadedVideoFiles(ctx context.Context, filePaths []string, title string) []importFile {
+	log := logger.FromCtx(ctx)
+
+	var files []importFile
+	for _, p := range filePaths {
+		if !library.IsVideoFile(p) {
+			log.Info("skipping downloaded file", zap.String("file", p), zap.String("reason", "not a video file"))
+			continue
+		}
+
+		if word := junkWord(p, title); word != "" {
+			log.Info("skipping downloaded file", zap.String("file", p), zap.String("reason", fmt.Sprintf("looks like a %s", word)))
+			continue
+		}
+
+		f := importFile{path: p, size: -1}
+		info, err := m.fs.Stat(p)
+		if err != nil {
+			log.Debug("failed to stat downloaded file, its size isn't checked", zap.String("file", p), zap.Error(err))
+		} else {
+			f.size = info.Size()
+		}
+		files = append(files, f)
+	}
+
+	return files
+}
+
+// junkWord is the junk word in the file's name or the name of its folder, or empty if there's none
+func junkWord(path, title string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + " " + filepath.Base(filepath.Dir(path))
+
+	for _, m := range junkWordRe.FindAllStringSubmatch(name, -1) {
+		word := strings.ToLower(m[1])
+		if !strings.Contains(strings.ToLower(title), word) {
+			return word
+		}
+	}
+
+	return ""
+}
+
+// tooSmall is why the file is too small to be media with the runtime in minutes, or empty if it's big enough. A file of
+// unknown size, an unknown runtime or no configured minimum size passes.
+func (m MediaManager) tooSmall(f importFile, runtime int32) string {
+	minimum := int64(m.configs.Import.MinimumSizePerMinute * float64(runtime))
+	if f.size < 0 || minimum <= 0 {
+		return ""
+	}
+
+	if size.BytesToMB(f.size) >= minimum {
+		return ""
+	}
+
+	return fmt.Sprintf("%d MB is below the minimum of %d MB for a %d minute runtime", size.BytesToMB(f.size), minimum, runtime)
+}
+
+// mainVideoFile picks the file to import for a movie or episode with the runtime in minutes out of a download's video
+// files: the largest one that isn't too small. The others are logged as skipped.
+func (m MediaManager) mainVideoFile(ctx context.Context, files []importFile, runtime int32) (importFile, bool) {
+	log := logger.FromCtx(ctx)
+
+	var candidates []importFile
+	for _, f := range files {
+		if reason := m.tooSmall(f, runtime); reason != "" {
+			log.Info("skipping downloaded file", zap.String("file", f.path), zap.String("reason", reason))
+			continue
+		}
+		candidates = append(candidates, f)
+	}
+	if len(candidates) == 0 {
+		return importFile{}, false
+	}
+
+	// the first of equally sized files wins
+	main := slices.MaxFunc(candidates, func(a, b importFile) int {
+		return cmp.Compare(a.size, b.size)
+	})
+	for _, f := range candidates {
+		if f.path != main.path {
+			log.Info("skipping downloaded file", zap.String("file", f.path), zap.String("reason", "a larger file is imported"))
+		}
+	}
+
+	return main, true
+}
+
+// episodesRuntime is the runtime in minutes of the episodes together, counting only the episodes whose runtime is known
+func (m MediaManager) episodesRuntime(ctx context.Context, episodes ...*storage.Episode) int32 {
+	var runtime int32
+	for _, episode := range episodes {
+		if episode.EpisodeMetadataID == nil {
+			continue
+		}
+
+		metadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*episode.EpisodeMetadataID)))
+		if err != nil || metadata.Runtime == nil {
+			continue
+		}
+		runtime += *metadata.Runtime
+	}
+
+	return runtime
+}
@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kasuboski/mediaz/config"
+	mio "github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJunkWord(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		title string
+		want  string
+	}{
+		{name: "movie", path: "/downloads/Movie.2020.1080p/Movie.2020.1080p.mkv", title: "Movie", want: ""},
+		{name: "sample name", path: "/downloads/Movie.2020.1080p/movie.2020.1080p-sample.mkv", title: "Movie", want: "sample"},
+		{name: "sample folder", path: "/downloads/Movie.2020.1080p/Sample/movie.mkv", title: "Movie", want: "sample"},
+		{name: "trailer", path: "/downloads/Movie.2020.1080p/Movie.Trailer.mkv", title: "Movie", want: "trailer"},
+		{name: "featurettes folder", path: "/downloads/Movie.2020.1080p/Featurettes/Making Of.mkv", title: "Movie", want: "featurette"},
+		{name: "part of a word", path: "/downloads/Resampled.2020/Resampled.mkv", title: "Movie", want: ""},
+		{name: "part of the title", path: "/downloads/Trailer.Park.Boys.S01E01/Trailer.Park.Boys.S01E01.mkv", title: "Trailer Park Boys", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, junkWord(tt.path, tt.title))
+		})
+	}
+}
+
+func TestMediaManager_mainVideoFile(t *testing.T) {
+	ctx := context.Background()
+	const mb = 1024 * 1024
+
+	dir := t.TempDir()
+	write := func(t *testing.T, name string, size int64) string {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		f, err := os.Create(p)
+		require.NoError(t, err)
+		require.NoError(t, f.Truncate(size))
+		require.NoError(t, f.Close())
+		return p
+	}
+
+	movie := write(t, "Movie.2020.1080p/Movie.2020.1080p.mkv", 300*mb)
+	extra := write(t, "Movie.2020.1080p/Deleted Scenes.mkv", 150*mb)
+	sample := write(t, "Movie.2020.1080p/Sample/movie-sample.mkv", 400*mb)
+	nfo := write(t, "Movie.2020.1080p/Movie.2020.1080p.nfo", 1)
+	missing := filepath.Join(dir, "Movie.2020.1080p", "missing.mkv")
+
+	m := MediaManager{fs: &mio.MediaFileSystem{}}
+	files := m.downloadedVideoFiles(ctx, []string{extra, movie, sample, nfo, missing}, "Movie")
+	assert.Equal(t, []importFile{
+		{path: extra, size: 150 * mb},
+		{path: movie, size: 300 * mb},
+		{path: missing, size: -1},
+	}, files, "samples and other files aren't video files to import")
+
+	t.Run("largest file", func(t *testing.T) {
+		m := MediaManager{}
+		main, ok := m.mainVideoFile(ctx, files, 100)
+		require.True(t, ok)
+		assert.Equal(t, movie, main.path)
+	})
+
+	t.Run("below the minimum size for the runtime", func(t *testing.T) {
+		m := MediaManager{configs: config.Manager{Import: config.ImportFilters{MinimumSizePerMinute: 2}}}
+		assert.Contains(t, m.tooSmall(importFile{path: extra, size: 150 * mb}, 100), "150 MB is below the minimum of 200 MB")
+		assert.Empty(t, m.tooSmall(importFile{path: movie, size: 300 * mb}, 100))
+		assert.Empty(t, m.tooSmall(importFile{path: missing, size: -1}, 100), "a file of unknown size passes")
+		assert.Empty(t, m.tooSmall(importFile{path: extra, size: 150 * mb}, 0), "an unknown runtime passes")
+
+		_, ok := m.mainVideoFile(ctx, []importFile{{path: extra, size: 150 * mb}}, 100)
+		assert.False(t, ok)
+	})
+}
+
+func TestMediaManager_episodesRuntime(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t, ctx)
+
+	var episodes []*storage.Episode
+	for i, runtime := range []*int32{ptr.To(int32(45)), ptr.To(int32(50)), nil} {
+		metadataID, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: int32(i + 1), Title: "Episode", Number: int32(i + 1), Runtime: runtime})
+		require.NoError(t, err)
+		episodes = append(episodes, &storage.Episode{Episode: model.Episode{EpisodeMetadataID: ptr.To(int32(metadataID))}})
+	}
+	episodes = append(episodes, &storage.Episode{})
+
+	m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+	assert.Equal(t, int32(45), m.episodesRuntime(ctx, episodes[0]))
+	assert.Equal(t, int32(95), m.episodesRuntime(ctx, episodes...), "a multi-episode file is as long as its episodes together")
+}
@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/kasuboski/mediaz/pkg/indexer"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+)
+
+// newznab groups categories by thousands, e.g. 2000 is Movies and 2040 is Movies/HD
+const (
+	newznabMovieGroup = 2
+	newznabTVGroup    = 5
+)
+
+type indexerCategoryKey struct {
+	sourceID  int32
+	indexerID int32
+}
+
+// indexerCategorySettings are the categories searched on an indexer for each media type
+type indexerCategorySettings struct {
+	movie []int32
+	tv    []int32
+}
+
+// forType returns the categories to search for the given media type, or nil if there's no preference
+func (s indexerCategorySettings) forType(mediaType *string) []int32 {
+	if mediaType == nil {
+		return nil
+	}
+
+	switch *mediaType {
+	case indexer.TypeMovie:
+		return s.movie
+	case indexer.TypeTV:
+		return s.tv
+	default:
+		return nil
+	}
+}
+
+// defaultIndexerCategories picks the top level movie and tv categories the indexer supports.
+// The global categories are used if the indexer doesn't report any.
+func defaultIndexerCategories(capabilities []prowlarr.IndexerCategory) indexerCategorySettings {
+	var settings indexerCategorySettings
+	for _, c := range capabilities {
+		if c.ID == nil {
+			continue
+		}
+
+		switch *c.ID / 1000 {
+		case newznabMovieGroup:
+			settings.movie = append(settings.movie, *c.ID)
+		case newznabTVGroup:
+			settings.tv = append(settings.tv, *c.ID)
+		}
+	}
+
+	if len(settings.movie) == 0 {
+		settings.movie = slices.Clone(MovieCategories)
+	}
+	if len(settings.tv) == 0 {
+		settings.tv = slices.Clone(TVCategories)
+	}
+
+	return settings
+}
+
+func toIndexerCategoryModel(sourceID, indexerID int32, settings indexerCategorySettings) (model.IndexerCategory, error) {
+	movie, err := json.Marshal(nonNilCategories(settings.movie))
+	if err != nil {
+		return model.IndexerCategory{}, err
+	}
+
+	tv, err := json.Marshal(nonNilCategories(settings.tv))
+	if err != nil {
+		return model.IndexerCategory{}, err
+	}
+
+	return model.IndexerCategory{
+		IndexerSourceID: sourceID,
+		IndexerID:       indexerID,
+		MovieCategories: string(movie),
+		TvCategories:    string(tv),
+	}, nil
+}
+
+func fromIndexerCategoryModel(m model.IndexerCategory) (indexerCategorySettings, error) {
+	var settings indexerCategorySettings
+	if err := json.Unmarshal([]byte(m.MovieCategories), &settings.movie); err != nil {
+		return settings, err
+	}
+	if err := json.Unmarshal([]byte(m.TvCategories), &settings.tv); err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+func nonNilCategories(categories []int32) []int32 {
+	if categories == nil {
+		return []int32{}
+	}
+	return categories
+}
+
+func toIndexerCategoryResponses(categories []prowlarr.IndexerCategory) []IndexerCategoryResponse {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	responses := make([]IndexerCategoryResponse, 0, len(categories))
+	for _, c := range categories {
+		if c.ID == nil {
+			continue
+		}
+
+		name, _ := c.Name.Get()
+		subCategories, _ := c.SubCategories.Get()
+		responses = append(responses, IndexerCategoryResponse{
+			ID:            *c.ID,
+			Name:          name,
+			SubCategories: toIndexerCategoryResponses(subCategories),
+		})
+	}
+
+	return responses
+}
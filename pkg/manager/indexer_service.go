@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"time"
 
@@ -116,6 +117,16 @@ func (is IndexerService) ListIndexers(ctx context.Context) ([]IndexerResponse, e
 	var all []IndexerResponse
 
 	keys := is.indexerCache.Keys()
+
+	var categorySettings map[indexerCategoryKey]indexerCategorySettings
+	if len(keys) > 0 {
+		var err error
+		categorySettings, err = is.listIndexerCategorySettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, sourceID := range keys {
 		cached, ok := is.indexerCache.Get(sourceID)
 		if !ok {
@@ -123,13 +134,7 @@ func (is IndexerService) ListIndexers(ctx context.Context) ([]IndexerResponse, e
 		}
 
 		for _, idx := range cached.Indexers {
-			all = append(all, IndexerResponse{
-				ID:       idx.ID,
-				Name:     idx.Name,
-				Source:   cached.SourceName,
-				Priority: idx.Priority,
-				URI:      idx.URI,
-			})
+			all = append(all, toSourceIndexerResponse(int32(sourceID), cached.SourceName, idx, categorySettings))
 		}
 	}
 
@@ -190,6 +195,42 @@ func (is IndexerService) UpdateIndexer(ctx context.Context, id int32, request Up
 	return toIndexerResponse(idx), nil
 }
 
+// UpdateIndexerCategories sets the movie and tv categories searched on an indexer from an indexer source
+func (is IndexerService) UpdateIndexerCategories(ctx context.Context, id int32, request UpdateIndexerCategoriesRequest) (IndexerResponse, error) {
+	if len(request.MovieCategories) == 0 || len(request.TVCategories) == 0 {
+		return IndexerResponse{}, fmt.Errorf("%w: movie and tv categories are required", ErrValidation)
+	}
+
+	cached, ok := is.indexerCache.Get(int64(request.SourceID))
+	if !ok {
+		return IndexerResponse{}, fmt.Errorf("indexer source %d: %w", request.SourceID, storage.ErrNotFound)
+	}
+
+	i := slices.IndexFunc(cached.Indexers, func(idx indexer.SourceIndexer) bool {
+		return idx.ID == id
+	})
+	if i < 0 {
+		return IndexerResponse{}, fmt.Errorf("indexer %d: %w", id, storage.ErrNotFound)
+	}
+
+	settings := indexerCategorySettings{
+		movie: request.MovieCategories,
+		tv:    request.TVCategories,
+	}
+
+	categories, err := toIndexerCategoryModel(request.SourceID, id, settings)
+	if err != nil {
+		return IndexerResponse{}, err
+	}
+
+	if err := is.indexerSrcStorage.UpsertIndexerCategories(ctx, categories); err != nil {
+		return IndexerResponse{}, err
+	}
+
+	key := indexerCategoryKey{sourceID: request.SourceID, indexerID: id}
+	return toSourceIndexerResponse(request.SourceID, cached.SourceName, cached.Indexers[i], map[indexerCategoryKey]indexerCategorySettings{key: settings}), nil
+}
+
 func (is IndexerService) DeleteIndexer(ctx context.Context, request DeleteIndexerRequest) error {
 	if request.ID == nil {
 		return fmt.Errorf("indexer id is required")
@@ -356,9 +397,64 @@ func (is IndexerService) RefreshIndexerSource(ctx context.Context, id int64) err
 		zap.Int64("sourceID", id),
 		zap.Int("count", len(indexers)))
 
+	if err := is.populateIndexerCategories(ctx, int32(id), indexers); err != nil {
+		log.Warn("failed to populate indexer categories", zap.Int64("sourceID", id), zap.Error(err))
+	}
+
 	return nil
 }
 
+// populateIndexerCategories stores default categories, based on the indexer capabilities, for indexers that don't have any yet
+func (is IndexerService) populateIndexerCategories(ctx context.Context, sourceID int32, indexers []indexer.SourceIndexer) error {
+	existing, err := is.indexerSrcStorage.ListIndexerCategories(ctx, table.IndexerCategory.IndexerSourceID.EQ(sqlite.Int32(sourceID)))
+	if err != nil {
+		return err
+	}
+
+	configured := make(map[int32]struct{}, len(existing))
+	for _, c := range existing {
+		configured[c.IndexerID] = struct{}{}
+	}
+
+	var allErrors error
+	for _, idx := range indexers {
+		if _, ok := configured[idx.ID]; ok {
+			continue
+		}
+
+		categories, err := toIndexerCategoryModel(sourceID, idx.ID, defaultIndexerCategories(idx.Categories))
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+
+		if err := is.indexerSrcStorage.UpsertIndexerCategories(ctx, categories); err != nil {
+			allErrors = errors.Join(allErrors, err)
+		}
+	}
+
+	return allErrors
+}
+
+// listIndexerCategorySettings loads the stored category settings of all source indexers
+func (is IndexerService) listIndexerCategorySettings(ctx context.Context, where ...sqlite.BoolExpression) (map[indexerCategoryKey]indexerCategorySettings, error) {
+	stored, err := is.indexerSrcStorage.ListIndexerCategories(ctx, where...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexer categories: %w", err)
+	}
+
+	settings := make(map[indexerCategoryKey]indexerCategorySettings, len(stored))
+	for _, c := range stored {
+		s, err := fromIndexerCategoryModel(*c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid categories for indexer %d: %w", c.IndexerID, err)
+		}
+		settings[indexerCategoryKey{sourceID: c.IndexerSourceID, indexerID: c.IndexerID}] = s
+	}
+
+	return settings, nil
+}
+
 func (is IndexerService) RefreshAllIndexerSources(ctx context.Context) error {
 	log := logger.FromCtx(ctx)
 
@@ -387,7 +483,7 @@ const sourceSearchTimeout = 30 * time.Second
 func (is IndexerService) SearchIndexers(ctx context.Context, indexers, categories []int32, opts indexer.SearchOptions) ([]*prowlarr.ReleaseResource, error) {
 	log := logger.FromCtx(ctx)
 
-	sourceIndexers := make(map[int64][]int32)
+	sourceIndexers := make(map[int64][]indexer.SourceIndexer)
 
 	keys := is.indexerCache.Keys()
 	for _, sourceID := range keys {
@@ -399,7 +495,7 @@ func (is IndexerService) SearchIndexers(ctx context.Context, indexers, categorie
 		for _, idx := range cached.Indexers {
 			for _, id := range indexers {
 				if idx.ID == id {
-					sourceIndexers[sourceID] = append(sourceIndexers[sourceID], id)
+					sourceIndexers[sourceID] = append(sourceIndexers[sourceID], idx)
 				}
 			}
 		}
@@ -417,15 +513,15 @@ func (is IndexerService) SearchIndexers(ctx context.Context, indexers, categorie
 	resultChan := make(chan result, len(sourceIndexers))
 	var wg sync.WaitGroup
 
-	for sourceID, idxIDs := range sourceIndexers {
+	for sourceID, idxs := range sourceIndexers {
 		wg.Add(1)
-		go func(srcID int64, indexerIDs []int32) {
+		go func(srcID int64, indexers []indexer.SourceIndexer) {
 			defer wg.Done()
 			ctxWithTimeout, cancel := context.WithTimeout(ctx, sourceSearchTimeout)
 			defer cancel()
-			releases, err := is.searchIndexerSource(ctxWithTimeout, srcID, indexerIDs, categories, opts)
+			releases, err := is.searchIndexerSource(ctxWithTimeout, srcID, indexers, categories, opts)
 			resultChan <- result{releases: releases, err: err}
-		}(sourceID, idxIDs)
+		}(sourceID, idxs)
 	}
 
 	go func() {
@@ -452,7 +548,7 @@ func (is IndexerService) SearchIndexers(ctx context.Context, indexers, categorie
 	return allReleases, searchErr
 }
 
-func (is IndexerService) searchIndexerSource(ctx context.Context, sourceID int64, indexerIDs, categories []int32, opts indexer.SearchOptions) ([]*prowlarr.ReleaseResource, error) {
+func (is IndexerService) searchIndexerSource(ctx context.Context, sourceID int64, indexers []indexer.SourceIndexer, categories []int32, opts indexer.SearchOptions) ([]*prowlarr.ReleaseResource, error) {
 	log := logger.FromCtx(ctx)
 
 	sourceConfig, err := is.indexerSrcStorage.GetIndexerSource(ctx, sourceID)
@@ -465,12 +561,23 @@ func (is IndexerService) searchIndexerSource(ctx context.Context, sourceID int64
 		return nil, fmt.Errorf("failed to create source: %w", err)
 	}
 
+	categorySettings, err := is.listIndexerCategorySettings(ctx, table.IndexerCategory.IndexerSourceID.EQ(sqlite.Int64(sourceID)))
+	if err != nil {
+		log.Warn("failed to load indexer categories, using defaults", zap.Error(err))
+	}
+
 	var sourceReleases []*prowlarr.ReleaseResource
-	for _, indexerID := range indexerIDs {
-		releases, err := source.Search(ctx, indexerID, categories, opts)
+	for _, idx := range indexers {
+		searchCategories := categories
+		if configured := categorySettings[indexerCategoryKey{sourceID: int32(sourceID), indexerID: idx.ID}].forType(opts.Type); len(configured) > 0 {
+			searchCategories = configured
+		}
+
+		// indexers that can't search by the given ids fall back to the text query
+		releases, err := source.Search(ctx, idx.ID, searchCategories, idx.SearchOptions(opts))
 		if err != nil {
 			log.Error("indexer search failed",
-				zap.Int32("indexerID", indexerID),
+				zap.Int32("indexerID", idx.ID),
 				zap.Error(err))
 			continue
 		}
@@ -489,6 +596,25 @@ func toIndexerResponse(idx model.Indexer) IndexerResponse {
 	}
 }
 
+func toSourceIndexerResponse(sourceID int32, sourceName string, idx indexer.SourceIndexer, categorySettings map[indexerCategoryKey]indexerCategorySettings) IndexerResponse {
+	settings, ok := categorySettings[indexerCategoryKey{sourceID: sourceID, indexerID: idx.ID}]
+	if !ok {
+		settings = defaultIndexerCategories(idx.Categories)
+	}
+
+	return IndexerResponse{
+		ID:              idx.ID,
+		Name:            idx.Name,
+		Source:          sourceName,
+		SourceID:        ptr.To(sourceID),
+		Priority:        idx.Priority,
+		URI:             idx.URI,
+		Categories:      toIndexerCategoryResponses(idx.Categories),
+		MovieCategories: settings.movie,
+		TVCategories:    settings.tv,
+	}
+}
+
 func toIndexerSourceResponse(src model.IndexerSource) IndexerSourceResponse {
 	return IndexerSourceResponse{
 		ID:             src.ID,
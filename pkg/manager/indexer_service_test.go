@@ -9,6 +9,8 @@ import (
 	"github.com/kasuboski/mediaz/pkg/indexer"
 	indexerMock "github.com/kasuboski/mediaz/pkg/indexer/mocks"
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
 	storageMocks "github.com/kasuboski/mediaz/pkg/storage/mocks"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 	"github.com/oapi-codegen/nullable"
@@ -25,6 +27,12 @@ func newTestIndexerService(ctrl *gomock.Controller) (*IndexerService, *storageMo
 	return svc, idxStorage, srcStorage, factory
 }
 
+// expectNoIndexerCategories allows category lookups and default population for tests that don't care about categories
+func expectNoIndexerCategories(srcStorage *storageMocks.MockIndexerSourceStorage) {
+	srcStorage.EXPECT().ListIndexerCategories(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	srcStorage.EXPECT().UpsertIndexerCategories(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+}
+
 func TestIndexerService_AddIndexer(t *testing.T) {
 	ctx := context.Background()
 
@@ -163,6 +171,7 @@ func TestIndexerService_ListIndexers(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, idxStorage, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		srcStorage.EXPECT().GetIndexerSource(ctx, int64(10)).Return(model.IndexerSource{
@@ -177,7 +186,15 @@ func TestIndexerService_ListIndexers(t *testing.T) {
 		got, err := svc.ListIndexers(ctx)
 		require.NoError(t, err)
 		assert.Equal(t, []IndexerResponse{
-			{ID: 99, Name: "cached-indexer", Priority: 3, Source: "prowlarr"},
+			{
+				ID:              99,
+				Name:            "cached-indexer",
+				Priority:        3,
+				Source:          "prowlarr",
+				SourceID:        ptr.To(int32(10)),
+				MovieCategories: MovieCategories,
+				TVCategories:    TVCategories,
+			},
 		}, got)
 	})
 
@@ -235,6 +252,8 @@ func TestIndexerService_CreateIndexerSource(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		srcStorage.EXPECT().CreateIndexerSource(ctx, gomock.Any()).Return(int64(1), nil)
@@ -473,6 +492,7 @@ func TestIndexerService_DeleteIndexerSource(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		srcStorage.EXPECT().GetIndexerSource(ctx, int64(1)).Return(model.IndexerSource{
@@ -496,6 +516,7 @@ func TestIndexerService_DeleteIndexerSource(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		// Populate cache so we can assert it survives a failed delete.
 		src := indexerMock.NewMockIndexerSource(ctrl)
@@ -626,6 +647,8 @@ func TestIndexerService_RefreshIndexerSource(t *testing.T) {
 			{ID: 1, Name: "indexer-1"},
 			{ID: 2, Name: "indexer-2"},
 		}, nil)
+		srcStorage.EXPECT().ListIndexerCategories(ctx, gomock.Any()).Return(nil, nil)
+		srcStorage.EXPECT().UpsertIndexerCategories(ctx, gomock.Any()).Return(nil).Times(2)
 
 		err := svc.RefreshIndexerSource(ctx, 1)
 		require.NoError(t, err)
@@ -666,6 +689,7 @@ func TestIndexerService_RefreshAllIndexerSources(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		srcStorage.EXPECT().ListIndexerSources(ctx, gomock.Any()).Return([]*model.IndexerSource{
@@ -740,6 +764,7 @@ func TestIndexerService_SearchIndexers(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		// RefreshIndexerSource call
@@ -768,6 +793,7 @@ func TestIndexerService_SearchIndexers(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		srcStorage.EXPECT().GetIndexerSource(ctx, int64(1)).Return(model.IndexerSource{
@@ -800,6 +826,7 @@ func TestIndexerService_SearchIndexers(t *testing.T) {
 		defer ctrl.Finish()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		// Source 1 with indexer 10
 		src1 := indexerMock.NewMockIndexerSource(ctrl)
@@ -849,6 +876,7 @@ func TestIndexerService_SearchIndexers(t *testing.T) {
 		defer cancel()
 
 		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		expectNoIndexerCategories(srcStorage)
 
 		src := indexerMock.NewMockIndexerSource(ctrl)
 		srcStorage.EXPECT().GetIndexerSource(ctx, int64(1)).Return(model.IndexerSource{
@@ -885,3 +913,223 @@ func TestIndexerService_SearchIndexers(t *testing.T) {
 		}
 	})
 }
+
+func TestIndexerService_UpdateIndexerCategories(t *testing.T) {
+	ctx := context.Background()
+
+	refresh := func(t *testing.T, svc *IndexerService, srcStorage *storageMocks.MockIndexerSourceStorage, factory *indexerMock.MockFactory, ctrl *gomock.Controller) {
+		src := indexerMock.NewMockIndexerSource(ctrl)
+		srcStorage.EXPECT().GetIndexerSource(ctx, int64(1)).Return(model.IndexerSource{
+			ID: 1, Name: "prowlarr", Scheme: "http", Host: "localhost", Enabled: true,
+		}, nil)
+		factory.EXPECT().NewIndexerSource(gomock.Any()).Return(src, nil)
+		src.EXPECT().ListIndexers(ctx).Return([]indexer.SourceIndexer{{ID: 5, Name: "tracker"}}, nil)
+		srcStorage.EXPECT().ListIndexerCategories(ctx, gomock.Any()).Return(nil, nil)
+		srcStorage.EXPECT().UpsertIndexerCategories(ctx, gomock.Any()).Return(nil)
+		require.NoError(t, svc.RefreshIndexerSource(ctx, 1))
+	}
+
+	t.Run("requires categories", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, _, _, _ := newTestIndexerService(ctrl)
+
+		_, err := svc.UpdateIndexerCategories(ctx, 5, UpdateIndexerCategoriesRequest{SourceID: 1, MovieCategories: []int32{2000}})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("returns not found for unknown source", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, _, _, _ := newTestIndexerService(ctrl)
+
+		_, err := svc.UpdateIndexerCategories(ctx, 5, UpdateIndexerCategoriesRequest{
+			SourceID:        1,
+			MovieCategories: []int32{2000},
+			TVCategories:    []int32{5000},
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("returns not found for unknown indexer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		refresh(t, svc, srcStorage, factory, ctrl)
+
+		_, err := svc.UpdateIndexerCategories(ctx, 6, UpdateIndexerCategoriesRequest{
+			SourceID:        1,
+			MovieCategories: []int32{2000},
+			TVCategories:    []int32{5000},
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("stores categories", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+		refresh(t, svc, srcStorage, factory, ctrl)
+
+		srcStorage.EXPECT().UpsertIndexerCategories(ctx, model.IndexerCategory{
+			IndexerSourceID: 1,
+			IndexerID:       5,
+			MovieCategories: "[2040,2045]",
+			TvCategories:    "[5070]",
+		}).Return(nil)
+
+		got, err := svc.UpdateIndexerCategories(ctx, 5, UpdateIndexerCategoriesRequest{
+			SourceID:        1,
+			MovieCategories: []int32{2040, 2045},
+			TVCategories:    []int32{5070},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, IndexerResponse{
+			ID:              5,
+			Name:            "tracker",
+			Source:          "prowlarr",
+			SourceID:        ptr.To(int32(1)),
+			MovieCategories: []int32{2040, 2045},
+			TVCategories:    []int32{5070},
+		}, got)
+	})
+}
+
+func TestIndexerService_RefreshIndexerSource_Categories(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+
+	src := indexerMock.NewMockIndexerSource(ctrl)
+	srcStorage.EXPECT().GetIndexerSource(ctx, int64(1)).Return(model.IndexerSource{
+		ID: 1, Name: "prowlarr", Scheme: "http", Host: "localhost", Enabled: true,
+	}, nil)
+	factory.EXPECT().NewIndexerSource(gomock.Any()).Return(src, nil)
+	src.EXPECT().ListIndexers(ctx).Return([]indexer.SourceIndexer{
+		{ID: 1, Name: "configured"},
+		{ID: 2, Name: "new", Categories: []prowlarr.IndexerCategory{
+			{ID: ptr.To(int32(2000))},
+			{ID: ptr.To(int32(100001))},
+			{ID: ptr.To(int32(5000))},
+			{ID: ptr.To(int32(5070))},
+		}},
+	}, nil)
+
+	// only the indexer without stored settings gets defaults, so edits survive a refresh
+	srcStorage.EXPECT().ListIndexerCategories(ctx, gomock.Any()).Return([]*model.IndexerCategory{
+		{IndexerSourceID: 1, IndexerID: 1, MovieCategories: "[2040]", TvCategories: "[5040]"},
+	}, nil)
+	srcStorage.EXPECT().UpsertIndexerCategories(ctx, model.IndexerCategory{
+		IndexerSourceID: 1,
+		IndexerID:       2,
+		MovieCategories: "[2000]",
+		TvCategories:    "[5000,5070]",
+	}).Return(nil)
+
+	require.NoError(t, svc.RefreshIndexerSource(ctx, 1))
+}
+
+func TestIndexerService_SearchIndexers_Categories(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+
+	src := indexerMock.NewMockIndexerSource(ctrl)
+	srcStorage.EXPECT().GetIndexerSource(gomock.Any(), int64(1)).Return(model.IndexerSource{
+		ID: 1, Name: "prowlarr", Scheme: "http", Host: "localhost", Enabled: true,
+	}, nil).Times(2)
+	factory.EXPECT().NewIndexerSource(gomock.Any()).Return(src, nil).Times(2)
+	src.EXPECT().ListIndexers(ctx).Return([]indexer.SourceIndexer{{ID: 1}, {ID: 2}}, nil)
+	srcStorage.EXPECT().ListIndexerCategories(ctx, gomock.Any()).Return([]*model.IndexerCategory{
+		{IndexerSourceID: 1, IndexerID: 1, MovieCategories: "[2040]", TvCategories: "[5040]"},
+		{IndexerSourceID: 1, IndexerID: 2, MovieCategories: "[2000]", TvCategories: "[5000]"},
+	}, nil)
+	require.NoError(t, svc.RefreshIndexerSource(ctx, 1))
+
+	srcStorage.EXPECT().ListIndexerCategories(gomock.Any(), gomock.Any()).Return([]*model.IndexerCategory{
+		{IndexerSourceID: 1, IndexerID: 1, MovieCategories: "[2040]", TvCategories: "[5040]"},
+	}, nil)
+	// indexer 1 uses its configured tv categories, indexer 2 has none stored and uses the requested ones
+	src.EXPECT().Search(gomock.Any(), int32(1), []int32{5040}, gomock.Any()).Return(nil, nil)
+	src.EXPECT().Search(gomock.Any(), int32(2), []int32{5000}, gomock.Any()).Return(nil, nil)
+
+	_, err := svc.SearchIndexers(ctx, []int32{1, 2}, TVCategories, indexer.SearchOptions{Type: ptr.To(indexer.TypeTV)})
+	require.NoError(t, err)
+}
+
+func TestDefaultIndexerCategories(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities []prowlarr.IndexerCategory
+		want         indexerCategorySettings
+	}{
+		{
+			name: "falls back to global categories",
+			want: indexerCategorySettings{movie: MovieCategories, tv: TVCategories},
+		},
+		{
+			name: "uses reported categories",
+			capabilities: []prowlarr.IndexerCategory{
+				{ID: ptr.To(int32(2000))},
+				{ID: ptr.To(int32(2045))},
+				{ID: ptr.To(int32(5070))},
+				{ID: ptr.To(int32(3000))},
+				{ID: nil},
+			},
+			want: indexerCategorySettings{movie: []int32{2000, 2045}, tv: []int32{5070}},
+		},
+		{
+			name:         "fills missing media type",
+			capabilities: []prowlarr.IndexerCategory{{ID: ptr.To(int32(2000))}},
+			want:         indexerCategorySettings{movie: []int32{2000}, tv: TVCategories},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, defaultIndexerCategories(tt.capabilities))
+		})
+	}
+}
+
+func TestIndexerService_SearchIndexers_IDs(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, _, srcStorage, factory := newTestIndexerService(ctrl)
+	expectNoIndexerCategories(srcStorage)
+
+	src := indexerMock.NewMockIndexerSource(ctrl)
+	srcStorage.EXPECT().GetIndexerSource(gomock.Any(), int64(1)).Return(model.IndexerSource{
+		ID: 1, Name: "prowlarr", Scheme: "http", Host: "localhost", Enabled: true,
+	}, nil).Times(2)
+	factory.EXPECT().NewIndexerSource(gomock.Any()).Return(src, nil).Times(2)
+	src.EXPECT().ListIndexers(ctx).Return([]indexer.SourceIndexer{
+		{ID: 1, MovieSearchParams: []prowlarr.MovieSearchParam{prowlarr.MovieSearchParamQ, prowlarr.MovieSearchParamImdbID}},
+		{ID: 2, MovieSearchParams: []prowlarr.MovieSearchParam{prowlarr.MovieSearchParamQ}},
+	}, nil)
+	require.NoError(t, svc.RefreshIndexerSource(ctx, 1))
+
+	opts := indexer.SearchOptions{Query: "Movie", Type: ptr.To(indexer.TypeMovie), ImdbID: ptr.To("tt1")}
+	// indexer 2 can't search by imdb id so it gets the text query
+	src.EXPECT().Search(gomock.Any(), int32(1), gomock.Any(), opts).Return(nil, nil)
+	src.EXPECT().Search(gomock.Any(), int32(2), gomock.Any(), indexer.SearchOptions{Query: "Movie", Type: ptr.To(indexer.TypeMovie)}).Return(nil, nil)
+
+	_, err := svc.SearchIndexers(ctx, []int32{1, 2}, MovieCategories, opts)
+	require.NoError(t, err)
+}
@@ -24,7 +24,7 @@ type JobService struct {
 
 // TriggerJobRequest represents the request to manually trigger a job
 type TriggerJobRequest struct {
-	Type string `json:"type" validate:"required,oneof=MovieIndex MovieReconcile SeriesIndex SeriesReconcile IndexerSync"`
+	Type string `json:"type" validate:"required,oneof=MovieIndex MovieReconcile SeriesIndex SeriesReconcile IndexerSync UpgradeSearch"`
 }
 
 // JobResponse represents a single job in API responses
@@ -59,7 +59,7 @@ func toJobResponse(job *storage.Job) JobResponse {
 // isValidJobType validates that a job type string matches one of the defined JobType constants
 func isValidJobType(jobType string) bool {
 	switch JobType(jobType) {
-	case MovieIndex, MovieReconcile, SeriesIndex, SeriesReconcile, IndexerSync:
+	case MovieIndex, MovieReconcile, SeriesIndex, SeriesReconcile, IndexerSync, RecycleBinCleanup, UpgradeSearch:
 		return true
 	default:
 		return false
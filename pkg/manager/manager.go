@@ -14,6 +14,7 @@ import (
 	"github.com/kasuboski/mediaz/config"
 	"github.com/kasuboski/mediaz/pkg/download"
 	"github.com/kasuboski/mediaz/pkg/indexer"
+	mio "github.com/kasuboski/mediaz/pkg/io"
 	"github.com/kasuboski/mediaz/pkg/library"
 	"github.com/kasuboski/mediaz/pkg/logger"
 	"github.com/kasuboski/mediaz/pkg/pagination"
@@ -29,6 +30,7 @@ type MediaManager struct {
 	tmdb                  tmdb.ITmdb
 	indexerService        *IndexerService
 	library               library.Library
+	fs                    mio.FileIO
 	movieStorage          storage.MovieStorage
 	movieMetaStorage      storage.MovieMetadataStorage
 	seriesStorage         storage.SeriesStorage
@@ -36,6 +38,9 @@ type MediaManager struct {
 	metadataService       MetadataService
 	downloadClientService *DownloadClientService
 	qualityService        *QualityService
+	blocklistService      *BlocklistService
+	pendingStorage        storage.PendingReleaseStorage
+	extraFileStorage      storage.ExtraFileStorage
 	jobService            *JobService
 	seriesService         *SeriesService
 	movieService          *MovieService
@@ -48,6 +53,7 @@ func New(tmbdClient tmdb.ITmdb, indexerFactory indexer.Factory, library library.
 		tmdb:                  tmbdClient,
 		indexerService:        NewIndexerService(store, store, indexerFactory),
 		library:               library,
+		fs:                    &mio.MediaFileSystem{},
 		movieStorage:          store,
 		movieMetaStorage:      store,
 		seriesStorage:         store,
@@ -55,12 +61,19 @@ func New(tmbdClient tmdb.ITmdb, indexerFactory indexer.Factory, library library.
 		metadataService:       NewMetadataService(tmbdClient, store, store),
 		downloadClientService: NewDownloadClientService(store, factory),
 		qualityService:        NewQualityService(store),
+		blocklistService:      NewBlocklistService(store),
+		pendingStorage:        store,
+		extraFileStorage:      store,
 		config:                fullConfig,
 		configs:               managerConfigs,
 	}
 
 	m.seriesService = NewSeriesService(tmbdClient, library, store, store, m.qualityService, &m)
 	m.movieService = NewMovieService(tmbdClient, library, store, m.qualityService, &m)
+	m.seriesService.folderFormat = fullConfig.Library.SeriesFolderFormat
+	m.movieService.folderFormat = fullConfig.Library.MovieFolderFormat
+	m.seriesService.recycleBin = fullConfig.Library.RecycleBin
+	m.movieService.recycleBin = fullConfig.Library.RecycleBin
 
 	executors := map[JobType]JobExecutor{
 		MovieReconcile: func(ctx context.Context, jobID int64) error {
@@ -78,6 +91,12 @@ func New(tmbdClient tmdb.ITmdb, indexerFactory indexer.Factory, library library.
 		IndexerSync: func(ctx context.Context, jobID int64) error {
 			return m.indexerService.RefreshAllIndexerSources(ctx)
 		},
+		RecycleBinCleanup: func(ctx context.Context, jobID int64) error {
+			return m.PurgeRecycleBin(ctx)
+		},
+		UpgradeSearch: func(ctx context.Context, jobID int64) error {
+			return m.SearchUpgrades(ctx)
+		},
 	}
 
 	m.jobService = NewJobService(store, store, store, managerConfigs, executors)
@@ -166,6 +185,7 @@ func (m MediaManager) GetConfigSummary() ConfigSummary {
 			MovieDir:         m.config.Library.MovieDir,
 			TVDir:            m.config.Library.TVDir,
 			DownloadMountDir: m.config.Library.DownloadMountDir,
+			RecycleBin:       m.config.Library.RecycleBin,
 		},
 		Server: ServerConfig{
 			Port: m.config.Server.Port,
@@ -185,7 +205,18 @@ func (m MediaManager) GetLibraryStats(ctx context.Context) (*storage.LibraryStat
 }
 
 func (m MediaManager) GetActiveActivity(ctx context.Context) (*ActiveActivityResponse, error) {
-	return m.jobService.GetActiveActivity(ctx)
+	activity, err := m.jobService.GetActiveActivity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Pending, err = m.ListPendingReleases(ctx)
+	if err != nil {
+		logger.FromCtx(ctx).Error("failed to list pending releases", zap.Error(err))
+		return nil, err
+	}
+
+	return activity, nil
 }
 
 func (m MediaManager) GetRecentFailures(ctx context.Context, hours int) (*FailuresResponse, error) {
@@ -316,6 +347,7 @@ func (m MediaManager) IndexMovieLibrary(ctx context.Context) error {
 			OriginalFilePath: &discoveredFile.RelativePath,
 			RelativePath:     &discoveredFile.RelativePath,
 			Size:             discoveredFile.Size,
+			Quality:          m.fileQuality(ctx, "movie", &discoveredFile.RelativePath),
 		}
 
 		log.Debug("discovered new movie file", zap.String("path", discoveredFile.RelativePath))
@@ -335,6 +367,8 @@ func (m MediaManager) IndexMovieLibrary(ctx context.Context) error {
 		return fmt.Errorf("failed to list movie files: %w", err)
 	}
 
+	m.identifyMovieFileQualities(ctx, movieFiles)
+
 	for _, f := range movieFiles {
 		movieName := library.MovieNameFromFilepath(*f.RelativePath)
 		foundMovie, err := m.movieStorage.GetMovieByPath(ctx, movieName)
@@ -399,6 +433,10 @@ func (m MediaManager) UpdateSeriesMonitored(ctx context.Context, seriesID int64,
 	return m.seriesService.UpdateSeriesMonitored(ctx, seriesID, monitored)
 }
 
+func (m MediaManager) UpdateSeriesType(ctx context.Context, seriesID int64, seriesType string) (*storage.Series, error) {
+	return m.seriesService.UpdateSeriesType(ctx, seriesID, seriesType)
+}
+
 func (m MediaManager) AddIndexer(ctx context.Context, request AddIndexerRequest) (IndexerResponse, error) {
 	return m.indexerService.AddIndexer(ctx, request)
 }
@@ -407,6 +445,10 @@ func (m MediaManager) UpdateIndexer(ctx context.Context, id int32, request Updat
 	return m.indexerService.UpdateIndexer(ctx, id, request)
 }
 
+func (m MediaManager) UpdateIndexerCategories(ctx context.Context, id int32, request UpdateIndexerCategoriesRequest) (IndexerResponse, error) {
+	return m.indexerService.UpdateIndexerCategories(ctx, id, request)
+}
+
 func (m MediaManager) DeleteIndexer(ctx context.Context, request DeleteIndexerRequest) error {
 	return m.indexerService.DeleteIndexer(ctx, request)
 }
@@ -502,6 +544,18 @@ func (m MediaManager) CancelJob(ctx context.Context, id int64) (JobResponse, err
 	return m.jobService.CancelJob(ctx, id)
 }
 
+func (m MediaManager) ListBlocklist(ctx context.Context, movieID, episodeID *int32) ([]*model.ReleaseBlocklist, error) {
+	return m.blocklistService.ListBlocklist(ctx, movieID, episodeID)
+}
+
+func (m MediaManager) AddBlocklistEntry(ctx context.Context, request AddBlocklistEntryRequest) (*model.ReleaseBlocklist, error) {
+	return m.blocklistService.AddBlocklistEntry(ctx, request)
+}
+
+func (m MediaManager) DeleteBlocklistEntry(ctx context.Context, id int64) error {
+	return m.blocklistService.DeleteBlocklistEntry(ctx, id)
+}
+
 func (m MediaManager) AddQualityDefinition(ctx context.Context, request AddQualityDefinitionRequest) (model.QualityDefinition, error) {
 	return m.qualityService.AddQualityDefinition(ctx, request)
 }
@@ -550,6 +604,26 @@ func (m MediaManager) DeleteQualityProfile(ctx context.Context, request DeleteQu
 	return m.qualityService.DeleteQualityProfile(ctx, request)
 }
 
+func (m MediaManager) ListCustomFormats(ctx context.Context) ([]*storage.CustomFormat, error) {
+	return m.qualityService.ListCustomFormats(ctx)
+}
+
+func (m MediaManager) GetCustomFormat(ctx context.Context, id int64) (storage.CustomFormat, error) {
+	return m.qualityService.GetCustomFormat(ctx, id)
+}
+
+func (m MediaManager) AddCustomFormat(ctx context.Context, request AddCustomFormatRequest) (storage.CustomFormat, error) {
+	return m.qualityService.AddCustomFormat(ctx, request)
+}
+
+func (m MediaManager) UpdateCustomFormat(ctx context.Context, id int64, request UpdateCustomFormatRequest) (storage.CustomFormat, error) {
+	return m.qualityService.UpdateCustomFormat(ctx, id, request)
+}
+
+func (m MediaManager) DeleteCustomFormat(ctx context.Context, id int64) error {
+	return m.qualityService.DeleteCustomFormat(ctx, id)
+}
+
 func (m MediaManager) CreateDownloadClient(ctx context.Context, request AddDownloadClientRequest) (model.DownloadClient, error) {
 	return m.downloadClientService.CreateDownloadClient(ctx, request)
 }
@@ -157,18 +157,39 @@ type AddMovieRequest struct {
 // AddSeriesRequest describes inputs to start managing a TV series.
 // TMDBID must refer to a valid TMDB series; QualityProfileID must match an existing quality profile.
 // MonitorNewSeasons indicates whether new seasons should be automatically monitored.
+// SeriesType is standard, anime or daily, defaulting to standard.
 type AddSeriesRequest struct {
-	TMDBID            int   `json:"tmdbID" validate:"required,gt=0"`
-	QualityProfileID  int32 `json:"qualityProfileID" validate:"required,gt=0"`
-	MonitorNewSeasons bool  `json:"monitorNewSeasons,omitempty"`
+	TMDBID            int    `json:"tmdbID" validate:"required,gt=0"`
+	QualityProfileID  int32  `json:"qualityProfileID" validate:"required,gt=0"`
+	MonitorNewSeasons bool   `json:"monitorNewSeasons,omitempty"`
+	SeriesType        string `json:"seriesType,omitempty" validate:"omitempty,oneof=standard anime daily"`
 }
 
 type IndexerResponse struct {
 	ID       int32  `json:"id"`
 	Name     string `json:"name"`
 	Source   string `json:"source"`
+	SourceID *int32 `json:"sourceId,omitempty"`
 	Priority int32  `json:"priority"`
 	URI      string `json:"uri"`
+	// Categories are the categories the indexer reports it supports
+	Categories []IndexerCategoryResponse `json:"categories,omitempty"`
+	// MovieCategories and TVCategories are the categories searched for each media type
+	MovieCategories []int32 `json:"movieCategories,omitempty"`
+	TVCategories    []int32 `json:"tvCategories,omitempty"`
+}
+
+type IndexerCategoryResponse struct {
+	ID            int32                     `json:"id"`
+	Name          string                    `json:"name"`
+	SubCategories []IndexerCategoryResponse `json:"subCategories,omitempty"`
+}
+
+// UpdateIndexerCategoriesRequest sets the categories searched on an indexer from an indexer source.
+type UpdateIndexerCategoriesRequest struct {
+	SourceID        int32   `json:"sourceId" validate:"required,gt=0"`
+	MovieCategories []int32 `json:"movieCategories" validate:"required,min=1"`
+	TVCategories    []int32 `json:"tvCategories" validate:"required,min=1"`
 }
 
 // AddIndexerRequest wraps a storage Indexer model to create a new indexer.
@@ -234,6 +255,7 @@ type LibraryConfig struct {
 	MovieDir         string `json:"movieDir"`
 	TVDir            string `json:"tvDir"`
 	DownloadMountDir string `json:"downloadMountDir"`
+	RecycleBin       string `json:"recycleBin,omitempty"`
 }
 
 type ServerConfig struct {
@@ -0,0 +1,523 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// ImportFile is a video file to import into the library by hand and the movie or episodes it's matched to. A movie is
+// matched by its TMDB id and episodes by their library series, season number and episode numbers. A file that isn't
+// matched has an empty MediaType.
+type ImportFile struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	MediaType string `json:"mediaType,omitempty"`
+
+	TMDBID int `json:"tmdbID,omitempty"`
+
+	SeriesID       int32   `json:"seriesId,omitempty"`
+	SeasonNumber   int32   `json:"seasonNumber,omitempty"`
+	EpisodeNumbers []int32 `json:"episodeNumbers,omitempty"`
+
+	// Title and Year describe the match, they're ignored when importing
+	Title string `json:"title,omitempty"`
+	Year  *int32 `json:"year,omitempty"`
+
+	// To is the file's path in the library once it's imported
+	To string `json:"to,omitempty"`
+	// Error is why the file couldn't be matched or imported
+	Error string `json:"error,omitempty"`
+}
+
+// ImportRequest imports files into the library. Files are imported as they're matched, so a scan's proposed matches
+// can be confirmed, changed or left out. A directory without files imports every match a scan of it proposes.
+// QualityProfileID adds a matched movie that isn't in the library yet.
+type ImportRequest struct {
+	Directory        string       `json:"directory,omitempty"`
+	Files            []ImportFile `json:"files,omitempty"`
+	QualityProfileID int32        `json:"qualityProfileID,omitempty"`
+}
+
+// ScanImport lists the video files in a directory and proposes a match for each. Episodes are matched to a library
+// series by the title in their name or their folders' names, then by season and episode. Everything else is matched
+// to a movie by searching TMDB for its title and year.
+func (m MediaManager) ScanImport(ctx context.Context, dir string) ([]*ImportFile, error) {
+	log := logger.FromCtx(ctx)
+
+	dir = filepath.Clean(dir)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s isn't a directory", ErrValidation, dir)
+	}
+
+	var paths []string
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// hidden directories hold things like in-progress extractions
+		if d.IsDir() && p != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+
+	matcher, err := m.newImportMatcher(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ImportFile, 0)
+	for _, p := range paths {
+		for _, f := range m.downloadedVideoFiles(ctx, []string{p}, importFolderTitle(dir, p)) {
+			file := &ImportFile{Path: f.path, Size: max(f.size, 0)}
+			err := matcher.match(ctx, file)
+			if err != nil {
+				log.Warn("failed to match file", zap.String("file", p), zap.Error(err))
+				file.Error = err.Error()
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// ImportFiles moves matched files into the library the same way completed downloads are imported and marks their
+// movie or episodes downloaded. A file that replaces one the movie or episode already has is imported as an upgrade.
+// Each file's outcome is reported on it, a failed file doesn't stop the others.
+func (m MediaManager) ImportFiles(ctx context.Context, request ImportRequest) ([]*ImportFile, error) {
+	log := logger.FromCtx(ctx)
+
+	files := make([]*ImportFile, 0, len(request.Files))
+	for i := range request.Files {
+		files = append(files, &request.Files[i])
+	}
+
+	if len(files) == 0 {
+		if request.Directory == "" {
+			return nil, fmt.Errorf("%w: a directory or files to import are required", ErrValidation)
+		}
+
+		var err error
+		files, err = m.ScanImport(ctx, request.Directory)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range files {
+		var err error
+		switch f.MediaType {
+		case "movie":
+			err = m.importMovieFile(ctx, f, request.QualityProfileID)
+		case "episode":
+			err = m.importEpisodeFile(ctx, f)
+		default:
+			if f.Error == "" {
+				f.Error = "the file isn't matched to a movie or episode"
+			}
+			continue
+		}
+
+		if err != nil {
+			log.Warn("failed to import file", zap.String("file", f.Path), zap.Error(err))
+			f.Error = err.Error()
+			continue
+		}
+
+		f.Error = ""
+		log.Info("imported file", zap.String("file", f.Path), zap.String("to", f.To))
+	}
+
+	return files, nil
+}
+
+// importMovieFile imports a file for the movie with its TMDB id, adding the movie to the library with the quality
+// profile if it isn't there yet
+func (m MediaManager) importMovieFile(ctx context.Context, f *ImportFile, qualityProfileID int32) error {
+	log := logger.FromCtx(ctx)
+
+	if !library.IsVideoFile(f.Path) {
+		return errors.New("not a video file")
+	}
+	if f.TMDBID <= 0 {
+		return errors.New("a movie is matched by its tmdb id")
+	}
+
+	metadata, err := m.GetMovieMetadata(ctx, f.TMDBID)
+	if err != nil {
+		return fmt.Errorf("failed to get movie metadata: %w", err)
+	}
+
+	movie, err := m.movieStorage.GetMovieByMetadataID(ctx, int(metadata.ID))
+	if errors.Is(err, storage.ErrNotFound) {
+		if qualityProfileID == 0 {
+			return fmt.Errorf("%s isn't in the library, a quality profile is needed to add it", metadata.Title)
+		}
+		movie, err = m.AddMovieToLibrary(ctx, AddMovieRequest{TMDBID: f.TMDBID, QualityProfileID: qualityProfileID})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get movie: %w", err)
+	}
+
+	var existingFiles []*model.MovieFile
+	if movie.Path != nil {
+		existingFiles, err = m.movieStorage.GetMovieFilesByMovieName(ctx, *movie.Path)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to list movie files: %w", err)
+		}
+	}
+	replacing := len(existingFiles) > 0
+
+	var replacedFile string
+	if len(existingFiles) > 0 {
+		if main := mainMovieFile(existingFiles); main.RelativePath != nil {
+			replacedFile = *main.RelativePath
+		}
+	}
+
+	// the file is named after itself, not the release the movie was last grabbed from
+	imported := *movie
+	imported.ReleaseTitle = nil
+	relativePath, err := m.addMovieFileToLibrary(ctx, metadata, f.Path, &imported, existingFiles)
+	if err != nil {
+		return err
+	}
+	f.To = relativePath
+	f.Title = metadata.Title
+	f.Year = metadata.Year
+
+	if replacing {
+		err = m.removeReplacedMovieFiles(ctx, existingFiles)
+		if err != nil {
+			log.Warn("failed to remove replaced movie files", zap.Error(err))
+		}
+	}
+
+	if movie.Machine().ToState(storage.MovieStateDownloaded) != nil {
+		log.Debug("movie keeps its state", zap.Int32("movie id", movie.ID), zap.String("state", string(movie.State)))
+		return nil
+	}
+
+	return m.updateMovieState(ctx, movie, storage.MovieStateDownloaded, importedTransitionMetadata(replacing, replacedFile))
+}
+
+// importEpisodeFile imports a file for the episodes of a library series it's matched to
+func (m MediaManager) importEpisodeFile(ctx context.Context, f *ImportFile) error {
+	log := logger.FromCtx(ctx)
+
+	if !library.IsVideoFile(f.Path) {
+		return errors.New("not a video file")
+	}
+	if f.SeriesID <= 0 || len(f.EpisodeNumbers) == 0 {
+		return errors.New("episodes are matched by their series id, season number and episode numbers")
+	}
+
+	series, err := m.seriesStorage.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int32(f.SeriesID)))
+	if err != nil {
+		return fmt.Errorf("failed to get series %d: %w", f.SeriesID, err)
+	}
+	if series.SeriesMetadataID == nil {
+		return fmt.Errorf("series %d isn't matched to TMDB yet", f.SeriesID)
+	}
+
+	seriesMetadata, err := m.seriesMetaStorage.GetSeriesMetadata(ctx, table.SeriesMetadata.ID.EQ(sqlite.Int32(*series.SeriesMetadataID)))
+	if err != nil {
+		return fmt.Errorf("failed to get series metadata: %w", err)
+	}
+
+	season, err := m.seriesStorage.GetSeason(ctx, table.Season.SeriesID.EQ(sqlite.Int32(series.ID)).
+		AND(table.Season.SeasonNumber.EQ(sqlite.Int32(f.SeasonNumber))))
+	if err != nil {
+		return fmt.Errorf("failed to get season %d of %s: %w", f.SeasonNumber, seriesMetadata.Title, err)
+	}
+
+	numbers := make([]sqlite.Expression, 0, len(f.EpisodeNumbers))
+	for _, n := range f.EpisodeNumbers {
+		numbers = append(numbers, sqlite.Int32(n))
+	}
+	episodes, err := m.seriesStorage.ListEpisodes(ctx, table.Episode.SeasonID.EQ(sqlite.Int32(season.ID)).
+		AND(table.Episode.EpisodeNumber.IN(numbers...)))
+	if err != nil {
+		return fmt.Errorf("failed to list episodes: %w", err)
+	}
+	if len(episodes) != len(numbers) {
+		return fmt.Errorf("season %d of %s doesn't have episodes %v", f.SeasonNumber, seriesMetadata.Title, f.EpisodeNumbers)
+	}
+
+	for _, e := range episodes {
+		// the file is named after itself and replaces the file an episode already has
+		e.ReleaseTitle = nil
+		e.IsUpgrade = e.EpisodeFileID != nil
+	}
+
+	replaced, err := m.addEpisodeFileToLibrary(ctx, series, seriesMetadata, season.SeasonNumber, f.Path, episodes...)
+	if err != nil {
+		return err
+	}
+	f.Title = seriesMetadata.Title
+
+	linked, err := m.seriesStorage.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int32(episodes[0].ID)))
+	if err == nil && linked.EpisodeFileID != nil {
+		if file, err := m.seriesStorage.GetEpisodeFile(ctx, *linked.EpisodeFileID); err == nil && file.RelativePath != nil {
+			f.To = *file.RelativePath
+		}
+	}
+
+	for _, e := range episodes {
+		if e.Machine().ToState(storage.EpisodeStateDownloaded) != nil {
+			log.Debug("episode keeps its state", zap.Int32("episode id", e.ID), zap.String("state", string(e.State)))
+			continue
+		}
+
+		err = m.updateEpisodeState(ctx, *e, storage.EpisodeStateDownloaded, importedTransitionMetadata(e.IsUpgrade, replaced[e.ID]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importMatcher proposes matches for the files of a scan. Library series are looked up by title and their episodes
+// are loaded the first time a file matches them.
+type importMatcher struct {
+	m MediaManager
+	// dir is the scanned directory
+	dir    string
+	series map[string]*importSeries
+}
+
+// importSeries is a library series files are matched against
+type importSeries struct {
+	series   *storage.Series
+	metadata *model.SeriesMetadata
+	loaded   bool
+	target   releaseSeries
+	seasons  map[int32]int32 // season number by season id
+	episodes []*storage.Episode
+}
+
+func (m MediaManager) newImportMatcher(ctx context.Context, dir string) (*importMatcher, error) {
+	series, err := m.seriesStorage.ListSeries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+
+	metadata, err := m.seriesMetaStorage.ListSeriesMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series metadata: %w", err)
+	}
+	byID := make(map[int32]*model.SeriesMetadata, len(metadata))
+	for _, md := range metadata {
+		byID[md.ID] = md
+	}
+
+	matcher := &importMatcher{m: m, dir: dir, series: make(map[string]*importSeries, len(series))}
+	for _, s := range series {
+		if s.SeriesMetadataID == nil || byID[*s.SeriesMetadataID] == nil {
+			continue
+		}
+		md := byID[*s.SeriesMetadataID]
+		matcher.series[titleKey(md.Title)] = &importSeries{series: s, metadata: md}
+	}
+
+	return matcher, nil
+}
+
+// match proposes a movie or episodes for the file
+func (im *importMatcher) match(ctx context.Context, f *ImportFile) error {
+	release := parser.Parse(filepath.Base(f.Path))
+	if len(release.Episodes) > 0 || release.AbsoluteEpisode > 0 || release.AirDate != nil {
+		return im.matchEpisodes(ctx, f)
+	}
+
+	return im.matchMovie(ctx, f)
+}
+
+func (im *importMatcher) matchEpisodes(ctx context.Context, f *ImportFile) error {
+	// episodes in season folders have the series title two folders up, folders above the scanned directory don't count
+	names := []string{filepath.Base(f.Path)}
+	for p := filepath.Dir(f.Path); len(names) < 3 && p != im.dir && strings.HasPrefix(p, im.dir); p = filepath.Dir(p) {
+		names = append(names, filepath.Base(p))
+	}
+
+	var s *importSeries
+	var titles []string
+	for _, name := range names {
+		title := parser.Parse(name).Title
+		if title == "" {
+			continue
+		}
+		titles = append(titles, title)
+		if s = im.series[titleKey(title)]; s != nil {
+			break
+		}
+	}
+	if s == nil {
+		f.Error = fmt.Sprintf("no series in the library is named %s", strings.Join(titles, " or "))
+		return nil
+	}
+
+	err := im.load(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	// episode numbers are only unique within a season, daily and anime episodes are matched across the series
+	candidates := s.episodes
+	episodeFile := library.EpisodeFileFromPath(f.Path)
+	if episodeFile.EpisodeNumber > 0 {
+		candidates = slices.DeleteFunc(slices.Clone(s.episodes), func(e *storage.Episode) bool {
+			return s.seasons[e.SeasonID] != int32(episodeFile.SeasonNumber)
+		})
+	}
+
+	episodes := im.m.matchEpisodeFileToEpisode(ctx, f.Path, candidates, s.target)
+	if len(episodes) == 0 {
+		f.Error = fmt.Sprintf("no episode of %s matches the file", s.metadata.Title)
+		return nil
+	}
+
+	f.MediaType = "episode"
+	f.SeriesID = s.series.ID
+	f.Title = s.metadata.Title
+	f.SeasonNumber = s.seasons[episodes[0].SeasonID]
+	for _, e := range episodes {
+		f.EpisodeNumbers = append(f.EpisodeNumbers, e.EpisodeNumber)
+	}
+	slices.Sort(f.EpisodeNumbers)
+
+	return nil
+}
+
+// load loads the series' seasons and episodes
+func (im *importMatcher) load(ctx context.Context, s *importSeries) error {
+	if s.loaded {
+		return nil
+	}
+
+	seasons, err := im.m.seriesStorage.ListSeasons(ctx, table.Season.SeriesID.EQ(sqlite.Int32(s.series.ID)))
+	if err != nil {
+		return fmt.Errorf("failed to list seasons: %w", err)
+	}
+
+	s.seasons = make(map[int32]int32, len(seasons))
+	ids := make([]sqlite.Expression, 0, len(seasons))
+	for _, season := range seasons {
+		s.seasons[season.ID] = season.SeasonNumber
+		ids = append(ids, sqlite.Int32(season.ID))
+	}
+
+	if len(ids) > 0 {
+		s.episodes, err = im.m.seriesStorage.ListEpisodes(ctx, table.Episode.SeasonID.IN(ids...))
+		if err != nil {
+			return fmt.Errorf("failed to list episodes: %w", err)
+		}
+	}
+
+	s.target = im.m.newReleaseSeries(ctx, s.series, s.metadata)
+	s.loaded = true
+	return nil
+}
+
+func (im *importMatcher) matchMovie(ctx context.Context, f *ImportFile) error {
+	// the file's name is used unless only its folder has the year, like "Movie (2020)/movie.mkv"
+	var term string
+	var year *int32
+	for _, name := range []string{filepath.Base(f.Path), filepath.Base(filepath.Dir(f.Path))} {
+		t, y := pathToSearchTermWithYear(name)
+		if t == "" {
+			continue
+		}
+		if term == "" {
+			term = t
+		}
+		if y != nil {
+			term, year = t, y
+			break
+		}
+	}
+	if term == "" {
+		f.Error = "the file's name doesn't have a title"
+		return nil
+	}
+
+	resp, err := im.m.SearchMovie(ctx, term)
+	if err != nil {
+		return fmt.Errorf("failed to search for movie: %w", err)
+	}
+
+	result := findMatchingMovieResult(resp.Results, year)
+	if result == nil || result.ID == nil {
+		f.Error = fmt.Sprintf("no movie found for %s", term)
+		return nil
+	}
+
+	f.MediaType = "movie"
+	f.TMDBID = *result.ID
+	if result.Title != nil {
+		f.Title = *result.Title
+	}
+	if result.ReleaseDate != nil && len(*result.ReleaseDate) >= 4 {
+		if y, err := strconv.Atoi((*result.ReleaseDate)[:4]); err == nil {
+			f.Year = ptr.To(int32(y))
+		}
+	}
+
+	return nil
+}
+
+// importFolderTitle is the title of the folder in the scanned directory a file is in, so that a junk word in the title
+// doesn't make the folder's files junk. Files directly in the directory have none.
+func importFolderTitle(dir, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return ""
+	}
+
+	folder, _, ok := strings.Cut(filepath.ToSlash(rel), "/")
+	if !ok {
+		return ""
+	}
+
+	return parser.Parse(folder).Title
+}
+
+// titleKey is a title with only its letters and digits, lowercased, so names match titles regardless of punctuation
+func titleKey(title string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, title)
+}
@@ -0,0 +1,243 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/library"
+	libraryMocks "github.com/kasuboski/mediaz/pkg/library/mocks"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	tmdbMocks "github.com/kasuboski/mediaz/pkg/tmdb/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newImportStore creates a store with the series "Show" that has episodes 1 and 2 of season 1 missing
+func newImportStore(t *testing.T, ctx context.Context) (storage.Storage, int32) {
+	store := newStore(t, ctx)
+
+	seriesMetadataID, err := store.CreateSeriesMetadata(ctx, model.SeriesMetadata{TmdbID: 10, Title: "Show"})
+	require.NoError(t, err)
+	seriesID, err := store.CreateSeries(ctx, storage.Series{Series: model.Series{
+		Path:             ptr.To("Show"),
+		Monitored:        1,
+		QualityProfileID: 1,
+		SeriesMetadataID: ptr.To(int32(seriesMetadataID)),
+	}}, storage.SeriesStateMissing)
+	require.NoError(t, err)
+
+	seasonID, err := store.CreateSeason(ctx, storage.Season{Season: model.Season{SeriesID: int32(seriesID), SeasonNumber: 1, Monitored: 1}}, storage.SeasonStateMissing)
+	require.NoError(t, err)
+
+	for i := int32(1); i <= 2; i++ {
+		metadataID, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 100 + i, Title: "Episode", Number: i})
+		require.NoError(t, err)
+		_, err = store.CreateEpisode(ctx, storage.Episode{Episode: model.Episode{
+			SeasonID:          int32(seasonID),
+			EpisodeNumber:     i,
+			Monitored:         1,
+			EpisodeMetadataID: ptr.To(int32(metadataID)),
+		}}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+	}
+
+	return store, int32(seriesID)
+}
+
+func TestMediaManager_ScanImport(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	dir := t.TempDir()
+	write := func(name string) string {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte("video"), 0o644))
+		return p
+	}
+	episode := write("Show/Season 1/Show.S01E02.720p.mkv")
+	movie := write("The Matrix (1999)/matrix.mkv")
+	unknownSeries := write("Other.Show.S01E01.mkv")
+	write("The Matrix (1999)/matrix.nfo")
+	write("The Matrix (1999)/Sample/matrix-sample.mkv")
+	write(".mediaz-extract-1/Show.S01E01.mkv")
+
+	store, seriesID := newImportStore(t, ctx)
+
+	tmdbClient := tmdbMocks.NewMockITmdb(ctrl)
+	body, err := json.Marshal(map[string]any{"results": []map[string]any{
+		{"id": 603, "title": "The Matrix", "release_date": "1999-03-31"},
+	}})
+	require.NoError(t, err)
+	tmdbClient.EXPECT().SearchMovie(gomock.Any(), gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil)
+
+	m := New(tmdbClient, nil, nil, store, nil, config.Manager{}, config.Config{})
+	files, err := m.ScanImport(ctx, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*ImportFile{
+		{Path: unknownSeries, Size: 5, Error: "no series in the library is named Other Show"},
+		{Path: episode, Size: 5, MediaType: "episode", SeriesID: seriesID, SeasonNumber: 1, EpisodeNumbers: []int32{2}, Title: "Show"},
+		{Path: movie, Size: 5, MediaType: "movie", TMDBID: 603, Title: "The Matrix", Year: ptr.To(int32(1999))},
+	}, files)
+
+	t.Run("not a directory", func(t *testing.T) {
+		_, err := m.ScanImport(ctx, movie)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestMediaManager_ImportFiles(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("imports an episode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store, seriesID := newImportStore(t, ctx)
+
+		filePath := "/import/Show.S01E02.720p.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		libraryMock.EXPECT().AddEpisode(ctx, "Show/Season 01/"+originalName(filePath), filePath).Return(library.EpisodeFile{
+			Size:         100,
+			RelativePath: "Show/Season 01/Show.S01E02.720p.mkv",
+		}, nil)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{})
+		files, err := m.ImportFiles(ctx, ImportRequest{Files: []ImportFile{
+			{Path: filePath, MediaType: "episode", SeriesID: seriesID, SeasonNumber: 1, EpisodeNumbers: []int32{2}},
+		}})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Empty(t, files[0].Error)
+		assert.Equal(t, "Show/Season 01/Show.S01E02.720p.mkv", files[0].To)
+
+		episode, err := store.GetEpisode(ctx, table.Episode.EpisodeNumber.EQ(sqlite.Int32(2)))
+		require.NoError(t, err)
+		assert.Equal(t, storage.EpisodeStateDownloaded, episode.State)
+		assert.NotNil(t, episode.EpisodeFileID)
+	})
+
+	t.Run("imports a movie", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := newStore(t, ctx)
+
+		metadataID, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{TmdbID: 603, Title: "The Matrix", Year: ptr.To(int32(1999))})
+		require.NoError(t, err)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{
+			Path:             ptr.To("The Matrix (1999)"),
+			Monitored:        1,
+			QualityProfileID: 1,
+			MovieMetadataID:  ptr.To(int32(metadataID)),
+		}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+
+		filePath := "/import/The Matrix (1999)/matrix.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		libraryMock.EXPECT().AddMovie(ctx, "The Matrix (1999)/matrix", filePath, "").Return(library.MovieFile{
+			Size:         100,
+			RelativePath: "The Matrix (1999)/matrix.mkv",
+		}, nil)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{})
+		files, err := m.ImportFiles(ctx, ImportRequest{Files: []ImportFile{
+			{Path: filePath, MediaType: "movie", TMDBID: 603},
+			{Path: "/import/unknown.mkv"},
+		}})
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Empty(t, files[0].Error)
+		assert.Equal(t, "The Matrix (1999)/matrix.mkv", files[0].To)
+		assert.Equal(t, "the file isn't matched to a movie or episode", files[1].Error)
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+	})
+
+	t.Run("replaces a movie file at the same path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := newStore(t, ctx)
+
+		metadataID, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{TmdbID: 603, Title: "The Matrix", Year: ptr.To(int32(1999))})
+		require.NoError(t, err)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{
+			Path:             ptr.To("The Matrix (1999)"),
+			Monitored:        1,
+			QualityProfileID: 1,
+			MovieMetadataID:  ptr.To(int32(metadataID)),
+		}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+
+		existingPath := "The Matrix (1999)/The Matrix (1999).mkv"
+		replacedFileID, err := store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To(existingPath), Size: 50})
+		require.NoError(t, err)
+
+		filePath := "/import/The.Matrix.1999.2160p.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		gomock.InOrder(
+			libraryMock.EXPECT().MoveMovieFile(ctx, existingPath, "The Matrix (1999)/The Matrix (1999).replaced.mkv").Return(nil),
+			libraryMock.EXPECT().AddMovie(ctx, "The Matrix (1999)/The Matrix (1999)", filePath, "").Return(library.MovieFile{
+				Size:         100,
+				RelativePath: existingPath,
+			}, nil),
+			libraryMock.EXPECT().DeleteMovieFile(gomock.Any(), "The Matrix (1999)/The Matrix (1999).replaced.mkv").Return(nil),
+		)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{Library: config.Library{MovieFileFormat: "{Title} ({Year})"}})
+		files, err := m.ImportFiles(ctx, ImportRequest{Files: []ImportFile{
+			{Path: filePath, MediaType: "movie", TMDBID: 603},
+		}})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Empty(t, files[0].Error)
+		assert.Equal(t, existingPath, files[0].To)
+
+		mfs, err := store.GetMovieFilesByMovieName(ctx, "The Matrix (1999)")
+		require.NoError(t, err)
+		require.Len(t, mfs, 1)
+		assert.NotEqual(t, int32(replacedFileID), mfs[0].ID)
+		assert.Equal(t, existingPath, *mfs[0].RelativePath)
+		assert.Equal(t, int64(100), mfs[0].Size)
+
+		history, err := store.GetEntityTransitions(ctx, "movie", movieID)
+		require.NoError(t, err)
+		imported := history.History[len(history.History)-1]
+		assert.Equal(t, string(storage.MovieStateDownloaded), imported.ToState)
+		require.NotNil(t, imported.Metadata)
+		assert.Equal(t, existingPath, imported.Metadata.ReplacedFile)
+	})
+
+	t.Run("movie that isn't in the library needs a quality profile", func(t *testing.T) {
+		store := newStore(t, ctx)
+		_, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{TmdbID: 603, Title: "The Matrix"})
+		require.NoError(t, err)
+
+		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		files, err := m.ImportFiles(ctx, ImportRequest{Files: []ImportFile{
+			{Path: "/import/matrix.mkv", MediaType: "movie", TMDBID: 603},
+		}})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, "The Matrix isn't in the library, a quality profile is needed to add it", files[0].Error)
+	})
+
+	t.Run("nothing to import", func(t *testing.T) {
+		m := New(nil, nil, nil, newStore(t, ctx), nil, config.Manager{}, config.Config{})
+		_, err := m.ImportFiles(ctx, ImportRequest{})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
@@ -413,6 +413,10 @@ func FromMediaDetails(det tmdb.MediaDetails) model.MovieMetadata {
 		model.Website = det.Homepage
 	}
 
+	if det.OriginalLanguage != nil && *det.OriginalLanguage != "" {
+		model.OriginalLanguage = det.OriginalLanguage
+	}
+
 	if det.Popularity != nil {
 		p := float64(*det.Popularity)
 		model.Popularity = &p
@@ -462,15 +466,22 @@ func FromSeriesDetails(series tmdb.SeriesDetails) (model.SeriesMetadata, error)
 		poster = &p
 	}
 
+	var originalLanguage *string
+	if series.OriginalLanguage != "" {
+		ol := series.OriginalLanguage
+		originalLanguage = &ol
+	}
+
 	return model.SeriesMetadata{
-		TmdbID:       int32(series.ID),
-		Title:        series.Name,
-		SeasonCount:  int32(series.NumberOfSeasons),
-		EpisodeCount: int32(series.NumberOfEpisodes),
-		FirstAirDate: airDate,
-		PosterPath:   poster,
-		Overview:     &series.Overview,
-		Status:       series.Status,
+		TmdbID:           int32(series.ID),
+		Title:            series.Name,
+		SeasonCount:      int32(series.NumberOfSeasons),
+		EpisodeCount:     int32(series.NumberOfEpisodes),
+		FirstAirDate:     airDate,
+		PosterPath:       poster,
+		Overview:         &series.Overview,
+		Status:           series.Status,
+		OriginalLanguage: originalLanguage,
 	}, nil
 }
 
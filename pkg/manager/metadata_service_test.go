@@ -887,6 +887,16 @@ func TestFromMediaDetails(t *testing.T) {
 		assert.Equal(t, model.MovieMetadata{TmdbID: 1, Title: "Movie", Runtime: 100, ReleaseDate: &releaseDate, Year: &year}, got)
 	})
 
+	t.Run("maps original language", func(t *testing.T) {
+		got := FromMediaDetails(tmdb.MediaDetails{
+			ID:               1,
+			Title:            ptr.To("Movie"),
+			Runtime:          ptr.To(100),
+			OriginalLanguage: ptr.To("fr"),
+		})
+		assert.Equal(t, model.MovieMetadata{TmdbID: 1, Title: "Movie", Runtime: 100, OriginalLanguage: ptr.To("fr")}, got)
+	})
+
 	t.Run("ignores invalid release date", func(t *testing.T) {
 		got := FromMediaDetails(tmdb.MediaDetails{
 			ID:          1,
@@ -934,6 +944,12 @@ func TestFromSeriesDetails(t *testing.T) {
 		assert.Equal(t, model.SeriesMetadata{TmdbID: 1, Title: "Show", FirstAirDate: &airDate, Overview: ptr.To(""), PosterPath: ptr.To("/poster.jpg")}, got)
 	})
 
+	t.Run("maps original language when non-empty", func(t *testing.T) {
+		got, err := FromSeriesDetails(tmdb.SeriesDetails{ID: 1, Name: "Show", OriginalLanguage: "ja"})
+		require.NoError(t, err)
+		assert.Equal(t, model.SeriesMetadata{TmdbID: 1, Title: "Show", Overview: ptr.To(""), OriginalLanguage: ptr.To("ja")}, got)
+	})
+
 	t.Run("nil poster and air date when empty", func(t *testing.T) {
 		got, err := FromSeriesDetails(tmdb.SeriesDetails{ID: 1, Name: "Show"})
 		require.NoError(t, err)
@@ -4,11 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
+	"path/filepath"
 
 	"github.com/go-jet/jet/v2/sqlite"
 	"github.com/kasuboski/mediaz/pkg/download"
-	"github.com/kasuboski/mediaz/pkg/indexer"
+	"github.com/kasuboski/mediaz/pkg/library"
 	"github.com/kasuboski/mediaz/pkg/logger"
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
 	"github.com/kasuboski/mediaz/pkg/ptr"
@@ -18,8 +18,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// MovieCategories and TVCategories are the categories searched on an indexer that has no categories stored and doesn't
+// report any, see defaultIndexerCategories
 var (
-	// TODO: these are specific per indexer it seems.. need to store categories with the indexer
 	MovieCategories = []int32{2000}
 	TVCategories    = []int32{5000}
 )
@@ -60,6 +61,9 @@ func (m MediaManager) ReconcileMovies(ctx context.Context) error {
 		allErrors = errors.Join(allErrors, err)
 	}
 
+	// movies refused for disk space keep their state, the reconcile fails so it's noticed
+	allErrors = errors.Join(allErrors, snapshot.DiskSpaceError())
+
 	return allErrors
 }
 
@@ -81,7 +85,7 @@ func (m MediaManager) ReconcileMissingMovies(ctx context.Context, snapshot *Reco
 		log.Warn("skipping missing series reconciliation: no indexers available")
 		return nil
 	}
-	snapshot = newReconcileSnapshot(indexers, snapshot.GetDownloadClients())
+	snapshot = snapshot.withIndexers(indexers)
 
 	movies, err := m.movieStorage.ListMoviesByState(ctx, storage.MovieStateMissing)
 	if err != nil {
@@ -145,8 +149,9 @@ func (m MediaManager) reconcileDownloadingMovie(ctx context.Context, movie *stor
 		return nil
 	}
 
-	_, err := m.movieStorage.GetMovieFilesByMovieName(ctx, *movie.Path)
-	if err == nil {
+	// an upgrade replaces the files that are already tracked once it's imported
+	existingFiles, err := m.movieStorage.GetMovieFilesByMovieName(ctx, *movie.Path)
+	if err == nil && !movie.IsUpgrade {
 		log.Info("movie files already tracked")
 		return m.updateMovieState(ctx, movie, storage.MovieStateDownloaded, nil)
 	}
@@ -166,58 +171,145 @@ func (m MediaManager) reconcileDownloadingMovie(ctx context.Context, movie *stor
 	status, err := downloadClient.Get(ctx, download.GetRequest{
 		ID: movie.DownloadID,
 	})
+	if isDownloadGone(err) {
+		log.Info("download was removed from the download client", zap.String("download id", movie.DownloadID))
+		return m.retryMovieDownload(ctx, movie, BlocklistReasonDownloadRemoved)
+	}
 	if err != nil {
 		log.Warn("failed to get download status", zap.Error(err))
 		return err
 	}
 
 	log.Debug("status", zap.Any("status", status))
+	if status.Failed {
+		log.Info("download failed", zap.String("download id", movie.DownloadID), zap.String("error", status.Error))
+		return m.retryMovieDownload(ctx, movie, BlocklistReasonDownloadFailed)
+	}
+
 	if !status.Done {
 		log.Debug("download not finished")
 		return nil
 	}
 
+	filePaths, cleanup, err := m.extractDownload(ctx, status.FilePaths)
+	if err != nil {
+		log.Warn("failed to extract download", zap.Error(err))
+		return err
+	}
+	status.FilePaths = filePaths
+
 	movieMetadata, err := m.movieMetaStorage.GetMovieMetadata(ctx, table.MovieMetadata.ID.EQ(sqlite.Int32(*movie.MovieMetadataID)))
 	if err != nil {
 		log.Error("failed to get movie metadata", zap.Error(err))
 		return err
 	}
 
+	main, ok := m.mainVideoFile(ctx, m.downloadedVideoFiles(ctx, status.FilePaths, movieMetadata.Title), movieMetadata.Runtime)
+	if !ok {
+		return fmt.Errorf("no video file to import in download %s", movie.DownloadID)
+	}
+
+	var replacedFile string
+	if len(existingFiles) > 0 {
+		if main := mainMovieFile(existingFiles); main.RelativePath != nil {
+			replacedFile = *main.RelativePath
+		}
+	}
+
 	log.Debug("attempting to move downloaded file")
-	for _, f := range status.FilePaths {
-		err = m.addMovieFileToLibrary(ctx, movieMetadata.Title, f, movie)
+	_, err = m.addMovieFileToLibrary(ctx, movieMetadata, main.path, movie, existingFiles)
+	if err != nil {
+		log.Error("failed to add movie file to library", zap.Error(err))
+		return snapshot.trackDiskSpace(err)
+	}
+
+	log.Debug("successfully added movie file to library", zap.String("file", main.path))
+
+	if len(existingFiles) > 0 {
+		err = m.removeReplacedMovieFiles(ctx, existingFiles)
 		if err != nil {
-			log.Error("failed to add movie file to library", zap.Error(err))
-			return err
+			log.Warn("failed to remove replaced movie files", zap.Error(err))
 		}
+	}
 
-		log.Debug("successfully added movie file to library", zap.String("file", f))
+	err = m.updateMovieState(ctx, movie, storage.MovieStateDownloaded, importedTransitionMetadata(movie.IsUpgrade, replacedFile))
+	if err != nil {
+		return err
 	}
 
-	return m.updateMovieState(ctx, movie, storage.MovieStateDownloaded, nil)
+	cleanup()
+	return nil
 }
 
-func (m MediaManager) addMovieFileToLibrary(ctx context.Context, title, filePath string, movie *storage.Movie) error {
+// retryMovieDownload blocklists the release a movie was downloading and marks it missing so another release is searched for.
+// A failed upgrade goes back to downloaded since the movie still has its file.
+func (m MediaManager) retryMovieDownload(ctx context.Context, movie *storage.Movie, reason string) error {
+	err := m.blocklistService.blocklistMovieRelease(ctx, movie, reason)
+	if err != nil {
+		return fmt.Errorf("failed to blocklist movie release: %w", err)
+	}
+
+	if movie.IsUpgrade {
+		return m.updateMovieState(ctx, movie, storage.MovieStateDownloaded, nil)
+	}
+
+	return m.updateMovieState(ctx, movie, storage.MovieStateMissing, nil)
+}
+
+// addMovieFileToLibrary moves a downloaded file into the library and tracks it. The file is named with the configured
+// movie file format in the movie's folder. It returns the file's path in the library. A file it replaces that's at the
+// same path is set aside first, see setAsideMovieFile.
+func (m MediaManager) addMovieFileToLibrary(ctx context.Context, metadata *model.MovieMetadata, filePath string, movie *storage.Movie, replacing []*model.MovieFile) (string, error) {
 	log := logger.FromCtx(ctx)
 	log = log.With("movie id", movie.ID)
 
-	mf, err := m.library.AddMovie(ctx, title, filePath)
+	edition := importedFileEdition(movie.ReleaseTitle, filePath)
+	quality := m.fileQuality(ctx, "movie", movie.ReleaseTitle, &filePath)
+
+	tokens := movieNameTokens(metadata)
+	folder := m.movieFolder(tokens)
+	if movie.Path != nil && *movie.Path != "" {
+		folder = *movie.Path
+	}
+
+	tokens.Quality = quality
+	tokens.Edition = edition
+	tokens.Original = originalName(filePath)
+
+	name := filepath.Join(folder, m.movieFileName(tokens))
+	restore, err := m.setAsideMovieFile(ctx, movie.ID, folder, replacing, library.MovieFilePath(name, filepath.Ext(filePath), edition))
 	if err != nil {
-		return fmt.Errorf("failed to add movie to library: %w", err)
+		return "", err
 	}
 
-	_, err = m.movieStorage.CreateMovieFile(ctx, model.MovieFile{
+	mf, err := m.library.AddMovie(ctx, name, filePath, edition)
+	if err != nil {
+		restore()
+		return "", fmt.Errorf("failed to add movie to library: %w", err)
+	}
+
+	file := model.MovieFile{
 		RelativePath:     &mf.RelativePath,
 		Size:             mf.Size,
 		OriginalFilePath: &filePath,
-	})
+		SceneName:        movie.ReleaseTitle,
+		Languages:        importedFileLanguages(movie.ReleaseTitle, filePath),
+		Quality:          quality,
+	}
+	if edition != "" {
+		file.Edition = &edition
+	}
+
+	movieFileID, err := m.movieStorage.CreateMovieFile(ctx, file)
 	if err != nil {
-		return fmt.Errorf("failed to create movie file: %v", err)
+		return "", fmt.Errorf("failed to create movie file: %v", err)
 	}
 
 	log.Debug("created movie file", zap.String("path", mf.RelativePath))
 
-	return nil
+	m.recordExtraFiles(ctx, model.ExtraFile{MovieFileID: ptr.To(int32(movieFileID))}, mf.ExtraFiles)
+
+	return mf.RelativePath, nil
 }
 
 func (m MediaManager) reconcileMissingMovie(ctx context.Context, movie *storage.Movie, snapshot *ReconcileSnapshot) error {
@@ -257,10 +349,7 @@ func (m MediaManager) reconcileMissingMovie(ctx context.Context, movie *storage.
 	}
 
 	indexerIDs := snapshot.GetIndexerIDs()
-	releases, err := m.indexerService.SearchIndexers(ctx, indexerIDs, MovieCategories, indexer.SearchOptions{
-		Query: det.Title,
-		Type:  ptr.To(indexer.TypeMovie),
-	})
+	releases, err := m.indexerService.SearchIndexers(ctx, indexerIDs, MovieCategories, movieSearchOptions(det))
 	if err != nil {
 		log.Warn("some indexer sources failed during movie search", zap.Int32s("indexers", indexerIDs), zap.Error(err))
 		if len(releases) == 0 {
@@ -268,25 +357,23 @@ func (m MediaManager) reconcileMissingMovie(ctx context.Context, movie *storage.
 		}
 	}
 
-	availableProtocols := snapshot.GetProtocols()
-	log.Debug("releases for consideration", zap.Int("releases", len(releases)))
-	params := ReleaseFilterParams{
-		Title:         det.Title,
-		OriginalTitle: det.OriginalTitle,
-		CleanTitle:    det.CleanTitle,
-		Year:          det.Year,
-		Runtime:       det.Runtime,
-		Certification: det.Certification,
-		Studio:        det.Studio,
-	}
-	releases = slices.DeleteFunc(releases, RejectMovieReleaseFunc(ctx, params, profile, availableProtocols))
-	log.Debug("releases after rejection", zap.Int("releases", len(releases)))
-	if len(releases) == 0 {
+	ranked, _, err := m.movieReleases(ctx, movie, det, profile, snapshot, releases)
+	if err != nil {
+		return err
+	}
+	if len(ranked) == 0 {
+		m.clearPendingReleases(ctx, moviePendingTarget(movie.ID))
 		return nil
 	}
 
-	slices.SortFunc(releases, sortReleaseFunc())
-	chosenRelease := releases[len(releases)-1]
+	chosenRelease, err := m.delayRelease(ctx, moviePendingTarget(movie.ID), profile, ranked, snapshot.time)
+	if err != nil {
+		return err
+	}
+	if chosenRelease == nil {
+		log.Debug("waiting out the delay profile before grabbing a release")
+		return nil
+	}
 
 	log.Info("found release", zap.Any("title", chosenRelease.Title), zap.String("proto", string(*chosenRelease.Protocol)))
 
@@ -296,10 +383,45 @@ func (m MediaManager) reconcileMissingMovie(ctx context.Context, movie *storage.
 		return fmt.Errorf("failed to add movie download request: %w", err)
 	}
 
-	return m.updateMovieState(ctx, movie, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
-		DownloadID:       &status.ID,
-		DownloadClientID: &clientID,
-	})
+	metadata := releaseTransitionMetadata(chosenRelease)
+	metadata.DownloadID = &status.ID
+	metadata.DownloadClientID = &clientID
+
+	return m.updateMovieState(ctx, movie, storage.MovieStateDownloading, &metadata)
+}
+
+// movieReleases filters the releases down to those that can be grabbed for the movie and ranks them, best first. The
+// rejected releases are returned with the reason they were rejected.
+func (m MediaManager) movieReleases(ctx context.Context, movie *storage.Movie, det *model.MovieMetadata, profile storage.QualityProfile, snapshot *ReconcileSnapshot, releases []*prowlarr.ReleaseResource) ([]RankedRelease, []RankedRelease, error) {
+	log := logger.FromCtx(ctx)
+
+	blocklist, err := m.blocklistService.listMovieBlocklist(ctx, movie.ID)
+	if err != nil {
+		log.Warn("failed to list blocklisted releases", zap.Error(err))
+		return nil, nil, err
+	}
+
+	log.Debug("releases for consideration", zap.Int("releases", len(releases)))
+	params := ReleaseFilterParams{
+		Title:            det.Title,
+		OriginalTitle:    det.OriginalTitle,
+		CleanTitle:       det.CleanTitle,
+		Year:             det.Year,
+		Runtime:          det.Runtime,
+		Certification:    det.Certification,
+		Studio:           det.Studio,
+		OriginalLanguage: det.OriginalLanguage,
+		Filters:          m.configs.Releases,
+		Blocklist:        blocklist,
+	}
+	accepted, rejected := filterReleases(ctx, releases, movieReleaseRejection(ctx, params, profile, snapshot.GetProtocols()))
+	log.Debug("releases after rejection", zap.Int("releases", len(accepted)))
+
+	ranked := newReleaseRanker(profile, det.Runtime, snapshot.GetIndexers()).
+		withOriginalLanguage(det.OriginalLanguage).
+		withPreferredProtocol(m.delayProfile(profile).PreferredProtocol).
+		rank(accepted)
+	return ranked, rejected, nil
 }
 
 func (m MediaManager) ReconcileUnreleasedMovies(ctx context.Context, snapshot *ReconcileSnapshot) error {
@@ -472,6 +594,11 @@ func (m MediaManager) requestReleaseDownload(ctx context.Context, snapshot *Reco
 
 	id := c.ID
 
+	err := m.ensureDownloadSpace(ctx, release)
+	if err != nil {
+		return id, download.Status{}, snapshot.trackDiskSpace(err)
+	}
+
 	downloadClient, err := m.downloadClientService.buildRuntimeDownloadClient(ctx, *c)
 	if err != nil {
 		return id, download.Status{}, fmt.Errorf("failed to create download client: %w", err)
@@ -3,8 +3,11 @@ package manager
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"testing/fstest"
@@ -699,6 +702,81 @@ func Test_Manager_reconcileDownloadingMovie(t *testing.T) {
 		assert.Equal(t, storage.MovieStateDownloading, mov.State)
 	})
 
+	for _, tc := range []struct {
+		name   string
+		status download.Status
+		err    error
+		reason string
+	}{
+		{
+			name:   "download failed is blocklisted",
+			status: download.Status{ID: "123", Failed: true, Error: "unpacking failed"},
+			reason: BlocklistReasonDownloadFailed,
+		},
+		{
+			name:   "download removed is blocklisted",
+			err:    fmt.Errorf("no download found: %w", download.ErrDownloadNotFound),
+			reason: BlocklistReasonDownloadRemoved,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newStore(t, ctx)
+			mockDownloadClient := downloadMock.NewMockDownloadClient(ctrl)
+			downloadClientModel := model.DownloadClient{
+				Implementation: "transmission",
+				Type:           "torrent",
+				Port:           8080,
+				Host:           "transmission",
+				Scheme:         "http",
+			}
+
+			downloadClientID, err := store.CreateDownloadClient(ctx, downloadClientModel)
+			require.NoError(t, err)
+
+			downloadClientModel.ID = int32(downloadClientID)
+
+			mockFactory := downloadMock.NewMockFactory(ctrl)
+			mockFactory.EXPECT().NewDownloadClient(downloadClientModel).Return(mockDownloadClient, nil)
+			mockDownloadClient.EXPECT().Get(ctx, download.GetRequest{ID: "123"}).Return(tc.status, tc.err)
+
+			m := New(nil, nil, nil, store, mockFactory, config.Manager{}, config.Config{})
+			require.NotNil(t, m)
+
+			movieID, err := m.movieStorage.CreateMovie(ctx, storage.Movie{Movie: model.Movie{ID: 1, Monitored: 1, QualityProfileID: 1, Path: ptr.To("my-movie")}}, storage.MovieStateMissing)
+			require.NoError(t, err)
+
+			movie, err := m.movieStorage.GetMovie(ctx, movieID)
+			require.NoError(t, err)
+
+			err = m.updateMovieState(ctx, movie, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
+				DownloadID:       ptr.To("123"),
+				DownloadClientID: &downloadClientModel.ID,
+				ReleaseGUID:      ptr.To("guid-123"),
+				ReleaseTitle:     ptr.To("My.Movie.2024.1080p.WEB-DL-GRP"),
+			})
+			require.NoError(t, err)
+
+			movies, err := m.movieStorage.ListMoviesByState(ctx, storage.MovieStateDownloading)
+			require.NoError(t, err)
+			require.Len(t, movies, 1)
+
+			snapshot := newReconcileSnapshot(nil, []*model.DownloadClient{&downloadClientModel})
+			err = m.reconcileDownloadingMovie(ctx, movies[0], snapshot)
+			require.NoError(t, err)
+
+			mov, err := store.GetMovie(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, storage.MovieStateMissing, mov.State)
+
+			entries, err := m.ListBlocklist(ctx, ptr.To(int32(1)), nil)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			assert.Equal(t, "My.Movie.2024.1080p.WEB-DL-GRP", entries[0].Title)
+			assert.Equal(t, ptr.To("guid-123"), entries[0].GUID)
+			assert.Equal(t, tc.reason, entries[0].Reason)
+		})
+	}
+
 	t.Run("failed to get movie metadata", func(t *testing.T) {
 		store := newStore(t, ctx)
 
@@ -756,7 +834,7 @@ func Test_Manager_reconcileDownloadingMovie(t *testing.T) {
 	t.Run("failed to add movie file to library", func(t *testing.T) {
 		store := newStore(t, ctx)
 		mockLibrary := mockLibrary.NewMockLibrary(ctrl)
-		mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie", "test path").Return(library.MovieFile{}, errors.New("expected testing error"))
+		mockLibrary.EXPECT().AddMovie(gomock.Any(), "Movie 1/test path", "test path.mkv", "").Return(library.MovieFile{}, errors.New("expected testing error"))
 
 		downloadClientModel := model.DownloadClient{
 			Implementation: "transmission",
@@ -777,7 +855,7 @@ func Test_Manager_reconcileDownloadingMovie(t *testing.T) {
 		mockDownloadClient.EXPECT().Get(ctx, download.GetRequest{ID: "123"}).Return(download.Status{
 			ID:        "123",
 			Done:      true,
-			FilePaths: []string{"test path"},
+			FilePaths: []string{"test path.mkv"},
 		}, nil)
 
 		m := New(nil, nil, mockLibrary, store, mockFactory, config.Manager{}, config.Config{})
@@ -817,7 +895,7 @@ func Test_Manager_reconcileDownloadingMovie(t *testing.T) {
 	t.Run("successfully reconciled downloading movie", func(t *testing.T) {
 		store := newStore(t, ctx)
 		mockLibrary := mockLibrary.NewMockLibrary(ctrl)
-		mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie", "/downloads/movie.mp4").Return(library.MovieFile{
+		mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie/movie", "/downloads/movie.mp4", "Extended").Return(library.MovieFile{
 			Name:         "my-movie",
 			RelativePath: "my-movie/movie.mp4",
 			AbsolutePath: "/movies/my-movie/movie.mp4",
@@ -859,6 +937,7 @@ func Test_Manager_reconcileDownloadingMovie(t *testing.T) {
 		err = m.updateMovieState(ctx, movie, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
 			DownloadID:       &downloadID,
 			DownloadClientID: &downloadClientModel.ID,
+			ReleaseTitle:     ptr.To("My.Movie.2020.EXTENDED.1080p.BluRay.x264-GRP"),
 		})
 		require.NoError(t, err)
 
@@ -889,6 +968,284 @@ func Test_Manager_reconcileDownloadingMovie(t *testing.T) {
 		assert.Equal(t, "my-movie/movie.mp4", *mf.RelativePath)
 		assert.Equal(t, "/downloads/movie.mp4", *mf.OriginalFilePath)
 		assert.Equal(t, int64(1024), mf.Size)
+		assert.Equal(t, "Bluray-1080p", mf.Quality)
+		require.NotNil(t, mf.Edition)
+		assert.Equal(t, "Extended", *mf.Edition)
+	})
+
+	t.Run("imports the main video file of the download", func(t *testing.T) {
+		store := newStore(t, ctx)
+
+		downloadDir := t.TempDir()
+		writeFile := func(name string, size int64) string {
+			p := filepath.Join(downloadDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+			require.NoError(t, os.WriteFile(p, make([]byte, size), 0o644))
+			return p
+		}
+		main := writeFile("My.Movie.2020.1080p.mkv", 2048)
+		filePaths := []string{
+			writeFile("Sample/My.Movie.2020.1080p.sample.mkv", 4096),
+			writeFile("My.Movie.2020.1080p.nfo", 8),
+			writeFile("Extras/Interview.mkv", 1024),
+			main,
+		}
+
+		mockLibrary := mockLibrary.NewMockLibrary(ctrl)
+		mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie/My.Movie.2020.1080p", main, "").Return(library.MovieFile{
+			Name:         "My.Movie.2020.1080p.mkv",
+			RelativePath: "my-movie/My.Movie.2020.1080p.mkv",
+			AbsolutePath: "/movies/my-movie/My.Movie.2020.1080p.mkv",
+			Size:         2048,
+		}, nil)
+
+		downloadClientModel := model.DownloadClient{
+			Implementation: "transmission",
+			Type:           "torrent",
+			Port:           8080,
+			Host:           "transmission",
+			Scheme:         "http",
+		}
+		downloadClientID, err := store.CreateDownloadClient(ctx, downloadClientModel)
+		require.NoError(t, err)
+		downloadClientModel.ID = int32(downloadClientID)
+
+		mockDownloadClient := downloadMock.NewMockDownloadClient(ctrl)
+		mockFactory := downloadMock.NewMockFactory(ctrl)
+		mockFactory.EXPECT().NewDownloadClient(downloadClientModel).Return(mockDownloadClient, nil)
+		mockDownloadClient.EXPECT().Get(ctx, download.GetRequest{ID: "123"}).Return(download.Status{
+			ID:        "123",
+			Done:      true,
+			FilePaths: filePaths,
+		}, nil)
+
+		m := New(nil, nil, mockLibrary, store, mockFactory, config.Manager{}, config.Config{})
+
+		_, err = store.CreateMovieMetadata(ctx, model.MovieMetadata{Title: "my-movie", TmdbID: 1234})
+		require.NoError(t, err)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Monitored: 1, QualityProfileID: 1, MovieMetadataID: ptr.To(int32(1)), Path: ptr.To("my-movie")}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		downloadID := "123"
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
+			DownloadID:       &downloadID,
+			DownloadClientID: &downloadClientModel.ID,
+		}))
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+
+		err = m.reconcileDownloadingMovie(ctx, movie, newReconcileSnapshot(nil, []*model.DownloadClient{&downloadClientModel}))
+		require.NoError(t, err)
+
+		files, err := store.ListMovieFiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, files, 1, "only the main video file is imported")
+		assert.Equal(t, "my-movie/My.Movie.2020.1080p.mkv", *files[0].RelativePath)
+	})
+
+	t.Run("upgrade recycles the replaced file", func(t *testing.T) {
+		store := newStore(t, ctx)
+		mockLibrary := mockLibrary.NewMockLibrary(ctrl)
+		mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie/movie.1080p", "/downloads/movie.1080p.mkv", "").Return(library.MovieFile{
+			Name:         "movie.1080p.mkv",
+			RelativePath: "my-movie/movie.1080p.mkv",
+			AbsolutePath: "/movies/my-movie/movie.1080p.mkv",
+			Size:         4096,
+			ExtraFiles: []library.ExtraFile{
+				{RelativePath: "my-movie/movie.1080p.en.srt", AbsolutePath: "/movies/my-movie/movie.1080p.en.srt", Size: 10},
+			},
+		}, nil)
+		mockLibrary.EXPECT().RecycleMovieFile(gomock.Any(), "my-movie/movie.720p.en.srt", "/recycle").Return("/recycle/movies/my-movie/movie.720p.en.srt", nil)
+		mockLibrary.EXPECT().RecycleMovieFile(gomock.Any(), "my-movie/movie.720p.mkv", "/recycle").Return("/recycle/movies/my-movie/movie.720p.mkv", nil)
+
+		downloadClientModel := model.DownloadClient{
+			Implementation: "transmission",
+			Type:           "torrent",
+			Port:           8080,
+			Host:           "transmission",
+			Scheme:         "http",
+		}
+
+		downloadClientID, err := store.CreateDownloadClient(ctx, downloadClientModel)
+		require.NoError(t, err)
+
+		downloadClientModel.ID = int32(downloadClientID)
+
+		mockDownloadClient := downloadMock.NewMockDownloadClient(ctrl)
+		mockFactory := downloadMock.NewMockFactory(ctrl)
+		mockFactory.EXPECT().NewDownloadClient(downloadClientModel).Return(mockDownloadClient, nil)
+		mockDownloadClient.EXPECT().Get(ctx, download.GetRequest{ID: "123"}).Return(download.Status{
+			ID:        "123",
+			Done:      true,
+			FilePaths: []string{"/downloads/movie.1080p.mkv"},
+		}, nil)
+
+		m := New(nil, nil, mockLibrary, store, mockFactory, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle"}})
+		require.NotNil(t, m)
+
+		_, err = store.CreateMovieMetadata(ctx, model.MovieMetadata{Title: "my-movie", TmdbID: 1234})
+		require.NoError(t, err)
+
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Monitored: 1, QualityProfileID: 1, MovieMetadataID: ptr.To(int32(1)), Path: ptr.To("my-movie")}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, nil))
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloaded, nil))
+
+		replacedFileID, err := store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("my-movie/movie.720p.mkv"), Size: 1024})
+		require.NoError(t, err)
+		_, err = store.CreateExtraFile(ctx, model.ExtraFile{MovieFileID: ptr.To(int32(replacedFileID)), RelativePath: "my-movie/movie.720p.en.srt", Size: 10})
+		require.NoError(t, err)
+
+		downloadID := "123"
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
+			DownloadID:       &downloadID,
+			DownloadClientID: &downloadClientModel.ID,
+			IsUpgrade:        ptr.To(true),
+			UpgradeReason:    ptr.To(storage.UpgradeReasonCutoff),
+		}))
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		require.True(t, movie.IsUpgrade)
+
+		snapshot := newReconcileSnapshot(nil, []*model.DownloadClient{&downloadClientModel})
+
+		err = m.reconcileDownloadingMovie(ctx, movie, snapshot)
+		require.NoError(t, err)
+
+		movie, err = store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+
+		mfs, err := store.GetMovieFilesByMovieName(ctx, "my-movie")
+		require.NoError(t, err)
+		require.Len(t, mfs, 1)
+		assert.Equal(t, "my-movie/movie.1080p.mkv", *mfs[0].RelativePath)
+
+		extras, err := store.ListExtraFiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, extras, 1, "the replaced file's extra files are removed with it")
+		assert.Equal(t, "my-movie/movie.1080p.en.srt", extras[0].RelativePath)
+		assert.Equal(t, mfs[0].ID, *extras[0].MovieFileID)
+
+		history, err := store.GetEntityTransitions(ctx, "movie", movieID)
+		require.NoError(t, err)
+		imported := history.History[len(history.History)-1]
+		assert.Equal(t, string(storage.MovieStateDownloaded), imported.ToState)
+		require.NotNil(t, imported.Metadata)
+		assert.True(t, imported.Metadata.IsUpgrade)
+		assert.Equal(t, "my-movie/movie.720p.mkv", imported.Metadata.ReplacedFile)
+	})
+
+	// upgradeToSamePath sets up a movie being upgraded to a file that a naming format without the quality names the
+	// same as the file it replaces
+	upgradeToSamePath := func(t *testing.T, mockLibrary library.Library) (MediaManager, storage.Storage, *storage.Movie, *ReconcileSnapshot, int64) {
+		store := newStore(t, ctx)
+
+		downloadClientModel := model.DownloadClient{
+			Implementation: "transmission",
+			Type:           "torrent",
+			Port:           8080,
+			Host:           "transmission",
+			Scheme:         "http",
+		}
+		downloadClientID, err := store.CreateDownloadClient(ctx, downloadClientModel)
+		require.NoError(t, err)
+		downloadClientModel.ID = int32(downloadClientID)
+
+		mockDownloadClient := downloadMock.NewMockDownloadClient(ctrl)
+		mockFactory := downloadMock.NewMockFactory(ctrl)
+		mockFactory.EXPECT().NewDownloadClient(downloadClientModel).Return(mockDownloadClient, nil)
+		mockDownloadClient.EXPECT().Get(ctx, download.GetRequest{ID: "123"}).Return(download.Status{
+			ID:        "123",
+			Done:      true,
+			FilePaths: []string{"/downloads/movie.1080p.mkv"},
+		}, nil)
+
+		m := New(nil, nil, mockLibrary, store, mockFactory, config.Manager{}, config.Config{Library: config.Library{MovieFileFormat: "{Title}"}})
+
+		_, err = store.CreateMovieMetadata(ctx, model.MovieMetadata{Title: "my-movie", TmdbID: 1234})
+		require.NoError(t, err)
+
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Monitored: 1, QualityProfileID: 1, MovieMetadataID: ptr.To(int32(1)), Path: ptr.To("my-movie")}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, nil))
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloaded, nil))
+
+		replacedFileID, err := store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("my-movie/my-movie.mkv"), Size: 1024})
+		require.NoError(t, err)
+
+		downloadID := "123"
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
+			DownloadID:       &downloadID,
+			DownloadClientID: &downloadClientModel.ID,
+			IsUpgrade:        ptr.To(true),
+			UpgradeReason:    ptr.To(storage.UpgradeReasonCutoff),
+		}))
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+
+		return m, store, movie, newReconcileSnapshot(nil, []*model.DownloadClient{&downloadClientModel}), replacedFileID
+	}
+
+	t.Run("upgrade to the same path replaces the file in place", func(t *testing.T) {
+		mockLibrary := mockLibrary.NewMockLibrary(ctrl)
+		gomock.InOrder(
+			mockLibrary.EXPECT().MoveMovieFile(gomock.Any(), "my-movie/my-movie.mkv", "my-movie/my-movie.replaced.mkv").Return(nil),
+			mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie/my-movie", "/downloads/movie.1080p.mkv", "").Return(library.MovieFile{
+				Name:         "my-movie.mkv",
+				RelativePath: "my-movie/my-movie.mkv",
+				AbsolutePath: "/movies/my-movie/my-movie.mkv",
+				Size:         4096,
+			}, nil),
+			mockLibrary.EXPECT().DeleteMovieFile(gomock.Any(), "my-movie/my-movie.replaced.mkv").Return(nil),
+		)
+
+		m, store, movie, snapshot, _ := upgradeToSamePath(t, mockLibrary)
+
+		err := m.reconcileDownloadingMovie(ctx, movie, snapshot)
+		require.NoError(t, err)
+
+		movie, err = store.GetMovie(ctx, int64(movie.ID))
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+
+		mfs, err := store.GetMovieFilesByMovieName(ctx, "my-movie")
+		require.NoError(t, err)
+		require.Len(t, mfs, 1)
+		assert.Equal(t, "my-movie/my-movie.mkv", *mfs[0].RelativePath)
+		assert.Equal(t, int64(4096), mfs[0].Size)
+
+		history, err := store.GetEntityTransitions(ctx, "movie", int64(movie.ID))
+		require.NoError(t, err)
+		imported := history.History[len(history.History)-1]
+		require.NotNil(t, imported.Metadata)
+		assert.Equal(t, "my-movie/my-movie.mkv", imported.Metadata.ReplacedFile)
+	})
+
+	t.Run("upgrade to the same path moves the replaced file back if the import fails", func(t *testing.T) {
+		mockLibrary := mockLibrary.NewMockLibrary(ctrl)
+		gomock.InOrder(
+			mockLibrary.EXPECT().MoveMovieFile(gomock.Any(), "my-movie/my-movie.mkv", "my-movie/my-movie.replaced.mkv").Return(nil),
+			mockLibrary.EXPECT().AddMovie(gomock.Any(), "my-movie/my-movie", "/downloads/movie.1080p.mkv", "").Return(library.MovieFile{}, errors.New("copy failed")),
+			mockLibrary.EXPECT().MoveMovieFile(gomock.Any(), "my-movie/my-movie.replaced.mkv", "my-movie/my-movie.mkv").Return(nil),
+		)
+
+		m, store, movie, snapshot, replacedFileID := upgradeToSamePath(t, mockLibrary)
+
+		err := m.reconcileDownloadingMovie(ctx, movie, snapshot)
+		require.Error(t, err)
+
+		movie, err = store.GetMovie(ctx, int64(movie.ID))
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloading, movie.State)
+
+		mfs, err := store.GetMovieFilesByMovieName(ctx, "my-movie")
+		require.NoError(t, err)
+		require.Len(t, mfs, 1)
+		assert.Equal(t, int32(replacedFileID), mfs[0].ID)
+		assert.Equal(t, "my-movie/my-movie.mkv", *mfs[0].RelativePath)
 	})
 }
 
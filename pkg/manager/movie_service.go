@@ -35,6 +35,10 @@ type MovieService struct {
 	movieStorage     storage.MovieStorage
 	qualityService   *QualityService
 	metadataProvider MovieMetadataProvider
+	// folderFormat names the folder a new movie's files are imported into
+	folderFormat string
+	// recycleBin is where deleted files are moved instead of being removed, they're removed if it's empty
+	recycleBin string
 }
 
 // NewMovieService creates a MovieService with the given dependencies.
@@ -98,12 +102,13 @@ func (s *MovieService) AddMovieToLibrary(ctx context.Context, request AddMovieRe
 	}
 
 	// need to add the movie if it does not exist
+	folder := formatLibraryName(s.folderFormat, library.DefaultMovieFolderFormat, movieNameTokens(det), det.Title)
 	movie = &storage.Movie{
 		Movie: model.Movie{
 			MovieMetadataID:  &det.ID,
 			QualityProfileID: profile.ID,
 			Monitored:        1,
-			Path:             &det.Title,
+			Path:             &folder,
 		},
 	}
 
@@ -125,7 +130,7 @@ func (s *MovieService) AddMovieToLibrary(ctx context.Context, request AddMovieRe
 	return movie, nil
 }
 
-// DeleteMovie removes a movie and optionally its files from disk.
+// DeleteMovie removes a movie and optionally its files from disk. The files are moved to the recycle bin if one is configured.
 func (s *MovieService) DeleteMovie(ctx context.Context, movieID int64, deleteFiles bool) error {
 	log := logger.FromCtx(ctx)
 
@@ -139,7 +144,11 @@ func (s *MovieService) DeleteMovie(ctx context.Context, movieID int64, deleteFil
 			return fmt.Errorf("cannot delete files: movie path is nil")
 		}
 
-		if err := s.library.DeleteMovieDirectory(ctx, *movie.Path); err != nil {
+		if s.recycleBin != "" {
+			if _, err := s.library.RecycleMovieDirectory(ctx, *movie.Path, s.recycleBin); err != nil {
+				return fmt.Errorf("failed to recycle movie directory %s: %w", *movie.Path, err)
+			}
+		} else if err := s.library.DeleteMovieDirectory(ctx, *movie.Path); err != nil {
 			return fmt.Errorf("failed to delete movie directory %s: %w", *movie.Path, err)
 		}
 	}
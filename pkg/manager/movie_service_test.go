@@ -122,6 +122,36 @@ func TestMovieService_AddMovieToLibrary(t *testing.T) {
 	assert.Equal(t, expected, movie)
 }
 
+func TestMovieService_AddMovieToLibrary_FolderFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	svc, store, _, _ := newTestMovieService(ctrl)
+	svc.folderFormat = "{Title} ({Year}) {tmdb-id}"
+
+	releaseDate := time.Now().AddDate(0, 0, -1)
+	svc.metadataProvider = &mockMovieMetadataProvider{metadata: &model.MovieMetadata{
+		ID:          1,
+		TmdbID:      1234,
+		Title:       "Face/Off",
+		Year:        ptr.To(int32(1997)),
+		ReleaseDate: &releaseDate,
+	}}
+
+	store.EXPECT().GetQualityProfile(ctx, int64(1)).Return(storage.QualityProfile{ID: 1}, nil)
+	store.EXPECT().GetMovieByMetadataID(ctx, 1).Return(nil, storage.ErrNotFound)
+	store.EXPECT().CreateMovie(ctx, gomock.Any(), storage.MovieStateMissing).DoAndReturn(func(_ context.Context, movie storage.Movie, _ storage.MovieState) (int64, error) {
+		require.NotNil(t, movie.Path)
+		assert.Equal(t, "Face-Off (1997) {tmdb-1234}", *movie.Path)
+		return 1, nil
+	})
+	store.EXPECT().GetMovie(ctx, int64(1)).Return(&storage.Movie{Movie: model.Movie{ID: 1}}, nil)
+
+	_, err := svc.AddMovieToLibrary(ctx, AddMovieRequest{TMDBID: 1234, QualityProfileID: 1})
+	require.NoError(t, err)
+}
+
 func TestMovieService_AddMovieToLibrary_AlreadyExists_Downloaded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -572,6 +602,30 @@ func TestMovieService_DeleteMovie_WithFiles(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMovieService_DeleteMovie_WithRecycleBin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	svc, store, _, lib := newTestMovieService(ctrl)
+	svc.recycleBin = "/recycle"
+
+	path := "Test Movie"
+	movie := &storage.Movie{
+		Movie: model.Movie{
+			ID:   1,
+			Path: &path,
+		},
+	}
+
+	store.EXPECT().GetMovie(ctx, int64(1)).Return(movie, nil)
+	lib.EXPECT().RecycleMovieDirectory(ctx, path, "/recycle").Return("/recycle/movies/20240101T000000Z/Test Movie", nil)
+	store.EXPECT().DeleteMovie(ctx, int64(1)).Return(nil)
+
+	err := svc.DeleteMovie(ctx, 1, true)
+	require.NoError(t, err)
+}
+
 func TestMovieService_DeleteMovie_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
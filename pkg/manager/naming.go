@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// movieNameTokens are the naming tokens that come from the movie's metadata
+func movieNameTokens(metadata *model.MovieMetadata) library.NameTokens {
+	if metadata == nil {
+		return library.NameTokens{}
+	}
+
+	tokens := library.NameTokens{
+		Title:  metadata.Title,
+		TMDBID: metadata.TmdbID,
+	}
+	if metadata.Year != nil {
+		tokens.Year = *metadata.Year
+	}
+	return tokens
+}
+
+// seriesNameTokens are the naming tokens that come from the series' metadata
+func seriesNameTokens(metadata *model.SeriesMetadata) library.NameTokens {
+	if metadata == nil {
+		return library.NameTokens{}
+	}
+
+	tokens := library.NameTokens{
+		Title:  metadata.Title,
+		TMDBID: metadata.TmdbID,
+	}
+	if metadata.FirstAirDate != nil {
+		tokens.Year = int32(metadata.FirstAirDate.Year())
+	}
+	return tokens
+}
+
+// formatLibraryName renders the format, or the default format if it's empty. It falls back to the fallback if nothing
+// is left of the name, e.g. a format of only tokens that have no value.
+func formatLibraryName(format, defaultFormat string, tokens library.NameTokens, fallback string) string {
+	return cmp.Or(library.FormatName(cmp.Or(format, defaultFormat), tokens), fallback)
+}
+
+// originalName is the downloaded file's name without its extension
+func originalName(filePath string) string {
+	base := filepath.Base(filePath)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// movieFolder is the folder the movie folder format names a movie's folder
+func (m MediaManager) movieFolder(tokens library.NameTokens) string {
+	return formatLibraryName(m.config.Library.MovieFolderFormat, library.DefaultMovieFolderFormat, tokens, tokens.Title)
+}
+
+// movieFileName is the name the movie file format names a movie file, without its extension
+func (m MediaManager) movieFileName(tokens library.NameTokens) string {
+	return formatLibraryName(m.config.Library.MovieFileFormat, library.DefaultMovieFileFormat, tokens, tokens.Original)
+}
+
+// seriesFolder is the folder the series folder format names a series' folder
+func (m MediaManager) seriesFolder(tokens library.NameTokens) string {
+	return formatLibraryName(m.config.Library.SeriesFolderFormat, library.DefaultSeriesFolderFormat, tokens, tokens.Title)
+}
+
+// episodeFileName is where an episode file goes inside its series' folder without its extension, i.e. the season
+// folder and the name the episode file format names it
+func (m MediaManager) episodeFileName(tokens library.NameTokens) string {
+	seasonFolder := formatLibraryName(m.config.Library.SeasonFolderFormat, library.DefaultSeasonFolderFormat, tokens, fmt.Sprintf("Season %02d", tokens.Season))
+	name := formatLibraryName(m.config.Library.EpisodeFileFormat, library.DefaultEpisodeFileFormat, tokens, tokens.Original)
+	return filepath.Join(seasonFolder, name)
+}
+
+// episodeNameTokens are the naming tokens for a file containing the episodes. The episode title is the first episode's.
+func (m MediaManager) episodeNameTokens(ctx context.Context, seriesMetadata *model.SeriesMetadata, seasonNumber int32, episodes []*storage.Episode) library.NameTokens {
+	tokens := seriesNameTokens(seriesMetadata)
+	tokens.Season = seasonNumber
+
+	for _, episode := range episodes {
+		tokens.Episodes = append(tokens.Episodes, episode.EpisodeNumber)
+	}
+	slices.Sort(tokens.Episodes)
+
+	first := slices.MinFunc(episodes, func(a, b *storage.Episode) int {
+		return cmp.Compare(a.EpisodeNumber, b.EpisodeNumber)
+	})
+	if first.EpisodeMetadataID != nil {
+		metadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*first.EpisodeMetadataID)))
+		if err != nil {
+			logger.FromCtx(ctx).Warn("failed to get episode metadata for naming", zap.Int32("episode_id", first.ID), zap.Error(err))
+		} else {
+			tokens.EpisodeTitle = metadata.Title
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,363 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// OrganizeResult lists the tracked library files that don't match the naming formats and where they're moved to
+type OrganizeResult struct {
+	DryRun  bool              `json:"dryRun"`
+	Renames []*OrganizeRename `json:"renames"`
+}
+
+// OrganizeRename is a library file moved to match the naming formats. Paths are relative to the movie or TV library.
+type OrganizeRename struct {
+	MediaType string `json:"mediaType"`
+	MovieID   *int32 `json:"movieId,omitempty"`
+	SeriesID  *int32 `json:"seriesId,omitempty"`
+	FileID    int32  `json:"fileId"`
+	// Extra is set for a companion file, e.g. a subtitle, that moves along with its movie or episode file. FileID is the
+	// companion file's id then.
+	Extra bool   `json:"extra,omitempty"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Error string `json:"error,omitempty"`
+}
+
+// organizePlan is where a movie's or series' folder and files move to
+type organizePlan struct {
+	folder  string
+	renames []*OrganizeRename
+}
+
+// changed is true if the folder or any of the files move
+func (p organizePlan) changed(currentFolder string) bool {
+	return p.folder != currentFolder || slices.ContainsFunc(p.renames, func(r *OrganizeRename) bool {
+		return r.From != r.To
+	})
+}
+
+// conflicts marks files that would be moved to the same path, which happens when the file format doesn't tell them apart
+func (p organizePlan) conflicts() bool {
+	seen := make(map[string]bool, len(p.renames))
+	conflict := false
+	for _, r := range p.renames {
+		if seen[r.To] {
+			r.Error = "another file is moved to the same path"
+			conflict = true
+		}
+		seen[r.To] = true
+	}
+	return conflict
+}
+
+// OrganizeLibrary moves tracked movie and episode files to the paths the naming formats give them. A dry run only lists
+// the moves. Otherwise each movie's or series' files are moved and their paths updated together, and a failed move puts
+// the files that were already moved back.
+func (m MediaManager) OrganizeLibrary(ctx context.Context, dryRun bool) (*OrganizeResult, error) {
+	result := &OrganizeResult{DryRun: dryRun, Renames: []*OrganizeRename{}}
+
+	movies, err := m.organizeMovies(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	result.Renames = append(result.Renames, movies...)
+
+	series, err := m.organizeSeries(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	result.Renames = append(result.Renames, series...)
+
+	return result, nil
+}
+
+func (m MediaManager) organizeMovies(ctx context.Context, dryRun bool) ([]*OrganizeRename, error) {
+	log := logger.FromCtx(ctx)
+
+	movies, err := m.movieStorage.ListMovies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list movies: %w", err)
+	}
+
+	var renames []*OrganizeRename
+	for _, movie := range movies {
+		if movie.Path == nil || movie.MovieMetadataID == nil {
+			continue
+		}
+
+		plan, err := m.movieOrganizePlan(ctx, movie)
+		if err != nil {
+			log.Warn("failed to plan movie organize", zap.Int32("movie id", movie.ID), zap.Error(err))
+			continue
+		}
+		if !plan.changed(*movie.Path) {
+			continue
+		}
+
+		if !plan.conflicts() && !dryRun {
+			m.applyOrganizePlan(ctx, plan, m.library.MoveMovieFile, func(paths, extraPaths map[int32]string) error {
+				return m.movieStorage.UpdateMovieFilePaths(ctx, int64(movie.ID), plan.folder, paths, extraPaths)
+			})
+		}
+
+		renames = append(renames, movedFiles(plan)...)
+	}
+
+	return renames, nil
+}
+
+// movieOrganizePlan names the movie's folder and each of its files with the naming formats
+func (m MediaManager) movieOrganizePlan(ctx context.Context, movie *storage.Movie) (organizePlan, error) {
+	metadata, err := m.movieMetaStorage.GetMovieMetadata(ctx, table.MovieMetadata.ID.EQ(sqlite.Int32(*movie.MovieMetadataID)))
+	if err != nil {
+		return organizePlan{}, fmt.Errorf("failed to get movie metadata: %w", err)
+	}
+
+	plan := organizePlan{folder: m.movieFolder(movieNameTokens(metadata))}
+
+	files, err := m.movieStorage.GetMovieFilesByMovieName(ctx, *movie.Path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return organizePlan{}, fmt.Errorf("failed to get movie files: %w", err)
+	}
+
+	for _, file := range files {
+		// files are matched to the movie by path prefix, so a movie "Alien" would match files of "Aliens"
+		if file.RelativePath == nil || !strings.HasPrefix(*file.RelativePath, *movie.Path+"/") {
+			continue
+		}
+
+		tokens := movieNameTokens(metadata)
+		tokens.Quality = file.Quality
+		if file.Edition != nil {
+			tokens.Edition = *file.Edition
+		}
+		tokens.Original = originalName(organizeOriginalPath(file.OriginalFilePath, *file.RelativePath))
+
+		rename := &OrganizeRename{
+			MediaType: "movie",
+			MovieID:   &movie.ID,
+			FileID:    file.ID,
+			From:      *file.RelativePath,
+			To:        library.MovieFilePath(filepath.Join(plan.folder, m.movieFileName(tokens)), path.Ext(*file.RelativePath), tokens.Edition),
+		}
+		plan.renames = append(plan.renames, rename)
+
+		extras, err := m.extraFileRenames(ctx, rename, table.ExtraFile.MovieFileID.EQ(sqlite.Int32(file.ID)))
+		if err != nil {
+			return organizePlan{}, err
+		}
+		plan.renames = append(plan.renames, extras...)
+	}
+
+	return plan, nil
+}
+
+func (m MediaManager) organizeSeries(ctx context.Context, dryRun bool) ([]*OrganizeRename, error) {
+	log := logger.FromCtx(ctx)
+
+	series, err := m.seriesStorage.ListSeries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+
+	var renames []*OrganizeRename
+	for _, s := range series {
+		if s.Path == nil || s.SeriesMetadataID == nil {
+			continue
+		}
+
+		plan, err := m.seriesOrganizePlan(ctx, s)
+		if err != nil {
+			log.Warn("failed to plan series organize", zap.Int32("series id", s.ID), zap.Error(err))
+			continue
+		}
+		if !plan.changed(*s.Path) {
+			continue
+		}
+
+		if !plan.conflicts() && !dryRun {
+			m.applyOrganizePlan(ctx, plan, m.library.MoveSeriesFile, func(paths, extraPaths map[int32]string) error {
+				return m.seriesStorage.UpdateSeriesFilePaths(ctx, int64(s.ID), plan.folder, paths, extraPaths)
+			})
+		}
+
+		renames = append(renames, movedFiles(plan)...)
+	}
+
+	return renames, nil
+}
+
+// seriesOrganizePlan names the series' folder and each of its episode files with the naming formats
+func (m MediaManager) seriesOrganizePlan(ctx context.Context, series *storage.Series) (organizePlan, error) {
+	seriesMetadata, err := m.seriesMetaStorage.GetSeriesMetadata(ctx, table.SeriesMetadata.ID.EQ(sqlite.Int32(*series.SeriesMetadataID)))
+	if err != nil {
+		return organizePlan{}, fmt.Errorf("failed to get series metadata: %w", err)
+	}
+
+	plan := organizePlan{folder: m.seriesFolder(seriesNameTokens(seriesMetadata))}
+
+	seasons, err := m.seriesStorage.ListSeasons(ctx, table.Season.SeriesID.EQ(sqlite.Int32(series.ID)))
+	if err != nil {
+		return organizePlan{}, fmt.Errorf("failed to list seasons: %w", err)
+	}
+
+	for _, season := range seasons {
+		episodes, err := m.seriesStorage.ListEpisodes(ctx, table.Episode.SeasonID.EQ(sqlite.Int32(season.ID)))
+		if err != nil {
+			return organizePlan{}, fmt.Errorf("failed to list episodes: %w", err)
+		}
+
+		// a multi-episode file is shared by its episodes
+		byFile := make(map[int32][]*storage.Episode)
+		for _, episode := range episodes {
+			if episode.EpisodeFileID != nil {
+				byFile[*episode.EpisodeFileID] = append(byFile[*episode.EpisodeFileID], episode)
+			}
+		}
+
+		fileIDs := make([]int32, 0, len(byFile))
+		for id := range byFile {
+			fileIDs = append(fileIDs, id)
+		}
+		slices.Sort(fileIDs)
+
+		for _, id := range fileIDs {
+			file, err := m.seriesStorage.GetEpisodeFile(ctx, id)
+			if err != nil {
+				return organizePlan{}, fmt.Errorf("failed to get episode file %d: %w", id, err)
+			}
+			if file.RelativePath == nil {
+				continue
+			}
+
+			tokens := m.episodeNameTokens(ctx, seriesMetadata, season.SeasonNumber, byFile[id])
+			tokens.Quality = file.Quality
+			tokens.Original = originalName(organizeOriginalPath(file.OriginalFilePath, *file.RelativePath))
+
+			rename := &OrganizeRename{
+				MediaType: "episode",
+				SeriesID:  &series.ID,
+				FileID:    file.ID,
+				From:      *file.RelativePath,
+				To:        library.EpisodeFilePath(filepath.Join(plan.folder, m.episodeFileName(tokens)), path.Ext(*file.RelativePath)),
+			}
+			plan.renames = append(plan.renames, rename)
+
+			extras, err := m.extraFileRenames(ctx, rename, table.ExtraFile.EpisodeFileID.EQ(sqlite.Int32(file.ID)))
+			if err != nil {
+				return organizePlan{}, err
+			}
+			plan.renames = append(plan.renames, extras...)
+		}
+	}
+
+	return plan, nil
+}
+
+// extraFileRenames moves the companion files of the file the rename moves along with it. What follows the file's name in
+// a companion file's name is kept, e.g. the ".en.srt" of a subtitle.
+func (m MediaManager) extraFileRenames(ctx context.Context, file *OrganizeRename, owner sqlite.BoolExpression) ([]*OrganizeRename, error) {
+	extras, err := m.extraFileStorage.ListExtraFiles(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extra files: %w", err)
+	}
+
+	fromStem := strings.TrimSuffix(path.Base(file.From), path.Ext(file.From))
+	toStem := strings.TrimSuffix(path.Base(file.To), path.Ext(file.To))
+
+	renames := make([]*OrganizeRename, 0, len(extras))
+	for _, extra := range extras {
+		suffix := "." + path.Base(extra.RelativePath)
+		if name := path.Base(extra.RelativePath); strings.HasPrefix(name, fromStem+".") {
+			suffix = strings.TrimPrefix(name, fromStem)
+		}
+
+		renames = append(renames, &OrganizeRename{
+			MediaType: file.MediaType,
+			MovieID:   file.MovieID,
+			SeriesID:  file.SeriesID,
+			FileID:    extra.ID,
+			Extra:     true,
+			From:      extra.RelativePath,
+			To:        path.Join(path.Dir(file.To), toStem+suffix),
+		})
+	}
+
+	return renames, nil
+}
+
+// applyOrganizePlan moves the plan's files and then updates their paths. If a move or the update fails, the files that
+// were moved are put back and every file in the plan gets the error.
+func (m MediaManager) applyOrganizePlan(ctx context.Context, plan organizePlan, move func(ctx context.Context, from, to string) error, update func(paths, extraPaths map[int32]string) error) {
+	log := logger.FromCtx(ctx)
+
+	var moved []*OrganizeRename
+	fail := func(err error) {
+		for _, r := range slices.Backward(moved) {
+			if undoErr := move(ctx, r.To, r.From); undoErr != nil {
+				log.Error("failed to move file back", zap.String("from", r.To), zap.String("to", r.From), zap.Error(undoErr))
+			}
+		}
+		for _, r := range plan.renames {
+			r.Error = err.Error()
+		}
+	}
+
+	paths := make(map[int32]string, len(plan.renames))
+	extraPaths := make(map[int32]string)
+	for _, r := range plan.renames {
+		if r.Extra {
+			extraPaths[r.FileID] = r.To
+		} else {
+			paths[r.FileID] = r.To
+		}
+		if r.From == r.To {
+			continue
+		}
+
+		err := move(ctx, r.From, r.To)
+		if err != nil {
+			fail(fmt.Errorf("failed to move %s: %w", r.From, err))
+			return
+		}
+		moved = append(moved, r)
+	}
+
+	err := update(paths, extraPaths)
+	if err != nil {
+		fail(fmt.Errorf("failed to update file paths: %w", err))
+	}
+}
+
+// movedFiles are the plan's files whose path changes
+func movedFiles(plan organizePlan) []*OrganizeRename {
+	var moved []*OrganizeRename
+	for _, r := range plan.renames {
+		if r.From != r.To {
+			moved = append(moved, r)
+		}
+	}
+	return moved
+}
+
+// organizeOriginalPath is the file's downloaded path if it's known, otherwise its library path
+func organizeOriginalPath(originalFilePath *string, relativePath string) string {
+	if originalFilePath != nil && *originalFilePath != "" {
+		return *originalFilePath
+	}
+	return relativePath
+}
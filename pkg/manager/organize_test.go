@@ -0,0 +1,187 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/config"
+	libraryMocks "github.com/kasuboski/mediaz/pkg/library/mocks"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMediaManager_OrganizeLibrary(t *testing.T) {
+	ctx := context.Background()
+
+	naming := config.Library{
+		MovieFolderFormat:  "{Title} ({Year})",
+		MovieFileFormat:    "{Title} ({Year}) [{Quality}]",
+		SeriesFolderFormat: "{Title} ({Year})",
+		EpisodeFileFormat:  "{Title} - S{Season:00}E{Episode:00} - {EpisodeTitle}",
+	}
+
+	setup := func(t *testing.T) (storage.Storage, int64, int64, int64, int64) {
+		store := newStore(t, ctx)
+
+		movieMetadataID, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{TmdbID: 1, Title: "Movie", Runtime: 100, Year: ptr.To(int32(2020))})
+		require.NoError(t, err)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{
+			Path:            ptr.To("Movie"),
+			Monitored:       1,
+			MovieMetadataID: ptr.To(int32(movieMetadataID)),
+		}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		movieFileID, err := store.CreateMovieFile(ctx, model.MovieFile{
+			RelativePath:     ptr.To("Movie/movie.2020.1080p.bluray.mkv"),
+			OriginalFilePath: ptr.To("/downloads/movie.2020.1080p.bluray.mkv"),
+			Quality:          "Bluray-1080p",
+			Size:             100,
+		})
+		require.NoError(t, err)
+		_, err = store.CreateExtraFile(ctx, model.ExtraFile{
+			MovieFileID:  ptr.To(int32(movieFileID)),
+			RelativePath: "Movie/movie.2020.1080p.bluray.en.srt",
+			Size:         10,
+		})
+		require.NoError(t, err)
+
+		firstAirDate := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		seriesMetadataID, err := store.CreateSeriesMetadata(ctx, model.SeriesMetadata{TmdbID: 2, Title: "Series", FirstAirDate: &firstAirDate})
+		require.NoError(t, err)
+		seriesID, err := store.CreateSeries(ctx, storage.Series{Series: model.Series{
+			Path:             ptr.To("Series"),
+			Monitored:        1,
+			SeriesMetadataID: ptr.To(int32(seriesMetadataID)),
+		}}, storage.SeriesStateMissing)
+		require.NoError(t, err)
+		seasonID, err := store.CreateSeason(ctx, storage.Season{Season: model.Season{SeriesID: int32(seriesID), SeasonNumber: 1, Monitored: 1}}, storage.SeasonStateMissing)
+		require.NoError(t, err)
+		episodeMetadataID, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 3, Title: "Pilot", Number: 1})
+		require.NoError(t, err)
+		episodeID, err := store.CreateEpisode(ctx, storage.Episode{Episode: model.Episode{
+			SeasonID:          int32(seasonID),
+			EpisodeNumber:     1,
+			Monitored:         1,
+			EpisodeMetadataID: ptr.To(int32(episodeMetadataID)),
+		}}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+		episodeFileID, err := store.CreateEpisodeFile(ctx, model.EpisodeFile{RelativePath: ptr.To("Series/Season 01/series.s01e01.mkv"), Size: 100})
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateEpisodeEpisodeFileID(ctx, episodeID, episodeFileID))
+
+		return store, movieID, movieFileID, seriesID, episodeFileID
+	}
+
+	t.Run("dry run lists the moves", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store, movieID, movieFileID, seriesID, episodeFileID := setup(t)
+
+		m := New(nil, nil, libraryMocks.NewMockLibrary(ctrl), store, nil, config.Manager{}, config.Config{Library: naming})
+		result, err := m.OrganizeLibrary(ctx, true)
+		require.NoError(t, err)
+
+		extras, err := store.ListExtraFiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, extras, 1)
+
+		assert.True(t, result.DryRun)
+		assert.Equal(t, []*OrganizeRename{
+			{
+				MediaType: "movie",
+				MovieID:   ptr.To(int32(movieID)),
+				FileID:    int32(movieFileID),
+				From:      "Movie/movie.2020.1080p.bluray.mkv",
+				To:        "Movie (2020)/Movie (2020) [Bluray-1080p].mkv",
+			},
+			{
+				MediaType: "movie",
+				MovieID:   ptr.To(int32(movieID)),
+				FileID:    extras[0].ID,
+				Extra:     true,
+				From:      "Movie/movie.2020.1080p.bluray.en.srt",
+				To:        "Movie (2020)/Movie (2020) [Bluray-1080p].en.srt",
+			},
+			{
+				MediaType: "episode",
+				SeriesID:  ptr.To(int32(seriesID)),
+				FileID:    int32(episodeFileID),
+				From:      "Series/Season 01/series.s01e01.mkv",
+				To:        "Series (2019)/Season 01/Series - S01E01 - Pilot.mkv",
+			},
+		}, result.Renames)
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, "Movie", *movie.Path, "a dry run doesn't change anything")
+	})
+
+	t.Run("moves files and updates their paths", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store, movieID, _, seriesID, episodeFileID := setup(t)
+
+		lib := libraryMocks.NewMockLibrary(ctrl)
+		lib.EXPECT().MoveMovieFile(gomock.Any(), "Movie/movie.2020.1080p.bluray.mkv", "Movie (2020)/Movie (2020) [Bluray-1080p].mkv").Return(nil)
+		lib.EXPECT().MoveMovieFile(gomock.Any(), "Movie/movie.2020.1080p.bluray.en.srt", "Movie (2020)/Movie (2020) [Bluray-1080p].en.srt").Return(nil)
+		lib.EXPECT().MoveSeriesFile(gomock.Any(), "Series/Season 01/series.s01e01.mkv", "Series (2019)/Season 01/Series - S01E01 - Pilot.mkv").Return(nil)
+
+		m := New(nil, nil, lib, store, nil, config.Manager{}, config.Config{Library: naming})
+		result, err := m.OrganizeLibrary(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, result.Renames, 3)
+		for _, r := range result.Renames {
+			assert.Empty(t, r.Error)
+		}
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, "Movie (2020)", *movie.Path)
+		files, err := store.GetMovieFilesByMovieName(ctx, "Movie (2020)")
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, "Movie (2020)/Movie (2020) [Bluray-1080p].mkv", *files[0].RelativePath)
+		extras, err := store.ListExtraFiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, extras, 1)
+		assert.Equal(t, "Movie (2020)/Movie (2020) [Bluray-1080p].en.srt", extras[0].RelativePath, "extra files move with their file")
+
+		series, err := store.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int64(seriesID)))
+		require.NoError(t, err)
+		assert.Equal(t, "Series (2019)", *series.Path)
+		file, err := store.GetEpisodeFile(ctx, int32(episodeFileID))
+		require.NoError(t, err)
+		assert.Equal(t, "Series (2019)/Season 01/Series - S01E01 - Pilot.mkv", *file.RelativePath)
+
+		again, err := m.OrganizeLibrary(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, again.Renames, "an organized library has nothing to move")
+	})
+
+	t.Run("failed move leaves the media as it was", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store, movieID, _, _, _ := setup(t)
+
+		lib := libraryMocks.NewMockLibrary(ctrl)
+		lib.EXPECT().MoveMovieFile(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("file exists"))
+		lib.EXPECT().MoveSeriesFile(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		m := New(nil, nil, lib, store, nil, config.Manager{}, config.Config{Library: naming})
+		result, err := m.OrganizeLibrary(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, result.Renames, 3)
+		assert.Contains(t, result.Renames[0].Error, "file exists")
+		assert.Contains(t, result.Renames[1].Error, "file exists", "the movie's extra files don't move either")
+		assert.Empty(t, result.Renames[2].Error)
+
+		movie, err := store.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, "Movie", *movie.Path)
+	})
+}
@@ -0,0 +1,127 @@
+package manager
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"go.uber.org/zap"
+)
+
+// qualityIdentity is the resolution and source a release or file name is tagged with, empty when the name doesn't say
+type qualityIdentity struct {
+	resolution string
+	source     string
+}
+
+// identifyQuality identifies a release or library file from its names, earlier names win. Paths are identified by their
+// file name.
+func identifyQuality(names ...*string) qualityIdentity {
+	var identity qualityIdentity
+	for _, name := range names {
+		if name == nil || *name == "" {
+			continue
+		}
+
+		parsed := parser.Parse(filepath.Base(*name))
+		if identity.resolution == "" {
+			identity.resolution = parsed.Resolution
+		}
+		if identity.source == "" {
+			identity.source = parsed.Source
+		}
+	}
+
+	// interlaced broadcasts are the 1080p qualities
+	if identity.resolution == "1080i" {
+		identity.resolution = "1080p"
+	}
+
+	return identity
+}
+
+// matches reports whether a quality definition with the resolution and source describes the release. A definition
+// or name without one of them matches any, leaving it to the size limits.
+func (id qualityIdentity) matches(resolution, source string) bool {
+	if resolution != "" && id.resolution != "" && resolution != id.resolution {
+		return false
+	}
+	if source != "" && id.source != "" && source != id.source {
+		return false
+	}
+	return true
+}
+
+// fileQuality names the quality of a library file: the quality definition for the media type with exactly its
+// resolution and source, or its source and resolution like "DVD-480p" when no definition describes it. Files whose
+// names don't tag either have no quality.
+func (m MediaManager) fileQuality(ctx context.Context, mediaType string, names ...*string) string {
+	identity := identifyQuality(names...)
+	if identity.resolution == "" && identity.source == "" {
+		return ""
+	}
+
+	definitions, err := m.qualityService.ListQualityDefinitions(ctx)
+	if err != nil {
+		logger.FromCtx(ctx).Warn("failed to list quality definitions", zap.Error(err))
+	}
+
+	for _, def := range definitions {
+		if def != nil && def.MediaType == mediaType && def.Resolution == identity.resolution && def.Source == identity.source {
+			return def.Name
+		}
+	}
+
+	switch {
+	case identity.source == "":
+		return identity.resolution
+	case identity.resolution == "":
+		return identity.source
+	default:
+		return identity.source + "-" + identity.resolution
+	}
+}
+
+// identifyMovieFileQualities stores the quality of movie files tracked before their quality was identified
+func (m MediaManager) identifyMovieFileQualities(ctx context.Context, files []*model.MovieFile) {
+	log := logger.FromCtx(ctx)
+	for _, f := range files {
+		if f == nil || f.Quality != "" {
+			continue
+		}
+
+		quality := m.fileQuality(ctx, "movie", f.SceneName, f.OriginalFilePath, f.RelativePath)
+		if quality == "" {
+			continue
+		}
+
+		if err := m.movieStorage.UpdateMovieFileQuality(ctx, int64(f.ID), quality); err != nil {
+			log.Warn("failed to store movie file quality", zap.Int32("movie file id", f.ID), zap.Error(err))
+			continue
+		}
+		f.Quality = quality
+	}
+}
+
+// identifyEpisodeFileQualities stores the quality of episode files tracked before their quality was identified
+func (m MediaManager) identifyEpisodeFileQualities(ctx context.Context, files []*model.EpisodeFile) {
+	log := logger.FromCtx(ctx)
+	for _, f := range files {
+		if f == nil || f.Quality != "" {
+			continue
+		}
+
+		quality := m.fileQuality(ctx, "episode", f.SceneName, f.OriginalFilePath, f.RelativePath)
+		if quality == "" {
+			continue
+		}
+
+		f.Quality = quality
+		if err := m.seriesStorage.UpdateEpisodeFile(ctx, f.ID, *f); err != nil {
+			log.Warn("failed to store episode file quality", zap.Int32("episode file id", f.ID), zap.Error(err))
+			f.Quality = ""
+		}
+	}
+}
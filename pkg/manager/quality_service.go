@@ -2,9 +2,14 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/parser"
 	"github.com/kasuboski/mediaz/pkg/storage"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
@@ -26,6 +31,9 @@ type AddQualityDefinitionRequest struct {
 	PreferredSize float64 `json:"preferredSize"`
 	MinSize       float64 `json:"minSize"`
 	MaxSize       float64 `json:"maxSize"`
+	// Resolution and Source limit the definition to releases identified as them, empty matches any
+	Resolution string `json:"resolution"`
+	Source     string `json:"source"`
 }
 
 type UpdateQualityDefinitionRequest struct {
@@ -34,6 +42,9 @@ type UpdateQualityDefinitionRequest struct {
 	PreferredSize float64 `json:"preferredSize"`
 	MinSize       float64 `json:"minSize"`
 	MaxSize       float64 `json:"maxSize"`
+	// Resolution and Source limit the definition to releases identified as them, empty matches any
+	Resolution string `json:"resolution"`
+	Source     string `json:"source"`
 }
 
 type DeleteQualityDefinitionRequest struct {
@@ -50,6 +61,9 @@ func (qs QualityService) AddQualityDefinition(ctx context.Context, request AddQu
 	if request.MinSize >= request.MaxSize {
 		return model.QualityDefinition{}, fmt.Errorf("min size must be less than max size")
 	}
+	if err := validateQualityIdentity(request.Resolution, request.Source); err != nil {
+		return model.QualityDefinition{}, err
+	}
 
 	definition := model.QualityDefinition{
 		Name:          request.Name,
@@ -57,6 +71,8 @@ func (qs QualityService) AddQualityDefinition(ctx context.Context, request AddQu
 		PreferredSize: request.PreferredSize,
 		MinSize:       request.MinSize,
 		MaxSize:       request.MaxSize,
+		Resolution:    request.Resolution,
+		Source:        request.Source,
 	}
 
 	id, err := qs.qualityStorage.CreateQualityDefinition(ctx, definition)
@@ -94,6 +110,9 @@ func (qs QualityService) UpdateQualityDefinition(ctx context.Context, id int64,
 	if request.MinSize >= request.MaxSize {
 		return model.QualityDefinition{}, fmt.Errorf("min size must be less than max size")
 	}
+	if err := validateQualityIdentity(request.Resolution, request.Source); err != nil {
+		return model.QualityDefinition{}, err
+	}
 
 	definition := model.QualityDefinition{
 		ID:            int32(id),
@@ -102,6 +121,8 @@ func (qs QualityService) UpdateQualityDefinition(ctx context.Context, id int64,
 		PreferredSize: request.PreferredSize,
 		MinSize:       request.MinSize,
 		MaxSize:       request.MaxSize,
+		Resolution:    request.Resolution,
+		Source:        request.Source,
 	}
 
 	err := qs.qualityStorage.UpdateQualityDefinition(ctx, id, definition)
@@ -111,18 +132,53 @@ func (qs QualityService) UpdateQualityDefinition(ctx context.Context, id int64,
 	return qs.qualityStorage.GetQualityDefinition(ctx, id)
 }
 
+// validateQualityIdentity makes sure a definition's resolution and source are ones releases can be identified as
+func validateQualityIdentity(resolution, source string) error {
+	if resolution != "" && !slices.Contains(parser.Resolutions(), resolution) {
+		return fmt.Errorf("%w: unknown resolution %q, expected one of %s", ErrValidation, resolution, strings.Join(parser.Resolutions(), ", "))
+	}
+	if source != "" && !slices.Contains(parser.Sources(), source) {
+		return fmt.Errorf("%w: unknown source %q, expected one of %s", ErrValidation, source, strings.Join(parser.Sources(), ", "))
+	}
+	return nil
+}
+
 type AddQualityProfileRequest struct {
-	Name            string  `json:"name" validate:"required"`
-	CutoffQualityID *int32  `json:"cutoffQualityId,omitempty"`
-	UpgradeAllowed  bool    `json:"upgradeAllowed"`
-	QualityIDs      []int32 `json:"qualityIds" validate:"required,min=1,dive,gt=0"`
+	Name            string                        `json:"name" validate:"required"`
+	CutoffQualityID *int32                        `json:"cutoffQualityId,omitempty"`
+	UpgradeAllowed  bool                          `json:"upgradeAllowed"`
+	QualityIDs      []int32                       `json:"qualityIds" validate:"required,min=1,dive,gt=0"`
+	MinFormatScore  int32                         `json:"minFormatScore"`
+	Formats         []QualityProfileFormatRequest `json:"formats" validate:"dive"`
+	RequiredTerms   []string                      `json:"requiredTerms"`
+	IgnoredTerms    []string                      `json:"ignoredTerms"`
+	PreferredTerms  []storage.PreferredTerm       `json:"preferredTerms" validate:"dive"`
+	Languages       []string                      `json:"languages"`
+	RequireLanguage bool                          `json:"requireLanguage"`
+	Editions        []string                      `json:"editions"`
+	RequireEdition  bool                          `json:"requireEdition"`
 }
 
 type UpdateQualityProfileRequest struct {
-	Name            string  `json:"name" validate:"required"`
-	CutoffQualityID *int32  `json:"cutoffQualityId,omitempty"`
-	UpgradeAllowed  bool    `json:"upgradeAllowed"`
-	QualityIDs      []int32 `json:"qualityIds" validate:"required,min=1,dive,gt=0"`
+	Name            string                        `json:"name" validate:"required"`
+	CutoffQualityID *int32                        `json:"cutoffQualityId,omitempty"`
+	UpgradeAllowed  bool                          `json:"upgradeAllowed"`
+	QualityIDs      []int32                       `json:"qualityIds" validate:"required,min=1,dive,gt=0"`
+	MinFormatScore  int32                         `json:"minFormatScore"`
+	Formats         []QualityProfileFormatRequest `json:"formats" validate:"dive"`
+	RequiredTerms   []string                      `json:"requiredTerms"`
+	IgnoredTerms    []string                      `json:"ignoredTerms"`
+	PreferredTerms  []storage.PreferredTerm       `json:"preferredTerms" validate:"dive"`
+	Languages       []string                      `json:"languages"`
+	RequireLanguage bool                          `json:"requireLanguage"`
+	Editions        []string                      `json:"editions"`
+	RequireEdition  bool                          `json:"requireEdition"`
+}
+
+// QualityProfileFormatRequest scores a custom format in a quality profile
+type QualityProfileFormatRequest struct {
+	CustomFormatID int32 `json:"customFormatId" validate:"required,gt=0"`
+	Score          int32 `json:"score"`
 }
 
 type DeleteQualityProfileRequest struct {
@@ -177,10 +233,36 @@ func (qs QualityService) AddQualityProfile(ctx context.Context, request AddQuali
 		return storage.QualityProfile{}, err
 	}
 
+	if err := validateReleaseTerms(request.RequiredTerms, request.IgnoredTerms, request.PreferredTerms); err != nil {
+		return storage.QualityProfile{}, err
+	}
+
+	if err := validateProfileLanguages(request.Languages); err != nil {
+		return storage.QualityProfile{}, err
+	}
+
+	editions, err := normalizeProfileEditions(request.Editions)
+	if err != nil {
+		return storage.QualityProfile{}, err
+	}
+
+	formats, err := qs.qualityProfileFormats(ctx, request.Formats)
+	if err != nil {
+		return storage.QualityProfile{}, err
+	}
+
 	profile := model.QualityProfile{
 		Name:            request.Name,
 		CutoffQualityID: request.CutoffQualityID,
 		UpgradeAllowed:  request.UpgradeAllowed,
+		MinFormatScore:  request.MinFormatScore,
+		RequiredTerms:   encodeProfileList(request.RequiredTerms),
+		IgnoredTerms:    encodeProfileList(request.IgnoredTerms),
+		PreferredTerms:  encodeProfileList(request.PreferredTerms),
+		Languages:       encodeProfileList(request.Languages),
+		RequireLanguage: request.RequireLanguage,
+		Editions:        encodeProfileList(editions),
+		RequireEdition:  request.RequireEdition,
 	}
 
 	id, err := qs.qualityStorage.CreateQualityProfile(ctx, profile)
@@ -200,6 +282,11 @@ func (qs QualityService) AddQualityProfile(ctx context.Context, request AddQuali
 		return storage.QualityProfile{}, err
 	}
 
+	err = qs.qualityStorage.SetQualityProfileFormats(ctx, id, formats)
+	if err != nil {
+		return storage.QualityProfile{}, err
+	}
+
 	return qs.qualityStorage.GetQualityProfile(ctx, id)
 }
 
@@ -215,6 +302,24 @@ func (qs QualityService) UpdateQualityProfile(ctx context.Context, id int64, req
 		return storage.QualityProfile{}, err
 	}
 
+	if err := validateReleaseTerms(request.RequiredTerms, request.IgnoredTerms, request.PreferredTerms); err != nil {
+		return storage.QualityProfile{}, err
+	}
+
+	if err := validateProfileLanguages(request.Languages); err != nil {
+		return storage.QualityProfile{}, err
+	}
+
+	editions, err := normalizeProfileEditions(request.Editions)
+	if err != nil {
+		return storage.QualityProfile{}, err
+	}
+
+	formats, err := qs.qualityProfileFormats(ctx, request.Formats)
+	if err != nil {
+		return storage.QualityProfile{}, err
+	}
+
 	existingProfile, err := qs.qualityStorage.GetQualityProfile(ctx, id)
 	if err != nil {
 		return storage.QualityProfile{}, err
@@ -225,6 +330,14 @@ func (qs QualityService) UpdateQualityProfile(ctx context.Context, id int64, req
 		Name:            request.Name,
 		CutoffQualityID: request.CutoffQualityID,
 		UpgradeAllowed:  request.UpgradeAllowed,
+		MinFormatScore:  request.MinFormatScore,
+		RequiredTerms:   encodeProfileList(request.RequiredTerms),
+		IgnoredTerms:    encodeProfileList(request.IgnoredTerms),
+		PreferredTerms:  encodeProfileList(request.PreferredTerms),
+		Languages:       encodeProfileList(request.Languages),
+		RequireLanguage: request.RequireLanguage,
+		Editions:        encodeProfileList(editions),
+		RequireEdition:  request.RequireEdition,
 	}
 
 	err = qs.qualityStorage.UpdateQualityProfile(ctx, id, profile)
@@ -249,6 +362,11 @@ func (qs QualityService) UpdateQualityProfile(ctx context.Context, id int64, req
 		return storage.QualityProfile{}, err
 	}
 
+	err = qs.qualityStorage.SetQualityProfileFormats(ctx, id, formats)
+	if err != nil {
+		return storage.QualityProfile{}, err
+	}
+
 	return qs.qualityStorage.GetQualityProfile(ctx, id)
 }
 
@@ -259,6 +377,150 @@ func (qs QualityService) DeleteQualityProfile(ctx context.Context, request Delet
 	return qs.qualityStorage.DeleteQualityProfile(ctx, int64(*request.ID))
 }
 
+// qualityProfileFormats checks the scored custom formats exist and aren't repeated
+func (qs QualityService) qualityProfileFormats(ctx context.Context, requests []QualityProfileFormatRequest) ([]model.QualityProfileFormat, error) {
+	formats := make([]model.QualityProfileFormat, 0, len(requests))
+	seen := make(map[int32]struct{}, len(requests))
+	for _, req := range requests {
+		if _, ok := seen[req.CustomFormatID]; ok {
+			return nil, fmt.Errorf("%w: custom format %d is scored more than once", ErrValidation, req.CustomFormatID)
+		}
+		seen[req.CustomFormatID] = struct{}{}
+
+		_, err := qs.qualityStorage.GetCustomFormat(ctx, int64(req.CustomFormatID))
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: custom format %d does not exist", ErrValidation, req.CustomFormatID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		formats = append(formats, model.QualityProfileFormat{
+			CustomFormatID: req.CustomFormatID,
+			Score:          req.Score,
+		})
+	}
+
+	return formats, nil
+}
+
+type AddCustomFormatRequest struct {
+	Name           string                              `json:"name" validate:"required"`
+	Specifications []storage.CustomFormatSpecification `json:"specifications" validate:"required,min=1,dive"`
+}
+
+type UpdateCustomFormatRequest struct {
+	Name           string                              `json:"name" validate:"required"`
+	Specifications []storage.CustomFormatSpecification `json:"specifications" validate:"required,min=1,dive"`
+}
+
+func (qs QualityService) ListCustomFormats(ctx context.Context) ([]*storage.CustomFormat, error) {
+	return qs.qualityStorage.ListCustomFormats(ctx)
+}
+
+func (qs QualityService) GetCustomFormat(ctx context.Context, id int64) (storage.CustomFormat, error) {
+	return qs.qualityStorage.GetCustomFormat(ctx, id)
+}
+
+func (qs QualityService) AddCustomFormat(ctx context.Context, request AddCustomFormatRequest) (storage.CustomFormat, error) {
+	format, err := validateCustomFormat(request.Name, request.Specifications)
+	if err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	if err := qs.checkCustomFormatName(ctx, format.Name, 0); err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	id, err := qs.qualityStorage.CreateCustomFormat(ctx, format)
+	if err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	return qs.qualityStorage.GetCustomFormat(ctx, id)
+}
+
+func (qs QualityService) UpdateCustomFormat(ctx context.Context, id int64, request UpdateCustomFormatRequest) (storage.CustomFormat, error) {
+	format, err := validateCustomFormat(request.Name, request.Specifications)
+	if err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	if _, err := qs.qualityStorage.GetCustomFormat(ctx, id); err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	if err := qs.checkCustomFormatName(ctx, format.Name, id); err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	format.ID = int32(id)
+	if err := qs.qualityStorage.UpdateCustomFormat(ctx, id, format); err != nil {
+		return storage.CustomFormat{}, err
+	}
+
+	return qs.qualityStorage.GetCustomFormat(ctx, id)
+}
+
+// DeleteCustomFormat deletes a custom format, removing its score from every quality profile
+func (qs QualityService) DeleteCustomFormat(ctx context.Context, id int64) error {
+	if _, err := qs.qualityStorage.GetCustomFormat(ctx, id); err != nil {
+		return err
+	}
+
+	return qs.qualityStorage.DeleteCustomFormat(ctx, id)
+}
+
+// checkCustomFormatName makes sure no other custom format uses the name
+func (qs QualityService) checkCustomFormatName(ctx context.Context, name string, id int64) error {
+	existing, err := qs.qualityStorage.ListCustomFormats(ctx, table.CustomFormat.Name.EQ(sqlite.String(name)))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range existing {
+		if int64(f.ID) != id {
+			return fmt.Errorf("%w: custom format %q already exists", ErrValidation, name)
+		}
+	}
+
+	return nil
+}
+
+func validateCustomFormat(name string, specs []storage.CustomFormatSpecification) (storage.CustomFormat, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return storage.CustomFormat{}, fmt.Errorf("%w: name is required", ErrValidation)
+	}
+
+	if len(specs) == 0 {
+		return storage.CustomFormat{}, fmt.Errorf("%w: at least one specification is required", ErrValidation)
+	}
+
+	for i, spec := range specs {
+		switch spec.Type {
+		case storage.CustomFormatSpecTitle, storage.CustomFormatSpecReleaseGroup, storage.CustomFormatSpecSource,
+			storage.CustomFormatSpecResolution, storage.CustomFormatSpecCodec, storage.CustomFormatSpecEdition,
+			storage.CustomFormatSpecLanguage:
+		default:
+			return storage.CustomFormat{}, fmt.Errorf("%w: specification %d has unknown type %q", ErrValidation, i, spec.Type)
+		}
+
+		if spec.Value == "" {
+			return storage.CustomFormat{}, fmt.Errorf("%w: specification %d requires a value", ErrValidation, i)
+		}
+
+		if _, err := regexp.Compile(spec.Value); err != nil {
+			return storage.CustomFormat{}, fmt.Errorf("%w: specification %d has an invalid pattern: %s", ErrValidation, i, err)
+		}
+	}
+
+	return storage.CustomFormat{
+		Name:           name,
+		Specifications: specs,
+	}, nil
+}
+
 func MeetsQualitySize(qs storage.QualityDefinition, fileSize uint64, runtime uint64) bool {
 	if runtime == 0 {
 		return false
@@ -37,8 +37,8 @@ func TestQualityService_GetQualityProfile(t *testing.T) {
 			CutoffQualityID: nil,
 			UpgradeAllowed:  false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 1, Name: "HDTV-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 17.1, MaxSize: 2000},
-				{ID: 2, Name: "WEBDL-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
+				{ID: 1, Name: "HDTV-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 17.1, MaxSize: 2000, Resolution: "720p", Source: "HDTV"},
+				{ID: 2, Name: "WEBDL-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "720p", Source: "WEB-DL"},
 			},
 		}
 		assert.Equal(t, want, profile)
@@ -56,13 +56,13 @@ func TestQualityService_GetQualityProfile(t *testing.T) {
 			CutoffQualityID: nil,
 			UpgradeAllowed:  false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 23, Name: "Remux-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 69.1, MaxSize: 1000},
-				{ID: 22, Name: "Bluray-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 50.4, MaxSize: 1000},
-				{ID: 18, Name: "Bluray-720p", MediaType: "episode", PreferredSize: 995, MinSize: 17.1, MaxSize: 1000},
-				{ID: 19, Name: "HDTV-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000},
-				{ID: 20, Name: "WEBDL-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000},
-				{ID: 21, Name: "WEBRip-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000},
-				{ID: 17, Name: "WEBRip-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000},
+				{ID: 23, Name: "Remux-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 69.1, MaxSize: 1000, Resolution: "1080p", Source: "Remux"},
+				{ID: 22, Name: "Bluray-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 50.4, MaxSize: 1000, Resolution: "1080p", Source: "BluRay"},
+				{ID: 18, Name: "Bluray-720p", MediaType: "episode", PreferredSize: 995, MinSize: 17.1, MaxSize: 1000, Resolution: "720p", Source: "BluRay"},
+				{ID: 19, Name: "HDTV-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000, Resolution: "1080p", Source: "HDTV"},
+				{ID: 20, Name: "WEBDL-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000, Resolution: "1080p", Source: "WEB-DL"},
+				{ID: 21, Name: "WEBRip-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000, Resolution: "1080p", Source: "WEBRip"},
+				{ID: 17, Name: "WEBRip-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000, Resolution: "720p", Source: "WEBRip"},
 			},
 		}
 		assert.Equal(t, want, profile)
@@ -81,29 +81,29 @@ func TestQualityService_ListMovieQualityProfiles(t *testing.T) {
 		{
 			ID: 3, Name: "Ultra High Definition", CutoffQualityID: nil, UpgradeAllowed: false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 9, Name: "Remux-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 102, MaxSize: 2000},
-				{ID: 13, Name: "Bluray-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 102, MaxSize: 2000},
-				{ID: 10, Name: "HDTV-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 85, MaxSize: 2000},
-				{ID: 11, Name: "WEBDL-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 34.5, MaxSize: 2000},
-				{ID: 12, Name: "WEBRip-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 34.5, MaxSize: 2000},
+				{ID: 9, Name: "Remux-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 102, MaxSize: 2000, Resolution: "1080p", Source: "Remux"},
+				{ID: 13, Name: "Bluray-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 102, MaxSize: 2000, Resolution: "2160p", Source: "BluRay"},
+				{ID: 10, Name: "HDTV-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 85, MaxSize: 2000, Resolution: "2160p", Source: "HDTV"},
+				{ID: 11, Name: "WEBDL-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 34.5, MaxSize: 2000, Resolution: "2160p", Source: "WEB-DL"},
+				{ID: 12, Name: "WEBRip-2160p", MediaType: "movie", PreferredSize: 1999, MinSize: 34.5, MaxSize: 2000, Resolution: "2160p", Source: "WEBRip"},
 			},
 		},
 		{
 			ID: 2, Name: "High Definition", CutoffQualityID: nil, UpgradeAllowed: false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 8, Name: "Bluray-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 50.8, MaxSize: 2000},
-				{ID: 5, Name: "HDTV-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 33.8, MaxSize: 2000},
-				{ID: 4, Name: "Bluray-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 25.7, MaxSize: 2000},
-				{ID: 3, Name: "WEBRip-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
-				{ID: 6, Name: "WEBDL-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
-				{ID: 7, Name: "WEBRip-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
+				{ID: 8, Name: "Bluray-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 50.8, MaxSize: 2000, Resolution: "1080p", Source: "BluRay"},
+				{ID: 5, Name: "HDTV-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 33.8, MaxSize: 2000, Resolution: "1080p", Source: "HDTV"},
+				{ID: 4, Name: "Bluray-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 25.7, MaxSize: 2000, Resolution: "720p", Source: "BluRay"},
+				{ID: 3, Name: "WEBRip-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "720p", Source: "WEBRip"},
+				{ID: 6, Name: "WEBDL-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "1080p", Source: "WEB-DL"},
+				{ID: 7, Name: "WEBRip-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "1080p", Source: "WEBRip"},
 			},
 		},
 		{
 			ID: 1, Name: "Standard Definition", CutoffQualityID: nil, UpgradeAllowed: false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 1, Name: "HDTV-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 17.1, MaxSize: 2000},
-				{ID: 2, Name: "WEBDL-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
+				{ID: 1, Name: "HDTV-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 17.1, MaxSize: 2000, Resolution: "720p", Source: "HDTV"},
+				{ID: 2, Name: "WEBDL-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "720p", Source: "WEB-DL"},
 			},
 		},
 	}
@@ -122,30 +122,30 @@ func TestQualityService_ListEpisodeQualityProfiles(t *testing.T) {
 		{
 			ID: 6, Name: "Ultra High Definition", CutoffQualityID: nil, UpgradeAllowed: false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 27, Name: "Bluray-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 94.6, MaxSize: 1000},
-				{ID: 23, Name: "Remux-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 69.1, MaxSize: 1000},
-				{ID: 24, Name: "HDTV-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 25, MaxSize: 1000},
-				{ID: 25, Name: "WEBDL-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 25, MaxSize: 1000},
-				{ID: 26, Name: "WEBRip-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 25, MaxSize: 1000},
+				{ID: 27, Name: "Bluray-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 94.6, MaxSize: 1000, Resolution: "2160p", Source: "BluRay"},
+				{ID: 23, Name: "Remux-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 69.1, MaxSize: 1000, Resolution: "1080p", Source: "Remux"},
+				{ID: 24, Name: "HDTV-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 25, MaxSize: 1000, Resolution: "2160p", Source: "HDTV"},
+				{ID: 25, Name: "WEBDL-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 25, MaxSize: 1000, Resolution: "2160p", Source: "WEB-DL"},
+				{ID: 26, Name: "WEBRip-2160p", MediaType: "episode", PreferredSize: 995, MinSize: 25, MaxSize: 1000, Resolution: "2160p", Source: "WEBRip"},
 			},
 		},
 		{
 			ID: 5, Name: "High Definition", CutoffQualityID: nil, UpgradeAllowed: false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 23, Name: "Remux-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 69.1, MaxSize: 1000},
-				{ID: 22, Name: "Bluray-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 50.4, MaxSize: 1000},
-				{ID: 18, Name: "Bluray-720p", MediaType: "episode", PreferredSize: 995, MinSize: 17.1, MaxSize: 1000},
-				{ID: 19, Name: "HDTV-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000},
-				{ID: 20, Name: "WEBDL-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000},
-				{ID: 21, Name: "WEBRip-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000},
-				{ID: 17, Name: "WEBRip-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000},
+				{ID: 23, Name: "Remux-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 69.1, MaxSize: 1000, Resolution: "1080p", Source: "Remux"},
+				{ID: 22, Name: "Bluray-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 50.4, MaxSize: 1000, Resolution: "1080p", Source: "BluRay"},
+				{ID: 18, Name: "Bluray-720p", MediaType: "episode", PreferredSize: 995, MinSize: 17.1, MaxSize: 1000, Resolution: "720p", Source: "BluRay"},
+				{ID: 19, Name: "HDTV-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000, Resolution: "1080p", Source: "HDTV"},
+				{ID: 20, Name: "WEBDL-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000, Resolution: "1080p", Source: "WEB-DL"},
+				{ID: 21, Name: "WEBRip-1080p", MediaType: "episode", PreferredSize: 995, MinSize: 15, MaxSize: 1000, Resolution: "1080p", Source: "WEBRip"},
+				{ID: 17, Name: "WEBRip-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000, Resolution: "720p", Source: "WEBRip"},
 			},
 		},
 		{
 			ID: 4, Name: "Standard Definition", CutoffQualityID: nil, UpgradeAllowed: false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 15, Name: "HDTV-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000},
-				{ID: 16, Name: "WEBDL-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000},
+				{ID: 15, Name: "HDTV-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000, Resolution: "720p", Source: "HDTV"},
+				{ID: 16, Name: "WEBDL-720p", MediaType: "episode", PreferredSize: 995, MinSize: 10, MaxSize: 1000, Resolution: "720p", Source: "WEB-DL"},
 			},
 		},
 	}
@@ -173,8 +173,8 @@ func TestQualityService_UpdateQualityProfile(t *testing.T) {
 			CutoffQualityID: nil,
 			UpgradeAllowed:  false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 3, Name: "WEBRip-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
-				{ID: 7, Name: "WEBRip-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
+				{ID: 3, Name: "WEBRip-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "720p", Source: "WEBRip"},
+				{ID: 7, Name: "WEBRip-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "1080p", Source: "WEBRip"},
 			},
 		}
 		assert.Equal(t, want, updated)
@@ -233,8 +233,8 @@ func TestQualityService_AddQualityProfile(t *testing.T) {
 			CutoffQualityID: nil,
 			UpgradeAllowed:  false,
 			Qualities: []storage.QualityDefinition{
-				{ID: 3, Name: "WEBRip-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
-				{ID: 7, Name: "WEBRip-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000},
+				{ID: 3, Name: "WEBRip-720p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "720p", Source: "WEBRip"},
+				{ID: 7, Name: "WEBRip-1080p", MediaType: "movie", PreferredSize: 1999, MinSize: 12.5, MaxSize: 2000, Resolution: "1080p", Source: "WEBRip"},
 			},
 		}
 		assert.Equal(t, want, profile)
@@ -359,6 +359,7 @@ func TestQualityService_UpdateQualityProfile_Mock(t *testing.T) {
 		store.EXPECT().UpdateQualityProfile(ctx, int64(1), gomock.Any()).Return(nil)
 		store.EXPECT().DeleteQualityProfileItemsByProfileID(ctx, int64(1)).Return(nil)
 		store.EXPECT().CreateQualityProfileItems(ctx, gomock.Any()).Return(nil)
+		store.EXPECT().SetQualityProfileFormats(ctx, int64(1), gomock.Len(0)).Return(nil)
 		store.EXPECT().GetQualityProfile(ctx, int64(1)).Return(existingProfile, nil)
 
 		_, err := qs.UpdateQualityProfile(ctx, 1, UpdateQualityProfileRequest{
@@ -368,3 +369,191 @@ func TestQualityService_UpdateQualityProfile_Mock(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestQualityService_CustomFormats(t *testing.T) {
+	hdr := AddCustomFormatRequest{
+		Name: "HDR",
+		Specifications: []storage.CustomFormatSpecification{
+			{Type: storage.CustomFormatSpecTitle, Value: `\bHDR(10)?\b`},
+		},
+	}
+
+	t.Run("add, update and delete", func(t *testing.T) {
+		ctx := context.Background()
+		qs := NewQualityService(newQualityServiceStore(t))
+
+		format, err := qs.AddCustomFormat(ctx, hdr)
+		require.NoError(t, err)
+		assert.NotZero(t, format.ID)
+		assert.Equal(t, "HDR", format.Name)
+		assert.Equal(t, hdr.Specifications, format.Specifications)
+
+		updated, err := qs.UpdateCustomFormat(ctx, int64(format.ID), UpdateCustomFormatRequest{
+			Name:           "HDR",
+			Specifications: []storage.CustomFormatSpecification{{Type: storage.CustomFormatSpecTitle, Value: `\bDV\b`}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `\bDV\b`, updated.Specifications[0].Value)
+
+		require.NoError(t, qs.DeleteCustomFormat(ctx, int64(format.ID)))
+		_, err = qs.GetCustomFormat(ctx, int64(format.ID))
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		ctx := context.Background()
+		qs := NewQualityService(newQualityServiceStore(t))
+
+		_, err := qs.AddCustomFormat(ctx, hdr)
+		require.NoError(t, err)
+
+		tests := map[string]AddCustomFormatRequest{
+			"duplicate name":    hdr,
+			"missing name":      {Specifications: hdr.Specifications},
+			"no specifications": {Name: "Empty"},
+			"unknown type":      {Name: "Bad", Specifications: []storage.CustomFormatSpecification{{Type: "bitrate", Value: "x"}}},
+			"invalid pattern":   {Name: "Bad", Specifications: []storage.CustomFormatSpecification{{Type: storage.CustomFormatSpecTitle, Value: "("}}},
+		}
+		for name, req := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := qs.AddCustomFormat(ctx, req)
+				assert.ErrorIs(t, err, ErrValidation)
+			})
+		}
+	})
+
+	t.Run("quality profile scores formats", func(t *testing.T) {
+		ctx := context.Background()
+		qs := NewQualityService(newQualityServiceStore(t))
+
+		format, err := qs.AddCustomFormat(ctx, hdr)
+		require.NoError(t, err)
+
+		profile, err := qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+			Name:           "HDR Profile",
+			QualityIDs:     []int32{3, 7},
+			MinFormatScore: 10,
+			Formats:        []QualityProfileFormatRequest{{CustomFormatID: format.ID, Score: 100}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), profile.MinFormatScore)
+		require.Len(t, profile.Formats, 1)
+		assert.Equal(t, format, profile.Formats[0].Format)
+		assert.Equal(t, int32(100), profile.Formats[0].Score)
+
+		profile, err = qs.UpdateQualityProfile(ctx, int64(profile.ID), UpdateQualityProfileRequest{
+			Name:       "HDR Profile",
+			QualityIDs: []int32{3, 7},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), profile.MinFormatScore)
+		assert.Empty(t, profile.Formats)
+
+		_, err = qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+			Name:       "Missing Format",
+			QualityIDs: []int32{3},
+			Formats:    []QualityProfileFormatRequest{{CustomFormatID: 999, Score: 1}},
+		})
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+			Name:       "Repeated Format",
+			QualityIDs: []int32{3},
+			Formats:    []QualityProfileFormatRequest{{CustomFormatID: format.ID, Score: 1}, {CustomFormatID: format.ID, Score: 2}},
+		})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestQualityService_ReleaseTerms(t *testing.T) {
+	t.Run("saves the terms", func(t *testing.T) {
+		ctx := context.Background()
+		qs := NewQualityService(newQualityServiceStore(t))
+
+		profile, err := qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+			Name:           "Terms",
+			QualityIDs:     []int32{3},
+			RequiredTerms:  []string{"1080p"},
+			IgnoredTerms:   []string{"HC", `/\b3d\b/`},
+			PreferredTerms: []storage.PreferredTerm{{Term: "FLUX", Score: 10}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, storage.ReleaseTerms{"1080p"}, profile.RequiredTerms)
+		assert.Equal(t, storage.ReleaseTerms{"HC", `/\b3d\b/`}, profile.IgnoredTerms)
+		assert.Equal(t, storage.PreferredTerms{{Term: "FLUX", Score: 10}}, profile.PreferredTerms)
+
+		updated, err := qs.UpdateQualityProfile(ctx, int64(profile.ID), UpdateQualityProfileRequest{
+			Name:         "Terms",
+			QualityIDs:   []int32{3},
+			IgnoredTerms: []string{"HC"},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, updated.RequiredTerms)
+		assert.Equal(t, storage.ReleaseTerms{"HC"}, updated.IgnoredTerms)
+		assert.Nil(t, updated.PreferredTerms)
+	})
+
+	t.Run("rejects invalid terms", func(t *testing.T) {
+		ctx := context.Background()
+		qs := NewQualityService(newQualityServiceStore(t))
+
+		_, err := qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+			Name:         "Terms",
+			QualityIDs:   []int32{3},
+			IgnoredTerms: []string{"/(/"},
+		})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestQualityService_Languages(t *testing.T) {
+	ctx := context.Background()
+	qs := NewQualityService(newQualityServiceStore(t))
+
+	profile, err := qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+		Name:            "Original",
+		QualityIDs:      []int32{3},
+		Languages:       []string{"original", "en"},
+		RequireLanguage: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, storage.ProfileLanguages{"original", "en"}, profile.Languages)
+	assert.True(t, profile.RequireLanguage)
+
+	_, err = qs.UpdateQualityProfile(ctx, int64(profile.ID), UpdateQualityProfileRequest{
+		Name:       "Original",
+		QualityIDs: []int32{3},
+		Languages:  []string{"english"},
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestQualityService_Editions(t *testing.T) {
+	ctx := context.Background()
+	qs := NewQualityService(newQualityServiceStore(t))
+
+	profile, err := qs.AddQualityProfile(ctx, AddQualityProfileRequest{
+		Name:           "Extended",
+		QualityIDs:     []int32{3},
+		Editions:       []string{"extended cut", "directors cut"},
+		RequireEdition: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, storage.ProfileEditions{"Extended", "Director's Cut"}, profile.Editions)
+	assert.True(t, profile.RequireEdition)
+
+	updated, err := qs.UpdateQualityProfile(ctx, int64(profile.ID), UpdateQualityProfileRequest{
+		Name:       "Extended",
+		QualityIDs: []int32{3},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, updated.Editions)
+	assert.False(t, updated.RequireEdition)
+
+	_, err = qs.UpdateQualityProfile(ctx, int64(profile.ID), UpdateQualityProfileRequest{
+		Name:       "Extended",
+		QualityIDs: []int32{3},
+		Editions:   []string{""},
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+}
@@ -1,10 +1,16 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/ptr"
 	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestQualitySizeCutoff(t *testing.T) {
@@ -58,3 +64,116 @@ func TestQualitySizeCutoff(t *testing.T) {
 		})
 	}
 }
+
+func TestIdentifyQuality(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []*string
+		want  qualityIdentity
+	}{
+		{
+			name:  "release title",
+			names: []*string{ptr.To("Movie.2020.1080p.BluRay.x264-GRP")},
+			want:  qualityIdentity{resolution: "1080p", source: "BluRay"},
+		},
+		{
+			name:  "earlier names win",
+			names: []*string{ptr.To("Movie.2020.720p.WEB-DL-GRP"), ptr.To("/downloads/Movie.2020.1080p.BluRay-GRP.mkv")},
+			want:  qualityIdentity{resolution: "720p", source: "WEB-DL"},
+		},
+		{
+			name:  "later names fill in what earlier ones don't say",
+			names: []*string{nil, ptr.To("Movie.2020.HDTV-GRP"), ptr.To("Movie (2020)/Movie.2020.720p.mkv")},
+			want:  qualityIdentity{resolution: "720p", source: "HDTV"},
+		},
+		{
+			name:  "paths are identified by their file name",
+			names: []*string{ptr.To("Movie 1080p/Movie.mkv")},
+			want:  qualityIdentity{},
+		},
+		{
+			name:  "interlaced is 1080p",
+			names: []*string{ptr.To("Show.S01E01.1080i.HDTV-GRP")},
+			want:  qualityIdentity{resolution: "1080p", source: "HDTV"},
+		},
+		{
+			name: "no names",
+			want: qualityIdentity{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, identifyQuality(tt.names...))
+		})
+	}
+}
+
+func TestMatchQuality(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{Name: "Bluray-1080p", Resolution: "1080p", Source: "BluRay", MinSize: 10, MaxSize: 100},
+			{Name: "WEBDL-1080p", Resolution: "1080p", Source: "WEB-DL", MinSize: 10, MaxSize: 100},
+			{Name: "Any-720p", Resolution: "720p", MinSize: 5, MaxSize: 100},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		title string
+		size  uint64
+		want  int
+		ok    bool
+	}{
+		{name: "bluray", title: "Movie.2020.1080p.BluRay-GRP", size: 5000, want: 0, ok: true},
+		{name: "web-dl of the same size", title: "Movie.2020.1080p.WEB-DL-GRP", size: 5000, want: 1, ok: true},
+		{name: "definition without a source", title: "Movie.2020.720p.HDTV-GRP", size: 1000, want: 2, ok: true},
+		{name: "size still applies", title: "Movie.2020.1080p.WEB-DL-GRP", size: 500, want: -1, ok: false},
+		{name: "no definition for the source", title: "Movie.2020.1080p.HDTV-GRP", size: 5000, want: -1, ok: false},
+		{name: "untagged falls back to size", title: "Movie.2020-GRP", size: 5000, want: 0, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, ok := matchQuality(profile, identifyQuality(&tt.title), tt.size, 100)
+			assert.Equal(t, tt.want, i)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestMediaManager_fileQuality(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t, ctx)
+	m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+
+	assert.Equal(t, "Bluray-1080p", m.fileQuality(ctx, "movie", ptr.To("Movie.2020.1080p.BluRay.x264-GRP"), ptr.To("Movie (2020)/Movie.mkv")))
+	assert.Equal(t, "WEBDL-720p", m.fileQuality(ctx, "episode", nil, ptr.To("Show/Season 1/Show.S01E01.720p.WEB-DL-GRP.mkv")))
+	assert.Equal(t, "DVD", m.fileQuality(ctx, "movie", ptr.To("Movie.2020.DVDRip.XviD-GRP")))
+	assert.Equal(t, "HDTV-576p", m.fileQuality(ctx, "movie", ptr.To("Movie.2020.576p.HDTV-GRP")))
+	assert.Equal(t, "", m.fileQuality(ctx, "movie", ptr.To("Movie (2020)/Movie.mkv")))
+}
+
+func TestMediaManager_identifyMovieFileQualities(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t, ctx)
+	m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+
+	_, err := store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("Movie (2020)/Movie.2020.2160p.WEB-DL.mkv"), Size: 1})
+	require.NoError(t, err)
+	_, err = store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("Other (2020)/Other.mkv"), Size: 1})
+	require.NoError(t, err)
+	_, err = store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("Kept (2020)/Kept.2020.720p.HDTV.mkv"), Size: 1, Quality: "Custom"})
+	require.NoError(t, err)
+
+	files, err := store.ListMovieFiles(ctx)
+	require.NoError(t, err)
+	m.identifyMovieFileQualities(ctx, files)
+
+	files, err = store.ListMovieFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, "WEBDL-2160p", files[0].Quality)
+	assert.Equal(t, "", files[1].Quality)
+	assert.Equal(t, "Custom", files[2].Quality)
+}
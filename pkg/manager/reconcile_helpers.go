@@ -1,9 +1,12 @@
 package manager
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/kasuboski/mediaz/pkg/library"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 )
 
@@ -14,7 +17,16 @@ type ReconcileSnapshot struct {
 	downloadClients   []*model.DownloadClient
 	indexers          []model.Indexer
 	indexerIDs        []int32
-	mu                sync.Mutex
+	// refused are the grabs and imports refused for disk space, shared with the snapshots derived from this one
+	refused *refusedForDiskSpace
+	mu      sync.Mutex
+}
+
+// refusedForDiskSpace are the grabs and imports of a reconcile that were refused for disk space
+type refusedForDiskSpace struct {
+	mu    sync.Mutex
+	first error
+	count int
 }
 
 func newReconcileSnapshot(indexers []model.Indexer, downloadClients []*model.DownloadClient) *ReconcileSnapshot {
@@ -30,11 +42,54 @@ func newReconcileSnapshot(indexers []model.Indexer, downloadClients []*model.Dow
 		downloadProtocols: protocols,
 		indexerIDs:        ids,
 		indexers:          indexers,
+		refused:           &refusedForDiskSpace{},
 		mu:                sync.Mutex{},
 		time:              now(),
 	}
 }
 
+// withIndexers is a new snapshot with the indexers that shares the time and the grabs and imports refused for disk space
+// with this one
+func (r *ReconcileSnapshot) withIndexers(indexers []model.Indexer) *ReconcileSnapshot {
+	s := newReconcileSnapshot(indexers, r.GetDownloadClients())
+	s.refused = r.refused
+	s.time = r.time
+	return s
+}
+
+// trackDiskSpace remembers the error if a grab or import was refused for disk space and returns it
+func (r *ReconcileSnapshot) trackDiskSpace(err error) error {
+	if r.refused == nil || !errors.Is(err, library.ErrInsufficientDiskSpace) {
+		return err
+	}
+
+	r.refused.mu.Lock()
+	defer r.refused.mu.Unlock()
+	if r.refused.first == nil {
+		r.refused.first = err
+	}
+	r.refused.count++
+	return err
+}
+
+// DiskSpaceError reports the grabs and imports refused for disk space during the reconcile. It's nil if there weren't any.
+func (r *ReconcileSnapshot) DiskSpaceError() error {
+	if r.refused == nil {
+		return nil
+	}
+
+	r.refused.mu.Lock()
+	defer r.refused.mu.Unlock()
+	switch r.refused.count {
+	case 0:
+		return nil
+	case 1:
+		return r.refused.first
+	default:
+		return fmt.Errorf("%w, and %d more grabs or imports were refused", r.refused.first, r.refused.count-1)
+	}
+}
+
 func (r *ReconcileSnapshot) GetDownloadClient(id int32) *model.DownloadClient {
 	dcs := r.GetDownloadClients()
 
@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// RestoreRecycledFilesRequest restores files from the recycle bin by their ids
+type RestoreRecycledFilesRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// RestoredFile is the outcome of restoring a file from the recycle bin. Path is where it was restored to in its
+// library, Error is why it couldn't be.
+type RestoredFile struct {
+	ID    string `json:"id"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ListRecycleBin lists the files in the recycle bin, most recently deleted first. It's empty if no recycle bin is
+// configured.
+func (m MediaManager) ListRecycleBin(ctx context.Context) ([]library.RecycledFile, error) {
+	if m.config.Library.RecycleBin == "" {
+		return []library.RecycledFile{}, nil
+	}
+
+	return m.library.ListRecycleBin(ctx, m.config.Library.RecycleBin)
+}
+
+// RestoreRecycledFiles moves files from the recycle bin back into their library. They're picked up by the next library
+// index like any other file found in the library. Each file's outcome is reported, a failed file doesn't stop the others.
+func (m MediaManager) RestoreRecycledFiles(ctx context.Context, request RestoreRecycledFilesRequest) ([]RestoredFile, error) {
+	log := logger.FromCtx(ctx)
+
+	if m.config.Library.RecycleBin == "" {
+		return nil, fmt.Errorf("%w: no recycle bin is configured", ErrValidation)
+	}
+	if len(request.IDs) == 0 {
+		return nil, fmt.Errorf("%w: ids of the files to restore are required", ErrValidation)
+	}
+
+	restored := make([]RestoredFile, 0, len(request.IDs))
+	for _, id := range request.IDs {
+		path, err := m.library.RestoreRecycledFile(ctx, m.config.Library.RecycleBin, id)
+		if err != nil {
+			log.Warn("failed to restore file from recycle bin", zap.String("id", id), zap.Error(err))
+			restored = append(restored, RestoredFile{ID: id, Error: err.Error()})
+			continue
+		}
+
+		log.Info("restored file from recycle bin", zap.String("id", id), zap.String("path", path))
+		restored = append(restored, RestoredFile{ID: id, Path: path})
+	}
+
+	return restored, nil
+}
+
+// PurgeRecycleBin permanently removes the files that have been in the recycle bin longer than its retention
+func (m MediaManager) PurgeRecycleBin(ctx context.Context) error {
+	log := logger.FromCtx(ctx)
+
+	days := m.config.Library.RecycleBinRetentionDays
+	if m.config.Library.RecycleBin == "" || days <= 0 {
+		log.Debug("recycle bin isn't purged")
+		return nil
+	}
+
+	purged, err := m.library.PurgeRecycleBin(ctx, m.config.Library.RecycleBin, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return err
+	}
+
+	log.Debug("purged recycle bin", zap.Int("folders", purged))
+	return nil
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/library"
+	mockLibrary "github.com/kasuboski/mediaz/pkg/library/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMediaManager_ListRecycleBin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lists the recycle bin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lib := mockLibrary.NewMockLibrary(ctrl)
+		files := []library.RecycledFile{{ID: "movies/20240101T000000Z/Movie/Movie.mkv", Library: library.RecycleBinMovies, Path: "Movie/Movie.mkv"}}
+		lib.EXPECT().ListRecycleBin(ctx, "/recycle").Return(files, nil)
+
+		m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle"}})
+		got, err := m.ListRecycleBin(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, files, got)
+	})
+
+	t.Run("no recycle bin", func(t *testing.T) {
+		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{})
+		got, err := m.ListRecycleBin(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestMediaManager_RestoreRecycledFiles(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports each file's outcome", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lib := mockLibrary.NewMockLibrary(ctrl)
+		lib.EXPECT().RestoreRecycledFile(ctx, "/recycle", "movies/20240101T000000Z/Movie/Movie.mkv").Return("Movie/Movie.mkv", nil)
+		lib.EXPECT().RestoreRecycledFile(ctx, "/recycle", "movies/20240101T000000Z/Other/Other.mkv").Return("", errors.New("file already exists"))
+
+		m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle"}})
+		restored, err := m.RestoreRecycledFiles(ctx, RestoreRecycledFilesRequest{IDs: []string{
+			"movies/20240101T000000Z/Movie/Movie.mkv",
+			"movies/20240101T000000Z/Other/Other.mkv",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []RestoredFile{
+			{ID: "movies/20240101T000000Z/Movie/Movie.mkv", Path: "Movie/Movie.mkv"},
+			{ID: "movies/20240101T000000Z/Other/Other.mkv", Error: "file already exists"},
+		}, restored)
+	})
+
+	t.Run("no recycle bin", func(t *testing.T) {
+		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{})
+		_, err := m.RestoreRecycledFiles(ctx, RestoreRecycledFilesRequest{IDs: []string{"movies/20240101T000000Z/Movie/Movie.mkv"}})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle"}})
+		_, err := m.RestoreRecycledFiles(ctx, RestoreRecycledFilesRequest{})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestMediaManager_PurgeRecycleBin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("purges files older than the retention", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lib := mockLibrary.NewMockLibrary(ctrl)
+		lib.EXPECT().PurgeRecycleBin(ctx, "/recycle", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, before time.Time) (int, error) {
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), before, time.Minute)
+			return 1, nil
+		})
+
+		m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle", RecycleBinRetentionDays: 7}})
+		require.NoError(t, m.PurgeRecycleBin(ctx))
+	})
+
+	t.Run("keeps files without a retention", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lib := mockLibrary.NewMockLibrary(ctrl)
+
+		m := New(nil, nil, lib, nil, nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle"}})
+		require.NoError(t, m.PurgeRecycleBin(ctx))
+	})
+}
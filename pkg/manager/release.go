@@ -1,37 +1,24 @@
 package manager
 
 import (
-	"cmp"
 	"context"
 	"fmt"
 	"regexp"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/kasuboski/mediaz/config"
 	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/parser"
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
 	"github.com/kasuboski/mediaz/pkg/size"
 	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 	"go.uber.org/zap"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
-var (
-	seasonPackPattern    = regexp.MustCompile(`(?i)(?:S(?:eason)?[\s._-]?\d{1,2}|[\s._]Complete[\s._])`)
-	episodePattern       = regexp.MustCompile(`(?i)\b(S\d{1,2}E\d{2,})|\b(\d{1,2}x\d{2,})`)
-	episodeNumberPattern = regexp.MustCompile(`(?i)S?(\d{1,2})(?:E|x)(\d{1,2})`)
-	seasonNumberPattern  = regexp.MustCompile(`(?i)(?:S(?:eason)?[\s._-]?(\d{1,2}))`)
-
-	releaseGroupRegex   = regexp.MustCompile(`(?:\s|-)[A-Z0-9]+\[[^\]]+\]`)
-	bracketedYearRegex  = regexp.MustCompile(`[\(\[\{]((?:19|20)\d{2})[\)\]\}]`)
-	trailingYearRegex   = regexp.MustCompile(`\s((?:19|20)\d{2})(?:\s|$)`)
-	countryCodeRegex    = regexp.MustCompile(`\s*[\(\[\{]([A-Z]{2,3})[\)\]\}]\s*`)
-	qualityRegex        = regexp.MustCompile(`(?i)\b(720p|1080p|4k|2160p|x264|h264|hevc|web[\s_-]dl|bluray|dvdrip|cam|ts|tc)\b`)
-	codecRegex          = regexp.MustCompile(`(?i)\b(h264|ac3|aac|dts|dd5\.1)\b`)
-	emptyBracketsRegex  = regexp.MustCompile(`\s*[\[\(\{][\]\)\}]\s*`)
-	multipleSpacesRegex = regexp.MustCompile(`\s+`)
-)
+var multipleSpacesRegex = regexp.MustCompile(`\s+`)
 
 type ReleaseFilterParams struct {
 	Title         string
@@ -41,29 +28,56 @@ type ReleaseFilterParams struct {
 	Runtime       int32
 	Certification *string
 	Studio        *string
+	// OriginalLanguage is the ISO 639-1 code a profile's "original" language resolves to
+	OriginalLanguage *string
+	// Filters reject releases that are unlikely to finish downloading
+	Filters   config.ReleaseFilters
+	Blocklist []*model.ReleaseBlocklist
 }
 
 type SeriesReleaseFilterParams struct {
 	Title         string
 	SeasonNumber  int32
 	EpisodeNumber int32
-	Runtime       int32
+	// AbsoluteEpisodeNumber is set for anime series so releases numbered like "Show - 123" match
+	AbsoluteEpisodeNumber *int32
+	// AirDate is set for daily series so releases named like "Show.2026.10.15" match
+	AirDate *time.Time
+	Runtime int32
+	// OriginalLanguage is the ISO 639-1 code a profile's "original" language resolves to
+	OriginalLanguage *string
+	// Filters reject releases that are unlikely to finish downloading
+	Filters   config.ReleaseFilters
+	Blocklist []*model.ReleaseBlocklist
 }
 
+// RejectMovieReleaseFunc returns a function that returns true if a release should be rejected for the movie
 func RejectMovieReleaseFunc(ctx context.Context, params ReleaseFilterParams, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) func(*prowlarr.ReleaseResource) bool {
-	return func(r *prowlarr.ReleaseResource) bool {
+	return rejectFunc(ctx, movieReleaseRejection(ctx, params, profile, protocolsAvailable))
+}
+
+// movieReleaseRejection returns a function that returns why a release is rejected for the movie
+func movieReleaseRejection(ctx context.Context, params ReleaseFilterParams, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) releaseRejection {
+	blocklist := newReleaseBlocklist(params.Blocklist)
+	rejection := releaseRejectionFunc(ctx, fixedRuntime(params.Runtime), params.OriginalLanguage, params.Filters, profile, protocolsAvailable)
+
+	return func(r *prowlarr.ReleaseResource) string {
 		if r == nil {
-			return true
+			return "missing release"
+		}
+
+		if blocklist.matches(r) {
+			return "blocklisted"
 		}
 
 		releaseTitle, err := r.Title.Get()
 		if err != nil {
-			return true
+			return "missing title"
 		}
 
 		releaseTitle = strings.TrimSpace(releaseTitle)
 		if releaseTitle == "" {
-			return true
+			return "missing title"
 		}
 
 		lowerReleaseTitle := strings.ToLower(normalizeSeparators(releaseTitle))
@@ -82,17 +96,17 @@ func RejectMovieReleaseFunc(ctx context.Context, params ReleaseFilterParams, pro
 		}
 
 		if !titleMatches {
-			return true
+			return fmt.Sprintf("title doesn't match %s", params.Title)
 		}
 
 		if params.Year != nil {
 			releaseYear := extractYear(releaseTitle)
 			if releaseYear != nil && *releaseYear != *params.Year {
-				return true
+				return fmt.Sprintf("year %d doesn't match %d", *releaseYear, *params.Year)
 			}
 		}
 
-		return rejectReleaseFunc(ctx, params.Runtime, profile, protocolsAvailable)(r)
+		return rejection(r)
 	}
 }
 
@@ -101,38 +115,32 @@ func normalizeSeparators(path string) string {
 	return multipleSpacesRegex.ReplaceAllString(normalized, " ")
 }
 
-func findYear(normalized string) *int32 {
-	matches := bracketedYearRegex.FindStringSubmatch(normalized)
-	if len(matches) > 1 {
-		year, err := strconv.Atoi(matches[1])
-		if err == nil {
-			y := int32(year)
-			return &y
-		}
-	}
-	matches = trailingYearRegex.FindStringSubmatch(normalized)
-	if len(matches) > 1 {
-		year, err := strconv.Atoi(matches[1])
-		if err == nil {
-			y := int32(year)
-			return &y
-		}
-	}
-
-	return nil
-}
-
+// extractYear returns the release year of a title, or nil if it doesn't have one
 func extractYear(title string) *int32 {
-	return findYear(normalizeSeparators(title))
+	year := parser.Parse(title).Year
+	if year == 0 {
+		return nil
+	}
+	y := int32(year)
+	return &y
 }
 
+// RejectSeasonReleaseFunc returns a function that returns true if a release should be rejected as a pack of the season
 func RejectSeasonReleaseFunc(ctx context.Context, params SeriesReleaseFilterParams, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) func(*prowlarr.ReleaseResource) bool {
-	return func(r *prowlarr.ReleaseResource) bool {
+	blocklist := newReleaseBlocklist(params.Blocklist)
+	rejection := releaseRejectionFunc(ctx, fixedRuntime(params.Runtime), params.OriginalLanguage, params.Filters, profile, protocolsAvailable)
+
+	return rejectFunc(ctx, func(r *prowlarr.ReleaseResource) string {
 		if rejectSeasonReleaseFunc(params.Title, params.SeasonNumber, r) {
-			return true
+			return fmt.Sprintf("not a pack of season %d of %s", params.SeasonNumber, params.Title)
 		}
-		return rejectReleaseFunc(ctx, params.Runtime, profile, protocolsAvailable)(r)
-	}
+
+		if blocklist.matches(r) {
+			return "blocklisted"
+		}
+
+		return rejection(r)
+	})
 }
 
 func rejectSeasonReleaseFunc(seriesTitle string, seasonNumber int32, r *prowlarr.ReleaseResource) bool {
@@ -145,43 +153,43 @@ func rejectSeasonReleaseFunc(seriesTitle string, seasonNumber int32, r *prowlarr
 		return true
 	}
 
-	if !seasonPackPattern.MatchString(foundTitle) {
-		return true
-	}
-
+	parsed := parser.Parse(foundTitle)
 	// we dont want individual episodes here
-	if episodePattern.MatchString(foundTitle) {
+	if len(parsed.Seasons) == 0 || len(parsed.Episodes) > 0 {
 		return true
 	}
 
-	normalizedSeriesTitle := strings.ToLower(seriesTitle)
-	normalizedReleaseTitle := strings.ToLower(foundTitle)
-
-	if !strings.Contains(normalizedReleaseTitle, normalizedSeriesTitle) {
+	if !strings.Contains(strings.ToLower(foundTitle), strings.ToLower(seriesTitle)) {
 		return true
 	}
 
-	matches := seasonNumberPattern.FindStringSubmatch(normalizedReleaseTitle)
-	for _, m := range matches {
-		if strings.Contains(m, fmt.Sprintf("%d", seasonNumber)) {
-			return false
-		}
-	}
-
-	return true
+	return !slices.Contains(parsed.Seasons, int(seasonNumber))
 }
 
+// RejectEpisodeReleaseFunc returns a function that returns true if a release should be rejected for the episode
 func RejectEpisodeReleaseFunc(ctx context.Context, params SeriesReleaseFilterParams, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) func(*prowlarr.ReleaseResource) bool {
-	return func(r *prowlarr.ReleaseResource) bool {
-		if rejectEpisodeReleaseFunc(params.Title, params.SeasonNumber, params.EpisodeNumber, r) {
-			return true
+	return rejectFunc(ctx, episodeReleaseRejection(ctx, params, profile, protocolsAvailable))
+}
+
+// episodeReleaseRejection returns a function that returns why a release is rejected for the episode
+func episodeReleaseRejection(ctx context.Context, params SeriesReleaseFilterParams, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) releaseRejection {
+	blocklist := newReleaseBlocklist(params.Blocklist)
+	rejection := releaseRejectionFunc(ctx, multiEpisodeRuntime(params.Runtime), params.OriginalLanguage, params.Filters, profile, protocolsAvailable)
+
+	return func(r *prowlarr.ReleaseResource) string {
+		if rejectEpisodeReleaseFunc(params, r) {
+			return fmt.Sprintf("not episode S%02dE%02d of %s", params.SeasonNumber, params.EpisodeNumber, params.Title)
+		}
+
+		if blocklist.matches(r) {
+			return "blocklisted"
 		}
 
-		return rejectReleaseFunc(ctx, params.Runtime, profile, protocolsAvailable)(r)
+		return rejection(r)
 	}
 }
 
-func rejectEpisodeReleaseFunc(seriesTitle string, seasonNumber, episodeNumber int32, r *prowlarr.ReleaseResource) bool {
+func rejectEpisodeReleaseFunc(params SeriesReleaseFilterParams, r *prowlarr.ReleaseResource) bool {
 	if r == nil {
 		return true
 	}
@@ -191,487 +199,199 @@ func rejectEpisodeReleaseFunc(seriesTitle string, seasonNumber, episodeNumber in
 		return true
 	}
 
-	normalizedSeriesTitle := strings.ToLower(seriesTitle)
+	normalizedSeriesTitle := strings.ToLower(params.Title)
 	normalizedReleaseTitle := strings.ToLower(foundTitle)
 
-	if !strings.Contains(normalizedReleaseTitle, normalizedSeriesTitle) {
+	if !strings.Contains(normalizedReleaseTitle, normalizedSeriesTitle) && !strings.Contains(normalizeSeparators(normalizedReleaseTitle), normalizeSeparators(normalizedSeriesTitle)) {
 		return true
 	}
 
-	matches := episodeNumberPattern.FindStringSubmatch(normalizedReleaseTitle)
-	if len(matches) != 3 {
-		return true
-	}
-
-	season, err := strconv.ParseInt(matches[1], 10, 32)
-	if err != nil {
-		return true
-	}
-
-	episode, err := strconv.ParseInt(matches[2], 10, 32)
-	if err != nil {
-		return true
-	}
-
-	match := !(int32(season) == seasonNumber && int32(episode) == episodeNumber)
-	return match
-}
-
-// rejectReleaseFunc returns a function that returns true if the given release should be rejected
-func rejectReleaseFunc(ctx context.Context, runtime int32, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) func(*prowlarr.ReleaseResource) bool {
-	log := logger.FromCtx(ctx)
-
-	return func(r *prowlarr.ReleaseResource) bool {
-		if r == nil {
+	parsed := parser.Parse(foundTitle)
+	if len(parsed.Seasons) == 0 || len(parsed.Episodes) == 0 {
+		switch {
+		case params.AbsoluteEpisodeNumber != nil:
+			return parsed.AbsoluteEpisode == 0 || int32(parsed.AbsoluteEpisode) != *params.AbsoluteEpisodeNumber
+		case params.AirDate != nil:
+			return parsed.AirDate == nil || !sameDay(*parsed.AirDate, *params.AirDate)
+		default:
 			return true
 		}
-
-		if r.Protocol != nil {
-			// reject if we don't have a download client for it
-			if _, has := protocolsAvailable[string(*r.Protocol)]; !has {
-				return true
-			}
-		}
-
-		if r.FileName.IsSpecified() {
-			_, parsed := parseReleaseFilename(r.FileName.MustGet())
-			if !parsed {
-				return true
-			}
-		}
-
-		sizeMB := size.BytesToMB(*r.Size)
-
-		// items are assumed to be sorted quality so the highest media quality available is selected
-		for _, quality := range profile.Qualities {
-
-			metQuality := MeetsQualitySize(quality, uint64(sizeMB), uint64(runtime))
-			if metQuality {
-				log.Debug("accepting release", zap.Any("release", r.Title), zap.Bool("metQuality", metQuality), zap.Any("size", r.Size), zap.Int32("runtime", runtime))
-				return false
-			}
-
-			// try again with the next item
-			log.Debug("rejecting release", zap.Any("release", r.Title), zap.Bool("metQuality", metQuality), zap.Any("size", r.Size), zap.Int32("runtime", runtime))
-		}
-
-		return true
 	}
-}
 
-// sortReleaseFunc returns a function that sorts releases by their number of seeders currently
-func sortReleaseFunc() func(*prowlarr.ReleaseResource, *prowlarr.ReleaseResource) int {
-	return func(r1 *prowlarr.ReleaseResource, r2 *prowlarr.ReleaseResource) int {
-		return cmp.Compare(nullableDefault(r1.Seeders), nullableDefault(r2.Seeders))
+	if int32(parsed.Seasons[0]) != params.SeasonNumber {
+		return true
 	}
-}
 
-// ParsedReleaseFile represents a parsed movie release filename with extracted metadata
-type ParsedReleaseFile struct {
-	Filename              string  `json:"filename"`
-	Title                 string  `json:"title"`
-	Year                  *int    `json:"year"`
-	Edition               *string `json:"edition"`
-	Customformat          *string `json:"customformat"`
-	Quality               *string `json:"quality"`
-	Mediainfo3D           *string `json:"mediainfo_3d"`
-	MediainfoDynamicrange *string `json:"mediainfo_dynamicrange"`
-	MediainfoAudio        *string `json:"mediainfo_audio"`
-	MediainfoVideo        *string `json:"mediainfo_video"`
-	Releasegroup          *string `json:"releasegroup"`
+	// multi-episode releases like S01E01-E03 cover every episode in the range
+	return !slices.Contains(parsed.Episodes, int(params.EpisodeNumber))
 }
 
-const filePattern = `^(?P<title>(?:\w|\s|')+)(?:\s*[(\[]?(?P<year>\d{4})[)\]]?)?(?:\s*\{(?P<edition>[^}]+)\})?(?:\s*(?P<customformat>(?:\[[^\]]+\])*))?.*$`
-
-var releaseFileRegex = regexp.MustCompile(filePattern)
-
-// parseReleaseFilename parses a release filename into a ParsedReleaseFile struct
-// if the filename does not match ok will be false
-func parseReleaseFilename(filename string) (ParsedReleaseFile, bool) {
-	// Create result struct with filename
-	result := ParsedReleaseFile{
-		Filename: filename,
-	}
-
-	sep := determineSeparator(filename)
-	prepdFilename := strings.ToLower(strings.ReplaceAll(filename, sep, " "))
-
-	quality, qMatches := findQuality(filename)
-	if len(qMatches) > 0 {
-		result.Quality = &quality
-		prepdFilename = removeFromName(prepdFilename, qMatches...)
-	}
-
-	dynamicRange := findDynamicRange(filename)
-	if len(dynamicRange) > 0 {
-		dRange := strings.Join(dynamicRange, " ")
-		result.MediainfoDynamicrange = &dRange
-		prepdFilename = removeFromName(prepdFilename, dynamicRange...)
-	}
-
-	audioInfo := findAudioInfo(filename)
-	if len(audioInfo) > 0 {
-		audio := strings.Join(audioInfo, " ")
-		result.MediainfoAudio = &audio
-		prepdFilename = removeFromName(prepdFilename, audioInfo...)
-	}
-
-	videoInfo := findVideoInfo(filename)
-	if len(videoInfo) > 0 {
-		video := strings.Join(videoInfo, " ")
-		result.MediainfoVideo = &video
-		prepdFilename = removeFromName(prepdFilename, videoInfo...)
-	}
-
-	if strings.Contains(prepdFilename, "3d") {
-		info := "3D"
-		result.Mediainfo3D = &info
-		prepdFilename = removeFromName(prepdFilename, "3d")
+// releaseEpisodeNumbers returns the episodes a release covers, or nil if it isn't a multi-episode release
+func releaseEpisodeNumbers(r *prowlarr.ReleaseResource) []int32 {
+	if r == nil {
+		return nil
 	}
 
-	stream := findStreamingService(prepdFilename)
-	if stream != "" {
-		prepdFilename = removeFromName(prepdFilename, stream)
+	title, err := r.Title.Get()
+	if err != nil {
+		return nil
 	}
 
-	group, matched := findReleaseGroup(prepdFilename)
-	if group != "" {
-		group = titleCase(group)
-		result.Releasegroup = &group
-		prepdFilename = removeFromName(prepdFilename, matched)
+	numbers := parser.Parse(title).Episodes
+	if len(numbers) < 2 {
+		return nil
 	}
 
-	matches := releaseFileRegex.FindStringSubmatch(prepdFilename)
-	if len(matches) == 0 {
-		return result, false
+	episodes := make([]int32, 0, len(numbers))
+	for _, n := range numbers {
+		episodes = append(episodes, int32(n))
 	}
+	return episodes
+}
 
-	// Get named capture group indices
-	groupNames := releaseFileRegex.SubexpNames()
-	for i, name := range groupNames {
-		if i == 0 { // Skip the full match
-			continue
-		}
-
-		// Get the matched value for this group
-		if i >= len(matches) {
-			break
-		}
-		value := matches[i]
-		if value == "" {
-			continue
-		}
+// releaseRuntime is the runtime in minutes a release's size is judged against
+type releaseRuntime func(r *prowlarr.ReleaseResource) int32
 
-		// Set the appropriate field based on group name
-		switch name {
-		case "title":
-			result.Title = titleCase(value)
-		case "year":
-			year, err := strconv.Atoi(value)
-			if err == nil {
-				result.Year = &year
-			}
-		case "edition":
-			// Remove the curly braces
-			edition := strings.Trim(value, "{}")
-			edition = titleCase(strings.Replace(edition, "edition-", "", 1))
-			result.Edition = &edition
-		case "customformat":
-			// Remove the square brackets
-			customFormat := strings.Trim(value, "[]")
-			combined := customFormat + stream
-			result.Customformat = &combined
-		}
+// fixedRuntime judges every release against the same runtime
+func fixedRuntime(runtime int32) releaseRuntime {
+	return func(*prowlarr.ReleaseResource) int32 {
+		return runtime
 	}
+}
 
-	// Parse additional metadata from customformat if present
-	if result.Customformat != nil {
-		formats := strings.Split(*result.Customformat, "][")
-		for _, format := range formats {
-			format = strings.TrimSpace(format)
-
-			// Try to identify the type of format
-			switch {
-			case strings.Contains(format, "IMAX") || strings.Contains(format, "AMZN"):
-				result.Customformat = &format
-			}
+// multiEpisodeRuntime judges a release against the runtime of an episode times the number of episodes it covers, so a
+// multi-episode release isn't taken for a single episode several times the size
+func multiEpisodeRuntime(runtime int32) releaseRuntime {
+	return func(r *prowlarr.ReleaseResource) int32 {
+		if episodes := releaseEpisodeNumbers(r); len(episodes) > 1 {
+			return runtime * int32(len(episodes))
 		}
+		return runtime
 	}
-
-	if result.Customformat == nil && stream != "" {
-		result.Customformat = &stream
-	}
-
-	return result, true
 }
 
-// findVideoInfo looks for all video related info in the filename. It returns all of them
-func findVideoInfo(filename string) []string {
-	encodings := []string{"x264", "x265", "H.264", "H264"}
-	videoStrings := make([]string, 0)
-	name := strings.ToLower(filename)
-	for _, e := range encodings {
-		if strings.Contains(name, strings.ToLower(e)) {
-			videoStrings = append(videoStrings, e)
-		}
-	}
-	return videoStrings
+// sameDay reports whether two times fall on the same calendar day, ignoring the time of day
+func sameDay(a, b time.Time) bool {
+	return a.Format(time.DateOnly) == b.Format(time.DateOnly)
 }
 
-// findAudioInfo looks for all audio related info in the filename. It returns all of them
-func findAudioInfo(filename string) []string {
-	// Define a list of audio strings
-	formats := []string{"DDPlus", "TrueHD", "DTS-HD", "DTS X", "DDP", "DD", "DTS", "Atmos"}
-	channels := []string{"stereo", "2.0", "2_0", "5.1", "5_1", "7.1", "7_1", "7 1"}
-	combo := make([]string, 0)
+// releaseRejection returns why a release is rejected, or an empty string if it's accepted
+type releaseRejection func(*prowlarr.ReleaseResource) string
 
-	audioStrings := make([]string, 0)
-	name := strings.ToLower(filename)
+// rejectFunc returns a function that returns true if the given release should be rejected, logging why
+func rejectFunc(ctx context.Context, rejection releaseRejection) func(*prowlarr.ReleaseResource) bool {
+	log := logger.FromCtx(ctx)
 
-	for _, f := range formats {
-		for _, c := range channels {
-			combo = append(combo, f+c)
-		}
-	}
-	foundValue := ""
-	for _, c := range combo {
-		if strings.Contains(name, strings.ToLower(c)) {
-			foundValue = c
-			break
+	return func(r *prowlarr.ReleaseResource) bool {
+		reason := rejection(r)
+		if reason == "" {
+			log.Debug("accepting release", zap.Any("release", r.Title), zap.Any("size", r.Size))
+			return false
 		}
-	}
-	if foundValue != "" {
-		normalized := strings.ReplaceAll(strings.ReplaceAll(foundValue, "_", "."), " ", ".")
-		audioStrings = append(audioStrings, normalized)
-		// if found a combo assume done
-		return audioStrings
-	}
 
-	foundFormats := findMatchingWords(name, formats)
-	audioStrings = append(audioStrings, foundFormats...)
-	channelValue := ""
-	for _, c := range channels {
-		if strings.Contains(name, strings.ToLower(c)) {
-			channelValue = c
-			break
+		if r != nil {
+			log.Debug("rejecting release", zap.Any("release", r.Title), zap.String("reason", reason))
 		}
+		return true
 	}
-	if channelValue != "" {
-		normalized := strings.ReplaceAll(strings.ReplaceAll(channelValue, "_", "."), " ", ".")
-		audioStrings = append(audioStrings, normalized)
-	}
-
-	return audioStrings
 }
 
-// findDynamicRange parses the filename looking for a dynamic range from a predefined list
-func findDynamicRange(filename string) []string {
-	name := strings.ToLower(filename)
+// filterReleases splits releases into the accepted ones and the rejected ones with the reason they were rejected
+func filterReleases(ctx context.Context, releases []*prowlarr.ReleaseResource, rejection releaseRejection) ([]*prowlarr.ReleaseResource, []RankedRelease) {
+	log := logger.FromCtx(ctx)
 
-	found := make([]string, 0)
+	var accepted []*prowlarr.ReleaseResource
+	var rejected []RankedRelease
+	for _, r := range releases {
+		if r == nil {
+			continue
+		}
 
-	if strings.Contains(name, strings.ToLower("DV")) {
-		found = append(found, "DV")
-	}
+		reason := rejection(r)
+		if reason == "" {
+			accepted = append(accepted, r)
+			continue
+		}
 
-	if strings.Contains(name, strings.ToLower("HDR10")) {
-		found = append(found, "HDR10")
-	} else if strings.Contains(name, strings.ToLower("HDR")) {
-		found = append(found, "HDR")
+		log.Debug("rejecting release", zap.Any("release", r.Title), zap.String("reason", reason))
+		rejected = append(rejected, RankedRelease{Release: r, Rejection: reason})
 	}
 
-	return found
+	return accepted, rejected
 }
 
-// findQuality parses the filename looking for a quality from a predefined list
-func findQuality(filename string) (quality string, matches []string) {
-	// Define a list of quality strings
-	resolutions := []string{"720", "1080", "2160"}
-	media := []string{"Bluray", "BRRip", "HDTV", "WEBDL", "WEBRip", "Remux", "SDTV", "DVD", "WEB", "HD"}
-	qualities := make([]string, 0)
-
-	// media-resolution with p
-	for _, res := range resolutions {
-		for _, med := range media {
-			qualities = append(qualities, fmt.Sprintf("%s-%sp", med, res))
-		}
-	}
-
-	name := strings.ToLower(filename)
-	// Normalize WEB-DL to WEBDL
-	name = strings.ReplaceAll(name, "web-dl", "webdl")
+// releaseRejectionFunc returns a function that returns why a release is rejected by the quality profile and release
+// filters, or an empty string if it's accepted
+func releaseRejectionFunc(ctx context.Context, runtime releaseRuntime, originalLanguage *string, filters config.ReleaseFilters, profile storage.QualityProfile, protocolsAvailable map[string]struct{}) releaseRejection {
+	scorer := newFormatScorer(profile)
+	terms := newTermFilter(profile)
+	languages := newLanguageFilter(profile, originalLanguage)
+	editions := newEditionFilter(profile)
 
-	for _, quality := range qualities {
-		q := strings.ToLower(quality)
-		if strings.Contains(name, q) {
-			// if we find a quality with both media and resolution, return it
-			return quality, []string{q}
+	return func(r *prowlarr.ReleaseResource) string {
+		if r == nil {
+			return "missing release"
 		}
-	}
 
-	// otherwise check if we can find the media and resolution separately
-	foundMedia := ""
-	for _, med := range media {
-		medLower := strings.ToLower(med)
-		// Also check for hyphenated version
-		if strings.Contains(name, medLower) || strings.Contains(name, strings.ReplaceAll(medLower, "dl", "-dl")) {
-			foundMedia = med
-			break
+		if r.Protocol != nil {
+			// reject if we don't have a download client for it
+			if _, has := protocolsAvailable[string(*r.Protocol)]; !has {
+				return fmt.Sprintf("no download client for %s", *r.Protocol)
+			}
 		}
-	}
 
-	foundResolution := ""
-	for _, res := range resolutions {
-		if strings.Contains(name, strings.ToLower(res)) {
-			foundResolution = res
-			break
+		if reason := releaseUnavailableReason(filters, r); reason != "" {
+			return reason
 		}
-	}
-
-	if foundMedia != "" && foundResolution != "" {
-		return fmt.Sprintf("%s-%sp", foundMedia, foundResolution), []string{foundMedia, foundResolution}
-	}
-
-	if foundMedia != "" && foundResolution == "" {
-		return foundMedia, []string{foundMedia}
-	}
 
-	if foundMedia == "" && foundResolution != "" {
-		return foundResolution + "p", []string{foundResolution}
-	}
-
-	return "", nil
-}
-
-var regexGroup = regexp.MustCompile(`[-\(]([^-\(\)]+)\)?$`)
+		if r.FileName.IsSpecified() && parser.Parse(r.FileName.MustGet()).Title == "" {
+			return "file name has no title"
+		}
 
-func findReleaseGroup(filename string) (group string, matched string) {
-	matches := regexGroup.FindStringSubmatch(filename)
-	// expected 2 matches. 1 for the full match, 1 for the group
-	if len(matches) != 2 {
-		return "", ""
-	}
+		if title, err := r.Title.Get(); err == nil {
+			if !terms.accepts(title) {
+				return "missing a required term or contains an ignored term"
+			}
 
-	return matches[1], matches[0]
+			if languages.rejects(title) {
+				return "not in the profile's languages"
+			}
 
-}
+			if editions.rejects(title) {
+				return "not one of the profile's editions"
+			}
+		}
 
-func findStreamingService(filename string) string {
-	name := strings.ToLower(filename)
-
-	if strings.Contains(name, "netflix") {
-		return "NF"
-	} else if len(findMatchingWords(name, []string{"amazon", "amzn"})) > 0 {
-		return "AMZN"
-	} else if strings.Contains(name, "hulu") {
-		return "Hulu"
-	} else if len(findMatchingWords(name, []string{"disney", "dsnp"})) > 0 {
-		return "DSNP"
-	}
-	return ""
-}
+		if !scorer.accepts(r) {
+			return fmt.Sprintf("custom format score of %d is below the minimum of %d", scorer.releaseScore(r), scorer.minScore)
+		}
 
-// determineSeparator tries to determine the separator between the various parts of the filename
-// It assumes it is one of `.`, `_`, `-`, ` `
-// It decides based on which one is most present in the filename
-func determineSeparator(filename string) string {
-	count := 0
-	currSep := ""
-	for _, sep := range []string{".", "_", "-", " "} {
-		if strings.Count(filename, sep) > count {
-			count = strings.Count(filename, sep)
-			currSep = sep
+		if r.Size == nil {
+			return "missing size"
 		}
-	}
+		sizeMB := size.BytesToMB(*r.Size)
 
-	return currSep
-}
+		// the release is ranked against the others by the quality it matched, see releaseRanker
+		title, _ := r.Title.Get()
+		runtime := runtime(r)
+		if _, ok := matchQuality(profile, identifyQuality(&title), uint64(sizeMB), uint64(runtime)); !ok {
+			return fmt.Sprintf("%d MB for a %d minute runtime doesn't match a quality in the profile", sizeMB, runtime)
+		}
 
-func titleCase(title string) string {
-	title = strings.TrimSpace(title)
-	return cases.Title(language.English).String(title)
+		return ""
+	}
 }
 
 // pathToSearchTerm takes a movie path and removes the year if present, preserving alternate titles
 func pathToSearchTerm(path string) string {
-	if path == "" {
-		return ""
-	}
-
-	cleaned := normalizeSeparators(path)
-
-	cleaned = releaseGroupRegex.ReplaceAllString(cleaned, "")
-
-	cleaned = bracketedYearRegex.ReplaceAllString(cleaned, " ")
-	cleaned = trailingYearRegex.ReplaceAllString(cleaned, " ")
-
-	// Remove country codes (e.g., (US), (UK), [AU])
-	cleaned = countryCodeRegex.ReplaceAllString(cleaned, " ")
-
-	// Remove common quality indicators
-	cleaned = qualityRegex.ReplaceAllString(cleaned, "")
-
-	// Remove codec and audio info
-	cleaned = codecRegex.ReplaceAllString(cleaned, "")
-
-	// Remove empty brackets and parentheses
-	cleaned = emptyBracketsRegex.ReplaceAllString(cleaned, " ")
-
-	// Clean up multiple spaces
-	cleaned = multipleSpacesRegex.ReplaceAllString(cleaned, " ")
-
-	return strings.TrimSpace(cleaned)
+	return parser.Parse(path).Title
 }
 
 // pathToSearchTermWithYear extracts the year from a movie path and returns both the search term and year
 func pathToSearchTermWithYear(path string) (string, *int32) {
-	if path == "" {
-		return "", nil
-	}
-
-	normalized := normalizeSeparators(path)
-	year := findYear(normalized)
-	searchTerm := pathToSearchTerm(normalized)
-
-	return searchTerm, year
+	return pathToSearchTerm(path), extractYear(path)
 }
 
 func extractYearFromPath(path string) *int32 {
-	return findYear(normalizeSeparators(path))
-}
-
-func removeFromName(filename string, toRemove ...string) string {
-	lowerRemove := make([]string, len(toRemove))
-	for i, r := range toRemove {
-		lowerRemove[i] = regexp.QuoteMeta(strings.ToLower(r))
-	}
-
-	rmRegex, err := regexp.Compile(fmt.Sprintf(`(?i)[\[\(]+[^\](]*(?:%s)[^[)]*[\]\)]+`, strings.Join(lowerRemove, "|")))
-	if err != nil {
-		return filename
-	}
-	return rmRegex.ReplaceAllLiteralString(filename, "")
-}
-
-// findMatchingWords takes a source string and a slice of candidate words
-// Returns a slice of words that were found as exact matches
-func findMatchingWords(source string, candidates []string) []string {
-	// Convert source to lowercase and split into words
-	reg := regexp.MustCompile(`\b[\w]+\b`)
-	sourceWords := reg.FindAllString(strings.ToLower(source), -1)
-
-	wordMap := make(map[string]bool)
-	for _, word := range sourceWords {
-		wordMap[word] = true
-	}
-
-	// Check each candidate for exact matches
-	matches := make([]string, 0)
-	for _, candidate := range candidates {
-		if wordMap[strings.ToLower(candidate)] {
-			matches = append(matches, candidate)
-		}
-	}
-
-	return matches
+	return extractYear(path)
 }
@@ -0,0 +1,102 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+)
+
+// releaseAvailability are the release filters that apply to a single indexer
+type releaseAvailability struct {
+	minimumSeeders       int32
+	maximumAge           time.Duration
+	retentionDays        int32
+	requiredIndexerFlags []string
+}
+
+// availabilityFor merges the global release filters with the overrides for the indexer. Indexer names are
+// matched case insensitively since config keys are lower cased.
+func availabilityFor(filters config.ReleaseFilters, indexerName string) releaseAvailability {
+	availability := releaseAvailability{
+		minimumSeeders:       filters.MinimumSeeders,
+		maximumAge:           filters.MaximumAge,
+		retentionDays:        filters.RetentionDays,
+		requiredIndexerFlags: filters.RequiredIndexerFlags,
+	}
+
+	for name, override := range filters.Indexers {
+		if !strings.EqualFold(name, indexerName) {
+			continue
+		}
+
+		if override.MinimumSeeders != nil {
+			availability.minimumSeeders = *override.MinimumSeeders
+		}
+		if override.MaximumAge != nil {
+			availability.maximumAge = *override.MaximumAge
+		}
+		if override.RetentionDays != nil {
+			availability.retentionDays = *override.RetentionDays
+		}
+		if override.RequiredIndexerFlags != nil {
+			availability.requiredIndexerFlags = override.RequiredIndexerFlags
+		}
+	}
+
+	return availability
+}
+
+// releaseUnavailableReason returns why a release is unlikely to finish downloading, or an empty string if it isn't.
+// Releases that don't report their seeders or age aren't rejected for them.
+func releaseUnavailableReason(filters config.ReleaseFilters, r *prowlarr.ReleaseResource) string {
+	indexerName, _ := r.Indexer.Get()
+	availability := availabilityFor(filters, indexerName)
+
+	protocol := releaseProtocol(r)
+	if protocol == prowlarr.DownloadProtocolTorrent {
+		if seeders, err := r.Seeders.Get(); err == nil && seeders < availability.minimumSeeders {
+			return fmt.Sprintf("%d seeders is below the minimum of %d", seeders, availability.minimumSeeders)
+		}
+
+		flags, _ := r.IndexerFlags.Get()
+		for _, required := range availability.requiredIndexerFlags {
+			if !slices.ContainsFunc(flags, func(flag string) bool { return strings.EqualFold(flag, required) }) {
+				return fmt.Sprintf("missing required indexer flag %s", required)
+			}
+		}
+	}
+
+	ageHours, ok := releaseAgeHours(r)
+	if !ok {
+		return ""
+	}
+	age := time.Duration(ageHours * float64(time.Hour))
+
+	if availability.maximumAge > 0 && age > availability.maximumAge {
+		return fmt.Sprintf("age of %s is over the maximum of %s", age.Round(time.Hour), availability.maximumAge)
+	}
+
+	if protocol == prowlarr.DownloadProtocolUsenet && availability.retentionDays > 0 && ageHours > float64(availability.retentionDays)*24 {
+		return fmt.Sprintf("age of %d days is over the retention of %d days", int(ageHours/24), availability.retentionDays)
+	}
+
+	return ""
+}
+
+// releaseAgeHours returns how long ago a release was published, from the most precise age the indexer reports
+func releaseAgeHours(r *prowlarr.ReleaseResource) (float64, bool) {
+	switch {
+	case r.AgeHours != nil:
+		return *r.AgeHours, true
+	case r.Age != nil:
+		return float64(*r.Age) * 24, true
+	case r.PublishDate != nil && !r.PublishDate.IsZero():
+		return now().Sub(*r.PublishDate).Hours(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseUnavailableReason(t *testing.T) {
+	filters := config.ReleaseFilters{
+		MinimumSeeders: 5,
+		MaximumAge:     time.Hour * 24 * 2000,
+		RetentionDays:  1000,
+		Indexers: map[string]config.IndexerReleaseFilters{
+			"private": {
+				MinimumSeeders:       ptr.To(int32(1)),
+				RequiredIndexerFlags: []string{"freeleech"},
+			},
+		},
+	}
+
+	torrent := func(indexer string, seeders int32, flags ...string) *prowlarr.ReleaseResource {
+		r := &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue("Movie.2019.1080p-GRP"),
+			Indexer:  nullable.NewNullableWithValue(indexer),
+			Seeders:  nullable.NewNullableWithValue(seeders),
+			Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+		if flags != nil {
+			r.IndexerFlags = nullable.NewNullableWithValue(flags)
+		}
+		return r
+	}
+	usenet := func(ageDays int32) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue("Movie.2019.1080p-GRP"),
+			Age:      ptr.To(ageDays),
+			Protocol: ptr.To(prowlarr.DownloadProtocolUsenet),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		release *prowlarr.ReleaseResource
+		want    string
+	}{
+		{"enough seeders", torrent("public", 5), ""},
+		{"too few seeders", torrent("public", 4), "4 seeders is below the minimum of 5"},
+		{"indexer minimum seeders", torrent("Private", 1, "freeleech"), ""},
+		{"missing required flag", torrent("Private", 10, "halfleech"), "missing required indexer flag freeleech"},
+		{"flags match case insensitively", torrent("private", 10, "FreeLeech"), ""},
+		{"unknown seeders", &prowlarr.ReleaseResource{Protocol: ptr.To(prowlarr.DownloadProtocolTorrent)}, ""},
+		{"within retention", usenet(900), ""},
+		{"past retention", usenet(1200), "age of 1200 days is over the retention of 1000 days"},
+		{"older than the maximum age", &prowlarr.ReleaseResource{AgeHours: ptr.To(float64(24 * 2100)), Protocol: ptr.To(prowlarr.DownloadProtocolTorrent)}, "age of 50400h0m0s is over the maximum of 48000h0m0s"},
+		{"publish date", &prowlarr.ReleaseResource{PublishDate: ptr.To(now().Add(-time.Hour * 24 * 2100)), Protocol: ptr.To(prowlarr.DownloadProtocolTorrent)}, "age of 50400h0m0s is over the maximum of 48000h0m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, releaseUnavailableReason(filters, tt.release))
+		})
+	}
+
+	t.Run("no filters", func(t *testing.T) {
+		assert.Equal(t, "", releaseUnavailableReason(config.ReleaseFilters{}, torrent("public", 0)))
+		assert.Equal(t, "", releaseUnavailableReason(config.ReleaseFilters{}, usenet(5000)))
+	})
+}
+
+func TestRejectUnavailableReleases(t *testing.T) {
+	profile := storage.QualityProfile{Qualities: []storage.QualityDefinition{{Name: "WEBDL-1080p", MinSize: 0, MaxSize: 1000}}}
+	protocols := map[string]struct{}{"torrent": {}}
+
+	release := func(title string, seeders int32) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue(title),
+			Size:     ptr.To(int64(1024 * 1024 * 1024)),
+			Seeders:  nullable.NewNullableWithValue(seeders),
+			Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+	}
+
+	filters := config.ReleaseFilters{MinimumSeeders: 1}
+
+	movie := ReleaseFilterParams{Title: "Movie", Runtime: 120, Filters: filters}
+	assert.True(t, RejectMovieReleaseFunc(context.Background(), movie, profile, protocols)(release("Movie.2019.1080p.WEB-DL-GRP", 0)))
+	assert.False(t, RejectMovieReleaseFunc(context.Background(), movie, profile, protocols)(release("Movie.2019.1080p.WEB-DL-GRP", 1)))
+
+	series := SeriesReleaseFilterParams{Title: "Show", SeasonNumber: 1, EpisodeNumber: 2, Runtime: 120, Filters: filters}
+	assert.True(t, RejectEpisodeReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01E02.1080p-GRP", 0)))
+	assert.True(t, RejectSeasonReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01.1080p-GRP", 0)))
+	assert.False(t, RejectSeasonReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01.1080p-GRP", 3)))
+}
+
+func TestFilterReleases_UnavailableReason(t *testing.T) {
+	profile := storage.QualityProfile{Qualities: []storage.QualityDefinition{{Name: "WEBDL-1080p", MinSize: 0, MaxSize: 1000}}}
+	protocols := map[string]struct{}{"torrent": {}}
+
+	dead := &prowlarr.ReleaseResource{
+		Title:    nullable.NewNullableWithValue("Movie.2019.1080p.WEB-DL-GRP"),
+		Size:     ptr.To(int64(1024 * 1024 * 1024)),
+		Seeders:  nullable.NewNullableWithValue(int32(0)),
+		Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+	}
+	seeded := &prowlarr.ReleaseResource{
+		Title:    nullable.NewNullableWithValue("Movie.2019.1080p.WEB-DL-OTHER"),
+		Size:     ptr.To(int64(1024 * 1024 * 1024)),
+		Seeders:  nullable.NewNullableWithValue(int32(5)),
+		Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+	}
+
+	params := ReleaseFilterParams{Title: "Movie", Runtime: 120, Filters: config.ReleaseFilters{MinimumSeeders: 1}}
+	accepted, rejected := filterReleases(context.Background(), []*prowlarr.ReleaseResource{dead, seeded}, movieReleaseRejection(context.Background(), params, profile, protocols))
+
+	assert.Equal(t, []*prowlarr.ReleaseResource{seeded}, accepted)
+	assert.Equal(t, []RankedRelease{{Release: dead, Rejection: "0 seeders is below the minimum of 1"}}, rejected)
+}
@@ -0,0 +1,206 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// delayProfile returns the first configured delay profile that applies to the quality profile, or a zero profile that
+// doesn't delay anything
+func (m MediaManager) delayProfile(profile storage.QualityProfile) config.DelayProfile {
+	for _, delay := range m.configs.DelayProfiles {
+		if len(delay.QualityProfiles) == 0 || slices.ContainsFunc(delay.QualityProfiles, func(name string) bool {
+			return strings.EqualFold(strings.TrimSpace(name), profile.Name)
+		}) {
+			return delay
+		}
+	}
+
+	return config.DelayProfile{}
+}
+
+// protocolDelay returns how long the delay profile waits before grabbing a release of the protocol
+func protocolDelay(delay config.DelayProfile, protocol prowlarr.DownloadProtocol) time.Duration {
+	switch protocol {
+	case prowlarr.DownloadProtocolUsenet:
+		return delay.Usenet
+	case prowlarr.DownloadProtocolTorrent:
+		return delay.Torrent
+	default:
+		return 0
+	}
+}
+
+// pendingTarget is the movie, episode or season pack pending releases are found for. Exactly one id is set.
+type pendingTarget struct {
+	movieID   *int32
+	episodeID *int32
+	seasonID  *int32
+}
+
+func moviePendingTarget(id int32) pendingTarget {
+	return pendingTarget{movieID: &id}
+}
+
+func episodePendingTarget(id int32) pendingTarget {
+	return pendingTarget{episodeID: &id}
+}
+
+func seasonPendingTarget(id int32) pendingTarget {
+	return pendingTarget{seasonID: &id}
+}
+
+func (t pendingTarget) where() sqlite.BoolExpression {
+	switch {
+	case t.movieID != nil:
+		return table.PendingRelease.MovieID.EQ(sqlite.Int32(*t.movieID))
+	case t.episodeID != nil:
+		return table.PendingRelease.EpisodeID.EQ(sqlite.Int32(*t.episodeID))
+	default:
+		return table.PendingRelease.SeasonID.EQ(sqlite.Int32(*t.seasonID))
+	}
+}
+
+// pendingReleaseKey identifies a release between searches by its guid, or its title for indexers without guids
+func pendingReleaseKey(guid *string, title string) string {
+	if guid != nil && *guid != "" {
+		return *guid
+	}
+	return normalizeBlocklistTitle(title)
+}
+
+// delayRelease returns the best ranked release that has waited out the quality profile's delay profile, or nil while
+// they're all still pending. The delay starts when the first acceptable release for the target is found, and a release
+// that meets the profile's cutoff skips it if the delay profile allows. Releases that are still waiting are stored so
+// they show up as pending activity, they're cleared once none are found or the target changes state.
+func (m MediaManager) delayRelease(ctx context.Context, target pendingTarget, profile storage.QualityProfile, ranked []RankedRelease, now time.Time) (*prowlarr.ReleaseResource, error) {
+	if len(ranked) == 0 {
+		m.clearPendingReleases(ctx, target)
+		return nil, nil
+	}
+
+	delay := m.delayProfile(profile)
+	if delay.Usenet <= 0 && delay.Torrent <= 0 {
+		return ranked[0].Release, nil
+	}
+
+	log := logger.FromCtx(ctx)
+
+	pending, err := m.pendingStorage.ListPendingReleases(ctx, target.where())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending releases: %w", err)
+	}
+
+	waitingSince := now
+	firstSeen := make(map[string]time.Time, len(pending))
+	for _, p := range pending {
+		firstSeen[pendingReleaseKey(p.GUID, p.Title)] = p.FirstSeen
+		if p.FirstSeen.Before(waitingSince) {
+			waitingSince = p.FirstSeen
+		}
+	}
+
+	cutoff := cutoffRank(profile)
+	for _, r := range ranked {
+		wait := protocolDelay(delay, releaseProtocol(r.Release))
+		bypass := delay.BypassIfCutoffMet && cutoff > 0 && r.Score.QualityRank >= cutoff
+		if wait > 0 && !bypass && now.Before(waitingSince.Add(wait)) {
+			continue
+		}
+
+		if len(pending) > 0 {
+			if err := m.pendingStorage.DeletePendingReleases(ctx, target.where()); err != nil {
+				log.Warn("failed to clear pending releases", zap.Error(err))
+			}
+		}
+		return r.Release, nil
+	}
+
+	err = m.pendingStorage.DeletePendingReleases(ctx, target.where())
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear pending releases: %w", err)
+	}
+
+	for _, r := range ranked {
+		release := pendingRelease(target, r.Release)
+		release.FirstSeen = now
+		if seen, ok := firstSeen[pendingReleaseKey(release.GUID, release.Title)]; ok {
+			release.FirstSeen = seen
+		}
+		release.GrabAfter = waitingSince.Add(protocolDelay(delay, releaseProtocol(r.Release)))
+
+		if _, err := m.pendingStorage.CreatePendingRelease(ctx, release); err != nil {
+			return nil, fmt.Errorf("failed to store pending release: %w", err)
+		}
+	}
+
+	log.Debug("delaying releases", zap.Int("releases", len(ranked)), zap.Time("waiting since", waitingSince))
+	return nil, nil
+}
+
+// clearPendingReleases removes the target's pending releases when no acceptable release is found for it anymore, so
+// the next delay starts when one is found again
+func (m MediaManager) clearPendingReleases(ctx context.Context, target pendingTarget) {
+	if err := m.pendingStorage.DeletePendingReleases(ctx, target.where()); err != nil {
+		logger.FromCtx(ctx).Warn("failed to clear pending releases", zap.Error(err))
+	}
+}
+
+// pendingRelease captures the release found for the target
+func pendingRelease(target pendingTarget, r *prowlarr.ReleaseResource) model.PendingRelease {
+	release := model.PendingRelease{
+		MovieID:   target.movieID,
+		EpisodeID: target.episodeID,
+		SeasonID:  target.seasonID,
+		Protocol:  string(releaseProtocol(r)),
+	}
+
+	if guid, err := r.GUID.Get(); err == nil && guid != "" {
+		release.GUID = &guid
+	}
+	release.Title, _ = r.Title.Get()
+	if indexer, err := r.Indexer.Get(); err == nil && indexer != "" {
+		release.Indexer = &indexer
+	}
+
+	return release
+}
+
+// ListPendingReleases lists the releases waiting out a delay profile, the ones that will be grabbed soonest first
+func (m MediaManager) ListPendingReleases(ctx context.Context) ([]*PendingRelease, error) {
+	releases, err := m.pendingStorage.ListPendingReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*PendingRelease, len(releases))
+	for i, r := range releases {
+		result[i] = &PendingRelease{
+			ID:        int64(r.ID),
+			MovieID:   r.MovieID,
+			EpisodeID: r.EpisodeID,
+			SeasonID:  r.SeasonID,
+			Title:     r.Title,
+			Protocol:  r.Protocol,
+			FirstSeen: r.FirstSeen,
+			GrabAfter: r.GrabAfter,
+		}
+		if r.Indexer != nil {
+			result[i].Indexer = *r.Indexer
+		}
+	}
+
+	return result, nil
+}
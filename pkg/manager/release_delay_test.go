@@ -0,0 +1,157 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaManager_delayProfile(t *testing.T) {
+	m := MediaManager{configs: config.Manager{DelayProfiles: []config.DelayProfile{
+		{QualityProfiles: []string{"Ultra HD"}, Usenet: time.Hour},
+		{Torrent: time.Minute},
+	}}}
+
+	assert.Equal(t, time.Hour, m.delayProfile(storage.QualityProfile{Name: "ultra hd"}).Usenet)
+	assert.Equal(t, time.Minute, m.delayProfile(storage.QualityProfile{Name: "HD-1080p"}).Torrent, "a profile without quality profiles applies to all")
+	assert.Equal(t, config.DelayProfile{}, MediaManager{}.delayProfile(storage.QualityProfile{Name: "HD-1080p"}))
+}
+
+func TestMediaManager_delayRelease(t *testing.T) {
+	ctx := context.Background()
+
+	profile := storage.QualityProfile{
+		Name:            "HD",
+		CutoffQualityID: ptr.To(int32(2)),
+		Qualities: []storage.QualityDefinition{
+			{ID: 2, Name: "Bluray-1080p"},
+			{ID: 1, Name: "WEBDL-720p"},
+		},
+	}
+
+	release := func(title string, protocol prowlarr.DownloadProtocol, qualityRank int) RankedRelease {
+		return RankedRelease{
+			Release: &prowlarr.ReleaseResource{
+				GUID:     nullable.NewNullableWithValue(title),
+				Title:    nullable.NewNullableWithValue(title),
+				Indexer:  nullable.NewNullableWithValue("indexer"),
+				Protocol: ptr.To(protocol),
+			},
+			Score: ReleaseScore{QualityRank: qualityRank},
+		}
+	}
+	webTorrent := release("Movie.2024.720p.WEB-DL-GRP", prowlarr.DownloadProtocolTorrent, 1)
+	blurayTorrent := release("Movie.2024.1080p.BluRay-GRP", prowlarr.DownloadProtocolTorrent, 2)
+	webUsenet := release("Movie.2024.720p.WEB-DL-NZB", prowlarr.DownloadProtocolUsenet, 1)
+
+	newManager := func(t *testing.T, delay config.DelayProfile) (MediaManager, pendingTarget) {
+		store := newStore(t, ctx)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Path: ptr.To("Movie (2024)"), Monitored: 1}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+
+		m := MediaManager{pendingStorage: store, configs: config.Manager{DelayProfiles: []config.DelayProfile{delay}}}
+		return m, moviePendingTarget(int32(movieID))
+	}
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("no delay grabs the best release", func(t *testing.T) {
+		m := MediaManager{}
+		got, err := m.delayRelease(ctx, moviePendingTarget(1), profile, []RankedRelease{webTorrent}, start)
+		require.NoError(t, err)
+		assert.Equal(t, webTorrent.Release, got)
+	})
+
+	t.Run("waits from when the first release was found", func(t *testing.T) {
+		m, target := newManager(t, config.DelayProfile{Torrent: 2 * time.Hour})
+
+		got, err := m.delayRelease(ctx, target, profile, []RankedRelease{webTorrent}, start)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		pending, err := m.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, target.movieID, pending[0].MovieID)
+		assert.Equal(t, "Movie.2024.720p.WEB-DL-GRP", pending[0].Title)
+		assert.Equal(t, "torrent", pending[0].Protocol)
+		assert.Equal(t, "indexer", pending[0].Indexer)
+		assert.True(t, start.Equal(pending[0].FirstSeen))
+		assert.True(t, start.Add(2*time.Hour).Equal(pending[0].GrabAfter))
+
+		// a better release showing up doesn't restart the delay
+		got, err = m.delayRelease(ctx, target, profile, []RankedRelease{blurayTorrent, webTorrent}, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		pending, err = m.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		for _, p := range pending {
+			assert.True(t, start.Add(2*time.Hour).Equal(p.GrabAfter))
+			if p.Title == "Movie.2024.720p.WEB-DL-GRP" {
+				assert.True(t, start.Equal(p.FirstSeen), "first seen is kept between searches")
+			} else {
+				assert.True(t, start.Add(time.Hour).Equal(p.FirstSeen))
+			}
+		}
+
+		got, err = m.delayRelease(ctx, target, profile, []RankedRelease{blurayTorrent, webTorrent}, start.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, blurayTorrent.Release, got)
+
+		pending, err = m.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, pending, "grabbing clears the pending releases")
+	})
+
+	t.Run("delays are per protocol", func(t *testing.T) {
+		m, target := newManager(t, config.DelayProfile{Torrent: 2 * time.Hour})
+
+		got, err := m.delayRelease(ctx, target, profile, []RankedRelease{blurayTorrent, webUsenet}, start)
+		require.NoError(t, err)
+		assert.Equal(t, webUsenet.Release, got, "usenet releases aren't delayed")
+	})
+
+	t.Run("release meeting the cutoff bypasses the delay", func(t *testing.T) {
+		m, target := newManager(t, config.DelayProfile{Torrent: 2 * time.Hour, BypassIfCutoffMet: true})
+
+		got, err := m.delayRelease(ctx, target, profile, []RankedRelease{webTorrent}, start)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		got, err = m.delayRelease(ctx, target, profile, []RankedRelease{blurayTorrent, webTorrent}, start.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, blurayTorrent.Release, got)
+	})
+
+	t.Run("no releases clears the pending releases", func(t *testing.T) {
+		m, target := newManager(t, config.DelayProfile{Torrent: 2 * time.Hour})
+
+		got, err := m.delayRelease(ctx, target, profile, []RankedRelease{webTorrent}, start)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		got, err = m.delayRelease(ctx, target, profile, nil, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		pending, err := m.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		// the delay starts over when a release is found again
+		got, err = m.delayRelease(ctx, target, profile, []RankedRelease{webTorrent}, start.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/storage"
+)
+
+// editionFilter checks release editions against the editions a quality profile wants
+type editionFilter struct {
+	// wanted are the lower cased edition names, best first
+	wanted  []string
+	require bool
+}
+
+func newEditionFilter(profile storage.QualityProfile) editionFilter {
+	filter := editionFilter{require: profile.RequireEdition}
+	for _, edition := range profile.Editions {
+		edition = strings.ToLower(parser.NormalizeEdition(edition))
+		if edition != "" && !slices.Contains(filter.wanted, edition) {
+			filter.wanted = append(filter.wanted, edition)
+		}
+	}
+	return filter
+}
+
+// rank returns how the profile ranks the release's edition, counted from the least wanted so higher is better. It's 0
+// for editions the profile doesn't want or if it accepts any. Releases without an edition are the theatrical cut.
+func (f editionFilter) rank(title string) int {
+	if len(f.wanted) == 0 {
+		return 0
+	}
+
+	edition := parser.Parse(title).Edition
+	if edition == "" {
+		edition = storage.TheatricalEdition
+	}
+
+	i := slices.Index(f.wanted, strings.ToLower(edition))
+	if i < 0 {
+		return 0
+	}
+	return len(f.wanted) - i
+}
+
+// rejects reports whether a release should be rejected for its edition
+func (f editionFilter) rejects(title string) bool {
+	return f.require && len(f.wanted) > 0 && f.rank(title) == 0
+}
+
+// normalizeProfileEditions returns the canonical names of a profile's editions, see parser.NormalizeEdition
+func normalizeProfileEditions(editions []string) ([]string, error) {
+	var normalized []string
+	for _, edition := range editions {
+		name := parser.NormalizeEdition(edition)
+		if name == "" {
+			return nil, fmt.Errorf("%w: editions can't be empty", ErrValidation)
+		}
+
+		if !slices.ContainsFunc(normalized, func(e string) bool { return strings.EqualFold(e, name) }) {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// importedFileEdition returns the edition of an imported file from its release title or its file name, or "" if
+// neither says
+func importedFileEdition(releaseTitle *string, filePath string) string {
+	if releaseTitle != nil {
+		if edition := parser.Parse(*releaseTitle).Edition; edition != "" {
+			return edition
+		}
+	}
+	return parser.Parse(filepath.Base(filePath)).Edition
+}
@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditionFilter(t *testing.T) {
+	profile := storage.QualityProfile{Editions: storage.ProfileEditions{"extended", "Directors Cut", storage.TheatricalEdition}}
+
+	t.Run("editions rank in the profile's order", func(t *testing.T) {
+		filter := newEditionFilter(profile)
+		assert.Equal(t, []string{"extended", "director's cut", "theatrical"}, filter.wanted)
+		assert.Equal(t, 3, filter.rank("Movie.2019.EXTENDED.1080p-GRP"))
+		assert.Equal(t, 3, filter.rank("Movie.2019.Extended.Cut.1080p-GRP"))
+		assert.Equal(t, 2, filter.rank("Movie.2019.Directors.Cut.1080p-GRP"))
+		assert.Equal(t, 1, filter.rank("Movie.2019.1080p-GRP"), "untagged releases are the theatrical cut")
+		assert.Equal(t, 0, filter.rank("Movie.2019.IMAX.1080p-GRP"))
+		assert.False(t, filter.rejects("Movie.2019.IMAX.1080p-GRP"), "other editions are only rejected when the edition is required")
+	})
+
+	t.Run("required edition rejects other editions", func(t *testing.T) {
+		required := storage.QualityProfile{Editions: storage.ProfileEditions{"Extended"}, RequireEdition: true}
+		filter := newEditionFilter(required)
+		assert.True(t, filter.rejects("Movie.2019.IMAX.1080p-GRP"))
+		assert.True(t, filter.rejects("Movie.2019.1080p-GRP"))
+		assert.False(t, filter.rejects("Movie.2019.EXTENDED.1080p-GRP"))
+	})
+
+	t.Run("no editions accepts any", func(t *testing.T) {
+		filter := newEditionFilter(storage.QualityProfile{RequireEdition: true})
+		assert.Equal(t, 0, filter.rank("Movie.2019.EXTENDED.1080p-GRP"))
+		assert.False(t, filter.rejects("Movie.2019.IMAX.1080p-GRP"))
+	})
+}
+
+func TestNormalizeProfileEditions(t *testing.T) {
+	editions, err := normalizeProfileEditions([]string{"directors cut", "EXTENDED", "Extended Edition", "Final Cut"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Director's Cut", "Extended", "Final Cut"}, editions)
+
+	_, err = normalizeProfileEditions([]string{" "})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestImportedFileEdition(t *testing.T) {
+	assert.Equal(t, "Extended", importedFileEdition(ptr.To("Movie.2019.EXTENDED.1080p-GRP"), "/downloads/movie.mkv"))
+	assert.Equal(t, "IMAX", importedFileEdition(ptr.To("Movie.2019.1080p-GRP"), "/downloads/Movie.2019.IMAX.1080p-GRP.mkv"))
+	assert.Equal(t, "", importedFileEdition(nil, "/downloads/Movie.2019.1080p-GRP.mkv"))
+}
+
+func TestEditionRejectAndSort(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{Name: "Bluray-1080p", MinSize: 20, MaxSize: 1000},
+			{Name: "WEBDL-720p", MinSize: 0, MaxSize: 20},
+		},
+		Editions: storage.ProfileEditions{"Extended"},
+	}
+
+	release := func(title string, sizeGB int64) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue(title),
+			Size:     ptr.To(sizeGB * 1024 * 1024 * 1024),
+			Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+	}
+	protocols := map[string]struct{}{"torrent": {}}
+
+	theatrical := release("Movie.2019.1080p.BluRay-GRP", 4)
+	extended := release("Movie.2019.EXTENDED.1080p.BluRay-GRP", 4)
+	extendedWeb := release("Movie.2019.EXTENDED.720p.WEB-DL-GRP", 1)
+
+	ranked := newReleaseRanker(profile, 120, nil).rank([]*prowlarr.ReleaseResource{theatrical, extendedWeb, extended})
+	assert.Equal(t, extended, ranked[0].Release, "the wanted edition outranks another edition of the same quality")
+	assert.Equal(t, theatrical, ranked[1].Release, "quality still outranks the edition")
+	assert.Equal(t, 1, ranked[0].Score.EditionRank)
+	assert.Equal(t, 0, ranked[1].Score.EditionRank)
+
+	params := ReleaseFilterParams{Title: "Movie", Runtime: 120}
+	assert.False(t, RejectMovieReleaseFunc(context.Background(), params, profile, protocols)(theatrical))
+
+	profile.RequireEdition = true
+	assert.True(t, RejectMovieReleaseFunc(context.Background(), params, profile, protocols)(theatrical))
+	assert.False(t, RejectMovieReleaseFunc(context.Background(), params, profile, protocols)(extended))
+}
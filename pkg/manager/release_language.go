@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/storage"
+)
+
+// languageFilter checks release languages against the languages a quality profile wants
+type languageFilter struct {
+	wanted  []string
+	require bool
+}
+
+// newLanguageFilter resolves the profile languages. "original" is replaced by the original language of the movie or
+// series, or dropped if it isn't known.
+func newLanguageFilter(profile storage.QualityProfile, originalLanguage *string) languageFilter {
+	filter := languageFilter{require: profile.RequireLanguage}
+	for _, lang := range profile.Languages {
+		lang = strings.ToLower(lang)
+		if lang == storage.OriginalLanguage {
+			if originalLanguage == nil || *originalLanguage == "" {
+				continue
+			}
+			lang = strings.ToLower(*originalLanguage)
+		}
+
+		if !slices.Contains(filter.wanted, lang) {
+			filter.wanted = append(filter.wanted, lang)
+		}
+	}
+
+	return filter
+}
+
+// mismatch reports whether a release is only tagged with languages the profile doesn't want. Untagged and
+// multi-language releases aren't a mismatch since we can't tell what they contain.
+func (f languageFilter) mismatch(title string) bool {
+	if len(f.wanted) == 0 {
+		return false
+	}
+
+	parsed := parser.Parse(title)
+	if parsed.MultiLanguage || len(parsed.Languages) == 0 {
+		return false
+	}
+
+	for _, code := range parsed.Languages {
+		if slices.Contains(f.wanted, code) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rejects reports whether a release should be rejected for its language
+func (f languageFilter) rejects(title string) bool {
+	return f.require && f.mismatch(title)
+}
+
+// validateProfileLanguages checks every language is an ISO 639-1 code or "original"
+func validateProfileLanguages(languages []string) error {
+	for _, lang := range languages {
+		lang = strings.ToLower(lang)
+		if lang == storage.OriginalLanguage {
+			continue
+		}
+
+		if len(lang) != 2 || lang[0] < 'a' || lang[0] > 'z' || lang[1] < 'a' || lang[1] > 'z' {
+			return fmt.Errorf("%w: language %q must be an ISO 639-1 code or %q", ErrValidation, lang, storage.OriginalLanguage)
+		}
+	}
+
+	return nil
+}
+
+// importedFileLanguages returns the languages to record on an imported file, from its release title or its file name
+func importedFileLanguages(releaseTitle *string, filePath string) string {
+	name := filepath.Base(filePath)
+	if releaseTitle != nil && *releaseTitle != "" {
+		name = *releaseTitle
+	}
+	// the languages are stored comma separated on library files
+	return strings.Join(parser.Parse(name).Languages, ",")
+}
@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageFilter(t *testing.T) {
+	profile := storage.QualityProfile{Languages: storage.ProfileLanguages{storage.OriginalLanguage, "en"}}
+
+	t.Run("original resolves to the movie's language", func(t *testing.T) {
+		filter := newLanguageFilter(profile, ptr.To("fr"))
+		assert.Equal(t, []string{"fr", "en"}, filter.wanted)
+		assert.False(t, filter.mismatch("Movie.2019.FRENCH.1080p-GRP"))
+		assert.False(t, filter.mismatch("Movie.2019.ENGLISH.1080p-GRP"))
+		assert.True(t, filter.mismatch("Movie.2019.GERMAN.1080p-GRP"))
+		assert.False(t, filter.mismatch("Movie.2019.1080p-GRP"), "untagged releases aren't a mismatch")
+		assert.False(t, filter.mismatch("Movie.2019.MULTi.1080p-GRP"), "multi language releases aren't a mismatch")
+		assert.False(t, filter.rejects("Movie.2019.GERMAN.1080p-GRP"), "mismatches are only rejected when the language is required")
+	})
+
+	t.Run("original is dropped when unknown", func(t *testing.T) {
+		filter := newLanguageFilter(profile, nil)
+		assert.Equal(t, []string{"en"}, filter.wanted)
+		assert.True(t, filter.mismatch("Movie.2019.FRENCH.1080p-GRP"))
+	})
+
+	t.Run("required language rejects mismatches", func(t *testing.T) {
+		required := profile
+		required.RequireLanguage = true
+		filter := newLanguageFilter(required, ptr.To("fr"))
+		assert.True(t, filter.rejects("Movie.2019.GERMAN.1080p-GRP"))
+		assert.False(t, filter.rejects("Movie.2019.FRENCH.1080p-GRP"))
+	})
+
+	t.Run("no languages", func(t *testing.T) {
+		filter := newLanguageFilter(storage.QualityProfile{RequireLanguage: true}, ptr.To("fr"))
+		assert.False(t, filter.rejects("Movie.2019.GERMAN.1080p-GRP"))
+	})
+}
+
+func TestValidateProfileLanguages(t *testing.T) {
+	assert.NoError(t, validateProfileLanguages([]string{"original", "en", "FR"}))
+	assert.ErrorIs(t, validateProfileLanguages([]string{"english"}), ErrValidation)
+	assert.ErrorIs(t, validateProfileLanguages([]string{""}), ErrValidation)
+}
+
+func TestImportedFileLanguages(t *testing.T) {
+	assert.Equal(t, "fr", importedFileLanguages(ptr.To("Movie.2019.FRENCH.1080p-GRP"), "/downloads/movie.mkv"))
+	assert.Equal(t, "de", importedFileLanguages(nil, "/downloads/Movie.2019.GERMAN.1080p-GRP.mkv"))
+	assert.Equal(t, "", importedFileLanguages(ptr.To(""), "/downloads/Movie.2019.1080p-GRP.mkv"))
+}
+
+func TestLanguageRejectAndSort(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{Name: "Bluray-1080p", MinSize: 20, MaxSize: 1000},
+			{Name: "WEBDL-720p", MinSize: 0, MaxSize: 20},
+		},
+		Languages: storage.ProfileLanguages{storage.OriginalLanguage},
+	}
+
+	release := func(title string, sizeGB int64) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue(title),
+			Size:     ptr.To(sizeGB * 1024 * 1024 * 1024),
+			Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+	}
+	protocols := map[string]struct{}{"torrent": {}}
+
+	dubbed := release("Movie.2019.GERMAN.1080p.BluRay-GRP", 4)
+	original := release("Movie.2019.FRENCH.720p.WEB-DL-GRP", 1)
+
+	ranked := newReleaseRanker(profile, 120, nil).withOriginalLanguage(ptr.To("fr")).rank([]*prowlarr.ReleaseResource{dubbed, original})
+	assert.Equal(t, original, ranked[0].Release, "the original language outranks a better quality dub")
+	assert.True(t, ranked[1].Score.LanguageMismatch)
+
+	params := ReleaseFilterParams{Title: "Movie", Runtime: 120, OriginalLanguage: ptr.To("fr")}
+	assert.False(t, RejectMovieReleaseFunc(context.Background(), params, profile, protocols)(dubbed))
+
+	profile.RequireLanguage = true
+	assert.True(t, RejectMovieReleaseFunc(context.Background(), params, profile, protocols)(dubbed))
+	assert.False(t, RejectMovieReleaseFunc(context.Background(), params, profile, protocols)(original))
+
+	series := SeriesReleaseFilterParams{Title: "Show", SeasonNumber: 1, EpisodeNumber: 2, Runtime: 120, OriginalLanguage: ptr.To("fr")}
+	assert.True(t, RejectEpisodeReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01E02.GERMAN.1080p-GRP", 1)))
+	assert.True(t, RejectSeasonReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01.GERMAN.1080p-GRP", 1)))
+}
@@ -0,0 +1,242 @@
+package manager
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/size"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+)
+
+// defaultIndexerPriority is used for releases from indexers we don't know the priority of. It matches Prowlarr's default.
+const defaultIndexerPriority = 25
+
+// ReleaseScore is the breakdown of how a release ranks. Releases are compared on each field in order,
+// later fields only break ties.
+type ReleaseScore struct {
+	// LanguageMismatch is set when the release is only tagged with languages the profile doesn't want
+	LanguageMismatch bool `json:"languageMismatch"`
+	// Quality is the name of the profile quality the release matched by its resolution, source and size
+	Quality string `json:"quality"`
+	// QualityRank is the position of the quality in the profile, counted from the lowest so higher is better
+	QualityRank int `json:"qualityRank"`
+	// EditionRank is the position of the release's edition in the profile's editions, counted from the least wanted
+	// so higher is better. It's 0 for editions the profile doesn't want or when it accepts any.
+	EditionRank int `json:"editionRank"`
+	// FormatScore is the sum of the scores of the custom formats the release matched
+	FormatScore int32    `json:"formatScore"`
+	Formats     []string `json:"formats"`
+	// PreferredScore is the sum of the scores of the profile's preferred terms the release contains
+	PreferredScore int32 `json:"preferredScore"`
+	// Revision is 1 for an original release and higher for PROPER, REPACK and anime v2 releases
+	Revision int `json:"revision"`
+	// PreferredProtocol is set when the release uses the delay profile's preferred protocol
+	PreferredProtocol bool `json:"preferredProtocol"`
+	// IndexerPriority is the priority of the indexer the release came from, lower is better
+	IndexerPriority int32 `json:"indexerPriority"`
+	// SizeDeviation is how far the release size is from the quality's preferred size, in MB per minute
+	SizeDeviation float64 `json:"sizeDeviation"`
+	// Seeders is only compared between torrent releases
+	Seeders int32 `json:"seeders"`
+	// AgeHours is only compared between usenet releases, newer is better
+	AgeHours float64 `json:"ageHours"`
+}
+
+// RankedRelease is a release that passed filtering with its score, or a release that was rejected with the reason why
+type RankedRelease struct {
+	Release *prowlarr.ReleaseResource `json:"release"`
+	Score   ReleaseScore              `json:"score"`
+	// Rejection is why the release was rejected. Rejected releases aren't scored.
+	Rejection string `json:"rejection,omitempty"`
+}
+
+// releaseRanker scores releases for a quality profile and runtime
+type releaseRanker struct {
+	profile           storage.QualityProfile
+	scorer            formatScorer
+	terms             termFilter
+	languages         languageFilter
+	editions          editionFilter
+	preferredProtocol prowlarr.DownloadProtocol
+	runtime           releaseRuntime
+	indexerPriorities map[int32]int32
+}
+
+func newReleaseRanker(profile storage.QualityProfile, runtime int32, indexers []model.Indexer) releaseRanker {
+	priorities := make(map[int32]int32, len(indexers))
+	for _, idx := range indexers {
+		priorities[idx.ID] = idx.Priority
+	}
+
+	return releaseRanker{
+		profile:           profile,
+		scorer:            newFormatScorer(profile),
+		terms:             newTermFilter(profile),
+		editions:          newEditionFilter(profile),
+		runtime:           fixedRuntime(runtime),
+		indexerPriorities: priorities,
+	}
+}
+
+// withRuntime judges each release's size against the runtime instead of the one the ranker was created with
+func (rk releaseRanker) withRuntime(runtime releaseRuntime) releaseRanker {
+	rk.runtime = runtime
+	return rk
+}
+
+// withOriginalLanguage resolves the profile's "original" language for the movie or series being ranked
+func (rk releaseRanker) withOriginalLanguage(originalLanguage *string) releaseRanker {
+	rk.languages = newLanguageFilter(rk.profile, originalLanguage)
+	return rk
+}
+
+// withPreferredProtocol ranks releases of the protocol above otherwise equal releases of other protocols
+func (rk releaseRanker) withPreferredProtocol(protocol string) releaseRanker {
+	rk.preferredProtocol = prowlarr.DownloadProtocol(strings.ToLower(strings.TrimSpace(protocol)))
+	return rk
+}
+
+// matchQuality returns the index of the first profile quality that describes the release and its size fits
+func matchQuality(profile storage.QualityProfile, identity qualityIdentity, sizeMB uint64, runtime uint64) (int, bool) {
+	for i, quality := range profile.Qualities {
+		if identity.matches(quality.Resolution, quality.Source) && MeetsQualitySize(quality, sizeMB, runtime) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+func (rk releaseRanker) score(r *prowlarr.ReleaseResource) ReleaseScore {
+	var score ReleaseScore
+	if r == nil {
+		return score
+	}
+
+	var sizeMB uint64
+	if r.Size != nil && *r.Size > 0 {
+		sizeMB = uint64(size.BytesToMB(*r.Size))
+	}
+
+	title, _ := r.Title.Get()
+	runtime := rk.runtime(r)
+	if i, ok := matchQuality(rk.profile, identifyQuality(&title), sizeMB, uint64(runtime)); ok {
+		quality := rk.profile.Qualities[i]
+		score.Quality = quality.Name
+		score.QualityRank = len(rk.profile.Qualities) - i
+		if runtime > 0 && quality.PreferredSize > 0 {
+			score.SizeDeviation = math.Abs(float64(sizeMB)/float64(runtime) - quality.PreferredSize)
+		}
+	}
+
+	score.LanguageMismatch = rk.languages.mismatch(title)
+	score.EditionRank = rk.editions.rank(title)
+	score.FormatScore, score.Formats = rk.scorer.evaluate(title)
+	score.PreferredScore = rk.terms.score(title)
+
+	score.Revision = parser.Parse(title).Revision
+	score.PreferredProtocol = rk.preferredProtocol != "" && releaseProtocol(r) == rk.preferredProtocol
+
+	score.IndexerPriority = defaultIndexerPriority
+	if r.IndexerID != nil {
+		if priority, ok := rk.indexerPriorities[*r.IndexerID]; ok {
+			score.IndexerPriority = priority
+		}
+	}
+
+	score.Seeders = nullableDefault(r.Seeders)
+	score.AgeHours, _ = releaseAgeHours(r)
+
+	return score
+}
+
+// rank scores the releases and sorts them best first
+func (rk releaseRanker) rank(releases []*prowlarr.ReleaseResource) []RankedRelease {
+	ranked := make([]RankedRelease, 0, len(releases))
+	for _, r := range releases {
+		if r == nil {
+			continue
+		}
+		ranked = append(ranked, RankedRelease{Release: r, Score: rk.score(r)})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b RankedRelease) int {
+		return compareRankedReleases(b, a)
+	})
+
+	return ranked
+}
+
+// compareRankedReleases returns a positive number if a ranks above b, negative if below.
+// Releases that tie on every score are ordered by title and guid so the result is deterministic.
+func compareRankedReleases(a, b RankedRelease) int {
+	if a.Score.LanguageMismatch != b.Score.LanguageMismatch {
+		if a.Score.LanguageMismatch {
+			return -1
+		}
+		return 1
+	}
+	if c := cmp.Compare(a.Score.QualityRank, b.Score.QualityRank); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Score.EditionRank, b.Score.EditionRank); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Score.FormatScore, b.Score.FormatScore); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Score.PreferredScore, b.Score.PreferredScore); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Score.Revision, b.Score.Revision); c != 0 {
+		return c
+	}
+	if a.Score.PreferredProtocol != b.Score.PreferredProtocol {
+		if a.Score.PreferredProtocol {
+			return 1
+		}
+		return -1
+	}
+	if c := cmp.Compare(b.Score.IndexerPriority, a.Score.IndexerPriority); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.Score.SizeDeviation, a.Score.SizeDeviation); c != 0 {
+		return c
+	}
+
+	aProtocol, bProtocol := releaseProtocol(a.Release), releaseProtocol(b.Release)
+	if aProtocol == bProtocol {
+		switch aProtocol {
+		case prowlarr.DownloadProtocolTorrent:
+			if c := cmp.Compare(a.Score.Seeders, b.Score.Seeders); c != 0 {
+				return c
+			}
+		case prowlarr.DownloadProtocolUsenet:
+			if c := cmp.Compare(b.Score.AgeHours, a.Score.AgeHours); c != 0 {
+				return c
+			}
+		}
+	}
+
+	aTitle, _ := a.Release.Title.Get()
+	bTitle, _ := b.Release.Title.Get()
+	if c := cmp.Compare(bTitle, aTitle); c != 0 {
+		return c
+	}
+
+	aGUID, _ := a.Release.GUID.Get()
+	bGUID, _ := b.Release.GUID.Get()
+	return cmp.Compare(bGUID, aGUID)
+}
+
+func releaseProtocol(r *prowlarr.ReleaseResource) prowlarr.DownloadProtocol {
+	if r == nil || r.Protocol == nil {
+		return prowlarr.DownloadProtocolUnknown
+	}
+	return *r.Protocol
+}
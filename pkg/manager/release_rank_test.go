@@ -0,0 +1,193 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseRanker_rank(t *testing.T) {
+	const gb = int64(1024 * 1024 * 1024)
+
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{Name: "Bluray-1080p", MinSize: 50, PreferredSize: 80, MaxSize: 100},
+			{Name: "WEBDL-720p", MinSize: 5, PreferredSize: 20, MaxSize: 49},
+		},
+		Formats: []storage.QualityProfileFormat{
+			{
+				Format: storage.CustomFormat{Name: "HDR", Specifications: []storage.CustomFormatSpecification{{Type: storage.CustomFormatSpecTitle, Value: `\bHDR\b`}}},
+				Score:  50,
+			},
+		},
+	}
+	indexers := []model.Indexer{{ID: 1, Priority: 10}, {ID: 2, Priority: 40}}
+
+	torrent := func(title string, size int64, indexerID int32, seeders int32) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:     nullable.NewNullableWithValue(title),
+			GUID:      nullable.NewNullableWithValue(title),
+			Size:      ptr.To(size),
+			IndexerID: ptr.To(indexerID),
+			Seeders:   nullable.NewNullableWithValue(seeders),
+			Protocol:  ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+	}
+	usenet := func(title string, size int64, age float64) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:     nullable.NewNullableWithValue(title),
+			GUID:      nullable.NewNullableWithValue(title),
+			Size:      ptr.To(size),
+			IndexerID: ptr.To(int32(1)),
+			AgeHours:  ptr.To(age),
+			Protocol:  ptr.To(prowlarr.DownloadProtocolUsenet),
+		}
+	}
+
+	// runtime of 100 minutes, so 1GB is ~10MB/min and 8GB is ~82MB/min
+	tests := []struct {
+		name  string
+		worse *prowlarr.ReleaseResource
+		best  *prowlarr.ReleaseResource
+	}{
+		{
+			name:  "quality position beats custom format score",
+			worse: torrent("Movie.720p.HDR-GRP", 1*gb, 1, 100),
+			best:  torrent("Movie.1080p-GRP", 8*gb, 1, 1),
+		},
+		{
+			name:  "custom format score beats proper",
+			worse: torrent("Movie.1080p.PROPER-GRP", 8*gb, 1, 100),
+			best:  torrent("Movie.1080p.HDR-GRP", 8*gb, 1, 1),
+		},
+		{
+			name:  "proper beats indexer priority",
+			worse: torrent("Movie.1080p-GRP", 8*gb, 1, 100),
+			best:  torrent("Movie.1080p.REPACK-GRP", 8*gb, 2, 1),
+		},
+		{
+			name:  "proper beats preferred protocol",
+			worse: usenet("Movie.1080p-GRP", 8*gb, 5),
+			best:  torrent("Movie.1080p.PROPER-GRP", 8*gb, 1, 1),
+		},
+		{
+			name:  "preferred protocol beats size",
+			worse: torrent("Movie.1080p-ONE", 8*gb, 1, 100),
+			best:  usenet("Movie.1080p-TWO", 6*gb, 500),
+		},
+		{
+			name:  "indexer priority beats size",
+			worse: torrent("Movie.1080p-ONE", 8*gb, 2, 100),
+			best:  torrent("Movie.1080p-TWO", 6*gb, 1, 1),
+		},
+		{
+			name:  "closer to preferred size beats seeders",
+			worse: torrent("Movie.1080p-ONE", 6*gb, 1, 100),
+			best:  torrent("Movie.1080p-TWO", 8*gb, 1, 1),
+		},
+		{
+			name:  "more seeders wins for torrents",
+			worse: torrent("Movie.1080p-ONE", 8*gb, 1, 1),
+			best:  torrent("Movie.1080p-TWO", 8*gb, 1, 100),
+		},
+		{
+			name:  "newer wins for usenet",
+			worse: usenet("Movie.1080p-ONE", 8*gb, 500),
+			best:  usenet("Movie.1080p-TWO", 8*gb, 5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rk := newReleaseRanker(profile, 100, indexers).withPreferredProtocol("usenet")
+
+			ranked := rk.rank([]*prowlarr.ReleaseResource{tt.worse, tt.best})
+			require.Len(t, ranked, 2)
+			assert.Equal(t, tt.best, ranked[0].Release)
+
+			ranked = rk.rank([]*prowlarr.ReleaseResource{tt.best, tt.worse})
+			assert.Equal(t, tt.best, ranked[0].Release, "order of the input doesn't matter")
+		})
+	}
+}
+
+func TestReleaseRanker_score(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{Name: "Bluray-1080p", MinSize: 50, PreferredSize: 80, MaxSize: 100},
+			{Name: "WEBDL-720p", MinSize: 5, PreferredSize: 20, MaxSize: 49},
+		},
+		Formats: []storage.QualityProfileFormat{
+			{
+				Format: storage.CustomFormat{Name: "HDR", Specifications: []storage.CustomFormatSpecification{{Type: storage.CustomFormatSpecTitle, Value: `\bHDR\b`}}},
+				Score:  50,
+			},
+		},
+	}
+
+	rk := newReleaseRanker(profile, 100, []model.Indexer{{ID: 3, Priority: 5}})
+	score := rk.score(&prowlarr.ReleaseResource{
+		Title:     nullable.NewNullableWithValue("Movie.2020.720p.WEB-DL.HDR.PROPER-GRP"),
+		Size:      ptr.To(int64(2000 * 1024 * 1024)),
+		IndexerID: ptr.To(int32(3)),
+		Seeders:   nullable.NewNullableWithValue(int32(12)),
+		Age:       ptr.To(int32(2)),
+	})
+
+	assert.Equal(t, ReleaseScore{
+		Quality:         "WEBDL-720p",
+		QualityRank:     1,
+		FormatScore:     50,
+		Formats:         []string{"HDR"},
+		Revision:        2,
+		IndexerPriority: 5,
+		SizeDeviation:   0,
+		Seeders:         12,
+		AgeHours:        48,
+	}, score)
+
+	unknown := rk.score(&prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie"), IndexerID: ptr.To(int32(9))})
+	assert.Equal(t, int32(defaultIndexerPriority), unknown.IndexerPriority)
+	assert.Equal(t, 0, unknown.QualityRank)
+}
+
+func TestReleaseRanker_multiEpisodeRuntime(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{{Name: "WEBDL-1080p", MinSize: 10, PreferredSize: 20, MaxSize: 30}},
+	}
+
+	rk := newReleaseRanker(profile, 45, nil).withRuntime(multiEpisodeRuntime(45))
+	score := rk.score(&prowlarr.ReleaseResource{
+		Title: nullable.NewNullableWithValue("ShowName.S01E01-E03.1080p.WEB-DL.x264-GROUP"),
+		Size:  ptr.To(int64(2700 * 1024 * 1024)),
+	})
+
+	assert.Equal(t, "WEBDL-1080p", score.Quality)
+	assert.Zero(t, score.SizeDeviation, "the release is as large as three episodes should be")
+}
+
+func TestReleaseRanker_rankDeterministic(t *testing.T) {
+	profile := storage.QualityProfile{Qualities: []storage.QualityDefinition{{Name: "Any", MinSize: 0, MaxSize: 1000}}}
+
+	release := func(title, guid string) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title: nullable.NewNullableWithValue(title),
+			GUID:  nullable.NewNullableWithValue(guid),
+			Size:  ptr.To(int64(1024 * 1024 * 1024)),
+		}
+	}
+
+	a, b, c := release("A", "1"), release("A", "2"), release("B", "1")
+	rk := newReleaseRanker(profile, 100, nil)
+
+	first := rk.rank([]*prowlarr.ReleaseResource{c, b, a})
+	second := rk.rank([]*prowlarr.ReleaseResource{a, c, b})
+	assert.Equal(t, first, second)
+	assert.Equal(t, []*prowlarr.ReleaseResource{a, b, c}, []*prowlarr.ReleaseResource{first[0].Release, first[1].Release, first[2].Release})
+}
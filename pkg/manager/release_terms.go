@@ -0,0 +1,130 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kasuboski/mediaz/pkg/storage"
+)
+
+// compileReleaseTerm turns a profile term into a case insensitive pattern. Terms wrapped in slashes are
+// regular expressions, anything else matches as a plain word.
+func compileReleaseTerm(term string) (*regexp.Regexp, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("term is empty")
+	}
+
+	if len(term) > 2 && strings.HasPrefix(term, "/") && strings.HasSuffix(term, "/") {
+		return regexp.Compile("(?i)" + term[1:len(term)-1])
+	}
+
+	return regexp.Compile(`(?i)(?:^|[^\p{L}\p{N}])` + regexp.QuoteMeta(term) + `(?:$|[^\p{L}\p{N}])`)
+}
+
+// compileReleaseTerms compiles the terms, skipping any that don't compile. Terms are validated when the profile is saved.
+func compileReleaseTerms(terms []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(terms))
+	for _, term := range terms {
+		pattern, err := compileReleaseTerm(term)
+		if err != nil {
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+type preferredTermPattern struct {
+	pattern *regexp.Regexp
+	score   int32
+}
+
+// termFilter applies the required, ignored and preferred terms of a quality profile to release titles
+type termFilter struct {
+	required  []*regexp.Regexp
+	ignored   []*regexp.Regexp
+	preferred []preferredTermPattern
+}
+
+func newTermFilter(profile storage.QualityProfile) termFilter {
+	filter := termFilter{
+		required: compileReleaseTerms(profile.RequiredTerms),
+		ignored:  compileReleaseTerms(profile.IgnoredTerms),
+	}
+
+	for _, p := range profile.PreferredTerms {
+		pattern, err := compileReleaseTerm(p.Term)
+		if err != nil {
+			continue
+		}
+		filter.preferred = append(filter.preferred, preferredTermPattern{pattern: pattern, score: p.Score})
+	}
+
+	return filter
+}
+
+// accepts reports whether the title contains one of the required terms, if there are any, and none of the ignored terms
+func (f termFilter) accepts(title string) bool {
+	for _, p := range f.ignored {
+		if p.MatchString(title) {
+			return false
+		}
+	}
+
+	if len(f.required) == 0 {
+		return true
+	}
+
+	for _, p := range f.required {
+		if p.MatchString(title) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// score sums the scores of the preferred terms the title contains
+func (f termFilter) score(title string) int32 {
+	var score int32
+	for _, p := range f.preferred {
+		if p.pattern.MatchString(title) {
+			score += p.score
+		}
+	}
+	return score
+}
+
+// validateReleaseTerms checks every term of a quality profile compiles
+func validateReleaseTerms(required, ignored []string, preferred []storage.PreferredTerm) error {
+	terms := make([]string, 0, len(required)+len(ignored)+len(preferred))
+	terms = append(terms, required...)
+	terms = append(terms, ignored...)
+	for _, p := range preferred {
+		terms = append(terms, p.Term)
+	}
+
+	for _, term := range terms {
+		if _, err := compileReleaseTerm(term); err != nil {
+			return fmt.Errorf("%w: invalid term %q: %v", ErrValidation, term, err)
+		}
+	}
+
+	return nil
+}
+
+// encodeProfileList marshals a quality profile list for storage, nil is stored as an empty list
+func encodeProfileList[T any](list []T) string {
+	if list == nil {
+		list = []T{}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermFilter(t *testing.T) {
+	filter := newTermFilter(storage.QualityProfile{
+		RequiredTerms:  storage.ReleaseTerms{"1080p", "2160p"},
+		IgnoredTerms:   storage.ReleaseTerms{"HC", `/\b3d\b/`},
+		PreferredTerms: storage.PreferredTerms{{Term: "FLUX", Score: 10}, {Term: "/web-?dl/", Score: 5}, {Term: "x264", Score: -5}},
+	})
+
+	tests := []struct {
+		title  string
+		accept bool
+		score  int32
+	}{
+		{"Movie.2019.1080p.WEB-DL.x264-GRP", true, 0},
+		{"Movie.2019.2160p.WEBDL.x265-FLUX", true, 15},
+		{"Movie.2019.720p.WEB-DL-FLUX", false, 15},
+		{"Movie.2019.1080p.HC.WEBRip-GRP", false, 0},
+		{"Movie.2019.1080p.BluRay.3D-GRP", false, 0},
+		{"Movie.2019.1080p.BluRay.3DS-GRP", true, 0},
+		{"Movie.2019.1080p.BluRay-CHC", true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.accept, filter.accepts(tt.title))
+			assert.Equal(t, tt.score, filter.score(tt.title))
+		})
+	}
+
+	t.Run("no terms", func(t *testing.T) {
+		filter := newTermFilter(storage.QualityProfile{})
+		assert.True(t, filter.accepts("Movie.2019.1080p-GRP"))
+		assert.Equal(t, int32(0), filter.score("Movie.2019.1080p-GRP"))
+	})
+}
+
+func TestValidateReleaseTerms(t *testing.T) {
+	assert.NoError(t, validateReleaseTerms([]string{"1080p", `/\b3d\b/`}, nil, []storage.PreferredTerm{{Term: "FLUX", Score: 1}}))
+	assert.ErrorIs(t, validateReleaseTerms([]string{""}, nil, nil), ErrValidation)
+	assert.ErrorIs(t, validateReleaseTerms(nil, []string{"/(/"}, nil), ErrValidation)
+	assert.ErrorIs(t, validateReleaseTerms(nil, nil, []storage.PreferredTerm{{Term: "/[/"}}), ErrValidation)
+}
+
+func TestReleaseTermsRejectAndSort(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities:      []storage.QualityDefinition{{Name: "WEBDL-1080p", MinSize: 0, MaxSize: 1000}},
+		IgnoredTerms:   storage.ReleaseTerms{"HC"},
+		PreferredTerms: storage.PreferredTerms{{Term: "FAVE", Score: 10}},
+	}
+
+	release := func(title string, seeders int32) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title:    nullable.NewNullableWithValue(title),
+			Size:     ptr.To(int64(1024 * 1024 * 1024)),
+			Seeders:  nullable.NewNullableWithValue(seeders),
+			Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+		}
+	}
+	protocols := map[string]struct{}{"torrent": {}}
+
+	plain := release("Movie.2019.1080p.WEB-DL-GRP", 500)
+	fave := release("Movie.2019.1080p.WEB-DL-FAVE", 10)
+	hardcoded := release("Movie.2019.1080p.HC.WEB-DL-FAVE", 1000)
+
+	rejectMovie := RejectMovieReleaseFunc(context.Background(), ReleaseFilterParams{Title: "Movie", Runtime: 120}, profile, protocols)
+	assert.False(t, rejectMovie(plain))
+	assert.False(t, rejectMovie(fave))
+	assert.True(t, rejectMovie(hardcoded))
+
+	series := SeriesReleaseFilterParams{Title: "Show", SeasonNumber: 1, EpisodeNumber: 2, Runtime: 120}
+	assert.True(t, RejectEpisodeReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01E02.1080p.HC.WEB-DL-GRP", 1)))
+	assert.False(t, RejectEpisodeReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01E02.1080p.WEB-DL-GRP", 1)))
+	assert.True(t, RejectSeasonReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01.1080p.HC.WEB-DL-GRP", 1)))
+	assert.False(t, RejectSeasonReleaseFunc(context.Background(), series, profile, protocols)(release("Show.S01.1080p.WEB-DL-GRP", 1)))
+
+	ranked := newReleaseRanker(profile, 120, nil).rank([]*prowlarr.ReleaseResource{plain, fave})
+	assert.Equal(t, fave, ranked[0].Release, "preferred terms outrank seeders")
+	assert.Equal(t, int32(10), ranked[0].Score.PreferredScore)
+}
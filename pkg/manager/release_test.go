@@ -2,208 +2,18 @@ package manager
 
 import (
 	"context"
-	"encoding/json"
-	"os"
-	"reflect"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
 	"github.com/kasuboski/mediaz/pkg/ptr"
 	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 	"github.com/oapi-codegen/nullable"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 )
 
-type testCases struct {
-	Testcases []ParsedReleaseFile `json:"testcases"`
-}
-
-func TestParseReleaseFilename(t *testing.T) {
-	b, err := os.ReadFile("testing/parse-moviefiles.json")
-	require.NoError(t, err)
-
-	var cases testCases
-	err = json.Unmarshal(b, &cases)
-	require.NoError(t, err)
-
-	for _, tc := range cases.Testcases {
-		t.Run(tc.Filename, func(t *testing.T) {
-			parsed, ok := parseReleaseFilename(tc.Filename)
-			require.True(t, ok, "failed to parse filename")
-
-			assert.Equal(t, tc.Filename, parsed.Filename)
-			// don't worry about title and year for now; maybe we similarity search or exact match from desired
-			// assert.Equal(t, tc.Title, parsed.Title)
-			// equalValuesPrettyPrint(t, tc.Year, parsed.Year)
-			equalValuesPrettyPrint(t, tc.Edition, parsed.Edition)
-			equalValuesPrettyPrint(t, tc.Customformat, parsed.Customformat)
-			equalValuesPrettyPrint(t, tc.Quality, parsed.Quality)
-			equalValuesPrettyPrint(t, tc.Mediainfo3D, parsed.Mediainfo3D)
-			assertArrayString(t, tc.MediainfoDynamicrange, parsed.MediainfoDynamicrange)
-			assertArrayString(t, tc.MediainfoAudio, parsed.MediainfoAudio)
-			equalValuesPrettyPrint(t, tc.MediainfoVideo, parsed.MediainfoVideo)
-			// don't actually really care atm
-			// equalValuesPrettyPrint(t, tc.Releasegroup, parsed.Releasegroup)
-		})
-	}
-}
-
-func TestFindQuality(t *testing.T) {
-	tests := []struct {
-		name     string
-		filename string
-		want     string
-	}{
-		{
-			name:     "full quality string with multiple formats",
-			filename: "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-			want:     "Bluray-1080p",
-		},
-		{
-			name:     "simple 720p quality",
-			filename: "Brothers 2024 720p [broski]",
-			want:     "720p",
-		},
-		{
-			name:     "2160p with multiple formats",
-			filename: "Step Brothers 2008 2160p UNRATED Bluray x265 DDP Atmos DTS KiNGDOM",
-			want:     "Bluray-2160p",
-		},
-		{
-			name:     "BRRip quality",
-			filename: "The-Brothers-Karamazov-1969-(Dostoevsky-Mini-Series)-1080p-BRRip-x264-Classics",
-			want:     "BRRip-1080p",
-		},
-		{
-			name:     "WEB quality",
-			filename: "Brothers 2024 1080p AMZN WEB DLip ExKinoRay",
-			want:     "WEB-1080p",
-		},
-		{
-			name:     "WEB-DL quality",
-			filename: "The.Brothers.Karamazov.1969.(Dostoevsky.Mini.Series).1080p.WEB-DL.x264.Classics",
-			want:     "WEBDL-1080p",
-		},
-		// Edge cases
-		{
-			name:     "empty string",
-			filename: "",
-			want:     "",
-		},
-		{
-			name:     "no quality string",
-			filename: "Just A Movie Title",
-			want:     "",
-		},
-		{
-			name:     "mixed case quality",
-			filename: "Movie (2024) bLuRaY 1080P",
-			want:     "Bluray-1080p",
-		},
-		{
-			name:     "HD only",
-			filename: "Movie (2024) HD",
-			want:     "HD",
-		},
-		{
-			name:     "similar but invalid quality",
-			filename: "Movie (2024) 1081",
-			want:     "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// not testing what it matched
-			got, _ := findQuality(tt.filename)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
-func TestDetermineSeparator(t *testing.T) {
-	tests := []struct {
-		name string
-		args string
-		want string
-	}{
-		{
-			name: "dash",
-			args: "movie-name-2021",
-			want: "-",
-		},
-		{
-			name: "underscore",
-			args: "movie_name_2021",
-			want: "_",
-		},
-		{
-			name: "dot",
-			args: "movie.name.2021",
-			want: ".",
-		},
-		{
-			name: "space",
-			args: "movie name 2021",
-			want: " ",
-		},
-		{
-			name: "mixed",
-			args: "movie_name_2021-RAR.mkv",
-			want: "_",
-		},
-		{
-			name: "full filename",
-			args: "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-			want: " ",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := determineSeparator(tt.args); got != tt.want {
-				t.Errorf("determineSeparator() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestRemoveFromName(t *testing.T) {
-	tests := []struct {
-		name     string
-		filename string
-		toRemove []string
-		want     string
-	}{
-		{
-			name:     "remove year",
-			filename: "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-			toRemove: []string{"2010"},
-			want:     "The Movie Title  {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-		},
-		{
-			name:     "remove quality",
-			filename: "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-			toRemove: []string{"Bluray-1080p"},
-			want:     "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-		},
-		{
-			name:     "remove audio info",
-			filename: "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][DTS 5.1][x264]-EVOLVE",
-			toRemove: []string{"DTS 5.1"},
-			want:     "The Movie Title (2010) {edition-Ultimate Extended Edition} [IMAX HYBRID][Bluray-1080p Proper][3D][DV HDR10][x264]-EVOLVE",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := removeFromName(tt.filename, tt.toRemove...)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestPathToSearchTerm(t *testing.T) {
 	tests := []struct {
 		name string
@@ -311,58 +121,6 @@ func TestPathToSearchTerm(t *testing.T) {
 	}
 }
 
-func TestFindMatchingWords(t *testing.T) {
-	tests := []struct {
-		name     string
-		source   string
-		words    []string
-		expected []string
-	}{
-		{
-			name:     "basic match",
-			source:   "This is a test string",
-			words:    []string{"is", "test"},
-			expected: []string{"is", "test"},
-		},
-		{
-			name:     "no match",
-			source:   "This is a test string",
-			words:    []string{"not", "present"},
-			expected: []string{},
-		},
-		{
-			name:     "mixed case match",
-			source:   "This IS a Test string",
-			words:    []string{"is", "test"},
-			expected: []string{"is", "test"},
-		},
-		{
-			name:     "prefix match",
-			source:   "I have DDP 5.1 audio",
-			words:    []string{"ddp", "dd"},
-			expected: []string{"ddp"},
-		},
-		{
-			name:     "match past word",
-			source:   "I have DDP5.1 audio",
-			words:    []string{"ddp", "dts"},
-			expected: []string{},
-		},
-		{
-			name:     "combo match doesn't work",
-			source:   "The.Menendez.Brothers.2024.1080p.WEBRip.1400MB.DD5.1.x264-Galaxy",
-			words:    []string{"DD5.1", "Atmos5_1", "DST5_1"},
-			expected: []string{},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.expected, findMatchingWords(tt.source, tt.words))
-		})
-	}
-}
-
 func TestRejectSeasonReleaseFunc(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -459,12 +217,14 @@ func TestRejectSeasonReleaseFunc(t *testing.T) {
 
 func TestRejectEpisodeReleaseFunc(t *testing.T) {
 	tests := []struct {
-		name          string
-		episodeTitle  string
-		seasonNumber  int32
-		episodeNumber int32
-		release       *prowlarr.ReleaseResource
-		want          bool
+		name           string
+		episodeTitle   string
+		seasonNumber   int32
+		episodeNumber  int32
+		absoluteNumber *int32
+		airDate        *time.Time
+		release        *prowlarr.ReleaseResource
+		want           bool
 	}{
 		{
 			name:          "nil release",
@@ -594,18 +354,144 @@ func TestRejectEpisodeReleaseFunc(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name:          "multi-episode release covers episode",
+			episodeTitle:  "ShowName",
+			seasonNumber:  1,
+			episodeNumber: 2,
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("ShowName.S01E01-E03.1080p.WEB-DL.AAC2.0.x264-GROUP"),
+			},
+			want: false,
+		},
+		{
+			name:          "multi-episode release list covers episode",
+			episodeTitle:  "ShowName",
+			seasonNumber:  1,
+			episodeNumber: 2,
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("ShowName.S01E01E02.1080p.WEB-DL.AAC2.0.x264-GROUP"),
+			},
+			want: false,
+		},
+		{
+			name:          "multi-episode release does not cover episode",
+			episodeTitle:  "ShowName",
+			seasonNumber:  1,
+			episodeNumber: 4,
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("ShowName.S01E01-E03.1080p.WEB-DL.AAC2.0.x264-GROUP"),
+			},
+			want: true,
+		},
+		{
+			name:           "anime absolute number matches",
+			episodeTitle:   "Frieren",
+			seasonNumber:   2,
+			episodeNumber:  3,
+			absoluteNumber: ptr.To(int32(31)),
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("[SubsPlease] Frieren - 31 (1080p) [ABCD1234]"),
+			},
+			want: false,
+		},
+		{
+			name:           "anime absolute number does not match",
+			episodeTitle:   "Frieren",
+			seasonNumber:   2,
+			episodeNumber:  3,
+			absoluteNumber: ptr.To(int32(31)),
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("[SubsPlease] Frieren - 30 (1080p) [ABCD1234]"),
+			},
+			want: true,
+		},
+		{
+			name:           "anime release with season and episode",
+			episodeTitle:   "Frieren",
+			seasonNumber:   2,
+			episodeNumber:  3,
+			absoluteNumber: ptr.To(int32(31)),
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("Frieren.S02E03.1080p.WEB-DL.x264-GROUP"),
+			},
+			want: false,
+		},
+		{
+			name:          "daily air date matches",
+			episodeTitle:  "The Daily Show",
+			seasonNumber:  2026,
+			episodeNumber: 120,
+			airDate:       ptr.To(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)),
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("The.Daily.Show.2026.10.15.Guest.Name.1080p.WEB.h264-GROUP"),
+			},
+			want: false,
+		},
+		{
+			name:          "daily air date does not match",
+			episodeTitle:  "The Daily Show",
+			seasonNumber:  2026,
+			episodeNumber: 120,
+			airDate:       ptr.To(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)),
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("The.Daily.Show.2026.10.16.Guest.Name.1080p.WEB.h264-GROUP"),
+			},
+			want: true,
+		},
+		{
+			name:          "air date ignored for standard series",
+			episodeTitle:  "The Daily Show",
+			seasonNumber:  2026,
+			episodeNumber: 120,
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("The.Daily.Show.2026.10.15.Guest.Name.1080p.WEB.h264-GROUP"),
+			},
+			want: true,
+		},
+		{
+			name:          "absolute number ignored for standard series",
+			episodeTitle:  "Frieren",
+			seasonNumber:  2,
+			episodeNumber: 3,
+			release: &prowlarr.ReleaseResource{
+				Title: nullable.NewNullableWithValue("[SubsPlease] Frieren - 31 (1080p) [ABCD1234]"),
+			},
+			want: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := rejectEpisodeReleaseFunc(tt.episodeTitle, tt.seasonNumber, tt.episodeNumber, tt.release)
+			got := rejectEpisodeReleaseFunc(SeriesReleaseFilterParams{
+				Title:                 tt.episodeTitle,
+				SeasonNumber:          tt.seasonNumber,
+				EpisodeNumber:         tt.episodeNumber,
+				AbsoluteEpisodeNumber: tt.absoluteNumber,
+				AirDate:               tt.airDate,
+			}, tt.release)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func equalValuesPrettyPrint(t testing.TB, expected, actual any) bool {
-	return assert.EqualValues(t, expected, actual, "exp=%v, got=%v", reflect.Indirect(reflect.ValueOf(expected)), reflect.Indirect(reflect.ValueOf(actual)))
+func TestEpisodeReleaseRejection_MultiEpisodeSize(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{{Name: "WEBDL-1080p", MinSize: 10, PreferredSize: 20, MaxSize: 30}},
+	}
+	params := SeriesReleaseFilterParams{Title: "ShowName", SeasonNumber: 1, EpisodeNumber: 2, Runtime: 45}
+	reject := episodeReleaseRejection(context.Background(), params, profile, map[string]struct{}{})
+
+	release := func(title string, sizeMB int64) *prowlarr.ReleaseResource {
+		return &prowlarr.ReleaseResource{
+			Title: nullable.NewNullableWithValue(title),
+			Size:  ptr.To(sizeMB * 1024 * 1024),
+		}
+	}
+
+	assert.Empty(t, reject(release("ShowName.S01E02.1080p.WEB-DL.x264-GROUP", 900)))
+	assert.Empty(t, reject(release("ShowName.S01E01-E03.1080p.WEB-DL.x264-GROUP", 2700)), "a release is sized against the runtime of every episode it covers")
+	assert.Equal(t, "900 MB for a 135 minute runtime doesn't match a quality in the profile", reject(release("ShowName.S01E01-E03.1080p.WEB-DL.x264-GROUP", 900)))
 }
 
 func TestNormalizeSeparators(t *testing.T) {
@@ -948,14 +834,31 @@ func TestRejectMovieReleaseFunc(t *testing.T) {
 		}
 		assert.True(t, reject(r))
 	})
-}
 
-func assertArrayString(t *testing.T, expected, actual *string) {
-	if expected == nil {
-		assert.Nil(t, actual)
-		return
-	}
-	expectedWords := strings.Split(*expected, " ")
-	actualWords := strings.Split(*actual, " ")
-	assert.ElementsMatch(t, expectedWords, actualWords)
+	t.Run("blocklisted release", func(t *testing.T) {
+		profile := storage.QualityProfile{
+			Qualities: []storage.QualityDefinition{{Name: "HDTV-720p", MinSize: 0, MaxSize: 100}},
+		}
+		blockedParams := params
+		blockedParams.Blocklist = []*model.ReleaseBlocklist{
+			{GUID: ptr.To("blocked-guid"), Title: "Something.Else"},
+			{InfoHash: ptr.To("ABC123"), Title: "Another.Thing"},
+			{Title: "Movie 2019 720p HDTV GRP"},
+		}
+		reject := RejectMovieReleaseFunc(context.Background(), blockedParams, profile, protocols)
+
+		release := func(guid, infoHash, title string) *prowlarr.ReleaseResource {
+			return &prowlarr.ReleaseResource{
+				GUID:     nullable.NewNullableWithValue(guid),
+				InfoHash: nullable.NewNullableWithValue(infoHash),
+				Title:    nullable.NewNullableWithValue(title),
+				Size:     ptr.To(int64(1024 * 1024 * 1024)),
+			}
+		}
+
+		assert.False(t, reject(release("ok-guid", "def456", "Movie.2019.1080p.WEB-DL-GRP")))
+		assert.True(t, reject(release("blocked-guid", "def456", "Movie.2019.1080p.WEB-DL-GRP")))
+		assert.True(t, reject(release("ok-guid", "abc123", "Movie.2019.1080p.WEB-DL-GRP")))
+		assert.True(t, reject(release("ok-guid", "def456", "Movie.2019.720p.HDTV-GRP")))
+	})
 }
@@ -19,11 +19,13 @@ import (
 type JobType string
 
 const (
-	MovieIndex      JobType = "MovieIndex"
-	MovieReconcile  JobType = "MovieReconcile"
-	SeriesIndex     JobType = "SeriesIndex"
-	SeriesReconcile JobType = "SeriesReconcile"
-	IndexerSync     JobType = "IndexerSync"
+	MovieIndex        JobType = "MovieIndex"
+	MovieReconcile    JobType = "MovieReconcile"
+	SeriesIndex       JobType = "SeriesIndex"
+	SeriesReconcile   JobType = "SeriesReconcile"
+	IndexerSync       JobType = "IndexerSync"
+	RecycleBinCleanup JobType = "RecycleBinCleanup"
+	UpgradeSearch     JobType = "UpgradeSearch"
 )
 
 const jobCancelTimeout = 30 * time.Second
@@ -74,7 +76,7 @@ func (s *Scheduler) runPruning(ctx context.Context) {
 func (s *Scheduler) pruneOldJobs(ctx context.Context) {
 	log := logger.FromCtx(ctx)
 
-	jobTypes := []JobType{MovieIndex, MovieReconcile, SeriesIndex, SeriesReconcile, IndexerSync}
+	jobTypes := []JobType{MovieIndex, MovieReconcile, SeriesIndex, SeriesReconcile, IndexerSync, RecycleBinCleanup, UpgradeSearch}
 	totalDeleted := int64(0)
 
 	for _, jobType := range jobTypes {
@@ -138,7 +140,7 @@ func (s *Scheduler) runJobScheduling(ctx context.Context) error {
 	ticker := time.NewTicker(s.config.Jobs.JobScheduleInterval)
 	defer ticker.Stop()
 
-	jobTypes := []JobType{MovieIndex, MovieReconcile, SeriesIndex, SeriesReconcile, IndexerSync}
+	jobTypes := []JobType{MovieIndex, MovieReconcile, SeriesIndex, SeriesReconcile, IndexerSync, RecycleBinCleanup, UpgradeSearch}
 
 	for {
 		select {
@@ -241,6 +243,10 @@ func (s *Scheduler) getIntervalForJobType(jobType JobType) time.Duration {
 		return s.config.Jobs.SeriesReconcile
 	case IndexerSync:
 		return s.config.Jobs.IndexerSync
+	case RecycleBinCleanup:
+		return s.config.Jobs.RecycleBinCleanup
+	case UpgradeSearch:
+		return s.config.Jobs.UpgradeSearch
 	default:
 		return 10 * time.Minute
 	}
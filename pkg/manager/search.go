@@ -8,6 +8,8 @@ import (
 	"github.com/kasuboski/mediaz/pkg/indexer"
 	"github.com/kasuboski/mediaz/pkg/logger"
 	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
 	"go.uber.org/zap"
@@ -55,6 +57,51 @@ func (m MediaManager) executeSearch(ctx context.Context, snapshot *ReconcileSnap
 	return releases, nil
 }
 
+// movieSearchOptions searches by the movie's external ids, falling back to the title on indexers without id support
+func movieSearchOptions(metadata *model.MovieMetadata) indexer.SearchOptions {
+	opts := indexer.SearchOptions{
+		Query: metadata.Title,
+		Type:  ptr.To(indexer.TypeMovie),
+	}
+	if metadata.TmdbID > 0 {
+		opts.TmdbID = ptr.To(metadata.TmdbID)
+	}
+	if metadata.ImdbID != nil && *metadata.ImdbID != "" {
+		opts.ImdbID = metadata.ImdbID
+	}
+
+	return opts
+}
+
+// seriesSearchOptions searches by the series' external ids, falling back to the title on indexers without id support
+func seriesSearchOptions(ctx context.Context, metadata *model.SeriesMetadata) indexer.SearchOptions {
+	opts := indexer.SearchOptions{
+		Query: metadata.Title,
+		Type:  ptr.To(indexer.TypeTV),
+	}
+	if metadata.TmdbID > 0 {
+		opts.TmdbID = ptr.To(metadata.TmdbID)
+	}
+
+	externalIDs, err := DeserializeExternalIDs(metadata.ExternalIds)
+	if err != nil {
+		logger.FromCtx(ctx).Warn("failed to read series external ids", zap.Int32("series_metadata_id", metadata.ID), zap.Error(err))
+		return opts
+	}
+	if externalIDs == nil {
+		return opts
+	}
+
+	if externalIDs.ImdbID != nil && *externalIDs.ImdbID != "" {
+		opts.ImdbID = externalIDs.ImdbID
+	}
+	if externalIDs.TvdbID != nil && *externalIDs.TvdbID > 0 {
+		opts.TvdbID = ptr.To(int32(*externalIDs.TvdbID))
+	}
+
+	return opts
+}
+
 func (m MediaManager) SearchForMovie(ctx context.Context, movieID int64) error {
 	log := logger.FromCtx(ctx).With("movie_id", movieID)
 	log.Debug("starting manual search for movie")
@@ -95,6 +142,59 @@ func (m MediaManager) SearchForMovie(ctx context.Context, movieID int64) error {
 	return nil
 }
 
+// ListMovieReleases searches the indexers for a movie and returns the releases that could be grabbed, best first. With
+// includeRejected the rejected releases follow them with the reason they were rejected.
+func (m MediaManager) ListMovieReleases(ctx context.Context, movieID int64, includeRejected bool) ([]RankedRelease, error) {
+	log := logger.FromCtx(ctx).With("movie_id", movieID)
+
+	movie, err := m.movieStorage.GetMovie(ctx, movieID)
+	if err != nil {
+		log.Error("failed to get movie", zap.Error(err))
+		return nil, fmt.Errorf("movie not found: %w", err)
+	}
+
+	if movie.MovieMetadataID == nil {
+		log.Error("movie has no metadata ID")
+		return nil, fmt.Errorf("movie has no metadata")
+	}
+
+	if movie.QualityProfileID == 0 {
+		log.Warn("movie quality profile id is nil, skipping search")
+		return nil, fmt.Errorf("movie has no quality profile")
+	}
+
+	det, err := m.movieMetaStorage.GetMovieMetadata(ctx, table.MovieMetadata.ID.EQ(sqlite.Int32(*movie.MovieMetadataID)))
+	if err != nil {
+		log.Error("failed to get movie metadata", zap.Error(err))
+		return nil, fmt.Errorf("failed to get movie metadata: %w", err)
+	}
+
+	profile, err := m.GetQualityProfile(ctx, int64(movie.QualityProfileID))
+	if err != nil {
+		log.Error("failed to get quality profile", zap.Error(err))
+		return nil, fmt.Errorf("failed to get quality profile: %w", err)
+	}
+
+	snapshot, err := m.prepareSearchSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := m.executeSearch(ctx, snapshot, MovieCategories, movieSearchOptions(det))
+	if err != nil {
+		return nil, err
+	}
+
+	ranked, rejected, err := m.movieReleases(ctx, movie, det, profile, snapshot, releases)
+	if err != nil {
+		return nil, err
+	}
+	if includeRejected {
+		ranked = append(ranked, rejected...)
+	}
+	return ranked, nil
+}
+
 func (m MediaManager) SearchForSeries(ctx context.Context, seriesID int64) error {
 	log := logger.FromCtx(ctx).With("series_id", seriesID)
 	log.Debug("starting manual search for series")
@@ -202,17 +302,15 @@ func (m MediaManager) SearchForSeason(ctx context.Context, seasonID int64) error
 		return fmt.Errorf("series has no title")
 	}
 
-	searchType := indexer.TypeTV
-	releases, err := m.executeSearch(ctx, snapshot, TVCategories, indexer.SearchOptions{
-		Query:  seriesMetadata.Title,
-		Season: &season.SeasonNumber,
-		Type:   &searchType,
-	})
+	opts := seriesSearchOptions(ctx, seriesMetadata)
+	opts.Season = &season.SeasonNumber
+	releases, err := m.executeSearch(ctx, snapshot, TVCategories, opts)
 	if err != nil {
 		return err
 	}
 
-	err = m.reconcileMissingSeason(ctx, seriesMetadata.Title, season, snapshot, qualityProfile, releases)
+	target := m.newReleaseSeries(ctx, series, seriesMetadata)
+	err = m.reconcileMissingSeason(ctx, target, season, snapshot, qualityProfile, releases)
 	if err != nil {
 		log.Error("failed to reconcile season", zap.Error(err))
 		return err
@@ -226,78 +324,151 @@ func (m MediaManager) SearchForEpisode(ctx context.Context, episodeID int64) err
 	log := logger.FromCtx(ctx).With("episode_id", episodeID)
 	log.Debug("starting manual search for episode")
 
+	search, err := m.searchEpisode(ctx, episodeID, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.reconcileMissingEpisode(ctx, search.series, search.season.SeasonNumber, search.episode, search.snapshot, search.qualityProfile, search.releases)
+	if err != nil {
+		log.Error("failed to reconcile episode", zap.Error(err))
+		return err
+	}
+
+	log.Debug("manual search completed for episode")
+	return nil
+}
+
+// ListEpisodeReleases searches the indexers for an episode and returns the releases that could be grabbed, best first.
+// With includeRejected the rejected releases follow them with the reason they were rejected.
+func (m MediaManager) ListEpisodeReleases(ctx context.Context, episodeID int64, includeRejected bool) ([]RankedRelease, error) {
+	log := logger.FromCtx(ctx).With("episode_id", episodeID)
+
+	search, err := m.searchEpisode(ctx, episodeID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.episode.EpisodeMetadataID == nil {
+		log.Error("episode has no metadata ID")
+		return nil, fmt.Errorf("episode has no metadata")
+	}
+
+	episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*search.episode.EpisodeMetadataID)))
+	if err != nil {
+		log.Error("failed to get episode metadata", zap.Error(err))
+		return nil, fmt.Errorf("failed to get episode metadata: %w", err)
+	}
+
+	ranked, rejected, err := m.episodeReleases(ctx, search.series, search.season.SeasonNumber, search.episode, episodeMetadata, search.snapshot, search.qualityProfile, search.releases)
+	if err != nil {
+		return nil, err
+	}
+	if includeRejected {
+		ranked = append(ranked, rejected...)
+	}
+	return ranked, nil
+}
+
+// episodeSearch is everything needed to pick a release for an episode
+type episodeSearch struct {
+	series         releaseSeries
+	season         *storage.Season
+	episode        *storage.Episode
+	snapshot       *ReconcileSnapshot
+	qualityProfile storage.QualityProfile
+	releases       []*prowlarr.ReleaseResource
+}
+
+// searchEpisode looks up the episode's series and quality profile and searches the indexers for it
+func (m MediaManager) searchEpisode(ctx context.Context, episodeID int64, requireMonitored bool) (*episodeSearch, error) {
+	log := logger.FromCtx(ctx).With("episode_id", episodeID)
+
 	episode, err := m.seriesStorage.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int32(int32(episodeID))))
 	if err != nil {
 		log.Error("failed to get episode", zap.Error(err))
-		return fmt.Errorf("episode not found: %w", err)
+		return nil, fmt.Errorf("episode not found: %w", err)
 	}
 
-	if episode.Monitored == 0 {
+	if requireMonitored && episode.Monitored == 0 {
 		log.Debug("episode is not monitored, cannot search")
-		return fmt.Errorf("episode is not monitored")
+		return nil, fmt.Errorf("episode is not monitored")
 	}
 
 	season, err := m.seriesStorage.GetSeason(ctx, table.Season.ID.EQ(sqlite.Int32(episode.SeasonID)))
 	if err != nil {
 		log.Error("failed to get season for episode", zap.Error(err))
-		return fmt.Errorf("season not found: %w", err)
+		return nil, fmt.Errorf("season not found: %w", err)
 	}
 
 	snapshot, err := m.prepareSearchSnapshot(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	series, err := m.seriesStorage.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int32(season.SeriesID)))
 	if err != nil {
 		log.Error("failed to get series for episode", zap.Error(err))
-		return fmt.Errorf("failed to get series: %w", err)
+		return nil, fmt.Errorf("failed to get series: %w", err)
 	}
 
 	if series.QualityProfileID == 0 {
 		log.Warn("series quality profile id is nil, skipping search")
-		return fmt.Errorf("series has no quality profile")
+		return nil, fmt.Errorf("series has no quality profile")
 	}
 
 	qualityProfile, err := m.GetQualityProfile(ctx, int64(series.QualityProfileID))
 	if err != nil {
 		log.Error("failed to get quality profile", zap.Error(err))
-		return fmt.Errorf("failed to get quality profile: %w", err)
+		return nil, fmt.Errorf("failed to get quality profile: %w", err)
 	}
 
 	if series.SeriesMetadataID == nil {
 		log.Error("series has no metadata ID")
-		return fmt.Errorf("series has no metadata")
+		return nil, fmt.Errorf("series has no metadata")
 	}
 
 	seriesMetadata, err := m.seriesMetaStorage.GetSeriesMetadata(ctx, table.SeriesMetadata.ID.EQ(sqlite.Int32(*series.SeriesMetadataID)))
 	if err != nil {
 		log.Error("failed to get series metadata", zap.Error(err))
-		return fmt.Errorf("failed to get series metadata: %w", err)
+		return nil, fmt.Errorf("failed to get series metadata: %w", err)
 	}
 
 	if seriesMetadata.Title == "" {
 		log.Error("series metadata has empty title")
-		return fmt.Errorf("series has no title")
+		return nil, fmt.Errorf("series has no title")
 	}
 
-	searchType := indexer.TypeTV
-	releases, err := m.executeSearch(ctx, snapshot, TVCategories, indexer.SearchOptions{
-		Query:   seriesMetadata.Title,
-		Season:  &season.SeasonNumber,
-		Episode: &episode.EpisodeNumber,
-		Type:    &searchType,
-	})
-	if err != nil {
-		return err
-	}
+	target := m.newReleaseSeries(ctx, series, seriesMetadata)
 
-	_, err = m.reconcileMissingEpisode(ctx, seriesMetadata.Title, season.SeasonNumber, episode, snapshot, qualityProfile, releases)
+	opts := seriesSearchOptions(ctx, seriesMetadata)
+	opts.Season = &season.SeasonNumber
+	opts.Episode = &episode.EpisodeNumber
+	if episode.EpisodeMetadataID != nil {
+		if absolute, ok := target.numbering.absolute(*episode.EpisodeMetadataID); ok {
+			opts.AbsoluteEpisode = &absolute
+		}
+
+		if target.daily() {
+			episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*episode.EpisodeMetadataID)))
+			if err != nil {
+				log.Warn("failed to get episode metadata for air date search", zap.Error(err))
+			} else {
+				opts.AirDate = episodeMetadata.AirDate
+			}
+		}
+	}
+	releases, err := m.executeSearch(ctx, snapshot, TVCategories, opts)
 	if err != nil {
-		log.Error("failed to reconcile episode", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	log.Debug("manual search completed for episode")
-	return nil
+	return &episodeSearch{
+		series:         target,
+		season:         season,
+		episode:        episode,
+		snapshot:       snapshot,
+		qualityProfile: qualityProfile,
+		releases:       releases,
+	}, nil
 }
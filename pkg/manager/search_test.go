@@ -10,6 +10,7 @@ import (
 	downloadMock "github.com/kasuboski/mediaz/pkg/download/mocks"
 	"github.com/kasuboski/mediaz/pkg/indexer"
 	indexerMock "github.com/kasuboski/mediaz/pkg/indexer/mocks"
+	"github.com/kasuboski/mediaz/pkg/ptr"
 	"github.com/kasuboski/mediaz/pkg/storage"
 	storageMocks "github.com/kasuboski/mediaz/pkg/storage/mocks"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
@@ -99,6 +100,40 @@ func TestMediaManager_SearchForMovie(t *testing.T) {
 	})
 }
 
+func TestMediaManager_ListMovieReleases(t *testing.T) {
+	t.Run("movie not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := storageMocks.NewMockStorage(ctrl)
+		store.EXPECT().GetMovie(gomock.Any(), int64(1)).Return(nil, storage.ErrNotFound)
+
+		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		_, err := m.ListMovieReleases(context.Background(), 1, false)
+
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("movie without quality profile", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := storageMocks.NewMockStorage(ctrl)
+		store.EXPECT().GetMovie(gomock.Any(), int64(1)).Return(&storage.Movie{
+			Movie: model.Movie{
+				ID:              1,
+				MovieMetadataID: ptr.To(int32(1)),
+			},
+		}, nil)
+
+		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		_, err := m.ListMovieReleases(context.Background(), 1, false)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no quality profile")
+	})
+}
+
 func TestMediaManager_SearchForSeries(t *testing.T) {
 	t.Run("series not found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -262,6 +297,8 @@ func TestMediaManager_SearchForSeason(t *testing.T) {
 		store.EXPECT().GetIndexerSource(gomock.Any(), int64(1)).Return(model.IndexerSource{
 			ID: 1, Name: "test-source", Scheme: "http", Host: "test", Enabled: true,
 		}, nil)
+		store.EXPECT().ListIndexerCategories(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		store.EXPECT().UpsertIndexerCategories(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		indexerFactory.EXPECT().NewIndexerSource(gomock.Any()).Return(mockIndexerSource, nil)
 		mockIndexerSource.EXPECT().ListIndexers(gomock.Any()).Return([]indexer.SourceIndexer{
 			{ID: 1, Name: "test-indexer", Priority: 1},
@@ -407,6 +444,8 @@ func TestMediaManager_prepareSearchSnapshot(t *testing.T) {
 		store.EXPECT().GetIndexerSource(gomock.Any(), int64(1)).Return(model.IndexerSource{
 			ID: 1, Name: "test-source", Scheme: "http", Host: "test", Enabled: true,
 		}, nil)
+		store.EXPECT().ListIndexerCategories(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		store.EXPECT().UpsertIndexerCategories(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		indexerFactory.EXPECT().NewIndexerSource(gomock.Any()).Return(mockIndexerSource, nil)
 		mockIndexerSource.EXPECT().ListIndexers(gomock.Any()).Return([]indexer.SourceIndexer{
 			{ID: 1, Name: "test-indexer", Priority: 1},
@@ -422,3 +461,47 @@ func TestMediaManager_prepareSearchSnapshot(t *testing.T) {
 		assert.Equal(t, []int32{1}, snapshot.GetIndexerIDs())
 	})
 }
+
+func TestMovieSearchOptions(t *testing.T) {
+	t.Run("includes ids", func(t *testing.T) {
+		got := movieSearchOptions(&model.MovieMetadata{TmdbID: 278, ImdbID: ptr.To("tt0111161"), Title: "The Shawshank Redemption"})
+		assert.Equal(t, indexer.SearchOptions{
+			Query:  "The Shawshank Redemption",
+			Type:   ptr.To(indexer.TypeMovie),
+			TmdbID: ptr.To(int32(278)),
+			ImdbID: ptr.To("tt0111161"),
+		}, got)
+	})
+
+	t.Run("skips missing ids", func(t *testing.T) {
+		got := movieSearchOptions(&model.MovieMetadata{ImdbID: ptr.To(""), Title: "Movie"})
+		assert.Equal(t, indexer.SearchOptions{Query: "Movie", Type: ptr.To(indexer.TypeMovie)}, got)
+	})
+}
+
+func TestSeriesSearchOptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("includes external ids", func(t *testing.T) {
+		externalIDs, err := SerializeExternalIDs(&ExternalIDsData{ImdbID: ptr.To("tt0903747"), TvdbID: ptr.To(81189)})
+		require.NoError(t, err)
+
+		got := seriesSearchOptions(ctx, &model.SeriesMetadata{TmdbID: 1396, Title: "Breaking Bad", ExternalIds: externalIDs})
+		assert.Equal(t, indexer.SearchOptions{
+			Query:  "Breaking Bad",
+			Type:   ptr.To(indexer.TypeTV),
+			TmdbID: ptr.To(int32(1396)),
+			ImdbID: ptr.To("tt0903747"),
+			TvdbID: ptr.To(int32(81189)),
+		}, got)
+	})
+
+	t.Run("ignores invalid external ids", func(t *testing.T) {
+		got := seriesSearchOptions(ctx, &model.SeriesMetadata{TmdbID: 1396, Title: "Breaking Bad", ExternalIds: ptr.To("not json")})
+		assert.Equal(t, indexer.SearchOptions{
+			Query:  "Breaking Bad",
+			Type:   ptr.To(indexer.TypeTV),
+			TmdbID: ptr.To(int32(1396)),
+		}, got)
+	})
+}
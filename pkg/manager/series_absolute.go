@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+)
+
+type seasonEpisode struct {
+	season  int32
+	episode int32
+}
+
+// absoluteNumbering maps between the absolute episode numbers anime releases use and TMDB season and episode numbers.
+// Episodes are numbered in season order starting at 1, specials aren't numbered.
+type absoluteNumbering struct {
+	byEpisodeMetadataID map[int32]int32
+	byAbsolute          map[int32]seasonEpisode
+}
+
+func newAbsoluteNumbering(seasons []*model.SeasonMetadata, episodes []*model.EpisodeMetadata) *absoluteNumbering {
+	numbering := &absoluteNumbering{
+		byEpisodeMetadataID: make(map[int32]int32),
+		byAbsolute:          make(map[int32]seasonEpisode),
+	}
+
+	seasons = slices.Clone(seasons)
+	slices.SortFunc(seasons, func(a, b *model.SeasonMetadata) int {
+		return int(a.Number - b.Number)
+	})
+
+	episodesBySeason := make(map[int32][]*model.EpisodeMetadata)
+	for _, e := range episodes {
+		if e == nil {
+			continue
+		}
+		episodesBySeason[e.SeasonMetadataID] = append(episodesBySeason[e.SeasonMetadataID], e)
+	}
+
+	var absolute int32
+	for _, season := range seasons {
+		if season == nil || season.Number == 0 {
+			continue
+		}
+
+		seasonEpisodes := episodesBySeason[season.ID]
+		slices.SortFunc(seasonEpisodes, func(a, b *model.EpisodeMetadata) int {
+			return int(a.Number - b.Number)
+		})
+
+		for _, e := range seasonEpisodes {
+			absolute++
+			numbering.byEpisodeMetadataID[e.ID] = absolute
+			numbering.byAbsolute[absolute] = seasonEpisode{season: season.Number, episode: e.Number}
+		}
+	}
+
+	return numbering
+}
+
+// absolute returns the absolute number of the given episode
+func (n *absoluteNumbering) absolute(episodeMetadataID int32) (int32, bool) {
+	if n == nil {
+		return 0, false
+	}
+	absolute, ok := n.byEpisodeMetadataID[episodeMetadataID]
+	return absolute, ok
+}
+
+// episode returns the season and episode number for an absolute number
+func (n *absoluteNumbering) episode(absolute int32) (seasonEpisode, bool) {
+	if n == nil {
+		return seasonEpisode{}, false
+	}
+	se, ok := n.byAbsolute[absolute]
+	return se, ok
+}
+
+// seriesAbsoluteNumbering loads the absolute numbering for anime series. It returns nil for standard series.
+func (m MediaManager) seriesAbsoluteNumbering(ctx context.Context, series *storage.Series) (*absoluteNumbering, error) {
+	if series == nil || series.SeriesType != storage.SeriesTypeAnime || series.SeriesMetadataID == nil {
+		return nil, nil
+	}
+
+	seasons, err := m.seriesMetaStorage.ListSeasonMetadata(ctx, table.SeasonMetadata.SeriesMetadataID.EQ(sqlite.Int32(*series.SeriesMetadataID)))
+	if err != nil {
+		return nil, err
+	}
+
+	seasonIDs := make([]sqlite.Expression, 0, len(seasons))
+	for _, s := range seasons {
+		seasonIDs = append(seasonIDs, sqlite.Int32(s.ID))
+	}
+	if len(seasonIDs) == 0 {
+		return newAbsoluteNumbering(nil, nil), nil
+	}
+
+	episodes, err := m.seriesMetaStorage.ListEpisodeMetadata(ctx, table.EpisodeMetadata.SeasonMetadataID.IN(seasonIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	return newAbsoluteNumbering(seasons, episodes), nil
+}
@@ -0,0 +1,45 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAbsoluteNumbering(t *testing.T) {
+	seasons := []*model.SeasonMetadata{
+		{ID: 2, Number: 2},
+		{ID: 0, Number: 0},
+		{ID: 1, Number: 1},
+	}
+	episodes := []*model.EpisodeMetadata{
+		{ID: 20, SeasonMetadataID: 2, Number: 1},
+		{ID: 12, SeasonMetadataID: 1, Number: 2},
+		{ID: 11, SeasonMetadataID: 1, Number: 1},
+		{ID: 1, SeasonMetadataID: 0, Number: 1},
+		{ID: 21, SeasonMetadataID: 2, Number: 2},
+	}
+
+	numbering := newAbsoluteNumbering(seasons, episodes)
+
+	for episodeMetadataID, want := range map[int32]int32{11: 1, 12: 2, 20: 3, 21: 4} {
+		got, ok := numbering.absolute(episodeMetadataID)
+		assert.True(t, ok)
+		assert.Equal(t, want, got, "episode metadata %d", episodeMetadataID)
+	}
+
+	_, ok := numbering.absolute(1)
+	assert.False(t, ok, "specials aren't numbered")
+
+	se, ok := numbering.episode(3)
+	assert.True(t, ok)
+	assert.Equal(t, seasonEpisode{season: 2, episode: 1}, se)
+
+	_, ok = numbering.episode(5)
+	assert.False(t, ok)
+
+	var standard *absoluteNumbering
+	_, ok = standard.absolute(11)
+	assert.False(t, ok)
+}
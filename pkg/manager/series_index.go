@@ -47,6 +47,8 @@ func (m MediaManager) IndexSeriesLibrary(ctx context.Context) error {
 		return err
 	}
 
+	m.identifyEpisodeFileQualities(ctx, episodeFiles)
+
 	for _, f := range episodeFiles {
 		if f == nil || f.RelativePath == nil {
 			continue
@@ -234,6 +236,7 @@ func (m *MediaManager) upsertEpisodeFile(ctx context.Context, discoveredFile lib
 
 	if matchedID == 0 {
 		ef := modelEpisodeFile(discoveredFile)
+		ef.Quality = m.fileQuality(ctx, "episode", &discoveredFile.RelativePath)
 		log.Debug("creating new episode file", zap.Int64("size", discoveredFile.Size))
 		_, err := m.seriesStorage.CreateEpisodeFile(ctx, ef)
 		if err != nil {
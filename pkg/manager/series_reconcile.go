@@ -1,14 +1,17 @@
 package manager
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"path/filepath"
 	"slices"
+	"time"
 
 	"github.com/go-jet/jet/v2/sqlite"
 	"github.com/kasuboski/mediaz/pkg/download"
-	"github.com/kasuboski/mediaz/pkg/indexer"
 	"github.com/kasuboski/mediaz/pkg/io"
 	"github.com/kasuboski/mediaz/pkg/library"
 	"github.com/kasuboski/mediaz/pkg/logger"
@@ -68,6 +71,9 @@ func (m MediaManager) ReconcileSeries(ctx context.Context) error {
 		allErrors = errors.Join(allErrors, err)
 	}
 
+	// episodes refused for disk space keep their state, the reconcile fails so it's noticed
+	allErrors = errors.Join(allErrors, snapshot.DiskSpaceError())
+
 	return allErrors
 }
 
@@ -91,7 +97,7 @@ func (m MediaManager) ReconcileMissingSeries(ctx context.Context, snapshot *Reco
 		return nil
 	}
 
-	snapshot = newReconcileSnapshot(indexers, snapshot.GetDownloadClients())
+	snapshot = snapshot.withIndexers(indexers)
 
 	where := table.SeriesTransition.ToState.EQ(sqlite.String(string(storage.SeriesStateMissing))).
 		AND(table.SeriesTransition.MostRecent.EQ(sqlite.Bool(true))).
@@ -362,10 +368,7 @@ func (m MediaManager) reconcileMissingSeries(ctx context.Context, series *storag
 		return err
 	}
 
-	releases, err := m.indexerService.SearchIndexers(ctx, snapshot.GetIndexerIDs(), TVCategories, indexer.SearchOptions{
-		Query: seriesMetadata.Title,
-		Type:  ptr.To(indexer.TypeTV),
-	})
+	releases, err := m.indexerService.SearchIndexers(ctx, snapshot.GetIndexerIDs(), TVCategories, seriesSearchOptions(ctx, seriesMetadata))
 	if err != nil {
 		log.Warn("some indexer sources failed during series search", zap.Int32s("indexers", snapshot.GetIndexerIDs()), zap.Error(err))
 		if len(releases) == 0 {
@@ -373,8 +376,6 @@ func (m MediaManager) reconcileMissingSeries(ctx context.Context, series *storag
 		}
 	}
 
-	slices.SortFunc(releases, sortReleaseFunc())
-
 	where := table.Season.SeriesID.EQ(sqlite.Int32(series.ID)).
 		AND(table.Season.Monitored.EQ(sqlite.Int(1))).
 		AND(table.SeasonTransition.ToState.EQ(sqlite.String(string(storage.SeasonStateMissing))))
@@ -424,9 +425,10 @@ func (m MediaManager) reconcileMissingSeries(ctx context.Context, series *storag
 		return nil
 	}
 
+	target := m.newReleaseSeries(ctx, series, seriesMetadata)
 	for _, s := range seasons {
 		log.Debug("reconciling season", zap.Any("season", s.ID))
-		err = m.reconcileMissingSeason(ctx, seriesMetadata.Title, s, snapshot, qualityProfile, releases)
+		err = m.reconcileMissingSeason(ctx, target, s, snapshot, qualityProfile, releases)
 		if err != nil {
 			log.Error("failed to reconcile missing season", zap.Error(err))
 			continue
@@ -437,7 +439,7 @@ func (m MediaManager) reconcileMissingSeries(ctx context.Context, series *storag
 	return nil
 }
 
-func (m MediaManager) reconcileMissingSeason(ctx context.Context, seriesTitle string, season *storage.Season, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) error {
+func (m MediaManager) reconcileMissingSeason(ctx context.Context, series releaseSeries, season *storage.Season, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) error {
 	log := logger.FromCtx(ctx)
 	log = log.With("reconcile loop", "missing series")
 
@@ -485,7 +487,7 @@ func (m MediaManager) reconcileMissingSeason(ctx context.Context, seriesTitle st
 	log.Debug("found missing episodes", zap.Int("count", len(missingEpisodes)))
 
 	if !allMissing {
-		return m.reconcileMissingEpisodes(ctx, seriesTitle, season.ID, metadata.Number, missingEpisodes, snapshot, qualityProfile, releases)
+		return m.reconcileMissingEpisodes(ctx, series, season.ID, metadata.Number, missingEpisodes, snapshot, qualityProfile, releases)
 	}
 
 	// If all missing episodes are unreleased, revert season state to continuing
@@ -512,29 +514,43 @@ func (m MediaManager) reconcileMissingSeason(ctx context.Context, seriesTitle st
 		}
 	}
 
+	blocklist, err := m.blocklistService.listEpisodesBlocklist(ctx, missingEpisodes)
+	if err != nil {
+		log.Warn("failed to list blocklisted releases", zap.Error(err))
+		return err
+	}
+
 	runtime := getSeasonRuntime(missingEpisodesMetadata, len(episodes))
 	log.Debug("considering releases for season pack", zap.Int("count", len(releases)))
 
-	var chosenSeasonPackRelease *prowlarr.ReleaseResource
 	seasonParams := SeriesReleaseFilterParams{
-		Title:        seriesTitle,
-		SeasonNumber: metadata.Number,
-		Runtime:      runtime,
+		Title:            series.title,
+		SeasonNumber:     metadata.Number,
+		Runtime:          runtime,
+		OriginalLanguage: series.originalLanguage,
+		Filters:          m.configs.Releases,
+		Blocklist:        blocklist,
+	}
+	accepted := slices.DeleteFunc(slices.Clone(releases), RejectSeasonReleaseFunc(ctx, seasonParams, qualityProfile, snapshot.GetProtocols()))
+	ranked := newReleaseRanker(qualityProfile, runtime, snapshot.GetIndexers()).
+		withOriginalLanguage(series.originalLanguage).
+		withPreferredProtocol(m.delayProfile(qualityProfile).PreferredProtocol).
+		rank(accepted)
+
+	if len(ranked) == 0 {
+		log.Debug("no season pack releases found, defaulting to individual episodes")
+		m.clearPendingReleases(ctx, seasonPendingTarget(season.ID))
+		return m.reconcileMissingEpisodes(ctx, series, season.ID, metadata.Number, missingEpisodes, snapshot, qualityProfile, releases)
 	}
-	for _, r := range releases {
-		if RejectSeasonReleaseFunc(ctx, seasonParams, qualityProfile, snapshot.GetProtocols())(r) {
-			continue
-		}
 
-		chosenSeasonPackRelease = r
-		break
+	chosenSeasonPackRelease, err := m.delayRelease(ctx, seasonPendingTarget(season.ID), qualityProfile, ranked, snapshot.time)
+	if err != nil {
+		return err
 	}
-
 	if chosenSeasonPackRelease == nil {
-		log.Debug("no season pack releases found, defaulting to individual episodes")
-		return m.reconcileMissingEpisodes(ctx, seriesTitle, season.ID, metadata.Number, missingEpisodes, snapshot, qualityProfile, releases)
+		log.Debug("waiting out the delay profile before grabbing a season pack")
+		return nil
 	}
-
 	log.Info("found season pack release", zap.Any("title", chosenSeasonPackRelease.Title), zap.String("proto", string(*chosenSeasonPackRelease.Protocol)))
 
 	clientID, status, err := m.requestReleaseDownload(ctx, snapshot, chosenSeasonPackRelease)
@@ -545,11 +561,12 @@ func (m MediaManager) reconcileMissingSeason(ctx context.Context, seriesTitle st
 
 	var allUpdated = true
 	for _, e := range missingEpisodes {
-		err = m.updateEpisodeState(ctx, *e, storage.EpisodeStateDownloading, &storage.TransitionStateMetadata{
-			DownloadID:             &status.ID,
-			DownloadClientID:       &clientID,
-			IsEntireSeasonDownload: ptr.To(true),
-		})
+		metadata := releaseTransitionMetadata(chosenSeasonPackRelease)
+		metadata.DownloadID = &status.ID
+		metadata.DownloadClientID = &clientID
+		metadata.IsEntireSeasonDownload = ptr.To(true)
+
+		err = m.updateEpisodeState(ctx, *e, storage.EpisodeStateDownloading, &metadata)
 		if err != nil {
 			allUpdated = false
 			log.Error("failed to update episode state in seasons pack", zap.Error(err))
@@ -570,15 +587,26 @@ func (m MediaManager) reconcileMissingSeason(ctx context.Context, seriesTitle st
 	return nil
 }
 
-func (m MediaManager) reconcileMissingEpisodes(ctx context.Context, seriesTitle string, seasonID int32, seasonNumber int32, episode []*storage.Episode, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) error {
+func (m MediaManager) reconcileMissingEpisodes(ctx context.Context, series releaseSeries, seasonID int32, seasonNumber int32, episode []*storage.Episode, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) error {
 	log := logger.FromCtx(ctx)
 
 	var allUpdated = true
+	// multi-episode releases move the other episodes they cover to downloading too
+	covered := make(map[int32]struct{})
 	for _, e := range episode {
-		updated, err := m.reconcileMissingEpisode(ctx, seriesTitle, seasonNumber, e, snapshot, qualityProfile, releases)
-		if !updated {
+		if e != nil {
+			if _, ok := covered[e.ID]; ok {
+				continue
+			}
+		}
+
+		downloading, err := m.reconcileMissingEpisode(ctx, series, seasonNumber, e, snapshot, qualityProfile, releases)
+		if len(downloading) == 0 {
 			allUpdated = false
 		}
+		for _, id := range downloading {
+			covered[id] = struct{}{}
+		}
 		if err != nil {
 			log.Error("failed to reconcile missing episode", zap.Error(err))
 			continue
@@ -592,52 +620,95 @@ func (m MediaManager) reconcileMissingEpisodes(ctx context.Context, seriesTitle
 	return m.updateSeasonState(ctx, int64(seasonID), storage.SeasonStateDownloading, nil)
 }
 
-func (m MediaManager) reconcileMissingEpisode(ctx context.Context, seriesTitle string, seasonNumber int32, episode *storage.Episode, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) (bool, error) {
+// episodeReleases filters the releases down to those that can be grabbed for the episode and ranks them, best first.
+// The rejected releases are returned with the reason they were rejected.
+func (m MediaManager) episodeReleases(ctx context.Context, series releaseSeries, seasonNumber int32, episode *storage.Episode, episodeMetadata *model.EpisodeMetadata, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) ([]RankedRelease, []RankedRelease, error) {
+	log := logger.FromCtx(ctx)
+
+	var runtime int32
+	if episodeMetadata.Runtime != nil {
+		runtime = *episodeMetadata.Runtime
+	}
+
+	blocklist, err := m.blocklistService.listEpisodesBlocklist(ctx, []*storage.Episode{episode})
+	if err != nil {
+		log.Warn("failed to list blocklisted releases", zap.Error(err))
+		return nil, nil, err
+	}
+
+	episodeParams := SeriesReleaseFilterParams{
+		Title:            series.title,
+		SeasonNumber:     seasonNumber,
+		EpisodeNumber:    episodeMetadata.Number,
+		Runtime:          runtime,
+		OriginalLanguage: series.originalLanguage,
+		Filters:          m.configs.Releases,
+		Blocklist:        blocklist,
+	}
+	if absolute, ok := series.numbering.absolute(episodeMetadata.ID); ok {
+		episodeParams.AbsoluteEpisodeNumber = &absolute
+	}
+	if series.daily() {
+		episodeParams.AirDate = episodeMetadata.AirDate
+	}
+	accepted, rejected := filterReleases(ctx, releases, episodeReleaseRejection(ctx, episodeParams, qualityProfile, snapshot.GetProtocols()))
+	ranked := newReleaseRanker(qualityProfile, runtime, snapshot.GetIndexers()).
+		withRuntime(multiEpisodeRuntime(runtime)).
+		withOriginalLanguage(series.originalLanguage).
+		withPreferredProtocol(m.delayProfile(qualityProfile).PreferredProtocol).
+		rank(accepted)
+	return ranked, rejected, nil
+}
+
+// reconcileMissingEpisode searches releases for a missing episode and starts a download. It returns the ids of the
+// episodes moved to downloading, which includes the other episodes a multi-episode release covers.
+func (m MediaManager) reconcileMissingEpisode(ctx context.Context, series releaseSeries, seasonNumber int32, episode *storage.Episode, snapshot *ReconcileSnapshot, qualityProfile storage.QualityProfile, releases []*prowlarr.ReleaseResource) ([]int32, error) {
 	log := logger.FromCtx(ctx)
 
 	if episode == nil {
 		log.Warn("episode is nil, skipping reconcile")
-		return false, fmt.Errorf("episode is nil")
+		return nil, fmt.Errorf("episode is nil")
 	}
 
 	if snapshot == nil {
 		log.Warn("snapshot is nil, skipping reconcile")
-		return false, nil
+		return nil, nil
 	}
 
 	episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*episode.EpisodeMetadataID)))
 	if err != nil {
 		log.Debug("failed to find episode metadata", zap.Int32("meta_id", *episode.EpisodeMetadataID))
-		return false, err
+		return nil, err
 	}
 
 	if !isReleased(snapshot.time, episodeMetadata.AirDate) {
 		log.Debug("episode is not yet released", zap.Any("air_date", episodeMetadata.AirDate))
-		return false, nil
+		return nil, nil
 	}
 
 	if episodeMetadata.Runtime == nil {
 		log.Warn("episode runtime is nil, skipping reconcile")
-		return false, nil
+		return nil, nil
 	}
 
-	var chosenRelease *prowlarr.ReleaseResource
-	episodeParams := SeriesReleaseFilterParams{
-		Title:         seriesTitle,
-		SeasonNumber:  seasonNumber,
-		EpisodeNumber: episodeMetadata.Number,
-		Runtime:       *episodeMetadata.Runtime,
+	ranked, _, err := m.episodeReleases(ctx, series, seasonNumber, episode, episodeMetadata, snapshot, qualityProfile, releases)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range releases {
-		if RejectEpisodeReleaseFunc(ctx, episodeParams, qualityProfile, snapshot.GetProtocols())(r) {
-			continue
-		}
-		chosenRelease = r
+
+	if len(ranked) == 0 {
+		log.Debug("no valid releases found for episode, skipping reconcile")
+		m.clearPendingReleases(ctx, episodePendingTarget(episode.ID))
+		return nil, nil
 	}
 
+	chosenRelease, err := m.delayRelease(ctx, episodePendingTarget(episode.ID), qualityProfile, ranked, snapshot.time)
+	if err != nil {
+		return nil, err
+	}
 	if chosenRelease == nil {
-		log.Debug("no valid releases found for episode, skipping reconcile")
-		return false, nil
+		log.Debug("waiting out the delay profile before grabbing a release")
+		return nil, nil
 	}
 
 	log.Info("found release", zap.Any("title", chosenRelease.Title), zap.String("proto", string(*chosenRelease.Protocol)))
@@ -645,20 +716,67 @@ func (m MediaManager) reconcileMissingEpisode(ctx context.Context, seriesTitle s
 	clientID, status, err := m.requestReleaseDownload(ctx, snapshot, chosenRelease)
 	if err != nil {
 		log.Debug("failed to request episode release download", zap.Error(err))
-		return false, err
+		return nil, err
 	}
 
-	err = m.updateEpisodeState(ctx, *episode, storage.EpisodeStateDownloading, &storage.TransitionStateMetadata{
-		DownloadID:       &status.ID,
-		DownloadClientID: &clientID,
-	})
+	metadata := releaseTransitionMetadata(chosenRelease)
+	metadata.DownloadID = &status.ID
+	metadata.DownloadClientID = &clientID
+
+	err = m.updateEpisodeState(ctx, *episode, storage.EpisodeStateDownloading, &metadata)
 	if err != nil {
 		log.Debug("failed to update episode state", zap.Error(err))
-		return false, err
+		return nil, err
+	}
+
+	downloading := []int32{episode.ID}
+	if covered := releaseEpisodeNumbers(chosenRelease); len(covered) > 0 {
+		downloading = append(downloading, m.markCoveredEpisodesDownloading(ctx, episode, covered, metadata)...)
 	}
 
 	log.Debug("successfully reconciled episode")
-	return true, nil
+	return downloading, nil
+}
+
+// markCoveredEpisodesDownloading moves the other missing episodes in the season that a multi-episode release covers
+// to downloading with the same download, so they're imported together. It returns the ids of the episodes it moved.
+func (m MediaManager) markCoveredEpisodesDownloading(ctx context.Context, episode *storage.Episode, covered []int32, metadata storage.TransitionStateMetadata) []int32 {
+	log := logger.FromCtx(ctx).With("episode id", episode.ID)
+
+	where := table.Episode.SeasonID.EQ(sqlite.Int32(episode.SeasonID)).
+		AND(table.Episode.ID.NOT_EQ(sqlite.Int32(episode.ID)))
+	siblings, err := m.seriesStorage.ListEpisodes(ctx, where)
+	if err != nil {
+		log.Warn("failed to list episodes covered by release", zap.Error(err))
+		return nil
+	}
+
+	var downloading []int32
+	for _, sibling := range siblings {
+		if sibling.State != storage.EpisodeStateMissing || sibling.EpisodeMetadataID == nil {
+			continue
+		}
+
+		episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*sibling.EpisodeMetadataID)))
+		if err != nil {
+			log.Warn("failed to get episode metadata", zap.Error(err), zap.Int32("episode_id", sibling.ID))
+			continue
+		}
+
+		if !slices.Contains(covered, episodeMetadata.Number) {
+			continue
+		}
+
+		err = m.updateEpisodeState(ctx, *sibling, storage.EpisodeStateDownloading, &metadata)
+		if err != nil {
+			log.Warn("failed to update covered episode state", zap.Error(err), zap.Int32("episode_id", sibling.ID))
+			continue
+		}
+
+		downloading = append(downloading, sibling.ID)
+	}
+
+	return downloading
 }
 
 func (m MediaManager) updateEpisodeState(ctx context.Context, episode storage.Episode, state storage.EpisodeState, metadata *storage.TransitionStateMetadata) error {
@@ -1030,6 +1148,9 @@ func (m MediaManager) reconcileDiscoveredEpisode(ctx context.Context, snapshot *
 
 		// Use library parsing to extract episode number from path
 		parsedFile := library.EpisodeFileFromPath(filePath)
+		if parsedFile.EpisodeNumber == 0 {
+			parsedFile.EpisodeNumber = m.discoveredEpisodeNumber(ctx, series, season, parsedFile)
+		}
 		if parsedFile.EpisodeNumber == 0 {
 			log.Warn("could not parse episode number from file path", zap.String("path", filePath))
 			return nil
@@ -1058,6 +1179,74 @@ func (m MediaManager) reconcileDiscoveredEpisode(ctx context.Context, snapshot *
 	return nil
 }
 
+// matchEpisodeFileByAirDate returns the episode that aired on the given day, or nil if none did
+func (m MediaManager) matchEpisodeFileByAirDate(ctx context.Context, airDate time.Time, episodes []*storage.Episode) *storage.Episode {
+	log := logger.FromCtx(ctx)
+
+	for _, episode := range episodes {
+		if episode.EpisodeMetadataID == nil {
+			continue
+		}
+
+		episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx,
+			table.EpisodeMetadata.ID.EQ(sqlite.Int32(*episode.EpisodeMetadataID)))
+		if err != nil {
+			log.Warn("failed to get episode metadata", zap.Error(err), zap.Int32("episode_id", episode.ID))
+			continue
+		}
+
+		if episodeMetadata.AirDate != nil && sameDay(*episodeMetadata.AirDate, airDate) {
+			log.Debug("matched file to episode by air date",
+				zap.Int32("episode_id", episode.ID),
+				zap.Time("air_date", airDate))
+			return episode
+		}
+	}
+
+	log.Warn("no matching episode found", zap.Time("file_air_date", airDate))
+	return nil
+}
+
+// discoveredEpisodeNumber maps an anime file's absolute number, or a daily file's air date, to its episode number
+// in the given season. It returns 0 if the series isn't anime or daily, or the file belongs to a different season.
+func (m MediaManager) discoveredEpisodeNumber(ctx context.Context, series *storage.Series, season *storage.Season, file library.EpisodeFile) int {
+	log := logger.FromCtx(ctx)
+
+	switch {
+	case series.SeriesType == storage.SeriesTypeDaily && file.AirDate != nil && season.SeasonMetadataID != nil:
+		episodes, err := m.seriesMetaStorage.ListEpisodeMetadata(ctx, table.EpisodeMetadata.SeasonMetadataID.EQ(sqlite.Int32(*season.SeasonMetadataID)))
+		if err != nil {
+			log.Warn("failed to list episode metadata", zap.Error(err))
+			return 0
+		}
+
+		for _, e := range episodes {
+			if e.AirDate != nil && sameDay(*e.AirDate, *file.AirDate) {
+				return int(e.Number)
+			}
+		}
+
+		log.Debug("no episode aired on file date in season", zap.Time("air_date", *file.AirDate), zap.Int32("season_number", season.SeasonNumber))
+		return 0
+	case file.AbsoluteEpisodeNumber > 0:
+		numbering, err := m.seriesAbsoluteNumbering(ctx, series)
+		if err != nil {
+			log.Warn("failed to load absolute episode numbering", zap.Error(err))
+			return 0
+		}
+
+		se, ok := numbering.episode(int32(file.AbsoluteEpisodeNumber))
+		if !ok || se.season != season.SeasonNumber {
+			log.Debug("absolute episode number is not in season", zap.Int("absolute_episode_number", file.AbsoluteEpisodeNumber), zap.Int32("season_number", season.SeasonNumber))
+			return 0
+		}
+
+		return int(se.episode)
+	default:
+		return 0
+	}
+}
+
 func (m MediaManager) linkSeriesMetadata(ctx context.Context, series *storage.Series, log *zap.SugaredLogger) error {
 	searchTerm, year := pathToSearchTermWithYear(*series.Path)
 	searchResp, err := m.SearchTV(ctx, searchTerm)
@@ -1286,13 +1475,39 @@ func (m MediaManager) reconcileDownloadingSeason(ctx context.Context, season *st
 		return m.reconcileSeasonPackDownload(ctx, episodes[0], episodes, snapshot)
 	}
 
-	log.Debug("processing individual episode downloads")
+	// episodes sharing a download came from a multi-episode release and are imported together
+	type downloadKey struct {
+		clientID int32
+		id       string
+	}
+	var order []downloadKey
+	downloads := make(map[downloadKey][]*storage.Episode)
 	for _, episode := range episodes {
-		err := m.reconcileDownloadingEpisode(ctx, episode, snapshot)
-		if err != nil {
-			log.Error("failed to reconcile downloading episode", zap.Error(err))
+		key := downloadKey{clientID: episode.DownloadClientID, id: episode.DownloadID}
+		if _, ok := downloads[key]; !ok {
+			order = append(order, key)
+		}
+		downloads[key] = append(downloads[key], episode)
+	}
+
+	log.Debug("processing individual episode downloads")
+	for _, key := range order {
+		group := downloads[key]
+		if len(group) > 1 && key.id != "" {
+			err := m.reconcileSeasonPackDownload(ctx, group[0], group, snapshot)
+			if err != nil {
+				log.Error("failed to reconcile multi-episode download", zap.Error(err))
+			}
 			continue
 		}
+
+		for _, episode := range group {
+			err := m.reconcileDownloadingEpisode(ctx, episode, snapshot)
+			if err != nil {
+				log.Error("failed to reconcile downloading episode", zap.Error(err))
+				continue
+			}
+		}
 	}
 
 	return nil
@@ -1322,17 +1537,33 @@ func (m MediaManager) reconcileSeasonPackDownload(ctx context.Context, episode *
 	status, err := downloadClient.Get(ctx, download.GetRequest{
 		ID: episode.DownloadID,
 	})
+	if isDownloadGone(err) {
+		log.Info("download was removed from the download client", zap.String("download id", episode.DownloadID))
+		return m.retryEpisodeDownloads(ctx, episodes, BlocklistReasonDownloadRemoved)
+	}
 	if err != nil {
 		log.Warn("failed to get download status", zap.Error(err))
 		return err
 	}
 
 	log.Debug("download status", zap.Any("status", status))
+	if status.Failed {
+		log.Info("download failed", zap.String("download id", episode.DownloadID), zap.String("error", status.Error))
+		return m.retryEpisodeDownloads(ctx, episodes, BlocklistReasonDownloadFailed)
+	}
+
 	if !status.Done {
 		log.Debug("download not finished")
 		return nil
 	}
 
+	filePaths, cleanup, err := m.extractDownload(ctx, status.FilePaths)
+	if err != nil {
+		log.Warn("failed to extract download", zap.Error(err))
+		return err
+	}
+	status.FilePaths = filePaths
+
 	season, err := m.seriesStorage.GetSeason(ctx, table.Season.ID.EQ(sqlite.Int32(episode.SeasonID)))
 	if err != nil {
 		log.Error("failed to get season", zap.Error(err))
@@ -1357,30 +1588,59 @@ func (m MediaManager) reconcileSeasonPackDownload(ctx context.Context, episode *
 		return err
 	}
 
-	// For each file in the season pack, match it to an episode and link it
-	for _, filePath := range status.FilePaths {
-		matchedEpisode := m.matchEpisodeFileToEpisode(ctx, filePath, episodes)
-		if matchedEpisode == nil {
-			log.Warn("could not match file to episode, skipping", zap.String("file", filePath))
+	target := m.newReleaseSeries(ctx, series, seriesMetadata)
+
+	// For each file in the season pack, match it to its episodes and link it. Larger files are matched first so an
+	// episode gets the largest of the files that match it.
+	files := m.downloadedVideoFiles(ctx, status.FilePaths, seriesMetadata.Title)
+	slices.SortStableFunc(files, func(a, b importFile) int {
+		return cmp.Compare(b.size, a.size)
+	})
+
+	replacedFiles := make(map[int32]string)
+	linked := make(map[int32]bool)
+	for _, f := range files {
+		matchedEpisodes := m.matchEpisodeFileToEpisode(ctx, f.path, episodes, target)
+		if len(matchedEpisodes) == 0 {
+			log.Warn("skipping downloaded file", zap.String("file", f.path), zap.String("reason", "could not match file to an episode"))
+			continue
+		}
+
+		if reason := m.tooSmall(f, m.episodesRuntime(ctx, matchedEpisodes...)); reason != "" {
+			log.Info("skipping downloaded file", zap.String("file", f.path), zap.String("reason", reason))
 			continue
 		}
 
-		err = m.addEpisodeFileToLibrary(ctx, seriesMetadata.Title, seasonMetadata.Number, filePath, matchedEpisode)
+		if !slices.ContainsFunc(matchedEpisodes, func(e *storage.Episode) bool { return !linked[e.ID] }) {
+			log.Info("skipping downloaded file", zap.String("file", f.path), zap.String("reason", "a larger file is imported for its episodes"))
+			continue
+		}
+		for _, e := range matchedEpisodes {
+			linked[e.ID] = true
+		}
+
+		replaced, err := m.addEpisodeFileToLibrary(ctx, series, seriesMetadata, seasonMetadata.Number, f.path, matchedEpisodes...)
+		if errors.Is(err, library.ErrInsufficientDiskSpace) {
+			// the episodes stay downloading so the rest of the pack is imported once there's space
+			return snapshot.trackDiskSpace(err)
+		}
 		if err != nil {
 			log.Warn("failed to add episode file to library", zap.Error(err))
 			continue
 		}
+		maps.Copy(replacedFiles, replaced)
 	}
 
 	// Update all episodes in the season pack to downloaded
 	for _, ep := range episodes {
-		err = m.updateEpisodeState(ctx, *ep, storage.EpisodeStateDownloaded, nil)
+		err = m.updateEpisodeState(ctx, *ep, storage.EpisodeStateDownloaded, importedTransitionMetadata(ep.IsUpgrade, replacedFiles[ep.ID]))
 		if err != nil {
 			log.Error("failed to update episode state", zap.Error(err))
 			continue
 		}
 	}
 
+	cleanup()
 	return nil
 }
 
@@ -1408,17 +1668,33 @@ func (m MediaManager) reconcileDownloadingEpisode(ctx context.Context, episode *
 	status, err := downloadClient.Get(ctx, download.GetRequest{
 		ID: episode.DownloadID,
 	})
+	if isDownloadGone(err) {
+		log.Info("download was removed from the download client", zap.String("download id", episode.DownloadID))
+		return m.retryEpisodeDownloads(ctx, []*storage.Episode{episode}, BlocklistReasonDownloadRemoved)
+	}
 	if err != nil {
 		log.Warn("failed to get download status", zap.Error(err))
 		return err
 	}
 
 	log.Debug("download status", zap.Any("status", status))
+	if status.Failed {
+		log.Info("download failed", zap.String("download id", episode.DownloadID), zap.String("error", status.Error))
+		return m.retryEpisodeDownloads(ctx, []*storage.Episode{episode}, BlocklistReasonDownloadFailed)
+	}
+
 	if !status.Done {
 		log.Debug("download not finished")
 		return nil
 	}
 
+	filePaths, cleanup, err := m.extractDownload(ctx, status.FilePaths)
+	if err != nil {
+		log.Warn("failed to extract download", zap.Error(err))
+		return err
+	}
+	status.FilePaths = filePaths
+
 	episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*episode.EpisodeMetadataID)))
 	if err != nil {
 		log.Error("failed to get episode metadata", zap.Error(err))
@@ -1450,10 +1726,50 @@ func (m MediaManager) reconcileDownloadingEpisode(ctx context.Context, episode *
 	}
 
 	log.Debug("processing individual episode download")
-	return m.processIndividualEpisodeDownload(ctx, episode, status, seriesMetadata, seasonMetadata, episodeMetadata)
+	err = m.processIndividualEpisodeDownload(ctx, episode, status, series, seriesMetadata, seasonMetadata, episodeMetadata)
+	if err != nil {
+		return snapshot.trackDiskSpace(err)
+	}
+
+	cleanup()
+	return nil
 }
 
-func (m MediaManager) processIndividualEpisodeDownload(ctx context.Context, episode *storage.Episode, status download.Status, seriesMetadata *model.SeriesMetadata, seasonMetadata *model.SeasonMetadata, episodeMetadata *model.EpisodeMetadata) error {
+// retryEpisodeDownloads blocklists the release the episodes were downloading and marks them missing so another release is searched for.
+// Episodes from a failed upgrade go back to downloaded since they still have their file.
+func (m MediaManager) retryEpisodeDownloads(ctx context.Context, episodes []*storage.Episode, reason string) error {
+	log := logger.FromCtx(ctx)
+
+	seasonIDs := make(map[int32]struct{})
+	for _, e := range episodes {
+		err := m.blocklistService.blocklistEpisodeRelease(ctx, e, reason)
+		if err != nil {
+			return fmt.Errorf("failed to blocklist episode release: %w", err)
+		}
+
+		state := storage.EpisodeStateMissing
+		if e.IsUpgrade {
+			state = storage.EpisodeStateDownloaded
+		}
+
+		err = m.updateEpisodeState(ctx, *e, state, nil)
+		if err != nil {
+			return err
+		}
+
+		seasonIDs[e.SeasonID] = struct{}{}
+	}
+
+	for id := range seasonIDs {
+		if err := m.evaluateAndUpdateSeasonState(ctx, id); err != nil {
+			log.Warn("failed to update season state after download retry", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (m MediaManager) processIndividualEpisodeDownload(ctx context.Context, episode *storage.Episode, status download.Status, series *storage.Series, seriesMetadata *model.SeriesMetadata, seasonMetadata *model.SeasonMetadata, episodeMetadata *model.EpisodeMetadata) error {
 	log := logger.FromCtx(ctx)
 	log = log.With("episode id", episode.ID, "series", seriesMetadata.Title, "season", seasonMetadata.Number, "episode", episodeMetadata.Number)
 
@@ -1462,32 +1778,92 @@ func (m MediaManager) processIndividualEpisodeDownload(ctx context.Context, epis
 		return nil
 	}
 
-	for _, filePath := range status.FilePaths {
-		err := m.addEpisodeFileToLibrary(ctx, seriesMetadata.Title, seasonMetadata.Number, filePath, episode)
-		if err != nil {
-			log.Error("failed to add episode file to library", zap.Error(err))
-			return err
-		}
-		log.Debug("successfully added episode file to library", zap.String("file", filePath))
+	var runtime int32
+	if episodeMetadata.Runtime != nil {
+		runtime = *episodeMetadata.Runtime
+	}
+
+	main, ok := m.mainVideoFile(ctx, m.downloadedVideoFiles(ctx, status.FilePaths, seriesMetadata.Title), runtime)
+	if !ok {
+		return fmt.Errorf("no video file to import in download %s", episode.DownloadID)
+	}
+
+	replaced, err := m.addEpisodeFileToLibrary(ctx, series, seriesMetadata, seasonMetadata.Number, main.path, episode)
+	if err != nil {
+		log.Error("failed to add episode file to library", zap.Error(err))
+		return err
 	}
+	replacedFile := replaced[episode.ID]
+	log.Debug("successfully added episode file to library", zap.String("file", main.path))
 
-	return m.updateEpisodeState(ctx, *episode, storage.EpisodeStateDownloaded, nil)
+	return m.updateEpisodeState(ctx, *episode, storage.EpisodeStateDownloaded, importedTransitionMetadata(episode.IsUpgrade, replacedFile))
 }
 
-func (m MediaManager) addEpisodeFileToLibrary(ctx context.Context, seriesTitle string, seasonNumber int32, filePath string, episode *storage.Episode) error {
+// addEpisodeFileToLibrary moves a downloaded file into the library and links it to the episodes it contains.
+// A multi-episode file gets a single episode file record shared by all of its episodes.
+// It returns the library paths of the files upgraded episodes were linked to before, by episode id.
+// The file is named with the configured episode file format in the series' season folder. A replaced file that's at
+// the same path is set aside first, see setAsideEpisodeFile.
+func (m MediaManager) addEpisodeFileToLibrary(ctx context.Context, series *storage.Series, seriesMetadata *model.SeriesMetadata, seasonNumber int32, filePath string, episodes ...*storage.Episode) (map[int32]string, error) {
 	log := logger.FromCtx(ctx)
-	log = log.With("series", seriesTitle, "season", seasonNumber, "episodes")
+	log = log.With("series", seriesMetadata.Title, "season", seasonNumber, "episodes", len(episodes))
 
-	if episode.EpisodeFileID != nil {
-		log.Debug("episode already has file linked, skipping", zap.Int32("episode_id", episode.ID))
-		return nil
+	// an upgrade replaces the file the episode is linked to
+	var unlinked []*storage.Episode
+	replaced := make(map[int32]*model.EpisodeFile)
+	replacedPaths := make(map[int32]string)
+	for _, episode := range episodes {
+		if episode.EpisodeFileID != nil && !episode.IsUpgrade {
+			log.Debug("episode already has file linked, skipping", zap.Int32("episode_id", episode.ID))
+			continue
+		}
+		unlinked = append(unlinked, episode)
+
+		if episode.EpisodeFileID == nil {
+			continue
+		}
+
+		file, ok := replaced[*episode.EpisodeFileID]
+		if !ok {
+			var err error
+			file, err = m.seriesStorage.GetEpisodeFile(ctx, *episode.EpisodeFileID)
+			if err != nil {
+				log.Warn("failed to get replaced episode file", zap.Int32("episode_file_id", *episode.EpisodeFileID), zap.Error(err))
+				continue
+			}
+			replaced[file.ID] = file
+		}
+		if file.RelativePath != nil {
+			replacedPaths[episode.ID] = *file.RelativePath
+		}
+	}
+	if len(unlinked) == 0 {
+		return nil, nil
 	}
 
-	ef, err := m.library.AddEpisode(ctx, seriesTitle, seasonNumber, filePath)
+	quality := m.fileQuality(ctx, "episode", unlinked[0].ReleaseTitle, &filePath)
+	tokens := m.episodeNameTokens(ctx, seriesMetadata, seasonNumber, unlinked)
+	tokens.Quality = quality
+	tokens.Original = originalName(filePath)
+
+	folder := m.seriesFolder(tokens)
+	if series != nil && series.Path != nil && *series.Path != "" {
+		folder = *series.Path
+	}
+	name := filepath.Join(folder, m.episodeFileName(tokens))
+
+	restore, err := m.setAsideEpisodeFile(ctx, replaced, library.EpisodeFilePath(name, filepath.Ext(filePath)))
+	if err != nil {
+		log.Error("failed to add episode to library", zap.Error(err))
+		return nil, err
+	}
+
+	ef, err := m.library.AddEpisode(ctx, name, filePath)
 	if err != nil {
 		if !errors.Is(err, io.ErrFileExists) {
+			restore()
 			log.Error("failed to add episode to library", zap.Error(err))
-			return err
+			return nil, err
 		}
 
 		log.Debug("file already exists in library, creating record for existing file")
@@ -1499,26 +1875,45 @@ func (m MediaManager) addEpisodeFileToLibrary(ctx context.Context, seriesTitle s
 		Size:             ef.Size,
 		RelativePath:     &ef.RelativePath,
 		OriginalFilePath: &filePath,
+		SceneName:        unlinked[0].ReleaseTitle,
+		Languages:        importedFileLanguages(unlinked[0].ReleaseTitle, filePath),
+		Quality:          quality,
 	})
 	if err != nil {
 		log.Error("failed to create episode file record", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	err = m.seriesStorage.UpdateEpisodeEpisodeFileID(ctx, int64(episode.ID), episodeFileID)
-	if err != nil {
-		log.Error("failed to link episode to file", zap.Error(err), zap.Int32("episode_id", episode.ID))
-		return err
+	m.recordExtraFiles(ctx, model.ExtraFile{EpisodeFileID: ptr.To(int32(episodeFileID))}, ef.ExtraFiles)
+
+	for _, episode := range unlinked {
+		err = m.seriesStorage.UpdateEpisodeEpisodeFileID(ctx, int64(episode.ID), episodeFileID)
+		if err != nil {
+			log.Error("failed to link episode to file", zap.Error(err), zap.Int32("episode_id", episode.ID))
+			return nil, err
+		}
+
+		log.Debug("linked episode to file", zap.Int32("episode_id", episode.ID), zap.String("path", ef.RelativePath))
 	}
 
-	log.Debug("linked episode to file", zap.Int32("episode_id", episode.ID), zap.String("path", ef.RelativePath))
+	for id, file := range replaced {
+		if int64(id) == episodeFileID {
+			continue
+		}
 
-	return nil
+		err = m.removeReplacedEpisodeFile(ctx, file)
+		if err != nil {
+			log.Warn("failed to remove replaced episode file", zap.Int32("episode_file_id", id), zap.Error(err))
+		}
+	}
+
+	return replacedPaths, nil
 }
 
-// matchEpisodeFileToEpisode matches a downloaded file to a specific episode using the library package's
-// episode extraction logic. Returns the matched episode or nil if no match is found.
-func (m MediaManager) matchEpisodeFileToEpisode(ctx context.Context, filePath string, episodes []*storage.Episode) *storage.Episode {
+// matchEpisodeFileToEpisode matches a downloaded file to its episodes using the library package's
+// episode extraction logic. Anime files are matched by absolute number and daily files by air date.
+// Returns every episode a multi-episode file contains, or nil if no match is found.
+func (m MediaManager) matchEpisodeFileToEpisode(ctx context.Context, filePath string, episodes []*storage.Episode, series releaseSeries) []*storage.Episode {
 	log := logger.FromCtx(ctx)
 	log = log.With("file_path", filePath, "candidate_episodes", len(episodes))
 
@@ -1528,7 +1923,33 @@ func (m MediaManager) matchEpisodeFileToEpisode(ctx context.Context, filePath st
 	log.Debug("extracted episode info from file",
 		zap.String("series_name", episodeFile.SeriesName),
 		zap.Int("season_number", episodeFile.SeasonNumber),
-		zap.Int("episode_number", episodeFile.EpisodeNumber))
+		zap.Ints("episode_numbers", episodeFile.Episodes()),
+		zap.Int("absolute_episode_number", episodeFile.AbsoluteEpisodeNumber))
+
+	if series.daily() && episodeFile.AirDate != nil && episodeFile.SeasonNumber == 0 {
+		if episode := m.matchEpisodeFileByAirDate(ctx, *episodeFile.AirDate, episodes); episode != nil {
+			return []*storage.Episode{episode}
+		}
+		return nil
+	}
+
+	if episodeFile.EpisodeNumber == 0 && episodeFile.AbsoluteEpisodeNumber > 0 && series.numbering != nil {
+		for _, episode := range episodes {
+			if episode.EpisodeMetadataID == nil {
+				continue
+			}
+
+			if absolute, ok := series.numbering.absolute(*episode.EpisodeMetadataID); ok && absolute == int32(episodeFile.AbsoluteEpisodeNumber) {
+				log.Debug("matched file to episode by absolute number",
+					zap.Int32("episode_id", episode.ID),
+					zap.Int32("absolute_episode_number", absolute))
+				return []*storage.Episode{episode}
+			}
+		}
+
+		log.Warn("no matching episode found", zap.Int("file_absolute_episode_number", episodeFile.AbsoluteEpisodeNumber))
+		return nil
+	}
 
 	// If we couldn't extract episode number, we can't match
 	if episodeFile.EpisodeNumber == 0 {
@@ -1536,7 +1957,10 @@ func (m MediaManager) matchEpisodeFileToEpisode(ctx context.Context, filePath st
 		return nil
 	}
 
-	// Look for an episode that matches the extracted episode number
+	fileEpisodes := episodeFile.Episodes()
+
+	// Look for the episodes that match the extracted episode numbers
+	var matched []*storage.Episode
 	for _, episode := range episodes {
 		if episode.EpisodeMetadataID == nil {
 			continue // Skip episodes without metadata
@@ -1551,15 +1975,17 @@ func (m MediaManager) matchEpisodeFileToEpisode(ctx context.Context, filePath st
 		}
 
 		// Check if the episode numbers match
-		if int32(episodeFile.EpisodeNumber) == episodeMetadata.Number {
+		if slices.Contains(fileEpisodes, int(episodeMetadata.Number)) {
 			log.Debug("matched file to episode",
 				zap.Int32("episode_id", episode.ID),
 				zap.Int32("episode_number", episodeMetadata.Number))
-			return episode
+			matched = append(matched, episode)
 		}
 	}
 
-	log.Warn("no matching episode found",
-		zap.Int("file_episode_number", episodeFile.EpisodeNumber))
-	return nil
+	if len(matched) == 0 {
+		log.Warn("no matching episode found",
+			zap.Ints("file_episode_numbers", fileEpisodes))
+	}
+	return matched
 }
@@ -1,12 +1,15 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/kasuboski/mediaz/pkg/logger"
 	"github.com/kasuboski/mediaz/pkg/storage"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"go.uber.org/zap"
 )
 
 // initialSeriesState returns Missing or Unreleased based on the first air date.
@@ -72,6 +75,11 @@ func determineSeasonState(episodes []*storage.Episode) (map[string]int, storage.
 		case storage.EpisodeStateDownloaded, storage.EpisodeStateCompleted:
 			done++
 		case storage.EpisodeStateDownloading:
+			// an upgrade is replacing a file the episode already has
+			if episode.IsUpgrade {
+				done++
+				continue
+			}
 			downloading++
 		case storage.EpisodeStateMissing:
 			missing++
@@ -172,3 +180,36 @@ func determineSeriesState(seasons []*storage.Season, tmdbStatus string) (seriesS
 
 	return counts, state
 }
+
+// releaseSeries is the series that releases and files are matched against
+type releaseSeries struct {
+	title      string
+	seriesType string
+	// originalLanguage is the ISO 639-1 code of the series' original language, if it's known
+	originalLanguage *string
+	// numbering is only set for anime series
+	numbering *absoluteNumbering
+}
+
+// daily reports whether episodes of the series are released by air date
+func (s releaseSeries) daily() bool {
+	return s.seriesType == storage.SeriesTypeDaily
+}
+
+// newReleaseSeries builds the release matching target for a series. Anime series fall back to
+// season and episode matching if their absolute numbering can't be loaded.
+func (m MediaManager) newReleaseSeries(ctx context.Context, series *storage.Series, metadata *model.SeriesMetadata) releaseSeries {
+	target := releaseSeries{title: metadata.Title, originalLanguage: metadata.OriginalLanguage}
+	if series != nil {
+		target.seriesType = series.SeriesType
+	}
+
+	numbering, err := m.seriesAbsoluteNumbering(ctx, series)
+	if err != nil {
+		logger.FromCtx(ctx).Warn("failed to load absolute episode numbering", zap.Error(err))
+		return target
+	}
+	target.numbering = numbering
+
+	return target
+}
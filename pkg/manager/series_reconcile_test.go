@@ -83,6 +83,76 @@ func TestMediaManager_updateEpisodeState(t *testing.T) {
 	})
 }
 
+func TestMediaManager_retryEpisodeDownloads(t *testing.T) {
+	ctx := context.Background()
+	store, err := mediaSqlite.New(ctx, ":memory:")
+	require.NoError(t, err)
+
+	schemas, err := storage.ReadSchemaFiles("../storage/sqlite/schema/schema.sql", "../storage/sqlite/schema/defaults.sql")
+	require.NoError(t, err)
+
+	err = store.Init(ctx, schemas...)
+	require.NoError(t, err)
+
+	m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+
+	seasonID, err := store.CreateSeason(ctx, storage.Season{
+		Season: model.Season{
+			SeriesID:         1,
+			SeasonMetadataID: ptr.To(int32(1)),
+			Monitored:        1,
+		},
+	}, storage.SeasonStateMissing)
+	require.NoError(t, err)
+
+	var episodes []*storage.Episode
+	for i := int32(1); i <= 2; i++ {
+		episodeID, err := store.CreateEpisode(ctx, storage.Episode{
+			Episode: model.Episode{
+				SeasonID:      int32(seasonID),
+				EpisodeNumber: i,
+				Monitored:     1,
+			},
+		}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+
+		err = store.UpdateEpisodeState(ctx, episodeID, storage.EpisodeStateDownloading, &storage.TransitionStateMetadata{
+			DownloadID:             ptr.To("123"),
+			DownloadClientID:       ptr.To(int32(1)),
+			IsEntireSeasonDownload: ptr.To(true),
+			ReleaseGUID:            ptr.To("pack-guid"),
+			ReleaseTitle:           ptr.To("Show.S01.1080p.WEB-DL-GRP"),
+		})
+		require.NoError(t, err)
+
+		episode, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+		require.NoError(t, err)
+		episodes = append(episodes, episode)
+	}
+
+	err = store.UpdateSeasonState(ctx, seasonID, storage.SeasonStateDownloading, nil)
+	require.NoError(t, err)
+
+	err = m.retryEpisodeDownloads(ctx, episodes, BlocklistReasonDownloadFailed)
+	require.NoError(t, err)
+
+	for _, e := range episodes {
+		episode, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int32(e.ID)))
+		require.NoError(t, err)
+		assert.Equal(t, storage.EpisodeStateMissing, episode.State)
+
+		entries, err := m.ListBlocklist(ctx, nil, &e.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Show.S01.1080p.WEB-DL-GRP", entries[0].Title)
+		assert.Equal(t, BlocklistReasonDownloadFailed, entries[0].Reason)
+	}
+
+	season, err := store.GetSeason(ctx, table.Season.ID.EQ(sqlite.Int64(seasonID)))
+	require.NoError(t, err)
+	assert.Equal(t, storage.SeasonStateMissing, season.State)
+}
+
 func TestMediaManager_reconcileMissingEpisodes(t *testing.T) {
 	t.Run("reconcile missing episodes - not all released", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -217,7 +287,7 @@ func TestMediaManager_reconcileMissingEpisodes(t *testing.T) {
 
 		m := New(nil, nil, nil, store, mockFactory, config.Manager{}, config.Config{})
 
-		err = m.reconcileMissingEpisodes(ctx, "Series", 1, 1, episodes, snapshot, qualityProfile, releases)
+		err = m.reconcileMissingEpisodes(ctx, releaseSeries{title: "Series"}, 1, 1, episodes, snapshot, qualityProfile, releases)
 		require.NoError(t, err)
 
 		episodes, err = store.ListEpisodes(ctx)
@@ -366,7 +436,7 @@ func TestMediaManager_reconcileMissingEpisodes(t *testing.T) {
 
 		m := New(nil, nil, nil, store, mockFactory, config.Manager{}, config.Config{})
 
-		err = m.reconcileMissingEpisodes(ctx, "Series", int32(seasonID), 1, episodes, snapshot, qualityProfile, releases)
+		err = m.reconcileMissingEpisodes(ctx, releaseSeries{title: "Series"}, int32(seasonID), 1, episodes, snapshot, qualityProfile, releases)
 		require.NoError(t, err)
 
 		episodes, err = store.ListEpisodes(ctx)
@@ -397,16 +467,108 @@ func TestMediaManager_reconcileMissingEpisodes(t *testing.T) {
 		assert.Equal(t, storage.SeasonStateDownloading, seasons[0].State)
 	})
 
+	t.Run("reconcile missing episodes - multi-episode release", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		mockDownloadClient := downloadMock.NewMockDownloadClient(ctrl)
+		mockFactory := downloadMock.NewMockFactory(ctrl)
+		downloadClientModel := model.DownloadClient{
+			ID:             2,
+			Implementation: "transmission",
+			Type:           "torrent",
+			Port:           8080,
+			Host:           "transmission",
+			Scheme:         "http",
+		}
+
+		snapshot := newReconcileSnapshot([]model.Indexer{{ID: 1}}, []*model.DownloadClient{&downloadClientModel})
+
+		seasonID, err := store.CreateSeason(ctx, storage.Season{Season: model.Season{SeriesID: 1, Monitored: 1}}, storage.SeasonStateMissing)
+		require.NoError(t, err)
+
+		// one download for both episodes
+		mockFactory.EXPECT().NewDownloadClient(downloadClientModel).Return(mockDownloadClient, nil).Times(1)
+		mockDownloadClient.EXPECT().Add(ctx, gomock.Any()).Return(download.Status{
+			ID:   "123",
+			Name: "test download",
+		}, nil).Times(1)
+
+		for i := int32(1); i <= 2; i++ {
+			metadataID, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{
+				TmdbID:  i,
+				Title:   "Test Episode",
+				Number:  i,
+				AirDate: ptr.To(snapshot.time.Add(time.Hour * -2)),
+				Runtime: ptr.To(int32(21)),
+			})
+			require.NoError(t, err)
+
+			_, err = store.CreateEpisode(ctx, storage.Episode{
+				Episode: model.Episode{
+					SeasonID:          int32(seasonID),
+					EpisodeNumber:     i,
+					EpisodeMetadataID: ptr.To(int32(metadataID)),
+				},
+			}, storage.EpisodeStateMissing)
+			require.NoError(t, err)
+		}
+
+		releases := []*prowlarr.ReleaseResource{
+			{
+				ID:       ptr.To(int32(1)),
+				Title:    nullable.NewNullableWithValue("Series.S01E01E02.1080p.WEB-DL.AAC2.0.x264-GROUP"),
+				Size:     sizeGBToBytes(2),
+				Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
+			},
+		}
+
+		qualityProfile := storage.QualityProfile{
+			Name: "Default",
+			Qualities: []storage.QualityDefinition{
+				{
+					Name:          "HD",
+					MinSize:       0,
+					MaxSize:       2000,
+					PreferredSize: 1000,
+					MediaType:     "tv",
+				},
+			},
+		}
+
+		episodes, err := store.ListEpisodes(ctx)
+		require.NoError(t, err)
+		require.Len(t, episodes, 2)
+
+		m := New(nil, nil, nil, store, mockFactory, config.Manager{}, config.Config{})
+
+		err = m.reconcileMissingEpisodes(ctx, releaseSeries{title: "Series"}, int32(seasonID), 1, episodes, snapshot, qualityProfile, releases)
+		require.NoError(t, err)
+
+		episodes, err = store.ListEpisodes(ctx)
+		require.NoError(t, err)
+		require.Len(t, episodes, 2)
+
+		for _, e := range episodes {
+			assert.Equal(t, storage.EpisodeStateDownloading, e.State)
+			assert.Equal(t, "123", e.DownloadID)
+			assert.Equal(t, int32(2), e.DownloadClientID)
+		}
+	})
+
 	t.Run("nil episode", func(t *testing.T) {
 		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{})
-		err := m.reconcileMissingEpisodes(context.Background(), "Series", 1, 1, []*storage.Episode{nil}, nil, storage.QualityProfile{}, nil)
+		err := m.reconcileMissingEpisodes(context.Background(), releaseSeries{title: "Series"}, 1, 1, []*storage.Episode{nil}, nil, storage.QualityProfile{}, nil)
 		require.NoError(t, err)
 	})
 
 	t.Run("nil snapshot", func(t *testing.T) {
 		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{})
 		episode := &storage.Episode{}
-		err := m.reconcileMissingEpisodes(context.Background(), "Series", 1, 1, []*storage.Episode{episode}, nil, storage.QualityProfile{}, nil)
+		err := m.reconcileMissingEpisodes(context.Background(), releaseSeries{title: "Series"}, 1, 1, []*storage.Episode{episode}, nil, storage.QualityProfile{}, nil)
 		require.NoError(t, err)
 	})
 
@@ -431,7 +593,7 @@ func TestMediaManager_reconcileMissingEpisodes(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		err = m.reconcileMissingEpisodes(ctx, "Series", 1, 1, []*storage.Episode{&episode}, &ReconcileSnapshot{}, storage.QualityProfile{}, nil)
+		err = m.reconcileMissingEpisodes(ctx, releaseSeries{title: "Series"}, 1, 1, []*storage.Episode{&episode}, &ReconcileSnapshot{}, storage.QualityProfile{}, nil)
 		require.NoError(t, err)
 	})
 
@@ -455,14 +617,14 @@ func TestMediaManager_reconcileMissingEpisodes(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		err = m.reconcileMissingEpisodes(ctx, "Series", 1, 1, []*storage.Episode{&episode}, &ReconcileSnapshot{}, storage.QualityProfile{}, nil)
+		err = m.reconcileMissingEpisodes(ctx, releaseSeries{title: "Series"}, 1, 1, []*storage.Episode{&episode}, &ReconcileSnapshot{}, storage.QualityProfile{}, nil)
 		require.NoError(t, err)
 	})
 }
 func TestMediaManager_reconcileMissingSeason(t *testing.T) {
 	t.Run("nil season", func(t *testing.T) {
 		m := New(nil, nil, nil, nil, nil, config.Manager{}, config.Config{})
-		err := m.reconcileMissingSeason(context.Background(), "Series", nil, nil, storage.QualityProfile{}, nil)
+		err := m.reconcileMissingSeason(context.Background(), releaseSeries{title: "Series"}, nil, nil, storage.QualityProfile{}, nil)
 		require.Error(t, err)
 		assert.Equal(t, "season is nil", err.Error())
 	})
@@ -480,7 +642,7 @@ func TestMediaManager_reconcileMissingSeason(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		err := m.reconcileMissingSeason(ctx, "Series", season, nil, storage.QualityProfile{}, nil)
+		err := m.reconcileMissingSeason(ctx, releaseSeries{title: "Series"}, season, nil, storage.QualityProfile{}, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not found in storage")
 	})
@@ -506,7 +668,7 @@ func TestMediaManager_reconcileMissingSeason(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		err = m.reconcileMissingSeason(ctx, "Series", season, nil, storage.QualityProfile{}, nil)
+		err = m.reconcileMissingSeason(ctx, releaseSeries{title: "Series"}, season, nil, storage.QualityProfile{}, nil)
 		require.NoError(t, err)
 	})
 
@@ -643,7 +805,7 @@ func TestMediaManager_reconcileMissingSeason(t *testing.T) {
 
 		m := New(nil, nil, nil, store, mockFactory, config.Manager{}, config.Config{})
 
-		err = m.reconcileMissingSeason(ctx, "Series", season, snapshot, qualityProfile, releases)
+		err = m.reconcileMissingSeason(ctx, releaseSeries{title: "Series"}, season, snapshot, qualityProfile, releases)
 		require.NoError(t, err)
 
 		episodes, err := store.ListEpisodes(ctx)
@@ -803,7 +965,7 @@ func TestMediaManager_reconcileMissingSeason(t *testing.T) {
 
 		m := New(nil, nil, nil, store, mockFactory, config.Manager{}, config.Config{})
 
-		err = m.reconcileMissingSeason(ctx, "Series", season, snapshot, qualityProfile, releases)
+		err = m.reconcileMissingSeason(ctx, releaseSeries{title: "Series"}, season, snapshot, qualityProfile, releases)
 		require.NoError(t, err)
 
 		episodes, err := store.ListEpisodes(ctx)
@@ -984,13 +1146,13 @@ func TestMediaManager_ReconcileMissingSeries(t *testing.T) {
 		releases := []*prowlarr.ReleaseResource{
 			{
 				ID:       ptr.To(int32(1)),
-				Title:    nullable.NewNullableWithValue("Series.S01E01.1080p.WEB-DL.AAC2.0.x264-GROUP"),
+				Title:    nullable.NewNullableWithValue("Series.S01E01.720p.WEB-DL.AAC2.0.x264-GROUP"),
 				Size:     sizeGBToBytes(2),
 				Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
 			},
 			{
 				ID:       ptr.To(int32(2)),
-				Title:    nullable.NewNullableWithValue("Series.S01E02.1080p.WEB-DL.AAC2.0.x264-GROUP"),
+				Title:    nullable.NewNullableWithValue("Series.S01E02.720p.WEB-DL.AAC2.0.x264-GROUP"),
 				Size:     sizeGBToBytes(2),
 				Protocol: ptr.To(prowlarr.DownloadProtocolTorrent),
 			},
@@ -1597,9 +1759,9 @@ func TestMediaManager_matchEpisodeFileToEpisode(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.S01E03.1080p.WEB-DL.x264-GROUP.mkv", episodes)
-		require.NotNil(t, result)
-		assert.Equal(t, int32(3), result.ID)
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.S01E03.1080p.WEB-DL.x264-GROUP.mkv", episodes, releaseSeries{})
+		require.Len(t, result, 1)
+		assert.Equal(t, int32(3), result[0].ID)
 	})
 
 	t.Run("matches 1x05 format", func(t *testing.T) {
@@ -1620,9 +1782,9 @@ func TestMediaManager_matchEpisodeFileToEpisode(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.1x05.720p.HDTV.x264-GROUP.mkv", episodes)
-		require.NotNil(t, result)
-		assert.Equal(t, int32(5), result.ID)
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.1x05.720p.HDTV.x264-GROUP.mkv", episodes, releaseSeries{})
+		require.Len(t, result, 1)
+		assert.Equal(t, int32(5), result[0].ID)
 	})
 
 	t.Run("no match when episode number not found", func(t *testing.T) {
@@ -1635,8 +1797,8 @@ func TestMediaManager_matchEpisodeFileToEpisode(t *testing.T) {
 		episodes := []*storage.Episode{{Episode: model.Episode{ID: 6, EpisodeMetadataID: ptr.To(int32(episodeMetadataID6))}}}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.Some.Random.File.mkv", episodes)
-		assert.Nil(t, result)
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.Some.Random.File.mkv", episodes, releaseSeries{})
+		assert.Empty(t, result)
 	})
 
 	t.Run("no match when episode number doesn't exist in episodes", func(t *testing.T) {
@@ -1654,8 +1816,102 @@ func TestMediaManager_matchEpisodeFileToEpisode(t *testing.T) {
 		}
 
 		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
-		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.S01E10.1080p.WEB-DL.x264-GROUP.mkv", episodes)
-		assert.Nil(t, result)
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.S01E10.1080p.WEB-DL.x264-GROUP.mkv", episodes, releaseSeries{})
+		assert.Empty(t, result)
+	})
+
+	t.Run("matches multi-episode file", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		seasonMetadataID, err := store.CreateSeasonMetadata(ctx, model.SeasonMetadata{TmdbID: 3, Title: "Season 1", Number: 1})
+		require.NoError(t, err)
+
+		var episodes []*storage.Episode
+		for i := int32(1); i <= 3; i++ {
+			id, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 20 + i, Title: "Episode", Number: i, SeasonMetadataID: int32(seasonMetadataID)})
+			require.NoError(t, err)
+			episodes = append(episodes, &storage.Episode{Episode: model.Episode{ID: i, EpisodeMetadataID: ptr.To(int32(id))}})
+		}
+
+		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.S01E01E02.1080p.WEB-DL.x264-GROUP.mkv", episodes, releaseSeries{})
+		require.Len(t, result, 2)
+		assert.Equal(t, int32(1), result[0].ID)
+		assert.Equal(t, int32(2), result[1].ID)
+
+		result = m.matchEpisodeFileToEpisode(ctx, "/downloads/Series.Name.S01E02-E03.1080p.WEB-DL.x264-GROUP.mkv", episodes, releaseSeries{})
+		require.Len(t, result, 2)
+		assert.Equal(t, int32(2), result[0].ID)
+		assert.Equal(t, int32(3), result[1].ID)
+	})
+
+	t.Run("matches anime absolute number", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		seriesMetadataID, err := store.CreateSeriesMetadata(ctx, model.SeriesMetadata{TmdbID: 1, Title: "Show"})
+		require.NoError(t, err)
+
+		var episodeMetadataIDs []int64
+		for _, seasonNumber := range []int32{0, 1, 2} {
+			seasonMetadataID, err := store.CreateSeasonMetadata(ctx, model.SeasonMetadata{SeriesMetadataID: int32(seriesMetadataID), TmdbID: 10 + seasonNumber, Title: "Season", Number: seasonNumber})
+			require.NoError(t, err)
+
+			for _, episodeNumber := range []int32{1, 2} {
+				id, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 100 + seasonNumber*10 + episodeNumber, Title: "Episode", Number: episodeNumber, SeasonMetadataID: int32(seasonMetadataID)})
+				require.NoError(t, err)
+				if seasonNumber == 2 {
+					episodeMetadataIDs = append(episodeMetadataIDs, id)
+				}
+			}
+		}
+
+		episodes := []*storage.Episode{
+			{Episode: model.Episode{ID: 1, EpisodeMetadataID: ptr.To(int32(episodeMetadataIDs[0]))}},
+			{Episode: model.Episode{ID: 2, EpisodeMetadataID: ptr.To(int32(episodeMetadataIDs[1]))}},
+		}
+
+		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		series := &storage.Series{Series: model.Series{SeriesType: storage.SeriesTypeAnime, SeriesMetadataID: ptr.To(int32(seriesMetadataID))}}
+		numbering, err := m.seriesAbsoluteNumbering(ctx, series)
+		require.NoError(t, err)
+		require.NotNil(t, numbering)
+
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/[SubsPlease] Show - 04 (1080p) [ABCD1234].mkv", episodes, releaseSeries{numbering: numbering})
+		require.Len(t, result, 1)
+		assert.Equal(t, int32(2), result[0].ID)
+
+		result = m.matchEpisodeFileToEpisode(ctx, "/downloads/[SubsPlease] Show - 04 (1080p) [ABCD1234].mkv", episodes, releaseSeries{})
+		assert.Empty(t, result)
+	})
+
+	t.Run("matches daily air date", func(t *testing.T) {
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		seasonMetadataID, err := store.CreateSeasonMetadata(ctx, model.SeasonMetadata{TmdbID: 1, Title: "Season 2026", Number: 2026})
+		require.NoError(t, err)
+
+		episodeMetadataID1, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 1, Title: "Guest One", Number: 119, SeasonMetadataID: int32(seasonMetadataID), AirDate: ptr.To(time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))})
+		require.NoError(t, err)
+		episodeMetadataID2, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 2, Title: "Guest Two", Number: 120, SeasonMetadataID: int32(seasonMetadataID), AirDate: ptr.To(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))})
+		require.NoError(t, err)
+
+		episodes := []*storage.Episode{
+			{Episode: model.Episode{ID: 1, EpisodeMetadataID: ptr.To(int32(episodeMetadataID1))}},
+			{Episode: model.Episode{ID: 2, EpisodeMetadataID: ptr.To(int32(episodeMetadataID2))}},
+		}
+
+		m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		daily := releaseSeries{title: "The Daily Show", seriesType: storage.SeriesTypeDaily}
+
+		result := m.matchEpisodeFileToEpisode(ctx, "/downloads/The.Daily.Show.2026.10.15.Guest.Two.1080p.WEB.h264-GROUP.mkv", episodes, daily)
+		require.Len(t, result, 1)
+		assert.Equal(t, int32(2), result[0].ID)
+
+		result = m.matchEpisodeFileToEpisode(ctx, "/downloads/The.Daily.Show.2026.10.16.Guest.Three.1080p.WEB.h264-GROUP.mkv", episodes, daily)
+		assert.Empty(t, result)
 	})
 }
 
@@ -1718,3 +1974,238 @@ func Test_Manager_ReconcileSeries(t *testing.T) {
 		assert.Contains(t, errStr, "episodes error")
 	})
 }
+
+func TestMediaManager_discoveredEpisodeNumber(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t, ctx)
+	m := New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+
+	seriesMetadataID, err := store.CreateSeriesMetadata(ctx, model.SeriesMetadata{TmdbID: 1, Title: "Show"})
+	require.NoError(t, err)
+
+	seasonMetadataIDs := make(map[int32]int32)
+	for _, seasonNumber := range []int32{1, 2} {
+		id, err := store.CreateSeasonMetadata(ctx, model.SeasonMetadata{SeriesMetadataID: int32(seriesMetadataID), TmdbID: seasonNumber, Title: "Season", Number: seasonNumber})
+		require.NoError(t, err)
+		seasonMetadataIDs[seasonNumber] = int32(id)
+
+		for _, episodeNumber := range []int32{1, 2} {
+			_, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{
+				TmdbID:           seasonNumber*10 + episodeNumber,
+				Title:            "Episode",
+				Number:           episodeNumber,
+				SeasonMetadataID: int32(id),
+				AirDate:          ptr.To(time.Date(2026, time.Month(seasonNumber), int(episodeNumber), 0, 0, 0, 0, time.UTC)),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	season := &storage.Season{Season: model.Season{SeasonNumber: 2, SeasonMetadataID: ptr.To(seasonMetadataIDs[2])}}
+
+	t.Run("daily file by air date", func(t *testing.T) {
+		series := &storage.Series{Series: model.Series{SeriesType: storage.SeriesTypeDaily, SeriesMetadataID: ptr.To(int32(seriesMetadataID))}}
+		file := library.EpisodeFileFromPath("Show/Show.2026.02.02.Guest.mkv")
+		assert.Equal(t, 2, m.discoveredEpisodeNumber(ctx, series, season, file))
+
+		file = library.EpisodeFileFromPath("Show/Show.2026.01.02.Guest.mkv")
+		assert.Equal(t, 0, m.discoveredEpisodeNumber(ctx, series, season, file))
+	})
+
+	t.Run("anime file by absolute number", func(t *testing.T) {
+		series := &storage.Series{Series: model.Series{SeriesType: storage.SeriesTypeAnime, SeriesMetadataID: ptr.To(int32(seriesMetadataID))}}
+		file := library.EpisodeFileFromPath("Show/Show - 03 [1080p].mkv")
+		assert.Equal(t, 1, m.discoveredEpisodeNumber(ctx, series, season, file))
+
+		file = library.EpisodeFileFromPath("Show/Show - 02 [1080p].mkv")
+		assert.Equal(t, 0, m.discoveredEpisodeNumber(ctx, series, season, file))
+	})
+
+	t.Run("standard series", func(t *testing.T) {
+		series := &storage.Series{Series: model.Series{SeriesType: storage.SeriesTypeStandard, SeriesMetadataID: ptr.To(int32(seriesMetadataID))}}
+		file := library.EpisodeFileFromPath("Show/Show - 03 [1080p].mkv")
+		assert.Equal(t, 0, m.discoveredEpisodeNumber(ctx, series, season, file))
+	})
+}
+
+func TestMediaManager_addEpisodeFileToLibrary(t *testing.T) {
+	series := &storage.Series{Series: model.Series{Path: ptr.To("Series")}}
+	seriesMetadata := &model.SeriesMetadata{Title: "Series"}
+
+	t.Run("links a multi-episode file to every episode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		var episodes []*storage.Episode
+		for i := int32(1); i <= 3; i++ {
+			episodeID, err := store.CreateEpisode(ctx, storage.Episode{
+				Episode: model.Episode{SeasonID: 1, EpisodeNumber: i, Monitored: 1},
+			}, storage.EpisodeStateMissing)
+			require.NoError(t, err)
+
+			episode, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+			require.NoError(t, err)
+			episodes = append(episodes, episode)
+		}
+
+		// the third episode already has a file and keeps it
+		err := store.UpdateEpisodeEpisodeFileID(ctx, int64(episodes[2].ID), 99)
+		require.NoError(t, err)
+		episodes[2].EpisodeFileID = ptr.To(int32(99))
+
+		filePath := "/downloads/Series.S01E01E02E03.1080p.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		libraryMock.EXPECT().AddEpisode(ctx, "Series/Season 01/"+originalName(filePath), filePath).Return(library.EpisodeFile{
+			Size:         100,
+			RelativePath: "Series/Season 1/Series.S01E01E02E03.1080p.mkv",
+		}, nil).Times(1)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{})
+		replaced, err := m.addEpisodeFileToLibrary(ctx, series, seriesMetadata, 1, filePath, episodes...)
+		require.NoError(t, err)
+		assert.Empty(t, replaced)
+
+		first, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int32(episodes[0].ID)))
+		require.NoError(t, err)
+		second, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int32(episodes[1].ID)))
+		require.NoError(t, err)
+		third, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int32(episodes[2].ID)))
+		require.NoError(t, err)
+
+		require.NotNil(t, first.EpisodeFileID)
+		require.NotNil(t, second.EpisodeFileID)
+		assert.Equal(t, *first.EpisodeFileID, *second.EpisodeFileID)
+		assert.Equal(t, int32(99), *third.EpisodeFileID)
+	})
+
+	t.Run("upgrade moves the replaced file to the recycle bin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		oldPath := "Series/Season 1/Series.S01E01.720p.HDTV.mkv"
+		oldFileID, err := store.CreateEpisodeFile(ctx, model.EpisodeFile{Size: 50, RelativePath: ptr.To(oldPath)})
+		require.NoError(t, err)
+
+		episodeID, err := store.CreateEpisode(ctx, storage.Episode{
+			Episode: model.Episode{SeasonID: 1, EpisodeNumber: 1, Monitored: 1},
+		}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateEpisodeEpisodeFileID(ctx, episodeID, oldFileID))
+
+		episode, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+		require.NoError(t, err)
+		episode.IsUpgrade = true
+
+		filePath := "/downloads/Series.S01E01.1080p.WEB-DL.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		libraryMock.EXPECT().AddEpisode(ctx, "Series/Season 01/"+originalName(filePath), filePath).Return(library.EpisodeFile{
+			Size:         100,
+			RelativePath: "Series/Season 1/Series.S01E01.1080p.WEB-DL.mkv",
+		}, nil).Times(1)
+		libraryMock.EXPECT().RecycleSeriesFile(gomock.Any(), oldPath, "/recycle").Return("/recycle/tv/"+oldPath, nil).Times(1)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: "/recycle"}})
+		replaced, err := m.addEpisodeFileToLibrary(ctx, series, seriesMetadata, 1, filePath, episode)
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]string{episode.ID: oldPath}, replaced)
+
+		updated, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+		require.NoError(t, err)
+		require.NotNil(t, updated.EpisodeFileID)
+		assert.NotEqual(t, int32(oldFileID), *updated.EpisodeFileID)
+
+		_, err = store.GetEpisodeFile(ctx, int32(oldFileID))
+		assert.Error(t, err)
+	})
+
+	t.Run("upgrade to the same path replaces the file in place", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		oldPath := "Series/Season 01/Series - S01E01.mkv"
+		oldFileID, err := store.CreateEpisodeFile(ctx, model.EpisodeFile{Size: 50, RelativePath: ptr.To(oldPath)})
+		require.NoError(t, err)
+
+		episodeID, err := store.CreateEpisode(ctx, storage.Episode{
+			Episode: model.Episode{SeasonID: 1, EpisodeNumber: 1, Monitored: 1},
+		}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateEpisodeEpisodeFileID(ctx, episodeID, oldFileID))
+
+		episode, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+		require.NoError(t, err)
+		episode.IsUpgrade = true
+
+		filePath := "/downloads/Series.S01E01.1080p.WEB-DL.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		gomock.InOrder(
+			libraryMock.EXPECT().MoveSeriesFile(ctx, oldPath, "Series/Season 01/Series - S01E01.replaced.mkv").Return(nil),
+			libraryMock.EXPECT().AddEpisode(ctx, "Series/Season 01/Series - S01E01", filePath).Return(library.EpisodeFile{
+				Size:         100,
+				RelativePath: oldPath,
+			}, nil),
+			libraryMock.EXPECT().DeleteSeriesFile(gomock.Any(), "Series/Season 01/Series - S01E01.replaced.mkv").Return(nil),
+		)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{Library: config.Library{
+			EpisodeFileFormat: "{Title} - S{Season:00}E{Episode:00}",
+		}})
+		replaced, err := m.addEpisodeFileToLibrary(ctx, series, seriesMetadata, 1, filePath, episode)
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]string{episode.ID: oldPath}, replaced)
+
+		updated, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+		require.NoError(t, err)
+		require.NotNil(t, updated.EpisodeFileID)
+		assert.NotEqual(t, int32(oldFileID), *updated.EpisodeFileID)
+
+		file, err := store.GetEpisodeFile(ctx, *updated.EpisodeFileID)
+		require.NoError(t, err)
+		assert.Equal(t, oldPath, *file.RelativePath)
+		assert.Equal(t, int64(100), file.Size)
+
+		_, err = store.GetEpisodeFile(ctx, int32(oldFileID))
+		assert.Error(t, err)
+	})
+
+	t.Run("names the file with the configured formats", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+		store := newStore(t, ctx)
+
+		metadataID, err := store.CreateEpisodeMetadata(ctx, model.EpisodeMetadata{TmdbID: 10, Title: "Pilot", Number: 1})
+		require.NoError(t, err)
+
+		var episodes []*storage.Episode
+		for i := int32(2); i >= 1; i-- {
+			episode := model.Episode{SeasonID: 1, EpisodeNumber: i, Monitored: 1}
+			if i == 1 {
+				episode.EpisodeMetadataID = ptr.To(int32(metadataID))
+			}
+			episodeID, err := store.CreateEpisode(ctx, storage.Episode{Episode: episode}, storage.EpisodeStateMissing)
+			require.NoError(t, err)
+
+			stored, err := store.GetEpisode(ctx, table.Episode.ID.EQ(sqlite.Int64(episodeID)))
+			require.NoError(t, err)
+			episodes = append(episodes, stored)
+		}
+		slices.Reverse(episodes)
+
+		filePath := "/downloads/series.s01e01e02.1080p.bluray.mkv"
+		libraryMock := libraryMocks.NewMockLibrary(ctrl)
+		libraryMock.EXPECT().AddEpisode(ctx, "Series (2020)/S01/Series - S01E01-E02 - Pilot [Bluray-1080p]", filePath).Return(library.EpisodeFile{
+			Size:         100,
+			RelativePath: "Series (2020)/S01/Series - S01E01-E02 - Pilot [Bluray-1080p].mkv",
+		}, nil).Times(1)
+
+		m := New(nil, nil, libraryMock, store, nil, config.Manager{}, config.Config{Library: config.Library{
+			SeasonFolderFormat: "S{Season:00}",
+			EpisodeFileFormat:  "{Title} - S{Season:00}E{Episode:00} - {EpisodeTitle} [{Quality}]",
+		}})
+		_, err = m.addEpisodeFileToLibrary(ctx, &storage.Series{Series: model.Series{Path: ptr.To("Series (2020)")}}, seriesMetadata, 1, filePath, episodes...)
+		require.NoError(t, err)
+	})
+}
@@ -34,6 +34,10 @@ type SeriesService struct {
 	seriesMetaStorage storage.SeriesMetadataStorage
 	qualityService    *QualityService
 	metadataProvider  SeriesMetadataProvider
+	// folderFormat names the folder a new series' files are imported into
+	folderFormat string
+	// recycleBin is where deleted files are moved instead of being removed, they're removed if it's empty
+	recycleBin string
 }
 
 // NewSeriesService creates a SeriesService with the given dependencies.
@@ -82,13 +86,15 @@ func (s SeriesService) AddSeriesToLibrary(ctx context.Context, request AddSeries
 	if request.MonitorNewSeasons {
 		monitorNewSeasons = 1
 	}
+	folder := formatLibraryName(s.folderFormat, library.DefaultSeriesFolderFormat, seriesNameTokens(seriesMetadata), seriesMetadata.Title)
 	series = &storage.Series{
 		Series: model.Series{
 			SeriesMetadataID:  &seriesMetadata.ID,
 			QualityProfileID:  qualityProfile.ID,
 			Monitored:         1,
-			Path:              &seriesMetadata.Title,
+			Path:              &folder,
 			MonitorNewSeasons: monitorNewSeasons,
+			SeriesType:        request.SeriesType,
 		},
 	}
 
@@ -188,7 +194,7 @@ func (s SeriesService) AddSeriesToLibrary(ctx context.Context, request AddSeries
 	return series, err
 }
 
-// DeleteSeries removes a series and optionally its files from disk.
+// DeleteSeries removes a series and optionally its files from disk. The files are moved to the recycle bin if one is configured.
 func (s SeriesService) DeleteSeries(ctx context.Context, seriesID int64, deleteDirectory bool) error {
 	log := logger.FromCtx(ctx)
 
@@ -224,7 +230,11 @@ func (s SeriesService) DeleteSeries(ctx context.Context, seriesID int64, deleteD
 			return fmt.Errorf("cannot delete directory: series path is nil")
 		}
 
-		if err := s.library.DeleteSeriesDirectory(ctx, *series.Path); err != nil {
+		if s.recycleBin != "" {
+			if _, err := s.library.RecycleSeriesDirectory(ctx, *series.Path, s.recycleBin); err != nil {
+				return fmt.Errorf("failed to recycle series directory %s: %w", *series.Path, err)
+			}
+		} else if err := s.library.DeleteSeriesDirectory(ctx, *series.Path); err != nil {
 			return fmt.Errorf("failed to delete series directory %s: %w", *series.Path, err)
 		}
 
@@ -266,6 +276,28 @@ func (s SeriesService) UpdateSeriesMonitored(ctx context.Context, seriesID int64
 	return series, nil
 }
 
+// UpdateSeriesType sets whether a series is numbered by season and episode, absolute episode number or air date.
+func (s SeriesService) UpdateSeriesType(ctx context.Context, seriesID int64, seriesType string) (*storage.Series, error) {
+	switch seriesType {
+	case storage.SeriesTypeStandard, storage.SeriesTypeAnime, storage.SeriesTypeDaily:
+	default:
+		return nil, fmt.Errorf("%w: series type must be %s, %s or %s", ErrValidation, storage.SeriesTypeStandard, storage.SeriesTypeAnime, storage.SeriesTypeDaily)
+	}
+
+	err := s.seriesStorage.UpdateSeriesType(ctx, seriesID, seriesType)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.seriesStorage.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int64(seriesID)))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromCtx(ctx).Info("updated series type", zap.Int64("series_id", seriesID), zap.String("series_type", seriesType))
+	return series, nil
+}
+
 // ---------------------------------------------------------------------------
 // TV Detail & Search
 // ---------------------------------------------------------------------------
@@ -82,6 +82,32 @@ func TestSeriesService_UpdateSeriesMonitored_Error(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestSeriesService_UpdateSeriesType(t *testing.T) {
+	t.Run("updates series type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+		svc, store, _, _ := newTestSeriesService(ctrl)
+
+		series := &storage.Series{Series: model.Series{ID: 1, SeriesType: storage.SeriesTypeAnime}}
+		store.EXPECT().UpdateSeriesType(ctx, int64(1), storage.SeriesTypeAnime).Return(nil)
+		store.EXPECT().GetSeries(ctx, gomock.Any()).Return(series, nil)
+
+		result, err := svc.UpdateSeriesType(ctx, 1, storage.SeriesTypeAnime)
+		require.NoError(t, err)
+		assert.Equal(t, series, result)
+	})
+
+	t.Run("rejects unknown series type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+		svc, _, _, _ := newTestSeriesService(ctrl)
+
+		result, err := svc.UpdateSeriesType(ctx, 1, "weekly")
+		require.ErrorIs(t, err, ErrValidation)
+		assert.Nil(t, result)
+	})
+}
+
 func TestSeriesService_DeleteSeries(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
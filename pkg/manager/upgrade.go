@@ -0,0 +1,689 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/parser"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/size"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"go.uber.org/zap"
+)
+
+// properWindow is how long after a file is added to the library a proper or repack of it is searched for. Releases are
+// fixed soon after they come out, so a fix that wasn't out by then won't be.
+const properWindow = 14 * 24 * time.Hour
+
+// libraryFile is how a file already in the library ranks, comparable to a ReleaseScore
+type libraryFile struct {
+	// qualityRank is 0 if the file doesn't match any quality in the profile
+	qualityRank int
+	revision    int
+	added       time.Time
+}
+
+// newLibraryFile ranks a library file the same way its release would have been. The quality and revision come from the
+// release name the file was imported from, falling back to its paths.
+func newLibraryFile(profile storage.QualityProfile, runtime int32, sizeBytes int64, added time.Time, names ...*string) libraryFile {
+	file := libraryFile{revision: 1, added: added}
+
+	if i, ok := matchQuality(profile, identifyQuality(names...), uint64(size.BytesToMB(sizeBytes)), uint64(runtime)); ok {
+		file.qualityRank = len(profile.Qualities) - i
+	}
+
+	for _, name := range names {
+		if name != nil {
+			file.revision = max(file.revision, parser.Parse(*name).Revision)
+		}
+	}
+
+	return file
+}
+
+// upgradePolicy is how a quality profile lets a library file be upgraded
+type upgradePolicy struct {
+	// cutoff is the rank of the profile's cutoff quality, 0 if the profile doesn't allow quality upgrades
+	cutoff  int
+	propers bool
+}
+
+func (m MediaManager) upgradePolicy(profile storage.QualityProfile) upgradePolicy {
+	policy := upgradePolicy{propers: m.configs.UpgradePropers}
+	if profile.UpgradeAllowed {
+		policy.cutoff = cutoffRank(profile)
+	}
+
+	return policy
+}
+
+// cutoffRank returns the rank of the profile's cutoff quality, comparable to a ReleaseScore's QualityRank. It's 0 if
+// the profile doesn't have a cutoff.
+func cutoffRank(profile storage.QualityProfile) int {
+	if profile.CutoffQualityID == nil {
+		return 0
+	}
+
+	for i, q := range profile.Qualities {
+		if q.ID == *profile.CutoffQualityID {
+			return len(profile.Qualities) - i
+		}
+	}
+
+	return 0
+}
+
+func (p upgradePolicy) enabled() bool {
+	return p.cutoff > 0 || p.propers
+}
+
+// wants reports whether the file could be upgraded at all, so there's a reason to search. A file that meets the cutoff
+// is only searched for propers while one could still come out, see properWindow.
+func (p upgradePolicy) wants(file libraryFile, now time.Time) bool {
+	if file.qualityRank == 0 {
+		return false
+	}
+	if file.qualityRank < p.cutoff {
+		return true
+	}
+	return p.propers && now.Sub(file.added) < properWindow
+}
+
+// upgrades returns the ranked releases the file can be upgraded to, the preferred one first. A better quality is
+// preferred over a proper of the quality the file already has.
+func (p upgradePolicy) upgrades(file libraryFile, ranked []RankedRelease) []RankedRelease {
+	if file.qualityRank == 0 {
+		return nil
+	}
+
+	var upgrades []RankedRelease
+	if file.qualityRank < p.cutoff {
+		for _, r := range ranked {
+			if r.Score.QualityRank > file.qualityRank {
+				upgrades = append(upgrades, r)
+			}
+		}
+	}
+
+	if p.propers {
+		for _, r := range ranked {
+			if r.Score.QualityRank == file.qualityRank && r.Score.Revision > file.revision {
+				upgrades = append(upgrades, r)
+			}
+		}
+	}
+
+	return upgrades
+}
+
+// chooseUpgrade returns the upgrade to grab and why, or nil while there's none or the quality profile's delay profile
+// is still holding them back. Upgrades are delayed like any other release, see delayRelease.
+func (m MediaManager) chooseUpgrade(ctx context.Context, target pendingTarget, profile storage.QualityProfile, policy upgradePolicy, file libraryFile, ranked []RankedRelease, now time.Time) (*prowlarr.ReleaseResource, string, error) {
+	upgrades := policy.upgrades(file, ranked)
+
+	release, err := m.delayRelease(ctx, target, profile, upgrades, now)
+	if err != nil || release == nil {
+		return nil, "", err
+	}
+
+	// an upgrade of the quality the file already has is a proper
+	if slices.ContainsFunc(upgrades, func(r RankedRelease) bool {
+		return r.Release == release && r.Score.QualityRank == file.qualityRank
+	}) {
+		return release, storage.UpgradeReasonProper, nil
+	}
+
+	return release, storage.UpgradeReasonCutoff, nil
+}
+
+// SearchUpgrades searches the indexers for upgrades of downloaded movies and episodes. It's its own job, run less often
+// than the reconciles, since every file that can still be upgraded is searched for each time.
+func (m MediaManager) SearchUpgrades(ctx context.Context) error {
+	log := logger.FromCtx(ctx)
+
+	dcs, err := m.ListDownloadClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := newReconcileSnapshot(make([]model.Indexer, 0), dcs)
+
+	var allErrors error
+
+	err = m.ReconcileMovieUpgrades(ctx, snapshot)
+	if err != nil {
+		log.Error("failed to reconcile movie upgrades", zap.Error(err))
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	err = m.ReconcileEpisodeUpgrades(ctx, snapshot)
+	if err != nil {
+		log.Error("failed to reconcile episode upgrades", zap.Error(err))
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	// upgrades refused for disk space are searched for again next time, the job fails so it's noticed
+	allErrors = errors.Join(allErrors, snapshot.DiskSpaceError())
+
+	return allErrors
+}
+
+// ReconcileMovieUpgrades grabs better releases for downloaded movies. Movies below their quality profile's cutoff are
+// upgraded to a better quality, and if enabled a proper of the quality a movie already has is grabbed. The movie goes
+// back to downloading and the new file replaces the old one when it's imported.
+func (m MediaManager) ReconcileMovieUpgrades(ctx context.Context, snapshot *ReconcileSnapshot) error {
+	log := logger.FromCtx(ctx)
+
+	if snapshot == nil {
+		return fmt.Errorf("snapshot is nil")
+	}
+
+	indexers, err := m.listIndexersInternal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(indexers) == 0 {
+		log.Debug("skipping movie upgrades: no indexers available")
+		return nil
+	}
+	snapshot = snapshot.withIndexers(indexers)
+
+	movies, err := m.movieStorage.ListMoviesByState(ctx, storage.MovieStateDownloaded)
+	if err != nil {
+		return fmt.Errorf("couldn't list downloaded movies: %w", err)
+	}
+
+	for _, movie := range movies {
+		if err := ctx.Err(); err != nil {
+			log.Debug("context was canceled")
+			return nil
+		}
+		err = m.reconcileMovieUpgrade(ctx, movie, snapshot)
+		if err != nil {
+			log.Warn("failed to reconcile movie upgrade", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (m MediaManager) reconcileMovieUpgrade(ctx context.Context, movie *storage.Movie, snapshot *ReconcileSnapshot) error {
+	log := logger.FromCtx(ctx).With("reconcile loop", "movie upgrade", "movie id", movie.ID)
+
+	if movie.Monitored == 0 || movie.Path == nil || movie.MovieMetadataID == nil || movie.QualityProfileID == 0 {
+		log.Debug("movie can't be upgraded, skipping reconcile")
+		return nil
+	}
+
+	profile, err := m.GetQualityProfile(ctx, int64(movie.QualityProfileID))
+	if err != nil {
+		log.Warn("failed to find movie qualityprofile", zap.Int32("quality_id", movie.QualityProfileID))
+		return err
+	}
+
+	policy := m.upgradePolicy(profile)
+	if !policy.enabled() {
+		log.Debug("upgrades are disabled for the movie's quality profile, skipping reconcile")
+		return nil
+	}
+
+	files, err := m.movieStorage.GetMovieFilesByMovieName(ctx, *movie.Path)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Debug("movie has no files, skipping reconcile")
+			return nil
+		}
+		return err
+	}
+
+	det, err := m.movieMetaStorage.GetMovieMetadata(ctx, table.MovieMetadata.ID.EQ(sqlite.Int32(*movie.MovieMetadataID)))
+	if err != nil {
+		log.Debug("failed to find movie metadata", zap.Int32("meta_id", *movie.MovieMetadataID))
+		return err
+	}
+
+	current := mainMovieFile(files)
+	existing := newLibraryFile(profile, det.Runtime, current.Size, current.DateAdded, current.SceneName, current.OriginalFilePath, current.RelativePath)
+	if !policy.wants(existing, snapshot.time) {
+		log.Debug("movie file can't be upgraded, skipping reconcile")
+		return nil
+	}
+
+	releases, err := m.indexerService.SearchIndexers(ctx, snapshot.GetIndexerIDs(), MovieCategories, movieSearchOptions(det))
+	if err != nil {
+		log.Warn("some indexer sources failed during movie search", zap.Error(err))
+		if len(releases) == 0 {
+			return err
+		}
+	}
+
+	ranked, _, err := m.movieReleases(ctx, movie, det, profile, snapshot, releases)
+	if err != nil {
+		return err
+	}
+
+	release, reason, err := m.chooseUpgrade(ctx, moviePendingTarget(movie.ID), profile, policy, existing, ranked, snapshot.time)
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		log.Debug("no upgrade to grab for movie")
+		return nil
+	}
+
+	log.Info("found upgrade release", zap.Any("title", release.Title), zap.String("reason", reason))
+
+	clientID, status, err := m.requestReleaseDownload(ctx, snapshot, release)
+	if err != nil {
+		return fmt.Errorf("failed to add movie download request: %w", err)
+	}
+
+	metadata := releaseTransitionMetadata(release)
+	metadata.DownloadID = &status.ID
+	metadata.DownloadClientID = &clientID
+	metadata.IsUpgrade = ptr.To(true)
+	metadata.UpgradeReason = &reason
+
+	return m.updateMovieState(ctx, movie, storage.MovieStateDownloading, &metadata)
+}
+
+// mainMovieFile returns the largest of a movie's files, anything else is an extra
+func mainMovieFile(files []*model.MovieFile) *model.MovieFile {
+	main := files[0]
+	for _, f := range files[1:] {
+		if f.Size > main.Size {
+			main = f
+		}
+	}
+	return main
+}
+
+// removeReplacedMovieFiles removes the files an upgrade replaced from the library
+func (m MediaManager) removeReplacedMovieFiles(ctx context.Context, files []*model.MovieFile) error {
+	log := logger.FromCtx(ctx)
+
+	var errs error
+	for _, f := range files {
+		err := m.discardExtraFiles(ctx, table.ExtraFile.MovieFileID.EQ(sqlite.Int32(f.ID)), m.library.DeleteMovieFile, m.library.RecycleMovieFile)
+		if err != nil {
+			log.Warn("failed to remove replaced movie file's extra files", zap.Int32("movie file id", f.ID), zap.Error(err))
+		}
+
+		if f.RelativePath != nil {
+			err := m.discardLibraryFile(ctx, *f.RelativePath, m.library.DeleteMovieFile, m.library.RecycleMovieFile)
+			if err != nil {
+				log.Warn("failed to remove replaced movie file", zap.String("path", *f.RelativePath), zap.Error(err))
+				errs = errors.Join(errs, err)
+				continue
+			}
+		}
+
+		err = m.movieStorage.DeleteMovieFile(ctx, int64(f.ID))
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		log.Info("removed replaced movie file", zap.Int32("movie file id", f.ID))
+	}
+
+	return errs
+}
+
+// ReconcileEpisodeUpgrades grabs better releases for downloaded episodes the same way ReconcileMovieUpgrades does for
+// movies. The episode goes back to downloading and the new file replaces the old one when it's imported.
+func (m MediaManager) ReconcileEpisodeUpgrades(ctx context.Context, snapshot *ReconcileSnapshot) error {
+	log := logger.FromCtx(ctx)
+
+	if snapshot == nil {
+		return fmt.Errorf("snapshot is nil")
+	}
+
+	indexers, err := m.listIndexersInternal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(indexers) == 0 {
+		log.Debug("skipping episode upgrades: no indexers available")
+		return nil
+	}
+	snapshot = snapshot.withIndexers(indexers)
+
+	where := table.EpisodeTransition.ToState.IN(sqlite.String(string(storage.EpisodeStateDownloaded)), sqlite.String(string(storage.EpisodeStateCompleted))).
+		AND(table.EpisodeTransition.MostRecent.EQ(sqlite.Bool(true))).
+		AND(table.Episode.Monitored.EQ(sqlite.Int(1)))
+
+	episodes, err := m.seriesStorage.ListEpisodes(ctx, where)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("couldn't list downloaded episodes: %w", err)
+	}
+
+	// the season, series and quality profile are looked up once for all of a season's episodes
+	seasons := make(map[int32]*seasonUpgrades)
+	for _, episode := range episodes {
+		if err := ctx.Err(); err != nil {
+			log.Debug("context was canceled")
+			return nil
+		}
+
+		upgrade, ok := seasons[episode.SeasonID]
+		if !ok {
+			upgrade, err = m.seasonUpgrades(ctx, episode.SeasonID)
+			if err != nil {
+				log.Warn("failed to look up season for episode upgrades", zap.Int32("season id", episode.SeasonID), zap.Error(err))
+			}
+			seasons[episode.SeasonID] = upgrade
+		}
+		if upgrade == nil {
+			continue
+		}
+
+		err = m.reconcileEpisodeUpgrade(ctx, episode, upgrade, snapshot)
+		if err != nil {
+			log.Warn("failed to reconcile episode upgrade", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// seasonUpgrades is what searching for upgrades of a season's episodes needs
+type seasonUpgrades struct {
+	season         *storage.Season
+	seriesMetadata *model.SeriesMetadata
+	target         releaseSeries
+	profile        storage.QualityProfile
+	policy         upgradePolicy
+}
+
+// seasonUpgrades looks up the season's series and quality profile. It returns nil if the season's episodes can't be
+// upgraded.
+func (m MediaManager) seasonUpgrades(ctx context.Context, seasonID int32) (*seasonUpgrades, error) {
+	season, err := m.seriesStorage.GetSeason(ctx, table.Season.ID.EQ(sqlite.Int32(seasonID)))
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := m.seriesStorage.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int32(season.SeriesID)))
+	if err != nil {
+		return nil, err
+	}
+
+	if series.Monitored == 0 || series.QualityProfileID == 0 || series.SeriesMetadataID == nil {
+		return nil, nil
+	}
+
+	profile, err := m.GetQualityProfile(ctx, int64(series.QualityProfileID))
+	if err != nil {
+		return nil, err
+	}
+
+	policy := m.upgradePolicy(profile)
+	if !policy.enabled() {
+		return nil, nil
+	}
+
+	seriesMetadata, err := m.seriesMetaStorage.GetSeriesMetadata(ctx, table.SeriesMetadata.ID.EQ(sqlite.Int32(*series.SeriesMetadataID)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &seasonUpgrades{
+		season:         season,
+		seriesMetadata: seriesMetadata,
+		target:         m.newReleaseSeries(ctx, series, seriesMetadata),
+		profile:        profile,
+		policy:         policy,
+	}, nil
+}
+
+func (m MediaManager) reconcileEpisodeUpgrade(ctx context.Context, episode *storage.Episode, upgrade *seasonUpgrades, snapshot *ReconcileSnapshot) error {
+	log := logger.FromCtx(ctx).With("reconcile loop", "episode upgrade", "episode id", episode.ID)
+
+	if episode.EpisodeFileID == nil || episode.EpisodeMetadataID == nil {
+		log.Debug("episode can't be upgraded, skipping reconcile")
+		return nil
+	}
+
+	file, err := m.seriesStorage.GetEpisodeFile(ctx, *episode.EpisodeFileID)
+	if err != nil {
+		return err
+	}
+
+	episodeMetadata, err := m.seriesMetaStorage.GetEpisodeMetadata(ctx, table.EpisodeMetadata.ID.EQ(sqlite.Int32(*episode.EpisodeMetadataID)))
+	if err != nil {
+		return err
+	}
+
+	if episodeMetadata.Runtime == nil {
+		log.Debug("episode runtime is nil, skipping reconcile")
+		return nil
+	}
+
+	existing := newLibraryFile(upgrade.profile, *episodeMetadata.Runtime, file.Size, file.Added, file.SceneName, file.OriginalFilePath, file.RelativePath)
+	if !upgrade.policy.wants(existing, snapshot.time) {
+		log.Debug("episode file can't be upgraded, skipping reconcile")
+		return nil
+	}
+
+	target := upgrade.target
+
+	opts := seriesSearchOptions(ctx, upgrade.seriesMetadata)
+	opts.Season = &upgrade.season.SeasonNumber
+	opts.Episode = &episode.EpisodeNumber
+	if absolute, ok := target.numbering.absolute(episodeMetadata.ID); ok {
+		opts.AbsoluteEpisode = &absolute
+	}
+	if target.daily() {
+		opts.AirDate = episodeMetadata.AirDate
+	}
+
+	releases, err := m.indexerService.SearchIndexers(ctx, snapshot.GetIndexerIDs(), TVCategories, opts)
+	if err != nil {
+		log.Warn("some indexer sources failed during episode search", zap.Error(err))
+		if len(releases) == 0 {
+			return err
+		}
+	}
+
+	ranked, _, err := m.episodeReleases(ctx, target, upgrade.season.SeasonNumber, episode, episodeMetadata, snapshot, upgrade.profile, releases)
+	if err != nil {
+		return err
+	}
+
+	release, reason, err := m.chooseUpgrade(ctx, episodePendingTarget(episode.ID), upgrade.profile, upgrade.policy, existing, ranked, snapshot.time)
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		log.Debug("no upgrade to grab for episode")
+		return nil
+	}
+
+	log.Info("found upgrade release", zap.Any("title", release.Title), zap.String("reason", reason))
+
+	clientID, status, err := m.requestReleaseDownload(ctx, snapshot, release)
+	if err != nil {
+		return fmt.Errorf("failed to request episode release download: %w", err)
+	}
+
+	metadata := releaseTransitionMetadata(release)
+	metadata.DownloadID = &status.ID
+	metadata.DownloadClientID = &clientID
+	metadata.IsUpgrade = ptr.To(true)
+	metadata.UpgradeReason = &reason
+
+	return m.updateEpisodeState(ctx, *episode, storage.EpisodeStateDownloading, &metadata)
+}
+
+// removeReplacedEpisodeFile removes an episode file an upgrade replaced once no episode links to it anymore
+func (m MediaManager) removeReplacedEpisodeFile(ctx context.Context, file *model.EpisodeFile) error {
+	log := logger.FromCtx(ctx).With("episode file id", file.ID)
+
+	_, err := m.seriesStorage.GetEpisodeByEpisodeFileID(ctx, int64(file.ID))
+	if err == nil {
+		log.Debug("episode file is still linked to an episode, keeping it")
+		return nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	err = m.discardExtraFiles(ctx, table.ExtraFile.EpisodeFileID.EQ(sqlite.Int32(file.ID)), m.library.DeleteSeriesFile, m.library.RecycleSeriesFile)
+	if err != nil {
+		log.Warn("failed to remove replaced episode file's extra files", zap.Error(err))
+	}
+
+	if file.RelativePath != nil {
+		err = m.discardLibraryFile(ctx, *file.RelativePath, m.library.DeleteSeriesFile, m.library.RecycleSeriesFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.seriesStorage.DeleteEpisodeFile(ctx, int64(file.ID))
+	if err != nil {
+		return err
+	}
+
+	log.Info("removed replaced episode file")
+	return nil
+}
+
+// setAsidePath is where a file an upgrade replaces is moved while the new file is imported to its path, e.g.
+// "Movie (2020)/Movie (2020).replaced.mkv". It keeps the extension so the file can still be played if it's recycled.
+func setAsidePath(relativePath string) string {
+	ext := path.Ext(relativePath)
+	return strings.TrimSuffix(relativePath, ext) + ".replaced" + ext
+}
+
+// setAsideMovieFile moves the replaced movie file at target, the path an upgrade's file is about to be imported to, out
+// of the way so the new file can take its place. Its record follows it so it's discarded from there once the new file
+// is imported. The returned func moves it back if the new file isn't imported.
+func (m MediaManager) setAsideMovieFile(ctx context.Context, movieID int32, folder string, files []*model.MovieFile, target string) (func(), error) {
+	log := logger.FromCtx(ctx).With("movie id", movieID, "path", target)
+
+	i := slices.IndexFunc(files, func(f *model.MovieFile) bool {
+		return f.RelativePath != nil && *f.RelativePath == target
+	})
+	if i < 0 {
+		return func() {}, nil
+	}
+	file := files[i]
+	aside := setAsidePath(target)
+
+	move := func(from, to string) error {
+		err := m.library.MoveMovieFile(ctx, from, to)
+		if err != nil {
+			return err
+		}
+
+		err = m.movieStorage.UpdateMovieFilePaths(ctx, int64(movieID), folder, map[int32]string{file.ID: to}, nil)
+		if err != nil {
+			return errors.Join(err, m.library.MoveMovieFile(ctx, to, from))
+		}
+
+		file.RelativePath = &to
+		return nil
+	}
+
+	err := move(target, aside)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set aside replaced movie file: %w", err)
+	}
+	log.Debug("set aside replaced movie file", zap.String("to", aside))
+
+	return func() {
+		err := move(aside, target)
+		if err != nil {
+			log.Error("failed to move replaced movie file back", zap.String("from", aside), zap.Error(err))
+		}
+	}, nil
+}
+
+// setAsideEpisodeFile moves the replaced episode file at target out of the way of an upgrade's file the same way
+// setAsideMovieFile does for movies
+func (m MediaManager) setAsideEpisodeFile(ctx context.Context, files map[int32]*model.EpisodeFile, target string) (func(), error) {
+	log := logger.FromCtx(ctx).With("path", target)
+
+	var file *model.EpisodeFile
+	for _, f := range files {
+		if f.RelativePath != nil && *f.RelativePath == target {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return func() {}, nil
+	}
+	aside := setAsidePath(target)
+
+	move := func(from, to string) error {
+		err := m.library.MoveSeriesFile(ctx, from, to)
+		if err != nil {
+			return err
+		}
+
+		updated := *file
+		updated.RelativePath = &to
+		err = m.seriesStorage.UpdateEpisodeFile(ctx, file.ID, updated)
+		if err != nil {
+			return errors.Join(err, m.library.MoveSeriesFile(ctx, to, from))
+		}
+
+		file.RelativePath = &to
+		return nil
+	}
+
+	err := move(target, aside)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set aside replaced episode file: %w", err)
+	}
+	log.Debug("set aside replaced episode file", zap.Int32("episode file id", file.ID), zap.String("to", aside))
+
+	return func() {
+		err := move(aside, target)
+		if err != nil {
+			log.Error("failed to move replaced episode file back", zap.String("from", aside), zap.Error(err))
+		}
+	}, nil
+}
+
+// discardLibraryFile removes a file an upgrade replaced from the library. It's moved to the recycle bin if one is configured.
+func (m MediaManager) discardLibraryFile(ctx context.Context, relativePath string, remove func(context.Context, string) error, recycle func(context.Context, string, string) (string, error)) error {
+	if m.config.Library.RecycleBin == "" {
+		return remove(ctx, relativePath)
+	}
+
+	_, err := recycle(ctx, relativePath, m.config.Library.RecycleBin)
+	return err
+}
+
+// importedTransitionMetadata records the file an upgrade replaced on the transition back to downloaded
+func importedTransitionMetadata(isUpgrade bool, replacedFile string) *storage.TransitionStateMetadata {
+	if !isUpgrade {
+		return nil
+	}
+
+	metadata := &storage.TransitionStateMetadata{IsUpgrade: ptr.To(true)}
+	if replacedFile != "" {
+		metadata.ReplacedFile = &replacedFile
+	}
+
+	return metadata
+}
@@ -0,0 +1,380 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/download"
+	downloadMock "github.com/kasuboski/mediaz/pkg/download/mocks"
+	"github.com/kasuboski/mediaz/pkg/indexer"
+	indexerMock "github.com/kasuboski/mediaz/pkg/indexer/mocks"
+	"github.com/kasuboski/mediaz/pkg/prowlarr"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	storageMocks "github.com/kasuboski/mediaz/pkg/storage/mocks"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewLibraryFile(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{Name: "Bluray-1080p", MinSize: 50, PreferredSize: 80, MaxSize: 100},
+			{Name: "WEBDL-720p", MinSize: 5, PreferredSize: 20, MaxSize: 49},
+		},
+	}
+
+	t.Run("quality from size", func(t *testing.T) {
+		file := newLibraryFile(profile, 100, *sizeGBToBytes(2), time.Time{}, ptr.To("Movie.2020.720p.WEB-DL-GRP"))
+		assert.Equal(t, libraryFile{qualityRank: 1, revision: 1}, file)
+	})
+
+	t.Run("highest revision of the names", func(t *testing.T) {
+		file := newLibraryFile(profile, 100, *sizeGBToBytes(6), time.Time{}, nil, ptr.To("/downloads/Movie.2020.1080p.BluRay.REPACK2-GRP.mkv"), ptr.To("Movie/Movie.mkv"))
+		assert.Equal(t, libraryFile{qualityRank: 2, revision: 3}, file)
+	})
+
+	t.Run("size outside the profile", func(t *testing.T) {
+		file := newLibraryFile(profile, 100, *sizeGBToBytes(20), time.Time{})
+		assert.Equal(t, libraryFile{revision: 1}, file)
+	})
+}
+
+func TestUpgradePolicy_Propers(t *testing.T) {
+	original := &prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie.720p-GRP")}
+	proper := &prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie.720p.PROPER-GRP")}
+	betterQuality := &prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie.1080p.PROPER-GRP")}
+
+	ranked := []RankedRelease{
+		{Release: betterQuality, Score: ReleaseScore{QualityRank: 2, Revision: 2}},
+		{Release: proper, Score: ReleaseScore{QualityRank: 1, Revision: 2}},
+		{Release: original, Score: ReleaseScore{QualityRank: 1, Revision: 1}},
+	}
+
+	tests := []struct {
+		name string
+		file libraryFile
+		want []RankedRelease
+	}{
+		{name: "proper of the same quality", file: libraryFile{qualityRank: 1, revision: 1}, want: ranked[1:2]},
+		{name: "already a proper", file: libraryFile{qualityRank: 1, revision: 2}, want: nil},
+		{name: "no proper of the quality", file: libraryFile{qualityRank: 3, revision: 1}, want: nil},
+		{name: "unknown quality", file: libraryFile{revision: 1}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, upgradePolicy{propers: true}.upgrades(tt.file, ranked))
+		})
+	}
+}
+
+func TestUpgradePolicy(t *testing.T) {
+	profile := storage.QualityProfile{
+		Qualities: []storage.QualityDefinition{
+			{ID: 8, Name: "Bluray-1080p"},
+			{ID: 6, Name: "WEBDL-1080p"},
+			{ID: 2, Name: "WEBDL-720p"},
+		},
+		UpgradeAllowed:  true,
+		CutoffQualityID: ptr.To(int32(6)),
+	}
+
+	t.Run("cutoff rank", func(t *testing.T) {
+		m := MediaManager{}
+		assert.Equal(t, upgradePolicy{cutoff: 2}, m.upgradePolicy(profile))
+
+		disallowed := profile
+		disallowed.UpgradeAllowed = false
+		assert.Equal(t, upgradePolicy{}, m.upgradePolicy(disallowed))
+		assert.False(t, m.upgradePolicy(disallowed).enabled())
+
+		m.configs.UpgradePropers = true
+		assert.Equal(t, upgradePolicy{propers: true}, m.upgradePolicy(disallowed))
+	})
+
+	t.Run("wants", func(t *testing.T) {
+		now := time.Now()
+		policy := upgradePolicy{cutoff: 2}
+		assert.True(t, policy.wants(libraryFile{qualityRank: 1, revision: 1}, now))
+		assert.False(t, policy.wants(libraryFile{qualityRank: 2, revision: 1, added: now}, now))
+		assert.False(t, policy.wants(libraryFile{revision: 1}, now))
+
+		policy.propers = true
+		assert.True(t, policy.wants(libraryFile{qualityRank: 3, revision: 1, added: now.Add(-time.Hour)}, now))
+		assert.False(t, policy.wants(libraryFile{qualityRank: 3, revision: 1, added: now.Add(-properWindow)}, now), "a proper won't come out for an old file")
+		assert.True(t, policy.wants(libraryFile{qualityRank: 1, revision: 1, added: now.Add(-properWindow)}, now), "still below the cutoff")
+	})
+
+	bluray := &prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie.1080p.BluRay-GRP")}
+	webdl := &prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie.720p.WEB-DL-GRP")}
+	proper := &prowlarr.ReleaseResource{Title: nullable.NewNullableWithValue("Movie.720p.WEB-DL.PROPER-GRP")}
+
+	tests := []struct {
+		name       string
+		policy     upgradePolicy
+		file       libraryFile
+		ranked     []RankedRelease
+		wantRel    *prowlarr.ReleaseResource
+		wantReason string
+	}{
+		{
+			name:   "better quality below the cutoff",
+			policy: upgradePolicy{cutoff: 2, propers: true},
+			file:   libraryFile{qualityRank: 1, revision: 1},
+			ranked: []RankedRelease{
+				{Release: bluray, Score: ReleaseScore{QualityRank: 3, Revision: 1}},
+				{Release: proper, Score: ReleaseScore{QualityRank: 1, Revision: 2}},
+			},
+			wantRel:    bluray,
+			wantReason: storage.UpgradeReasonCutoff,
+		},
+		{
+			name:   "cutoff already met",
+			policy: upgradePolicy{cutoff: 2},
+			file:   libraryFile{qualityRank: 2, revision: 1},
+			ranked: []RankedRelease{
+				{Release: bluray, Score: ReleaseScore{QualityRank: 3, Revision: 1}},
+			},
+		},
+		{
+			name:   "nothing better than the file",
+			policy: upgradePolicy{cutoff: 2},
+			file:   libraryFile{qualityRank: 1, revision: 1},
+			ranked: []RankedRelease{
+				{Release: webdl, Score: ReleaseScore{QualityRank: 1, Revision: 1}},
+			},
+		},
+		{
+			name:   "proper when there's no better quality",
+			policy: upgradePolicy{cutoff: 2, propers: true},
+			file:   libraryFile{qualityRank: 1, revision: 1},
+			ranked: []RankedRelease{
+				{Release: proper, Score: ReleaseScore{QualityRank: 1, Revision: 2}},
+				{Release: webdl, Score: ReleaseScore{QualityRank: 1, Revision: 1}},
+			},
+			wantRel:    proper,
+			wantReason: storage.UpgradeReasonProper,
+		},
+		{
+			name:   "propers disabled",
+			policy: upgradePolicy{cutoff: 2},
+			file:   libraryFile{qualityRank: 1, revision: 1},
+			ranked: []RankedRelease{
+				{Release: proper, Score: ReleaseScore{QualityRank: 1, Revision: 2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := New(nil, nil, nil, newStore(t, ctx), nil, config.Manager{}, config.Config{})
+
+			release, reason, err := m.chooseUpgrade(ctx, moviePendingTarget(1), profile, tt.policy, tt.file, tt.ranked, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRel, release)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func Test_Manager_ReconcileMovieUpgrades(t *testing.T) {
+	ctx := context.Background()
+
+	torrent := ptr.To(prowlarr.DownloadProtocolTorrent)
+	original := &prowlarr.ReleaseResource{ID: ptr.To(int32(1)), Title: nullable.NewNullableWithValue("Test.Movie.720p.HDTV-GRP"), Size: sizeGBToBytes(2), Seeders: nullable.NewNullableWithValue(int32(50)), Protocol: torrent}
+	proper := &prowlarr.ReleaseResource{ID: ptr.To(int32(2)), Title: nullable.NewNullableWithValue("Test.Movie.720p.HDTV.PROPER-GRP"), Size: sizeGBToBytes(2), Seeders: nullable.NewNullableWithValue(int32(5)), Protocol: torrent}
+	bluray := &prowlarr.ReleaseResource{ID: ptr.To(int32(3)), Title: nullable.NewNullableWithValue("Test.Movie.1080p.BluRay-GRP"), Size: sizeGBToBytes(6), Seeders: nullable.NewNullableWithValue(int32(5)), Protocol: torrent}
+
+	setup := func(t *testing.T, cfg config.Manager, sceneName string, profileID int32) (MediaManager, storage.Storage, int64, *ReconcileSnapshot, *downloadMock.MockDownloadClient) {
+		ctrl := gomock.NewController(t)
+		store := newStore(t, ctx)
+
+		mockIndexerSource := indexerMock.NewMockIndexerSource(ctrl)
+		mockIndexerSource.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*prowlarr.ReleaseResource{original, proper, bluray}, nil).AnyTimes()
+		mockIndexerSource.EXPECT().ListIndexers(gomock.Any()).Return([]indexer.SourceIndexer{{ID: 1, Name: "test", Priority: 1}}, nil).AnyTimes()
+
+		indexerFactory := indexerMock.NewMockFactory(ctrl)
+		indexerFactory.EXPECT().NewIndexerSource(gomock.Any()).Return(mockIndexerSource, nil).AnyTimes()
+
+		downloadClient := model.DownloadClient{Implementation: "transmission", Type: "torrent", Port: 8080, Host: "transmission", Scheme: "http"}
+		downloadClientID, err := store.CreateDownloadClient(ctx, downloadClient)
+		require.NoError(t, err)
+		downloadClient.ID = int32(downloadClientID)
+
+		mockDownloadClient := downloadMock.NewMockDownloadClient(ctrl)
+		mockFactory := downloadMock.NewMockFactory(ctrl)
+		mockFactory.EXPECT().NewDownloadClient(downloadClient).Return(mockDownloadClient, nil).AnyTimes()
+
+		m := New(nil, indexerFactory, nil, store, mockFactory, cfg, config.Config{})
+
+		sourceID, err := store.CreateIndexerSource(ctx, model.IndexerSource{Name: "test-source", Implementation: "prowlarr", Scheme: "http", Host: "test", Enabled: true})
+		require.NoError(t, err)
+		require.NoError(t, m.RefreshIndexerSource(ctx, sourceID))
+
+		metaID, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{Title: "Test Movie", TmdbID: 1234, Runtime: 100})
+		require.NoError(t, err)
+
+		movieID, err := store.CreateMovie(ctx, storage.Movie{
+			Movie: model.Movie{
+				Monitored:        1,
+				QualityProfileID: profileID,
+				Path:             ptr.To("Test Movie"),
+				MovieMetadataID:  ptr.To(int32(metaID)),
+			},
+		}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, nil))
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloaded, nil))
+
+		fileID, err := store.CreateMovieFile(ctx, model.MovieFile{
+			RelativePath: ptr.To("Test Movie/Test Movie.mkv"),
+			Size:         *sizeGBToBytes(2),
+			SceneName:    ptr.To(sceneName),
+		})
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateMovieMovieFileID(ctx, movieID, fileID))
+
+		snapshot := newReconcileSnapshot(nil, []*model.DownloadClient{&downloadClient})
+
+		return m, store, movieID, snapshot, mockDownloadClient
+	}
+
+	t.Run("grabs a proper of the same quality", func(t *testing.T) {
+		m, _, movieID, snapshot, mockDownloadClient := setup(t, config.Manager{UpgradePropers: true}, "Test.Movie.720p.HDTV-GRP", 1)
+		mockDownloadClient.EXPECT().Add(gomock.Any(), download.AddRequest{Release: proper}).Return(download.Status{ID: "proper"}, nil).Times(1)
+
+		err := m.ReconcileMovieUpgrades(ctx, snapshot)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloading, movie.State)
+		assert.True(t, movie.IsUpgrade)
+		assert.Equal(t, "proper", movie.DownloadID)
+	})
+
+	t.Run("grabs a better quality below the cutoff", func(t *testing.T) {
+		m, store, movieID, snapshot, mockDownloadClient := setup(t, config.Manager{}, "Test.Movie.720p.WEBRip-GRP", 2)
+		require.NoError(t, store.UpdateQualityProfile(ctx, 2, model.QualityProfile{Name: "High Definition", UpgradeAllowed: true, CutoffQualityID: ptr.To(int32(8))}))
+		mockDownloadClient.EXPECT().Add(gomock.Any(), download.AddRequest{Release: bluray}).Return(download.Status{ID: "bluray"}, nil).Times(1)
+
+		err := m.ReconcileMovieUpgrades(ctx, snapshot)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloading, movie.State)
+		assert.True(t, movie.IsUpgrade)
+
+		history, err := store.GetEntityTransitions(ctx, "movie", movieID)
+		require.NoError(t, err)
+		grab := history.History[len(history.History)-1].Metadata
+		require.NotNil(t, grab)
+		assert.Equal(t, storage.UpgradeReasonCutoff, grab.UpgradeReason)
+		assert.Equal(t, "Test.Movie.1080p.BluRay-GRP", grab.ReleaseTitle)
+	})
+
+	t.Run("file is already a proper", func(t *testing.T) {
+		m, _, movieID, snapshot, _ := setup(t, config.Manager{UpgradePropers: true}, "Test.Movie.720p.HDTV.PROPER-GRP", 1)
+
+		err := m.ReconcileMovieUpgrades(ctx, snapshot)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+	})
+
+	t.Run("doesn't look for a proper of an old file", func(t *testing.T) {
+		m, _, movieID, snapshot, _ := setup(t, config.Manager{UpgradePropers: true}, "Test.Movie.720p.HDTV-GRP", 1)
+		snapshot.time = time.Now().Add(properWindow)
+
+		err := m.ReconcileMovieUpgrades(ctx, snapshot)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+	})
+
+	t.Run("delays the upgrade with the delay profile", func(t *testing.T) {
+		cfg := config.Manager{UpgradePropers: true, DelayProfiles: []config.DelayProfile{{Torrent: time.Hour}}}
+		m, store, movieID, snapshot, _ := setup(t, cfg, "Test.Movie.720p.HDTV-GRP", 1)
+
+		err := m.ReconcileMovieUpgrades(ctx, snapshot)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+
+		pending, err := store.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "Test.Movie.720p.HDTV.PROPER-GRP", pending[0].Title)
+		assert.Equal(t, int32(movieID), *pending[0].MovieID)
+	})
+
+	t.Run("searched by the upgrade search job", func(t *testing.T) {
+		m, _, movieID, _, mockDownloadClient := setup(t, config.Manager{UpgradePropers: true}, "Test.Movie.720p.HDTV-GRP", 1)
+		mockDownloadClient.EXPECT().Add(gomock.Any(), download.AddRequest{Release: proper}).Return(download.Status{ID: "proper"}, nil).Times(1)
+
+		err := m.SearchUpgrades(ctx)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloading, movie.State)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m, _, movieID, snapshot, _ := setup(t, config.Manager{}, "Test.Movie.720p.HDTV-GRP", 1)
+
+		err := m.ReconcileMovieUpgrades(ctx, snapshot)
+		require.NoError(t, err)
+
+		movie, err := m.movieStorage.GetMovie(ctx, movieID)
+		require.NoError(t, err)
+		assert.Equal(t, storage.MovieStateDownloaded, movie.State)
+	})
+}
+
+func Test_Manager_ReconcileEpisodeUpgrades(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("looks up a season once for its episodes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := newStore(t, ctx)
+
+		mockIndexerSource := indexerMock.NewMockIndexerSource(ctrl)
+		mockIndexerSource.EXPECT().ListIndexers(gomock.Any()).Return([]indexer.SourceIndexer{{ID: 1, Name: "test", Priority: 1}}, nil).AnyTimes()
+		indexerFactory := indexerMock.NewMockFactory(ctrl)
+		indexerFactory.EXPECT().NewIndexerSource(gomock.Any()).Return(mockIndexerSource, nil).AnyTimes()
+
+		m := New(nil, indexerFactory, nil, store, nil, config.Manager{UpgradePropers: true}, config.Config{})
+
+		sourceID, err := store.CreateIndexerSource(ctx, model.IndexerSource{Name: "test-source", Implementation: "prowlarr", Scheme: "http", Host: "test", Enabled: true})
+		require.NoError(t, err)
+		require.NoError(t, m.RefreshIndexerSource(ctx, sourceID))
+
+		// the series isn't monitored, so nothing is looked up for its episodes after the season and series
+		seriesStorage := storageMocks.NewMockSeriesStorage(ctrl)
+		seriesStorage.EXPECT().ListEpisodes(gomock.Any(), gomock.Any()).Return([]*storage.Episode{
+			{Episode: model.Episode{ID: 1, SeasonID: 1, EpisodeNumber: 1, EpisodeFileID: ptr.To(int32(1))}},
+			{Episode: model.Episode{ID: 2, SeasonID: 1, EpisodeNumber: 2, EpisodeFileID: ptr.To(int32(2))}},
+		}, nil)
+		seriesStorage.EXPECT().GetSeason(gomock.Any(), gomock.Any()).Return(&storage.Season{Season: model.Season{ID: 1, SeriesID: 1}}, nil).Times(1)
+		seriesStorage.EXPECT().GetSeries(gomock.Any(), gomock.Any()).Return(&storage.Series{Series: model.Series{ID: 1, Monitored: 0}}, nil).Times(1)
+		m.seriesStorage = seriesStorage
+
+		err = m.ReconcileEpisodeUpgrades(ctx, newReconcileSnapshot(nil, nil))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// seasonEpisodePattern matches S01E05 and 1x05
+	seasonEpisodePattern = regexp.MustCompile(`(?i)\bs(\d{1,4})[ ._-]?e(\d{1,4})|\b(\d{1,2})x(\d{2,3})\b`)
+	// multiEpisodePattern matches episode lists and ranges like S01E01E02, S01E01-E03 or S01E01-03
+	multiEpisodePattern = regexp.MustCompile(`(?i)s\d{1,4}((?:[-_. ]?e\d{1,4})+)(?:-(\d{1,4})(?:[^\dp]|$))?`)
+	multiEpisodeToken   = regexp.MustCompile(`(?i)([-_. ]?)e(\d{1,4})`)
+	// seasonPackPattern matches S01, Season 1 and ranges like S01-S03 or Season 1-3
+	seasonPackPattern = regexp.MustCompile(`(?i)(?:\bs(\d{1,2})|\bseason[ ._-]?(\d{1,2}))\b(?:[ ._]?(?:-|to)[ ._]?(?:s|season[ ._-]?)?(\d{1,2})\b)?`)
+	// episodeFallbackPatterns are the looser episode numbering used by names without a season, ordered by preference
+	episodeFallbackPatterns = []*regexp.Regexp{
+		// Episode 5 or Ep 5 format
+		regexp.MustCompile(`(?i)\b(?:episode|ep)[ ._-]?(\d{1,4})\b`),
+		// E05 format (standalone E followed by digits)
+		regexp.MustCompile(`(?i)(?:^|[^a-z])e(\d{1,4})(?:[^a-z]|$)`),
+		// - 05 - format (episode number between dashes)
+		regexp.MustCompile(`-\s*(\d{1,3})\s*-`),
+	}
+	// absoluteEpisodePattern matches the anime convention of "Show - 123 [1080p]" or "Show - 123v2"
+	absoluteEpisodePattern = regexp.MustCompile(`(?i)\s-\s(\d{1,4})(?:v\d{1,2})?(?:[\s\[\(.]|$)`)
+	// airDatePattern matches the daily show convention of "Show.2026.10.15" or "Show 2026-10-15"
+	airDatePattern = regexp.MustCompile(`(?:^|[^\d])((?:19|20)\d{2})[.\-_ ](\d{2})[.\-_ ](\d{2})(?:[^\d]|$)`)
+)
+
+// maxEpisodeRange bounds how many episodes a range like S01E01-E10 can cover, so a title with a year
+// or resolution after the episode isn't mistaken for a huge range
+const maxEpisodeRange = 20
+
+// maxSeasonRange bounds how many seasons a pack like S01-S10 can cover
+const maxSeasonRange = 50
+
+type episodeInfo struct {
+	seasons  []int
+	episodes []int
+	absolute int
+	airDate  *time.Time
+
+	// index is where the first season, episode or date tag starts, or -1 if there isn't one
+	index int
+	// airDateSpan is the start and end of the air date, so its year isn't taken for the release year
+	airDateSpan []int
+}
+
+func parseEpisodes(name string) episodeInfo {
+	info := episodeInfo{index: -1}
+	mark := func(i int) {
+		if info.index < 0 || i < info.index {
+			info.index = i
+		}
+	}
+
+	if m := seasonEpisodePattern.FindStringSubmatchIndex(name); m != nil {
+		mark(m[0])
+
+		var season, episode string
+		if m[2] >= 0 {
+			season, episode = name[m[2]:m[3]], name[m[4]:m[5]]
+		} else {
+			season, episode = name[m[6]:m[7]], name[m[8]:m[9]]
+		}
+		s, _ := strconv.Atoi(season)
+		e, _ := strconv.Atoi(episode)
+		info.seasons = []int{s}
+
+		info.episodes = parseEpisodeNumbers(name)
+		if len(info.episodes) == 0 && e > 0 {
+			info.episodes = []int{e}
+		}
+
+		return info
+	}
+
+	if m := seasonPackPattern.FindStringSubmatchIndex(name); m != nil {
+		mark(m[0])
+		info.seasons = seasonRange(name, m)
+	}
+
+	if m := airDatePattern.FindStringSubmatchIndex(name); m != nil {
+		date, err := time.Parse("2006-01-02", strings.Join([]string{name[m[2]:m[3]], name[m[4]:m[5]], name[m[6]:m[7]]}, "-"))
+		if err == nil {
+			info.airDate = &date
+			info.airDateSpan = []int{m[2], m[7]}
+			mark(m[2])
+		}
+	}
+
+	if m := absoluteEpisodePattern.FindStringSubmatchIndex(name); m != nil {
+		if n, err := strconv.Atoi(name[m[2]:m[3]]); err == nil && n > 0 {
+			info.absolute = n
+			mark(m[0])
+		}
+	}
+
+	// the dashes around a daily show's date would look like an episode number
+	if info.airDate != nil {
+		return info
+	}
+
+	for _, pattern := range episodeFallbackPatterns {
+		m := pattern.FindStringSubmatchIndex(name)
+		if m == nil {
+			continue
+		}
+
+		if n, err := strconv.Atoi(name[m[2]:m[3]]); err == nil && n > 0 {
+			info.episodes = []int{n}
+			mark(m[0])
+			break
+		}
+	}
+
+	return info
+}
+
+// seasonRange expands a season pack match into every season it covers
+func seasonRange(name string, m []int) []int {
+	from, to := m[2], m[3]
+	if from < 0 {
+		from, to = m[4], m[5]
+	}
+	first, err := strconv.Atoi(name[from:to])
+	if err != nil {
+		return nil
+	}
+
+	if m[6] < 0 {
+		return []int{first}
+	}
+
+	last, err := strconv.Atoi(name[m[6]:m[7]])
+	if err != nil || last <= first || last-first > maxSeasonRange {
+		return []int{first}
+	}
+
+	seasons := make([]int, 0, last-first+1)
+	for s := first; s <= last; s++ {
+		seasons = append(seasons, s)
+	}
+	return seasons
+}
+
+// parseEpisodeNumbers returns the episode numbers of a name with a season and episode token, expanding
+// multi-episode lists and ranges like S01E01E02, S01E01-E03 and S01E01-03. It returns nil if there is no token.
+func parseEpisodeNumbers(name string) []int {
+	matches := multiEpisodePattern.FindStringSubmatch(name)
+	if len(matches) != 3 {
+		return nil
+	}
+
+	var episodes []int
+	add := func(episode int) {
+		if len(episodes) > 0 && episode <= episodes[len(episodes)-1] {
+			return
+		}
+		episodes = append(episodes, episode)
+	}
+	addRange := func(to int) {
+		from := episodes[len(episodes)-1]
+		if to <= from || to-from > maxEpisodeRange {
+			return
+		}
+		for e := from + 1; e <= to; e++ {
+			add(e)
+		}
+	}
+
+	for _, token := range multiEpisodeToken.FindAllStringSubmatch(matches[1], -1) {
+		episode, err := strconv.Atoi(token[2])
+		if err != nil {
+			return nil
+		}
+
+		if token[1] == "-" && len(episodes) > 0 {
+			addRange(episode)
+			continue
+		}
+		add(episode)
+	}
+
+	if matches[2] != "" && len(episodes) > 0 {
+		if to, err := strconv.Atoi(matches[2]); err == nil {
+			addRange(to)
+		}
+	}
+
+	return episodes
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAbsoluteEpisode(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int
+	}{
+		{name: "[SubsPlease] One Piece - 1071 (1080p) [ABCD1234]", expected: 1071},
+		{name: "Frieren - 13 [1080p]", expected: 13},
+		{name: "Frieren - 13v2 [1080p]", expected: 13},
+		{name: "Frieren - 05", expected: 5},
+		{name: "Frieren - S01E05 - The Hero", expected: 0},
+		{name: "The Office - 1x05 - Pilot", expected: 0},
+		{name: "Frieren - 1080p", expected: 0},
+		{name: "Random Show", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.name).AbsoluteEpisode; got != tt.expected {
+				t.Errorf("Parse(%q).AbsoluteEpisode = %d, want %d", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAirDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "The.Daily.Show.2026.10.15.Guest.Name.1080p.WEB.h264-GROUP", expected: "2026-10-15"},
+		{name: "The Daily Show - 2026-10-15 - Guest Name.mkv", expected: "2026-10-15"},
+		{name: "The_Daily_Show_2026_01_02", expected: "2026-01-02"},
+		{name: "The.Daily.Show.2026.13.45.Guest.Name", expected: ""},
+		{name: "Show.2024.S01E05.1080p", expected: ""},
+		{name: "Random Show", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := Parse(tt.name).AirDate
+			if tt.expected == "" {
+				if date != nil {
+					t.Errorf("Parse(%q).AirDate = %s, want no date", tt.name, date.Format("2006-01-02"))
+				}
+				return
+			}
+
+			if date == nil || date.Format("2006-01-02") != tt.expected {
+				t.Errorf("Parse(%q).AirDate = %v, want %s", tt.name, date, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseEpisodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		seasons  []int
+		expected []int
+	}{
+		{name: "Show.S01E01.1080p.WEB-DL", seasons: []int{1}, expected: []int{1}},
+		{name: "Show.S01E01E02.1080p.WEB-DL", seasons: []int{1}, expected: []int{1, 2}},
+		{name: "Show.S01E01.E02.E03.720p", seasons: []int{1}, expected: []int{1, 2, 3}},
+		{name: "Show.S01E01-E03.1080p.WEB-DL", seasons: []int{1}, expected: []int{1, 2, 3}},
+		{name: "Show - S02E05-06 - Two Parter", seasons: []int{2}, expected: []int{5, 6}},
+		{name: "Show.S01E01-1080p", seasons: []int{1}, expected: []int{1}},
+		{name: "Show.S01E01-2024", seasons: []int{1}, expected: []int{1}},
+		{name: "Show.1x05.720p", seasons: []int{1}, expected: []int{5}},
+		{name: "Show.S00E03.720p", seasons: []int{0}, expected: []int{3}},
+		{name: "Show.S03.1080p.WEB-DL", seasons: []int{3}},
+		{name: "Show.Season.3.1080p.WEB-DL", seasons: []int{3}},
+		{name: "Show_Season_03_Complete_720p.HDTV", seasons: []int{3}},
+		{name: "Show.S01-S03.1080p.BluRay", seasons: []int{1, 2, 3}},
+		{name: "Show Season 1-2 1080p", seasons: []int{1, 2}},
+		{name: "Show Name Episode 5 Title", expected: []int{5}},
+		{name: "Show Name - 05 - Episode Title", expected: []int{5}},
+		{name: "Ocean's 11 (2001) 1080p"},
+		{name: "Random Show"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.name)
+			if !reflect.DeepEqual(got.Seasons, tt.seasons) {
+				t.Errorf("Parse(%q).Seasons = %v, want %v", tt.name, got.Seasons, tt.seasons)
+			}
+			if !reflect.DeepEqual(got.Episodes, tt.expected) {
+				t.Errorf("Parse(%q).Episodes = %v, want %v", tt.name, got.Episodes, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var languagePattern = regexp.MustCompile(`(?i)\b(multi|dual[ ._-]audio|english|french|truefrench|vostfr|german|spanish|castellano|latino|italian|japanese|korean|chinese|russian|portuguese|dutch|swedish|norwegian|danish|finnish|polish|hindi|eng|ita|fre|ger|spa|jpn|kor|rus|hin)\b`)
+
+// languageCodes maps the language tags used in release names to ISO 639-1 codes
+var languageCodes = map[string]string{
+	"english":    "en",
+	"french":     "fr",
+	"truefrench": "fr",
+	"german":     "de",
+	"spanish":    "es",
+	"castellano": "es",
+	"latino":     "es",
+	"italian":    "it",
+	"japanese":   "ja",
+	"korean":     "ko",
+	"chinese":    "zh",
+	"russian":    "ru",
+	"portuguese": "pt",
+	"dutch":      "nl",
+	"swedish":    "sv",
+	"norwegian":  "no",
+	"danish":     "da",
+	"finnish":    "fi",
+	"polish":     "pl",
+	"hindi":      "hi",
+	"eng":        "en",
+	"ita":        "it",
+	"fre":        "fr",
+	"ger":        "de",
+	"spa":        "es",
+	"jpn":        "ja",
+	"kor":        "ko",
+	"rus":        "ru",
+	"hin":        "hi",
+}
+
+// parseLanguages finds the language tags in a name. Untagged names have no languages.
+func parseLanguages(name string) (codes []string, multi bool) {
+	for _, tag := range languagePattern.FindAllString(name, -1) {
+		tag = strings.ToLower(tag)
+		if tag == "multi" || strings.HasPrefix(tag, "dual") {
+			multi = true
+			continue
+		}
+
+		code, ok := languageCodes[tag]
+		if ok && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes, multi
+}
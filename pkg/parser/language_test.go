@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLanguages(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []string
+		multi bool
+	}{
+		{"Movie.2019.1080p.WEB-DL.x264-GRP", nil, false},
+		{"Movie.2019.FRENCH.1080p.WEB-DL.x264-GRP", []string{"fr"}, false},
+		{"Movie.2019.TRUEFRENCH.1080p.BluRay-GRP", []string{"fr"}, false},
+		{"Movie.2019.MULTi.1080p.BluRay.x264-GRP", nil, true},
+		{"Movie 2019 1080p BluRay Dual Audio x264-GRP", nil, true},
+		{"Movie.2019.German.DL.1080p.BluRay-GRP", []string{"de"}, false},
+		{"Movie.2019.Spanish.Castellano.1080p-GRP", []string{"es"}, false},
+		{"Movie.2019.VOSTFR.1080p.WEB-GRP", nil, false},
+		{"Movie.2019.iTA.ENG.1080p.WEB-DL-GRP", []string{"it", "en"}, false},
+		{"The.French.Dispatch.2021.1080p.BluRay-GRP", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.name)
+			assert.Equal(t, tt.codes, got.Languages)
+			assert.Equal(t, tt.multi, got.MultiLanguage)
+		})
+	}
+}
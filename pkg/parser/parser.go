@@ -0,0 +1,192 @@
+// Package parser turns release and file names into the details they're tagged with
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Release is everything a release or file name says about its contents
+type Release struct {
+	// Title is the movie or series title with separators replaced by spaces
+	Title string `json:"title,omitempty"`
+	Year  int    `json:"year,omitempty"`
+
+	// Seasons lists the season of an episode or every season of a season pack
+	Seasons []int `json:"seasons,omitempty"`
+	// Episodes lists every episode in the name, multi-episode names like S01E01E02 have more than one
+	Episodes []int `json:"episodes,omitempty"`
+	// AbsoluteEpisode is set for anime style names like "Show - 123 [1080p]" that number episodes across the whole series
+	AbsoluteEpisode int `json:"absoluteEpisode,omitempty"`
+	// AirDate is set for daily show names like "Show.2026.10.15.Guest.Name"
+	AirDate *time.Time `json:"airDate,omitempty"`
+
+	Resolution string   `json:"resolution,omitempty"`
+	Source     string   `json:"source,omitempty"`
+	Codec      string   `json:"codec,omitempty"`
+	HDR        []string `json:"hdr,omitempty"`
+	Audio      []string `json:"audio,omitempty"`
+	Edition    string   `json:"edition,omitempty"`
+
+	// Languages are the ISO 639-1 codes of the audio languages the name is tagged with
+	Languages []string `json:"languages,omitempty"`
+	// MultiLanguage is set for MULTi and dual audio releases, which carry more audio tracks than they name
+	MultiLanguage bool `json:"multiLanguage,omitempty"`
+
+	ReleaseGroup string `json:"releaseGroup,omitempty"`
+	Proper       bool   `json:"proper,omitempty"`
+	Repack       bool   `json:"repack,omitempty"`
+	// Revision is 1 for an original release and higher for a PROPER, REPACK, RERIP or anime v2 release
+	Revision int `json:"revision"`
+}
+
+var (
+	extensionPattern  = regexp.MustCompile(`(?i)\.(mkv|mp4|m4v|avi|wmv|mov|webm|m2ts|iso|nzb|torrent)$`)
+	yearPattern       = regexp.MustCompile(`(?:19|20)\d{2}`)
+	animeGroupPattern = regexp.MustCompile(`^\[([^\]]+)\]\s*`)
+	groupPattern      = regexp.MustCompile(`-([A-Za-z0-9]+)(?:\[[^\]]*\])?$`)
+	countryCode       = regexp.MustCompile(`\s*[\(\[\{]([A-Z]{2,3})[\)\]\}]\s*`)
+	emptyBrackets     = regexp.MustCompile(`\s*[\[\(\{]\s*[\]\)\}]\s*`)
+	groupInitials     = regexp.MustCompile(`\s*[\(\[]([A-Z](?:[ .][A-Z])+)[\)\]]$`)
+	multipleSpaces    = regexp.MustCompile(`\s+`)
+)
+
+// notGroups are the tags that end up after the last dash of a name but aren't release groups
+var notGroups = map[string]bool{"dl": true, "rip": true, "hd": true, "ma": true, "ray": true, "x": true, "es": true}
+
+// Parse parses a release or file name. Names that aren't releases still parse, with only the details that could be found.
+func Parse(name string) Release {
+	name = extensionPattern.ReplaceAllString(strings.TrimSpace(name), "")
+	// underscores are word characters to regexp, they'd hide every tag from a word boundary
+	name = strings.ReplaceAll(name, "_", " ")
+
+	var release Release
+	if m := animeGroupPattern.FindStringSubmatchIndex(name); m != nil {
+		release.ReleaseGroup = name[m[2]:m[3]]
+		name = name[m[1]:]
+	}
+
+	episodes := parseEpisodes(name)
+	release.Seasons = episodes.seasons
+	release.Episodes = episodes.episodes
+	release.AbsoluteEpisode = episodes.absolute
+	release.AirDate = episodes.airDate
+
+	// the title ends at the first tag that can't be part of it, everything after describes the release
+	end := len(name)
+	if episodes.index >= 0 {
+		end = episodes.index
+	}
+	resolution := resolutionPattern.FindStringIndex(name)
+	if resolution != nil && resolution[0] > 0 {
+		end = min(end, resolution[0])
+	}
+
+	year, yearIndex := parseYear(name, end, episodes.airDateSpan)
+	release.Year = year
+	if yearIndex >= 0 {
+		end = min(end, yearIndex)
+	}
+
+	// without a year or episode, tags like FRENCH or BluRay before the resolution aren't part of the title either
+	if year == 0 && episodes.index < 0 && resolution != nil {
+		if i := firstTagIndex(name[:end]); i > 0 {
+			end = i
+		}
+	}
+
+	title := name[:end]
+	// a name without any tags can still end in the initials of the group that released it, like "Title (T N)"
+	if end == len(name) {
+		if i := groupInitials.FindStringIndex(title); i != nil && i[0] > 0 {
+			title = title[:i[0]]
+		}
+	}
+	release.Title = cleanTitle(title)
+
+	tags := name[end:]
+	if end == len(name) {
+		tags = name
+	}
+
+	release.Resolution = parseResolution(tags)
+	release.Source = parseSource(tags)
+	release.Codec = parseCodec(tags)
+	release.HDR = parseHDR(tags)
+	release.Audio = parseAudio(tags)
+	release.Edition = parseEdition(tags)
+	release.Languages, release.MultiLanguage = parseLanguages(tags)
+	release.Proper, release.Repack, release.Revision = parseRevision(tags)
+
+	if release.ReleaseGroup == "" && end < len(name) {
+		if m := groupPattern.FindStringSubmatchIndex(name); m != nil && m[2] > end {
+			group := name[m[2]:m[3]]
+			if isGroup(group) {
+				release.ReleaseGroup = group
+			}
+		}
+	}
+
+	return release
+}
+
+// isGroup reports whether what follows the last dash of a name is a release group rather than a tag like WEB-DL
+func isGroup(group string) bool {
+	if _, err := strconv.Atoi(group); err == nil {
+		return false
+	}
+	if notGroups[strings.ToLower(group)] {
+		return false
+	}
+	return parseResolution(group) == "" && parseSource(group) == "" && parseCodec(group) == ""
+}
+
+// parseYear returns the release year and where it starts. A year in brackets is preferred, otherwise it's the last
+// year before the title ends. A year at the start of the name is the title, like "1917" or "2012".
+func parseYear(name string, end int, exclude []int) (int, int) {
+	matches := yearPattern.FindAllStringIndex(name, -1)
+
+	isBoundary := func(i int) bool {
+		if i < 0 || i >= len(name) {
+			return true
+		}
+		c := name[i]
+		return !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z')
+	}
+
+	year, index := 0, -1
+	for _, m := range matches {
+		if m[0] == 0 || !isBoundary(m[0]-1) || !isBoundary(m[1]) {
+			continue
+		}
+		if exclude != nil && m[0] >= exclude[0] && m[1] <= exclude[1] {
+			continue
+		}
+
+		y, err := strconv.Atoi(name[m[0]:m[1]])
+		if err != nil {
+			continue
+		}
+
+		if strings.ContainsRune("([{", rune(name[m[0]-1])) && m[1] < len(name) && strings.ContainsRune(")]}", rune(name[m[1]])) {
+			return y, m[0] - 1
+		}
+
+		if m[0] < end {
+			year, index = y, m[0]
+		}
+	}
+
+	return year, index
+}
+
+// cleanTitle replaces separators with spaces and drops country codes like (US) and leftover brackets
+func cleanTitle(title string) string {
+	title = strings.NewReplacer(".", " ", "-", " ").Replace(title)
+	title = countryCode.ReplaceAllString(title, " ")
+	title = emptyBrackets.ReplaceAllString(title, " ")
+	title = multipleSpaces.ReplaceAllString(title, " ")
+	return strings.Trim(title, " ([{")
+}
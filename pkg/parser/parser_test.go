@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCases struct {
+	Testcases []struct {
+		Name    string  `json:"name"`
+		Release Release `json:"release"`
+	} `json:"testcases"`
+}
+
+func TestParse(t *testing.T) {
+	b, err := os.ReadFile("testing/releases.json")
+	require.NoError(t, err)
+
+	var cases testCases
+	err = json.Unmarshal(b, &cases)
+	require.NoError(t, err)
+
+	for _, tc := range cases.Testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Release, Parse(tc.Name))
+		})
+	}
+}
+
+func TestParseTitleAndYear(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		year  int
+	}{
+		{name: "1917", title: "1917"},
+		{name: "2012 2009 1080p", title: "2012", year: 2009},
+		{name: "Movie Name (2019) 2020", title: "Movie Name", year: 2019},
+		{name: "Movie {2019}", title: "Movie", year: 2019},
+		{name: "Movie-Name-2022", title: "Movie Name", year: 2022},
+		{name: "The.Dark-Knight_2008.1080p", title: "The Dark Knight", year: 2008},
+		{name: "Movie (2019) Extended Edition", title: "Movie", year: 2019},
+		{name: "Hunt for the Wilderpeople (2016) [1080p]", title: "Hunt for the Wilderpeople", year: 2016},
+		{name: "Degrassi (CAN)", title: "Degrassi"},
+		{name: "Movie (2024) 1081", title: "Movie", year: 2024},
+		{name: "", title: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.name)
+			assert.Equal(t, tt.title, got.Title)
+			assert.Equal(t, tt.year, got.Year)
+		})
+	}
+}
+
+func TestParseRevision(t *testing.T) {
+	tests := []struct {
+		name     string
+		revision int
+		proper   bool
+		repack   bool
+	}{
+		{name: "Movie.2020.1080p.WEB-DL-GRP", revision: 1},
+		{name: "Movie.2020.PROPER.1080p.WEB-DL-GRP", revision: 2, proper: true},
+		{name: "Show.S01E01.REPACK.720p.HDTV-GRP", revision: 2, repack: true},
+		{name: "Movie 2020 RERIP 1080p BluRay-GRP", revision: 2, proper: true},
+		{name: "Show.S01E01.REPACK2.720p.HDTV-GRP", revision: 3, repack: true},
+		{name: "[Group] Show - 12v2 [1080p]", revision: 2},
+		{name: "[Group] Show - 12 v3 [1080p]", revision: 3},
+		{name: "show.s01e01.proper.720p.hdtv-grp", revision: 2, proper: true},
+		{name: "Improper.Conduct.2020.1080p-GRP", revision: 1},
+		{name: "[Group] Show - 12v1 [1080p]", revision: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.name)
+			assert.Equal(t, tt.revision, got.Revision)
+			assert.Equal(t, tt.proper, got.Proper)
+			assert.Equal(t, tt.repack, got.Repack)
+		})
+	}
+}
+
+func TestNormalizeEdition(t *testing.T) {
+	tests := []struct {
+		edition string
+		want    string
+	}{
+		{edition: "Directors.Cut", want: "Director's Cut"},
+		{edition: "director's cut", want: "Director's Cut"},
+		{edition: "EXTENDED", want: "Extended"},
+		{edition: "Extended_Edition", want: "Extended"},
+		{edition: "Theatrical Cut", want: "Theatrical"},
+		{edition: "imax", want: "IMAX"},
+		{edition: "Collectors Edition", want: "Collector's Edition"},
+		{edition: "Final Cut", want: "Final Cut"},
+		{edition: " Remastered ", want: "Remastered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edition, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeEdition(tt.edition))
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tag is a canonical value and the pattern of the ways release names write it
+type tag struct {
+	value   string
+	pattern *regexp.Regexp
+}
+
+func newTag(value, pattern string) tag {
+	return tag{value: value, pattern: regexp.MustCompile(`(?i)` + pattern)}
+}
+
+// firstTag returns the first of the tags the name contains, tags are ordered by precedence
+func firstTag(name string, tags []tag) string {
+	for _, t := range tags {
+		if t.pattern.MatchString(name) {
+			return t.value
+		}
+	}
+	return ""
+}
+
+var (
+	resolutionPattern = regexp.MustCompile(`(?i)\b(?:2160p|4k|uhd|1080[pi]|720p|576p|480p)\b|[\[\(](?:2160|1080|720|576|480)[\]\)]`)
+	resolutions       = []tag{
+		newTag("2160p", `\b(?:2160p|4k|uhd)\b|[\[\(]2160[\]\)]`),
+		newTag("1080p", `\b1080p\b|[\[\(]1080[\]\)]`),
+		newTag("1080i", `\b1080i\b`),
+		newTag("720p", `\b720p\b|[\[\(]720[\]\)]`),
+		newTag("576p", `\b576p\b|[\[\(]576[\]\)]`),
+		newTag("480p", `\b480p\b|[\[\(]480[\]\)]`),
+	}
+
+	sources = []tag{
+		newTag("Remux", `\b(?:bd)?remux\b`),
+		newTag("BluRay", `\b(?:blu-?ray|bdrip|brrip|bd25|bd50)\b`),
+		newTag("WEBRip", `\bweb-?rip\b`),
+		newTag("WEB-DL", `\b(?:web-?dl|web)\b`),
+		newTag("HDTV", `\bhdtv\b`),
+		newTag("SDTV", `\b(?:sdtv|pdtv|tvrip|dsr)\b`),
+		newTag("DVD", `\b(?:dvdrip|dvdr|dvd5|dvd9|dvd)\b`),
+		newTag("TELECINE", `\b(?:telecine|tc)\b`),
+		newTag("TELESYNC", `\b(?:telesync|hdts|ts)\b`),
+		newTag("CAM", `\b(?:hdcam|camrip|cam)\b`),
+	}
+
+	codecs = []tag{
+		newTag("x265", `\b(?:x265|h\.?265|hevc)\b`),
+		newTag("x264", `\b(?:x264|h\.?264|avc)\b`),
+		newTag("AV1", `\bav1\b`),
+		newTag("VC-1", `\bvc-?1\b`),
+		newTag("MPEG-2", `\bmpeg-?2\b`),
+		newTag("XviD", `\bxvid\b`),
+		newTag("DivX", `\bdivx\b`),
+	}
+
+	dolbyVisionPattern = regexp.MustCompile(`(?i)\b(?:dv|dovi|dolby[ ._-]?vision)\b`)
+	hdr10PlusPattern   = regexp.MustCompile(`(?i)\bhdr10(?:\+|plus)`)
+	hdr10Pattern       = regexp.MustCompile(`(?i)\bhdr10\b`)
+	hdrPattern         = regexp.MustCompile(`(?i)\bhdr\b`)
+	hlgPattern         = regexp.MustCompile(`(?i)\bhlg\b`)
+
+	// audio formats are grouped by family, a name only gets the most specific format of each
+	audioFormats = [][]tag{
+		{newTag("TrueHD", `\btrue-?hd\b`)},
+		{
+			newTag("DTS-HD MA", `\bdts-?hd[ ._-]?ma\b`),
+			newTag("DTS-X", `\bdts[ ._:-]?x\b`),
+			newTag("DTS-HD", `\bdts-?hd\b`),
+			newTag("DTS", `\bdts(?:\d|\b)`),
+		},
+		{
+			newTag("DDP", `\b(?:ddp(?:lus)?|dd\+|e-?ac-?3)(?:[^a-z]|$)`),
+			newTag("DD", `\b(?:dd|ac-?3)(?:\d|\b)`),
+		},
+		{newTag("AAC", `\baac(?:\d|\b)`)},
+		{newTag("FLAC", `\bflac(?:\d|\b)`)},
+		{newTag("Opus", `\bopus\b`)},
+		{newTag("MP3", `\bmp3\b`)},
+		{newTag("PCM", `\bl?pcm\b`)},
+		{newTag("Atmos", `\batmos\b`)},
+	}
+	audioChannelsPattern = regexp.MustCompile(`(?:^|[^\d])([124567])[ ._]([01])(?:[^\d]|$)`)
+
+	editionPattern = regexp.MustCompile(`(?i)\{edition-([^}]+)\}|\b(director'?s[ ._-]cut|extended(?:[ ._-](?:cut|edition))?|unrated|uncut|theatrical|imax|remastered|criterion|special[ ._-]edition|ultimate[ ._-]edition|collector'?s[ ._-]edition)\b`)
+
+	editions = []tag{
+		newTag("Director's Cut", `^director'?s[ -]cut$`),
+		newTag("Extended", `^extended(?:[ -](?:cut|edition))?$`),
+		newTag("Unrated", `^unrated$`),
+		newTag("Uncut", `^uncut$`),
+		newTag("Theatrical", `^theatrical(?:[ -](?:cut|edition))?$`),
+		newTag("IMAX", `^imax$`),
+		newTag("Remastered", `^remastered$`),
+		newTag("Criterion", `^criterion$`),
+		newTag("Special Edition", `^special[ -]edition$`),
+		newTag("Ultimate Edition", `^ultimate[ -]edition$`),
+		newTag("Collector's Edition", `^collector'?s[ -]edition$`),
+	}
+
+	properPattern        = regexp.MustCompile(`(?i)\b(proper|repack|rerip)(\d)?\b`)
+	animeRevisionPattern = regexp.MustCompile(`(?i)(?:\b|\d)v([2-9])\b`)
+)
+
+// firstTagIndex returns where the first quality, language or edition tag in the name starts, or -1
+func firstTagIndex(name string) int {
+	index := -1
+	first := func(pattern *regexp.Regexp) {
+		if m := pattern.FindStringIndex(name); m != nil && (index < 0 || m[0] < index) {
+			index = m[0]
+		}
+	}
+
+	for _, tags := range [][]tag{sources, codecs} {
+		for _, t := range tags {
+			first(t.pattern)
+		}
+	}
+	first(languagePattern)
+	first(editionPattern)
+	first(properPattern)
+	return index
+}
+
+// Resolutions lists the resolutions Parse reports, highest first
+func Resolutions() []string {
+	return tagValues(resolutions)
+}
+
+// Sources lists the sources Parse reports, best first
+func Sources() []string {
+	return tagValues(sources)
+}
+
+func tagValues(tags []tag) []string {
+	values := make([]string, len(tags))
+	for i, t := range tags {
+		values[i] = t.value
+	}
+	return values
+}
+
+func parseResolution(name string) string {
+	return firstTag(name, resolutions)
+}
+
+func parseSource(name string) string {
+	return firstTag(name, sources)
+}
+
+func parseCodec(name string) string {
+	return firstTag(name, codecs)
+}
+
+// parseHDR returns the dynamic range formats in the name, HDR10+ and HDR10 aren't also reported as HDR
+func parseHDR(name string) []string {
+	var hdr []string
+	if dolbyVisionPattern.MatchString(name) {
+		hdr = append(hdr, "DV")
+	}
+
+	switch {
+	case hdr10PlusPattern.MatchString(name):
+		hdr = append(hdr, "HDR10+")
+	case hdr10Pattern.MatchString(name):
+		hdr = append(hdr, "HDR10")
+	case hdrPattern.MatchString(name):
+		hdr = append(hdr, "HDR")
+	}
+
+	if hlgPattern.MatchString(name) {
+		hdr = append(hdr, "HLG")
+	}
+
+	return hdr
+}
+
+// parseAudio returns the audio formats in the name followed by the channel layout, like ["TrueHD", "Atmos", "7.1"]
+func parseAudio(name string) []string {
+	var audio []string
+	for _, family := range audioFormats {
+		if format := firstTag(name, family); format != "" {
+			audio = append(audio, format)
+		}
+	}
+
+	if m := audioChannelsPattern.FindStringSubmatch(name); m != nil {
+		audio = append(audio, m[1]+"."+m[2])
+	}
+
+	return audio
+}
+
+func parseEdition(name string) string {
+	m := editionPattern.FindStringSubmatch(name)
+	if m == nil {
+		return ""
+	}
+
+	// {edition-...} tags are already written the way the user wants them
+	if m[1] != "" {
+		return m[1]
+	}
+	return NormalizeEdition(m[2])
+}
+
+// NormalizeEdition returns the canonical name of a known edition written any of the ways release names write it, like
+// "Director's Cut" for DIRECTORS.CUT. Other editions are returned with separators replaced by spaces.
+func NormalizeEdition(edition string) string {
+	edition = strings.TrimSpace(strings.NewReplacer(".", " ", "_", " ").Replace(edition))
+	for _, t := range editions {
+		if t.pattern.MatchString(edition) {
+			return t.value
+		}
+	}
+	return edition
+}
+
+// parseRevision finds PROPER, REPACK and RERIP tags and the revision they make a release. Original releases are
+// revision 1, a PROPER, REPACK or RERIP is revision 2 (REPACK2 is 3) and anime releases carry their version,
+// e.g. `Show - 01v2`.
+func parseRevision(name string) (proper, repack bool, revision int) {
+	revision = 1
+
+	for _, m := range properPattern.FindAllStringSubmatch(name, -1) {
+		if strings.EqualFold(m[1], "repack") {
+			repack = true
+		} else {
+			proper = true
+		}
+
+		r := 2
+		if n, err := strconv.Atoi(m[2]); err == nil {
+			r = n + 1
+		}
+		revision = max(revision, r)
+	}
+
+	for _, m := range animeRevisionPattern.FindAllStringSubmatch(name, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			revision = max(revision, n)
+		}
+	}
+
+	return proper, repack, revision
+}
@@ -88,6 +88,10 @@ type HistoryEntry struct {
 type TransitionMetadata struct {
 	DownloadClient *DownloadClientInfo `json:"downloadClient,omitempty"`
 	DownloadID     string              `json:"downloadID,omitempty"`
+	ReleaseTitle   string              `json:"releaseTitle,omitempty"`
+	IsUpgrade      bool                `json:"isUpgrade,omitempty"`
+	UpgradeReason  string              `json:"upgradeReason,omitempty"`
+	ReplacedFile   string              `json:"replacedFile,omitempty"`
 }
 
 type TimelineResponse struct {
@@ -16,7 +16,6 @@ import (
 
 	sqlite "github.com/go-jet/jet/v2/sqlite"
 	storage "github.com/kasuboski/mediaz/pkg/storage"
-	sqlcdb "github.com/kasuboski/mediaz/pkg/storage/sqlcdb"
 	model "github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -79,6 +78,36 @@ func (mr *MockStorageMockRecorder) CountTransitionsByDate(ctx, startDate, endDat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransitionsByDate", reflect.TypeOf((*MockStorage)(nil).CountTransitionsByDate), ctx, startDate, endDate)
 }
 
+// CreateBlocklistEntry mocks base method.
+func (m *MockStorage) CreateBlocklistEntry(ctx context.Context, entry model.ReleaseBlocklist) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlocklistEntry", ctx, entry)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlocklistEntry indicates an expected call of CreateBlocklistEntry.
+func (mr *MockStorageMockRecorder) CreateBlocklistEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlocklistEntry", reflect.TypeOf((*MockStorage)(nil).CreateBlocklistEntry), ctx, entry)
+}
+
+// CreateCustomFormat mocks base method.
+func (m *MockStorage) CreateCustomFormat(ctx context.Context, format storage.CustomFormat) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomFormat", ctx, format)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomFormat indicates an expected call of CreateCustomFormat.
+func (mr *MockStorageMockRecorder) CreateCustomFormat(ctx, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomFormat", reflect.TypeOf((*MockStorage)(nil).CreateCustomFormat), ctx, format)
+}
+
 // CreateDownloadClient mocks base method.
 func (m *MockStorage) CreateDownloadClient(ctx context.Context, client model.DownloadClient) (int64, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +168,21 @@ func (mr *MockStorageMockRecorder) CreateEpisodeMetadata(ctx, episodeMeta any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEpisodeMetadata", reflect.TypeOf((*MockStorage)(nil).CreateEpisodeMetadata), ctx, episodeMeta)
 }
 
+// CreateExtraFile mocks base method.
+func (m *MockStorage) CreateExtraFile(ctx context.Context, file model.ExtraFile) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExtraFile", ctx, file)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExtraFile indicates an expected call of CreateExtraFile.
+func (mr *MockStorageMockRecorder) CreateExtraFile(ctx, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExtraFile", reflect.TypeOf((*MockStorage)(nil).CreateExtraFile), ctx, file)
+}
+
 // CreateIndexer mocks base method.
 func (m *MockStorage) CreateIndexer(ctx context.Context, indexer model.Indexer) (int64, error) {
 	m.ctrl.T.Helper()
@@ -229,6 +273,21 @@ func (mr *MockStorageMockRecorder) CreateMovieMetadata(ctx, movieMeta any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovieMetadata", reflect.TypeOf((*MockStorage)(nil).CreateMovieMetadata), ctx, movieMeta)
 }
 
+// CreatePendingRelease mocks base method.
+func (m *MockStorage) CreatePendingRelease(ctx context.Context, release model.PendingRelease) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingRelease", ctx, release)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePendingRelease indicates an expected call of CreatePendingRelease.
+func (mr *MockStorageMockRecorder) CreatePendingRelease(ctx, release any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingRelease", reflect.TypeOf((*MockStorage)(nil).CreatePendingRelease), ctx, release)
+}
+
 // CreateQualityDefinition mocks base method.
 func (m *MockStorage) CreateQualityDefinition(ctx context.Context, definition model.QualityDefinition) (int64, error) {
 	m.ctrl.T.Helper()
@@ -348,6 +407,34 @@ func (mr *MockStorageMockRecorder) CreateSeriesMetadata(ctx, SeriesMeta any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeriesMetadata", reflect.TypeOf((*MockStorage)(nil).CreateSeriesMetadata), ctx, SeriesMeta)
 }
 
+// DeleteBlocklistEntry mocks base method.
+func (m *MockStorage) DeleteBlocklistEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlocklistEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlocklistEntry indicates an expected call of DeleteBlocklistEntry.
+func (mr *MockStorageMockRecorder) DeleteBlocklistEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlocklistEntry", reflect.TypeOf((*MockStorage)(nil).DeleteBlocklistEntry), ctx, id)
+}
+
+// DeleteCustomFormat mocks base method.
+func (m *MockStorage) DeleteCustomFormat(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomFormat", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomFormat indicates an expected call of DeleteCustomFormat.
+func (mr *MockStorageMockRecorder) DeleteCustomFormat(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomFormat", reflect.TypeOf((*MockStorage)(nil).DeleteCustomFormat), ctx, id)
+}
+
 // DeleteDownloadClient mocks base method.
 func (m *MockStorage) DeleteDownloadClient(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -404,6 +491,20 @@ func (mr *MockStorageMockRecorder) DeleteEpisodeMetadata(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEpisodeMetadata", reflect.TypeOf((*MockStorage)(nil).DeleteEpisodeMetadata), ctx, id)
 }
 
+// DeleteExtraFiles mocks base method.
+func (m *MockStorage) DeleteExtraFiles(ctx context.Context, where sqlite.BoolExpression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExtraFiles", ctx, where)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExtraFiles indicates an expected call of DeleteExtraFiles.
+func (mr *MockStorageMockRecorder) DeleteExtraFiles(ctx, where any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExtraFiles", reflect.TypeOf((*MockStorage)(nil).DeleteExtraFiles), ctx, where)
+}
+
 // DeleteIndexer mocks base method.
 func (m *MockStorage) DeleteIndexer(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -522,6 +623,20 @@ func (mr *MockStorageMockRecorder) DeleteMovieMetadata(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovieMetadata", reflect.TypeOf((*MockStorage)(nil).DeleteMovieMetadata), ctx, id)
 }
 
+// DeletePendingReleases mocks base method.
+func (m *MockStorage) DeletePendingReleases(ctx context.Context, where sqlite.BoolExpression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingReleases", ctx, where)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingReleases indicates an expected call of DeletePendingReleases.
+func (mr *MockStorageMockRecorder) DeletePendingReleases(ctx, where any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingReleases", reflect.TypeOf((*MockStorage)(nil).DeletePendingReleases), ctx, where)
+}
+
 // DeleteQualityDefinition mocks base method.
 func (m *MockStorage) DeleteQualityDefinition(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -634,6 +749,36 @@ func (mr *MockStorageMockRecorder) DeleteSeriesMetadata(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeriesMetadata", reflect.TypeOf((*MockStorage)(nil).DeleteSeriesMetadata), ctx, id)
 }
 
+// GetBlocklistEntry mocks base method.
+func (m *MockStorage) GetBlocklistEntry(ctx context.Context, id int64) (*model.ReleaseBlocklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocklistEntry", ctx, id)
+	ret0, _ := ret[0].(*model.ReleaseBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocklistEntry indicates an expected call of GetBlocklistEntry.
+func (mr *MockStorageMockRecorder) GetBlocklistEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocklistEntry", reflect.TypeOf((*MockStorage)(nil).GetBlocklistEntry), ctx, id)
+}
+
+// GetCustomFormat mocks base method.
+func (m *MockStorage) GetCustomFormat(ctx context.Context, id int64) (storage.CustomFormat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFormat", ctx, id)
+	ret0, _ := ret[0].(storage.CustomFormat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFormat indicates an expected call of GetCustomFormat.
+func (mr *MockStorageMockRecorder) GetCustomFormat(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFormat", reflect.TypeOf((*MockStorage)(nil).GetCustomFormat), ctx, id)
+}
+
 // GetDownloadClient mocks base method.
 func (m *MockStorage) GetDownloadClient(ctx context.Context, id int64) (model.DownloadClient, error) {
 	m.ctrl.T.Helper()
@@ -784,21 +929,6 @@ func (mr *MockStorageMockRecorder) GetLibraryStats(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryStats", reflect.TypeOf((*MockStorage)(nil).GetLibraryStats), ctx)
 }
 
-// GetLibraryStatsByState mocks base method.
-func (m *MockStorage) GetLibraryStatsByState(ctx context.Context) ([]sqlcdb.GetLibraryStatsByStateRow, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLibraryStatsByState", ctx)
-	ret0, _ := ret[0].([]sqlcdb.GetLibraryStatsByStateRow)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetLibraryStatsByState indicates an expected call of GetLibraryStatsByState.
-func (mr *MockStorageMockRecorder) GetLibraryStatsByState(ctx any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryStatsByState", reflect.TypeOf((*MockStorage)(nil).GetLibraryStatsByState), ctx)
-}
-
 // GetMovie mocks base method.
 func (m *MockStorage) GetMovie(ctx context.Context, id int64) (*storage.Movie, error) {
 	m.ctrl.T.Helper()
@@ -1084,6 +1214,46 @@ func (mr *MockStorageMockRecorder) LinkSeriesMetadata(ctx, seriesID, metadataID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSeriesMetadata", reflect.TypeOf((*MockStorage)(nil).LinkSeriesMetadata), ctx, seriesID, metadataID)
 }
 
+// ListBlocklistEntries mocks base method.
+func (m *MockStorage) ListBlocklistEntries(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ReleaseBlocklist, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBlocklistEntries", varargs...)
+	ret0, _ := ret[0].([]*model.ReleaseBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBlocklistEntries indicates an expected call of ListBlocklistEntries.
+func (mr *MockStorageMockRecorder) ListBlocklistEntries(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlocklistEntries", reflect.TypeOf((*MockStorage)(nil).ListBlocklistEntries), varargs...)
+}
+
+// ListCustomFormats mocks base method.
+func (m *MockStorage) ListCustomFormats(ctx context.Context, where ...sqlite.BoolExpression) ([]*storage.CustomFormat, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCustomFormats", varargs...)
+	ret0, _ := ret[0].([]*storage.CustomFormat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomFormats indicates an expected call of ListCustomFormats.
+func (mr *MockStorageMockRecorder) ListCustomFormats(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomFormats", reflect.TypeOf((*MockStorage)(nil).ListCustomFormats), varargs...)
+}
+
 // ListDownloadClients mocks base method.
 func (m *MockStorage) ListDownloadClients(ctx context.Context) ([]*model.DownloadClient, error) {
 	m.ctrl.T.Helper()
@@ -1199,6 +1369,46 @@ func (mr *MockStorageMockRecorder) ListErrorJobs(ctx, hours any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListErrorJobs", reflect.TypeOf((*MockStorage)(nil).ListErrorJobs), ctx, hours)
 }
 
+// ListExtraFiles mocks base method.
+func (m *MockStorage) ListExtraFiles(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ExtraFile, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListExtraFiles", varargs...)
+	ret0, _ := ret[0].([]*model.ExtraFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExtraFiles indicates an expected call of ListExtraFiles.
+func (mr *MockStorageMockRecorder) ListExtraFiles(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExtraFiles", reflect.TypeOf((*MockStorage)(nil).ListExtraFiles), varargs...)
+}
+
+// ListIndexerCategories mocks base method.
+func (m *MockStorage) ListIndexerCategories(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.IndexerCategory, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIndexerCategories", varargs...)
+	ret0, _ := ret[0].([]*model.IndexerCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIndexerCategories indicates an expected call of ListIndexerCategories.
+func (mr *MockStorageMockRecorder) ListIndexerCategories(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndexerCategories", reflect.TypeOf((*MockStorage)(nil).ListIndexerCategories), varargs...)
+}
+
 // ListIndexerSources mocks base method.
 func (m *MockStorage) ListIndexerSources(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.IndexerSource, error) {
 	m.ctrl.T.Helper()
@@ -1319,6 +1529,26 @@ func (mr *MockStorageMockRecorder) ListMoviesByState(ctx, state any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMoviesByState", reflect.TypeOf((*MockStorage)(nil).ListMoviesByState), ctx, state)
 }
 
+// ListPendingReleases mocks base method.
+func (m *MockStorage) ListPendingReleases(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.PendingRelease, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPendingReleases", varargs...)
+	ret0, _ := ret[0].([]*model.PendingRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingReleases indicates an expected call of ListPendingReleases.
+func (mr *MockStorageMockRecorder) ListPendingReleases(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingReleases", reflect.TypeOf((*MockStorage)(nil).ListPendingReleases), varargs...)
+}
+
 // ListQualityDefinitions mocks base method.
 func (m *MockStorage) ListQualityDefinitions(ctx context.Context) ([]*model.QualityDefinition, error) {
 	m.ctrl.T.Helper()
@@ -1478,6 +1708,34 @@ func (mr *MockStorageMockRecorder) RunMigrations(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunMigrations", reflect.TypeOf((*MockStorage)(nil).RunMigrations), ctx)
 }
 
+// SetQualityProfileFormats mocks base method.
+func (m *MockStorage) SetQualityProfileFormats(ctx context.Context, profileID int64, formats []model.QualityProfileFormat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQualityProfileFormats", ctx, profileID, formats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetQualityProfileFormats indicates an expected call of SetQualityProfileFormats.
+func (mr *MockStorageMockRecorder) SetQualityProfileFormats(ctx, profileID, formats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQualityProfileFormats", reflect.TypeOf((*MockStorage)(nil).SetQualityProfileFormats), ctx, profileID, formats)
+}
+
+// UpdateCustomFormat mocks base method.
+func (m *MockStorage) UpdateCustomFormat(ctx context.Context, id int64, format storage.CustomFormat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomFormat", ctx, id, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomFormat indicates an expected call of UpdateCustomFormat.
+func (mr *MockStorageMockRecorder) UpdateCustomFormat(ctx, id, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomFormat", reflect.TypeOf((*MockStorage)(nil).UpdateCustomFormat), ctx, id, format)
+}
+
 // UpdateDownloadClient mocks base method.
 func (m *MockStorage) UpdateDownloadClient(ctx context.Context, id int64, client model.DownloadClient) error {
 	m.ctrl.T.Helper()
@@ -1595,6 +1853,34 @@ func (mr *MockStorageMockRecorder) UpdateMovie(ctx, movie any, where ...any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockStorage)(nil).UpdateMovie), varargs...)
 }
 
+// UpdateMovieFilePaths mocks base method.
+func (m *MockStorage) UpdateMovieFilePaths(ctx context.Context, movieID int64, path string, relativePaths, extraPaths map[int32]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieFilePaths", ctx, movieID, path, relativePaths, extraPaths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovieFilePaths indicates an expected call of UpdateMovieFilePaths.
+func (mr *MockStorageMockRecorder) UpdateMovieFilePaths(ctx, movieID, path, relativePaths, extraPaths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieFilePaths", reflect.TypeOf((*MockStorage)(nil).UpdateMovieFilePaths), ctx, movieID, path, relativePaths, extraPaths)
+}
+
+// UpdateMovieFileQuality mocks base method.
+func (m *MockStorage) UpdateMovieFileQuality(ctx context.Context, id int64, quality string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieFileQuality", ctx, id, quality)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovieFileQuality indicates an expected call of UpdateMovieFileQuality.
+func (mr *MockStorageMockRecorder) UpdateMovieFileQuality(ctx, id, quality any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieFileQuality", reflect.TypeOf((*MockStorage)(nil).UpdateMovieFileQuality), ctx, id, quality)
+}
+
 // UpdateMovieMetadata mocks base method.
 func (m *MockStorage) UpdateMovieMetadata(ctx context.Context, metadata model.MovieMetadata) error {
 	m.ctrl.T.Helper()
@@ -1712,6 +1998,20 @@ func (mr *MockStorageMockRecorder) UpdateSeries(ctx, series any, where ...any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockStorage)(nil).UpdateSeries), varargs...)
 }
 
+// UpdateSeriesFilePaths mocks base method.
+func (m *MockStorage) UpdateSeriesFilePaths(ctx context.Context, seriesID int64, path string, relativePaths, extraPaths map[int32]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeriesFilePaths", ctx, seriesID, path, relativePaths, extraPaths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeriesFilePaths indicates an expected call of UpdateSeriesFilePaths.
+func (mr *MockStorageMockRecorder) UpdateSeriesFilePaths(ctx, seriesID, path, relativePaths, extraPaths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesFilePaths", reflect.TypeOf((*MockStorage)(nil).UpdateSeriesFilePaths), ctx, seriesID, path, relativePaths, extraPaths)
+}
+
 // UpdateSeriesMetadata mocks base method.
 func (m *MockStorage) UpdateSeriesMetadata(ctx context.Context, metadata model.SeriesMetadata) error {
 	m.ctrl.T.Helper()
@@ -1740,6 +2040,34 @@ func (mr *MockStorageMockRecorder) UpdateSeriesState(ctx, id, state, metadata an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesState", reflect.TypeOf((*MockStorage)(nil).UpdateSeriesState), ctx, id, state, metadata)
 }
 
+// UpdateSeriesType mocks base method.
+func (m *MockStorage) UpdateSeriesType(ctx context.Context, seriesID int64, seriesType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeriesType", ctx, seriesID, seriesType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeriesType indicates an expected call of UpdateSeriesType.
+func (mr *MockStorageMockRecorder) UpdateSeriesType(ctx, seriesID, seriesType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesType", reflect.TypeOf((*MockStorage)(nil).UpdateSeriesType), ctx, seriesID, seriesType)
+}
+
+// UpsertIndexerCategories mocks base method.
+func (m *MockStorage) UpsertIndexerCategories(ctx context.Context, categories model.IndexerCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertIndexerCategories", ctx, categories)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertIndexerCategories indicates an expected call of UpsertIndexerCategories.
+func (mr *MockStorageMockRecorder) UpsertIndexerCategories(ctx, categories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertIndexerCategories", reflect.TypeOf((*MockStorage)(nil).UpsertIndexerCategories), ctx, categories)
+}
+
 // MockIndexerStorage is a mock of IndexerStorage interface.
 type MockIndexerStorage struct {
 	ctrl     *gomock.Controller
@@ -1907,6 +2235,26 @@ func (mr *MockIndexerSourceStorageMockRecorder) GetIndexerSource(ctx, id any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexerSource", reflect.TypeOf((*MockIndexerSourceStorage)(nil).GetIndexerSource), ctx, id)
 }
 
+// ListIndexerCategories mocks base method.
+func (m *MockIndexerSourceStorage) ListIndexerCategories(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.IndexerCategory, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIndexerCategories", varargs...)
+	ret0, _ := ret[0].([]*model.IndexerCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIndexerCategories indicates an expected call of ListIndexerCategories.
+func (mr *MockIndexerSourceStorageMockRecorder) ListIndexerCategories(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIndexerCategories", reflect.TypeOf((*MockIndexerSourceStorage)(nil).ListIndexerCategories), varargs...)
+}
+
 // ListIndexerSources mocks base method.
 func (m *MockIndexerSourceStorage) ListIndexerSources(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.IndexerSource, error) {
 	m.ctrl.T.Helper()
@@ -1941,6 +2289,20 @@ func (mr *MockIndexerSourceStorageMockRecorder) UpdateIndexerSource(ctx, id, sou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIndexerSource", reflect.TypeOf((*MockIndexerSourceStorage)(nil).UpdateIndexerSource), ctx, id, source)
 }
 
+// UpsertIndexerCategories mocks base method.
+func (m *MockIndexerSourceStorage) UpsertIndexerCategories(ctx context.Context, categories model.IndexerCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertIndexerCategories", ctx, categories)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertIndexerCategories indicates an expected call of UpsertIndexerCategories.
+func (mr *MockIndexerSourceStorageMockRecorder) UpsertIndexerCategories(ctx, categories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertIndexerCategories", reflect.TypeOf((*MockIndexerSourceStorage)(nil).UpsertIndexerCategories), ctx, categories)
+}
+
 // MockQualityStorage is a mock of QualityStorage interface.
 type MockQualityStorage struct {
 	ctrl     *gomock.Controller
@@ -1964,6 +2326,21 @@ func (m *MockQualityStorage) EXPECT() *MockQualityStorageMockRecorder {
 	return m.recorder
 }
 
+// CreateCustomFormat mocks base method.
+func (m *MockQualityStorage) CreateCustomFormat(ctx context.Context, format storage.CustomFormat) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomFormat", ctx, format)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomFormat indicates an expected call of CreateCustomFormat.
+func (mr *MockQualityStorageMockRecorder) CreateCustomFormat(ctx, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomFormat", reflect.TypeOf((*MockQualityStorage)(nil).CreateCustomFormat), ctx, format)
+}
+
 // CreateQualityDefinition mocks base method.
 func (m *MockQualityStorage) CreateQualityDefinition(ctx context.Context, definition model.QualityDefinition) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2023,6 +2400,20 @@ func (mr *MockQualityStorageMockRecorder) CreateQualityProfileItems(ctx, items a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQualityProfileItems", reflect.TypeOf((*MockQualityStorage)(nil).CreateQualityProfileItems), ctx, items)
 }
 
+// DeleteCustomFormat mocks base method.
+func (m *MockQualityStorage) DeleteCustomFormat(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomFormat", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomFormat indicates an expected call of DeleteCustomFormat.
+func (mr *MockQualityStorageMockRecorder) DeleteCustomFormat(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomFormat", reflect.TypeOf((*MockQualityStorage)(nil).DeleteCustomFormat), ctx, id)
+}
+
 // DeleteQualityDefinition mocks base method.
 func (m *MockQualityStorage) DeleteQualityDefinition(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -2079,6 +2470,21 @@ func (mr *MockQualityStorageMockRecorder) DeleteQualityProfileItemsByProfileID(c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQualityProfileItemsByProfileID", reflect.TypeOf((*MockQualityStorage)(nil).DeleteQualityProfileItemsByProfileID), ctx, profileID)
 }
 
+// GetCustomFormat mocks base method.
+func (m *MockQualityStorage) GetCustomFormat(ctx context.Context, id int64) (storage.CustomFormat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFormat", ctx, id)
+	ret0, _ := ret[0].(storage.CustomFormat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFormat indicates an expected call of GetCustomFormat.
+func (mr *MockQualityStorageMockRecorder) GetCustomFormat(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFormat", reflect.TypeOf((*MockQualityStorage)(nil).GetCustomFormat), ctx, id)
+}
+
 // GetQualityDefinition mocks base method.
 func (m *MockQualityStorage) GetQualityDefinition(ctx context.Context, id int64) (model.QualityDefinition, error) {
 	m.ctrl.T.Helper()
@@ -2124,6 +2530,26 @@ func (mr *MockQualityStorageMockRecorder) GetQualityProfileItem(ctx, id any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQualityProfileItem", reflect.TypeOf((*MockQualityStorage)(nil).GetQualityProfileItem), ctx, id)
 }
 
+// ListCustomFormats mocks base method.
+func (m *MockQualityStorage) ListCustomFormats(ctx context.Context, where ...sqlite.BoolExpression) ([]*storage.CustomFormat, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCustomFormats", varargs...)
+	ret0, _ := ret[0].([]*storage.CustomFormat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomFormats indicates an expected call of ListCustomFormats.
+func (mr *MockQualityStorageMockRecorder) ListCustomFormats(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomFormats", reflect.TypeOf((*MockQualityStorage)(nil).ListCustomFormats), varargs...)
+}
+
 // ListQualityDefinitions mocks base method.
 func (m *MockQualityStorage) ListQualityDefinitions(ctx context.Context) ([]*model.QualityDefinition, error) {
 	m.ctrl.T.Helper()
@@ -2174,6 +2600,34 @@ func (mr *MockQualityStorageMockRecorder) ListQualityProfiles(ctx any, where ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQualityProfiles", reflect.TypeOf((*MockQualityStorage)(nil).ListQualityProfiles), varargs...)
 }
 
+// SetQualityProfileFormats mocks base method.
+func (m *MockQualityStorage) SetQualityProfileFormats(ctx context.Context, profileID int64, formats []model.QualityProfileFormat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQualityProfileFormats", ctx, profileID, formats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetQualityProfileFormats indicates an expected call of SetQualityProfileFormats.
+func (mr *MockQualityStorageMockRecorder) SetQualityProfileFormats(ctx, profileID, formats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQualityProfileFormats", reflect.TypeOf((*MockQualityStorage)(nil).SetQualityProfileFormats), ctx, profileID, formats)
+}
+
+// UpdateCustomFormat mocks base method.
+func (m *MockQualityStorage) UpdateCustomFormat(ctx context.Context, id int64, format storage.CustomFormat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomFormat", ctx, id, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomFormat indicates an expected call of UpdateCustomFormat.
+func (mr *MockQualityStorageMockRecorder) UpdateCustomFormat(ctx, id, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomFormat", reflect.TypeOf((*MockQualityStorage)(nil).UpdateCustomFormat), ctx, id, format)
+}
+
 // UpdateQualityDefinition mocks base method.
 func (m *MockQualityStorage) UpdateQualityDefinition(ctx context.Context, id int64, definition model.QualityDefinition) error {
 	m.ctrl.T.Helper()
@@ -2436,6 +2890,34 @@ func (mr *MockMovieStorageMockRecorder) UpdateMovie(ctx, movie any, where ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieStorage)(nil).UpdateMovie), varargs...)
 }
 
+// UpdateMovieFilePaths mocks base method.
+func (m *MockMovieStorage) UpdateMovieFilePaths(ctx context.Context, movieID int64, path string, relativePaths, extraPaths map[int32]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieFilePaths", ctx, movieID, path, relativePaths, extraPaths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovieFilePaths indicates an expected call of UpdateMovieFilePaths.
+func (mr *MockMovieStorageMockRecorder) UpdateMovieFilePaths(ctx, movieID, path, relativePaths, extraPaths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieFilePaths", reflect.TypeOf((*MockMovieStorage)(nil).UpdateMovieFilePaths), ctx, movieID, path, relativePaths, extraPaths)
+}
+
+// UpdateMovieFileQuality mocks base method.
+func (m *MockMovieStorage) UpdateMovieFileQuality(ctx context.Context, id int64, quality string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieFileQuality", ctx, id, quality)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMovieFileQuality indicates an expected call of UpdateMovieFileQuality.
+func (mr *MockMovieStorageMockRecorder) UpdateMovieFileQuality(ctx, id, quality any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieFileQuality", reflect.TypeOf((*MockMovieStorage)(nil).UpdateMovieFileQuality), ctx, id, quality)
+}
+
 // UpdateMovieMovieFileID mocks base method.
 func (m *MockMovieStorage) UpdateMovieMovieFileID(ctx context.Context, id, fileID int64) error {
 	m.ctrl.T.Helper()
@@ -3232,6 +3714,20 @@ func (mr *MockSeriesStorageMockRecorder) UpdateSeries(ctx, series any, where ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockSeriesStorage)(nil).UpdateSeries), varargs...)
 }
 
+// UpdateSeriesFilePaths mocks base method.
+func (m *MockSeriesStorage) UpdateSeriesFilePaths(ctx context.Context, seriesID int64, path string, relativePaths, extraPaths map[int32]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeriesFilePaths", ctx, seriesID, path, relativePaths, extraPaths)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeriesFilePaths indicates an expected call of UpdateSeriesFilePaths.
+func (mr *MockSeriesStorageMockRecorder) UpdateSeriesFilePaths(ctx, seriesID, path, relativePaths, extraPaths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesFilePaths", reflect.TypeOf((*MockSeriesStorage)(nil).UpdateSeriesFilePaths), ctx, seriesID, path, relativePaths, extraPaths)
+}
+
 // UpdateSeriesState mocks base method.
 func (m *MockSeriesStorage) UpdateSeriesState(ctx context.Context, id int64, state storage.SeriesState, metadata *storage.TransitionStateMetadata) error {
 	m.ctrl.T.Helper()
@@ -3246,6 +3742,20 @@ func (mr *MockSeriesStorageMockRecorder) UpdateSeriesState(ctx, id, state, metad
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesState", reflect.TypeOf((*MockSeriesStorage)(nil).UpdateSeriesState), ctx, id, state, metadata)
 }
 
+// UpdateSeriesType mocks base method.
+func (m *MockSeriesStorage) UpdateSeriesType(ctx context.Context, seriesID int64, seriesType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeriesType", ctx, seriesID, seriesType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeriesType indicates an expected call of UpdateSeriesType.
+func (mr *MockSeriesStorageMockRecorder) UpdateSeriesType(ctx, seriesID, seriesType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesType", reflect.TypeOf((*MockSeriesStorage)(nil).UpdateSeriesType), ctx, seriesID, seriesType)
+}
+
 // MockSeriesMetadataStorage is a mock of SeriesMetadataStorage interface.
 type MockSeriesMetadataStorage struct {
 	ctrl     *gomock.Controller
@@ -3474,3 +3984,234 @@ func (mr *MockSeriesMetadataStorageMockRecorder) UpdateSeriesMetadata(ctx, metad
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeriesMetadata", reflect.TypeOf((*MockSeriesMetadataStorage)(nil).UpdateSeriesMetadata), ctx, metadata)
 }
+
+// MockBlocklistStorage is a mock of BlocklistStorage interface.
+type MockBlocklistStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlocklistStorageMockRecorder
+}
+
+// MockBlocklistStorageMockRecorder is the mock recorder for MockBlocklistStorage.
+type MockBlocklistStorageMockRecorder struct {
+	mock *MockBlocklistStorage
+}
+
+// NewMockBlocklistStorage creates a new mock instance.
+func NewMockBlocklistStorage(ctrl *gomock.Controller) *MockBlocklistStorage {
+	mock := &MockBlocklistStorage{ctrl: ctrl}
+	mock.recorder = &MockBlocklistStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlocklistStorage) EXPECT() *MockBlocklistStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateBlocklistEntry mocks base method.
+func (m *MockBlocklistStorage) CreateBlocklistEntry(ctx context.Context, entry model.ReleaseBlocklist) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlocklistEntry", ctx, entry)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlocklistEntry indicates an expected call of CreateBlocklistEntry.
+func (mr *MockBlocklistStorageMockRecorder) CreateBlocklistEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlocklistEntry", reflect.TypeOf((*MockBlocklistStorage)(nil).CreateBlocklistEntry), ctx, entry)
+}
+
+// DeleteBlocklistEntry mocks base method.
+func (m *MockBlocklistStorage) DeleteBlocklistEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlocklistEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlocklistEntry indicates an expected call of DeleteBlocklistEntry.
+func (mr *MockBlocklistStorageMockRecorder) DeleteBlocklistEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlocklistEntry", reflect.TypeOf((*MockBlocklistStorage)(nil).DeleteBlocklistEntry), ctx, id)
+}
+
+// GetBlocklistEntry mocks base method.
+func (m *MockBlocklistStorage) GetBlocklistEntry(ctx context.Context, id int64) (*model.ReleaseBlocklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocklistEntry", ctx, id)
+	ret0, _ := ret[0].(*model.ReleaseBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocklistEntry indicates an expected call of GetBlocklistEntry.
+func (mr *MockBlocklistStorageMockRecorder) GetBlocklistEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocklistEntry", reflect.TypeOf((*MockBlocklistStorage)(nil).GetBlocklistEntry), ctx, id)
+}
+
+// ListBlocklistEntries mocks base method.
+func (m *MockBlocklistStorage) ListBlocklistEntries(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ReleaseBlocklist, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBlocklistEntries", varargs...)
+	ret0, _ := ret[0].([]*model.ReleaseBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBlocklistEntries indicates an expected call of ListBlocklistEntries.
+func (mr *MockBlocklistStorageMockRecorder) ListBlocklistEntries(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlocklistEntries", reflect.TypeOf((*MockBlocklistStorage)(nil).ListBlocklistEntries), varargs...)
+}
+
+// MockPendingReleaseStorage is a mock of PendingReleaseStorage interface.
+type MockPendingReleaseStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingReleaseStorageMockRecorder
+}
+
+// MockPendingReleaseStorageMockRecorder is the mock recorder for MockPendingReleaseStorage.
+type MockPendingReleaseStorageMockRecorder struct {
+	mock *MockPendingReleaseStorage
+}
+
+// NewMockPendingReleaseStorage creates a new mock instance.
+func NewMockPendingReleaseStorage(ctrl *gomock.Controller) *MockPendingReleaseStorage {
+	mock := &MockPendingReleaseStorage{ctrl: ctrl}
+	mock.recorder = &MockPendingReleaseStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingReleaseStorage) EXPECT() *MockPendingReleaseStorageMockRecorder {
+	return m.recorder
+}
+
+// CreatePendingRelease mocks base method.
+func (m *MockPendingReleaseStorage) CreatePendingRelease(ctx context.Context, release model.PendingRelease) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingRelease", ctx, release)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePendingRelease indicates an expected call of CreatePendingRelease.
+func (mr *MockPendingReleaseStorageMockRecorder) CreatePendingRelease(ctx, release any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingRelease", reflect.TypeOf((*MockPendingReleaseStorage)(nil).CreatePendingRelease), ctx, release)
+}
+
+// DeletePendingReleases mocks base method.
+func (m *MockPendingReleaseStorage) DeletePendingReleases(ctx context.Context, where sqlite.BoolExpression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingReleases", ctx, where)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingReleases indicates an expected call of DeletePendingReleases.
+func (mr *MockPendingReleaseStorageMockRecorder) DeletePendingReleases(ctx, where any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingReleases", reflect.TypeOf((*MockPendingReleaseStorage)(nil).DeletePendingReleases), ctx, where)
+}
+
+// ListPendingReleases mocks base method.
+func (m *MockPendingReleaseStorage) ListPendingReleases(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.PendingRelease, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPendingReleases", varargs...)
+	ret0, _ := ret[0].([]*model.PendingRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingReleases indicates an expected call of ListPendingReleases.
+func (mr *MockPendingReleaseStorageMockRecorder) ListPendingReleases(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingReleases", reflect.TypeOf((*MockPendingReleaseStorage)(nil).ListPendingReleases), varargs...)
+}
+
+// MockExtraFileStorage is a mock of ExtraFileStorage interface.
+type MockExtraFileStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockExtraFileStorageMockRecorder
+}
+
+// MockExtraFileStorageMockRecorder is the mock recorder for MockExtraFileStorage.
+type MockExtraFileStorageMockRecorder struct {
+	mock *MockExtraFileStorage
+}
+
+// NewMockExtraFileStorage creates a new mock instance.
+func NewMockExtraFileStorage(ctrl *gomock.Controller) *MockExtraFileStorage {
+	mock := &MockExtraFileStorage{ctrl: ctrl}
+	mock.recorder = &MockExtraFileStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExtraFileStorage) EXPECT() *MockExtraFileStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateExtraFile mocks base method.
+func (m *MockExtraFileStorage) CreateExtraFile(ctx context.Context, file model.ExtraFile) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExtraFile", ctx, file)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExtraFile indicates an expected call of CreateExtraFile.
+func (mr *MockExtraFileStorageMockRecorder) CreateExtraFile(ctx, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExtraFile", reflect.TypeOf((*MockExtraFileStorage)(nil).CreateExtraFile), ctx, file)
+}
+
+// DeleteExtraFiles mocks base method.
+func (m *MockExtraFileStorage) DeleteExtraFiles(ctx context.Context, where sqlite.BoolExpression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExtraFiles", ctx, where)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExtraFiles indicates an expected call of DeleteExtraFiles.
+func (mr *MockExtraFileStorageMockRecorder) DeleteExtraFiles(ctx, where any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExtraFiles", reflect.TypeOf((*MockExtraFileStorage)(nil).DeleteExtraFiles), ctx, where)
+}
+
+// ListExtraFiles mocks base method.
+func (m *MockExtraFileStorage) ListExtraFiles(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ExtraFile, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range where {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListExtraFiles", varargs...)
+	ret0, _ := ret[0].([]*model.ExtraFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExtraFiles indicates an expected call of ListExtraFiles.
+func (mr *MockExtraFileStorageMockRecorder) ListExtraFiles(ctx any, where ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, where...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExtraFiles", reflect.TypeOf((*MockExtraFileStorage)(nil).ListExtraFiles), varargs...)
+}
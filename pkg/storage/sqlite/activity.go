@@ -497,6 +497,12 @@ func (s *SQLite) getEntityTransitionsEpisode(ctx context.Context, entityID int64
 		if r.CreatedAt.Valid {
 			entry.CreatedAt = r.CreatedAt.Time
 		}
+		if r.Metadata != "" {
+			entry.Metadata = &storage.TransitionMetadata{}
+			if err := json.Unmarshal([]byte(r.Metadata), entry.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal episode transition metadata: %w", err)
+			}
+		}
 		history = append(history, entry)
 	}
 
@@ -479,6 +479,50 @@ func TestGetEntityTransitions(t *testing.T) {
 		assert.Equal(t, "downloading", resp.History[1].ToState)
 	})
 
+	t.Run("upgrade transitions", func(t *testing.T) {
+		ctx := context.Background()
+		store := initSqlite(t, ctx)
+
+		_, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{TmdbID: 700, Title: "Upgrade Movie", Images: "[]"})
+		require.NoError(t, err)
+
+		p := "path/upgrade"
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Path: &p, Monitored: 1}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		require.NoError(t, store.LinkMovieMetadata(ctx, movieID, 1))
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, nil))
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloaded, nil))
+
+		isUpgrade := true
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, &storage.TransitionStateMetadata{
+			ReleaseTitle:  stringPtr("Upgrade.Movie.1080p.BluRay-GRP"),
+			IsUpgrade:     &isUpgrade,
+			UpgradeReason: stringPtr(storage.UpgradeReasonCutoff),
+		}))
+		require.NoError(t, store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloaded, &storage.TransitionStateMetadata{
+			IsUpgrade:    &isUpgrade,
+			ReplacedFile: stringPtr("Upgrade Movie/Upgrade.Movie.720p.HDTV-GRP.mkv"),
+		}))
+
+		resp, err := store.GetEntityTransitions(ctx, "movie", movieID)
+		require.NoError(t, err)
+		require.Len(t, resp.History, 5)
+
+		require.NotNil(t, resp.History[2].Metadata)
+		assert.False(t, resp.History[2].Metadata.IsUpgrade)
+
+		grab := resp.History[3].Metadata
+		require.NotNil(t, grab)
+		assert.Equal(t, "Upgrade.Movie.1080p.BluRay-GRP", grab.ReleaseTitle)
+		assert.True(t, grab.IsUpgrade)
+		assert.Equal(t, storage.UpgradeReasonCutoff, grab.UpgradeReason)
+
+		imported := resp.History[4].Metadata
+		require.NotNil(t, imported)
+		assert.True(t, imported.IsUpgrade)
+		assert.Equal(t, "Upgrade Movie/Upgrade.Movie.720p.HDTV-GRP.mkv", imported.ReplacedFile)
+	})
+
 	t.Run("movie transitions without download client", func(t *testing.T) {
 		ctx := context.Background()
 		store := initSqlite(t, ctx)
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+)
+
+// CreateBlocklistEntry stores a release that should not be grabbed again
+func (s *SQLite) CreateBlocklistEntry(ctx context.Context, entry model.ReleaseBlocklist) (int64, error) {
+	insertColumns := table.ReleaseBlocklist.AllColumns.Except(table.ReleaseBlocklist.ID)
+	if entry.CreatedAt == nil || entry.CreatedAt.IsZero() {
+		insertColumns = insertColumns.Except(table.ReleaseBlocklist.CreatedAt)
+	}
+
+	stmt := table.ReleaseBlocklist.
+		INSERT(insertColumns).
+		MODEL(entry).
+		RETURNING(table.ReleaseBlocklist.ID)
+
+	result, err := s.handleInsert(ctx, stmt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create blocklist entry: %w", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// GetBlocklistEntry gets a blocklist entry by id
+func (s *SQLite) GetBlocklistEntry(ctx context.Context, id int64) (*model.ReleaseBlocklist, error) {
+	stmt := table.ReleaseBlocklist.
+		SELECT(table.ReleaseBlocklist.AllColumns).
+		FROM(table.ReleaseBlocklist).
+		WHERE(table.ReleaseBlocklist.ID.EQ(sqlite.Int64(id)))
+
+	var entry model.ReleaseBlocklist
+	err := stmt.QueryContext(ctx, s.db, &entry)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get blocklist entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// ListBlocklistEntries lists blocklist entries, newest first
+func (s *SQLite) ListBlocklistEntries(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ReleaseBlocklist, error) {
+	stmt := table.ReleaseBlocklist.
+		SELECT(table.ReleaseBlocklist.AllColumns).
+		FROM(table.ReleaseBlocklist)
+
+	for _, w := range where {
+		stmt = stmt.WHERE(w)
+	}
+
+	stmt = stmt.ORDER_BY(table.ReleaseBlocklist.CreatedAt.DESC(), table.ReleaseBlocklist.ID.DESC())
+
+	entries := make([]*model.ReleaseBlocklist, 0)
+	err := stmt.QueryContext(ctx, s.db, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocklist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// DeleteBlocklistEntry removes a blocklist entry by id
+func (s *SQLite) DeleteBlocklistEntry(ctx context.Context, id int64) error {
+	stmt := table.ReleaseBlocklist.
+		DELETE().
+		WHERE(table.ReleaseBlocklist.ID.EQ(sqlite.Int64(id)))
+
+	_, err := s.handleDelete(ctx, stmt)
+	return err
+}
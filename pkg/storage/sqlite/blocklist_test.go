@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocklistStorage(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	movieID, err := store.CreateMovie(ctx, storage.Movie{
+		Movie: model.Movie{
+			Path:             ptr.To("Movie (2024)"),
+			Monitored:        1,
+			QualityProfileID: 1,
+		},
+	}, storage.MovieStateMissing)
+	require.NoError(t, err)
+
+	first := model.ReleaseBlocklist{
+		MovieID:  ptr.To(int32(movieID)),
+		GUID:     ptr.To("guid-1"),
+		InfoHash: ptr.To("ABCDEF"),
+		Title:    "Movie.2024.1080p.WEB-DL-GRP",
+		Protocol: ptr.To("torrent"),
+		Indexer:  ptr.To("indexer"),
+		Reason:   "download failed",
+	}
+
+	firstID, err := store.CreateBlocklistEntry(ctx, first)
+	require.NoError(t, err)
+	assert.NotZero(t, firstID)
+
+	stored, err := store.GetBlocklistEntry(ctx, firstID)
+	require.NoError(t, err)
+	assert.Equal(t, first.Title, stored.Title)
+	assert.Equal(t, first.GUID, stored.GUID)
+	assert.Equal(t, first.InfoHash, stored.InfoHash)
+	assert.Equal(t, first.Reason, stored.Reason)
+	assert.Equal(t, first.MovieID, stored.MovieID)
+	assert.NotNil(t, stored.CreatedAt)
+
+	secondID, err := store.CreateBlocklistEntry(ctx, model.ReleaseBlocklist{
+		MovieID: ptr.To(int32(movieID)),
+		Title:   "Movie.2024.720p.HDTV-GRP",
+		Reason:  "manual",
+	})
+	require.NoError(t, err)
+
+	entries, err := store.ListBlocklistEntries(ctx, table.ReleaseBlocklist.MovieID.EQ(sqlite.Int64(movieID)))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, int32(secondID), entries[0].ID)
+	assert.Equal(t, int32(firstID), entries[1].ID)
+
+	err = store.DeleteBlocklistEntry(ctx, firstID)
+	require.NoError(t, err)
+
+	_, err = store.GetBlocklistEntry(ctx, firstID)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+}
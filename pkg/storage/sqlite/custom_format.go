@@ -0,0 +1,236 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/logger"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+)
+
+// CreateCustomFormat stores a custom format and its specifications
+func (s *SQLite) CreateCustomFormat(ctx context.Context, format storage.CustomFormat) (int64, error) {
+	m, err := customFormatModel(format)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := table.CustomFormat.
+		INSERT(table.CustomFormat.AllColumns.Except(table.CustomFormat.ID)).
+		MODEL(m).
+		RETURNING(table.CustomFormat.ID)
+
+	result, err := s.handleInsert(ctx, stmt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create custom format: %w", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// GetCustomFormat gets a custom format by id
+func (s *SQLite) GetCustomFormat(ctx context.Context, id int64) (storage.CustomFormat, error) {
+	stmt := table.CustomFormat.
+		SELECT(table.CustomFormat.AllColumns).
+		FROM(table.CustomFormat).
+		WHERE(table.CustomFormat.ID.EQ(sqlite.Int64(id)))
+
+	var m model.CustomFormat
+	err := stmt.QueryContext(ctx, s.db, &m)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return storage.CustomFormat{}, storage.ErrNotFound
+		}
+		return storage.CustomFormat{}, fmt.Errorf("failed to get custom format: %w", err)
+	}
+
+	return customFormatFromModel(m)
+}
+
+// ListCustomFormats lists custom formats ordered by name
+func (s *SQLite) ListCustomFormats(ctx context.Context, where ...sqlite.BoolExpression) ([]*storage.CustomFormat, error) {
+	stmt := table.CustomFormat.
+		SELECT(table.CustomFormat.AllColumns).
+		FROM(table.CustomFormat)
+
+	for _, w := range where {
+		stmt = stmt.WHERE(w)
+	}
+
+	stmt = stmt.ORDER_BY(table.CustomFormat.Name.ASC())
+
+	models := make([]*model.CustomFormat, 0)
+	err := stmt.QueryContext(ctx, s.db, &models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom formats: %w", err)
+	}
+
+	formats := make([]*storage.CustomFormat, 0, len(models))
+	for _, m := range models {
+		format, err := customFormatFromModel(*m)
+		if err != nil {
+			return nil, err
+		}
+		formats = append(formats, &format)
+	}
+
+	return formats, nil
+}
+
+// UpdateCustomFormat updates the name and specifications of a custom format
+func (s *SQLite) UpdateCustomFormat(ctx context.Context, id int64, format storage.CustomFormat) error {
+	m, err := customFormatModel(format)
+	if err != nil {
+		return err
+	}
+
+	stmt := table.CustomFormat.
+		UPDATE(table.CustomFormat.Name, table.CustomFormat.Specifications).
+		MODEL(m).
+		WHERE(table.CustomFormat.ID.EQ(sqlite.Int64(id)))
+
+	_, err = s.handleStatement(ctx, stmt)
+	return err
+}
+
+// DeleteCustomFormat deletes a custom format and its scores on every quality profile
+func (s *SQLite) DeleteCustomFormat(ctx context.Context, id int64) error {
+	log := logger.FromCtx(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	deleteScores := table.QualityProfileFormat.DELETE().WHERE(table.QualityProfileFormat.CustomFormatID.EQ(sqlite.Int64(id)))
+	if _, err := deleteScores.ExecContext(ctx, tx); err != nil {
+		log.Errorw("failed to delete custom format scores", "id", id, "error", err)
+		tx.Rollback()
+		return err
+	}
+
+	deleteFormat := table.CustomFormat.DELETE().WHERE(table.CustomFormat.ID.EQ(sqlite.Int64(id)))
+	if _, err := deleteFormat.ExecContext(ctx, tx); err != nil {
+		log.Errorw("failed to delete custom format", "id", id, "error", err)
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetQualityProfileFormats replaces the custom format scores of a quality profile
+func (s *SQLite) SetQualityProfileFormats(ctx context.Context, profileID int64, formats []model.QualityProfileFormat) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	deleteStmt := table.QualityProfileFormat.DELETE().WHERE(table.QualityProfileFormat.ProfileID.EQ(sqlite.Int64(profileID)))
+	if _, err := deleteStmt.ExecContext(ctx, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(formats) > 0 {
+		for i := range formats {
+			formats[i].ProfileID = int32(profileID)
+		}
+
+		insertStmt := table.QualityProfileFormat.
+			INSERT(table.QualityProfileFormat.AllColumns.Except(table.QualityProfileFormat.ID)).
+			MODELS(formats)
+		if _, err := insertStmt.ExecContext(ctx, tx); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to set quality profile formats: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// attachQualityProfileFormats loads the custom format scores of the given profiles
+func (s *SQLite) attachQualityProfileFormats(ctx context.Context, profiles ...*storage.QualityProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	ids := make([]sqlite.Expression, 0, len(profiles))
+	for _, p := range profiles {
+		ids = append(ids, sqlite.Int32(p.ID))
+	}
+
+	stmt := sqlite.
+		SELECT(table.QualityProfileFormat.AllColumns, table.CustomFormat.AllColumns).
+		FROM(table.QualityProfileFormat.INNER_JOIN(table.CustomFormat, table.CustomFormat.ID.EQ(table.QualityProfileFormat.CustomFormatID))).
+		WHERE(table.QualityProfileFormat.ProfileID.IN(ids...)).
+		ORDER_BY(table.CustomFormat.Name.ASC())
+
+	var rows []struct {
+		model.QualityProfileFormat
+		model.CustomFormat
+	}
+	if err := stmt.QueryContext(ctx, s.db, &rows); err != nil {
+		return fmt.Errorf("failed to list quality profile formats: %w", err)
+	}
+
+	byProfile := make(map[int32][]storage.QualityProfileFormat)
+	for _, row := range rows {
+		format, err := customFormatFromModel(row.CustomFormat)
+		if err != nil {
+			return err
+		}
+		byProfile[row.ProfileID] = append(byProfile[row.ProfileID], storage.QualityProfileFormat{
+			Format: format,
+			Score:  row.Score,
+		})
+	}
+
+	for _, p := range profiles {
+		p.Formats = byProfile[p.ID]
+	}
+
+	return nil
+}
+
+func customFormatModel(format storage.CustomFormat) (model.CustomFormat, error) {
+	specs := format.Specifications
+	if specs == nil {
+		specs = make([]storage.CustomFormatSpecification, 0)
+	}
+
+	encoded, err := json.Marshal(specs)
+	if err != nil {
+		return model.CustomFormat{}, fmt.Errorf("failed to encode custom format specifications: %w", err)
+	}
+
+	return model.CustomFormat{
+		ID:             format.ID,
+		Name:           format.Name,
+		Specifications: string(encoded),
+	}, nil
+}
+
+func customFormatFromModel(m model.CustomFormat) (storage.CustomFormat, error) {
+	format := storage.CustomFormat{
+		ID:             m.ID,
+		Name:           m.Name,
+		Specifications: make([]storage.CustomFormatSpecification, 0),
+	}
+
+	if m.Specifications == "" {
+		return format, nil
+	}
+
+	if err := json.Unmarshal([]byte(m.Specifications), &format.Specifications); err != nil {
+		return storage.CustomFormat{}, fmt.Errorf("failed to decode custom format specifications: %w", err)
+	}
+
+	return format, nil
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFormatStorage(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	hdr := storage.CustomFormat{
+		Name: "HDR",
+		Specifications: []storage.CustomFormatSpecification{
+			{Type: storage.CustomFormatSpecTitle, Value: `\bHDR(10)?\b`},
+			{Type: storage.CustomFormatSpecTitle, Value: `\bDV\b`},
+		},
+	}
+
+	hdrID, err := store.CreateCustomFormat(ctx, hdr)
+	require.NoError(t, err)
+
+	stored, err := store.GetCustomFormat(ctx, hdrID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(hdrID), stored.ID)
+	assert.Equal(t, hdr.Name, stored.Name)
+	assert.Equal(t, hdr.Specifications, stored.Specifications)
+
+	camID, err := store.CreateCustomFormat(ctx, storage.CustomFormat{
+		Name: "CAM",
+		Specifications: []storage.CustomFormatSpecification{
+			{Type: storage.CustomFormatSpecSource, Value: `^(cam|ts)$`, Required: true},
+		},
+	})
+	require.NoError(t, err)
+
+	formats, err := store.ListCustomFormats(ctx)
+	require.NoError(t, err)
+	require.Len(t, formats, 2)
+	assert.Equal(t, "CAM", formats[0].Name)
+	assert.Equal(t, "HDR", formats[1].Name)
+
+	formats, err = store.ListCustomFormats(ctx, table.CustomFormat.Name.EQ(sqlite.String("HDR")))
+	require.NoError(t, err)
+	require.Len(t, formats, 1)
+
+	stored.Name = "HDR Any"
+	stored.Specifications = stored.Specifications[:1]
+	require.NoError(t, store.UpdateCustomFormat(ctx, hdrID, stored))
+
+	updated, err := store.GetCustomFormat(ctx, hdrID)
+	require.NoError(t, err)
+	assert.Equal(t, "HDR Any", updated.Name)
+	assert.Len(t, updated.Specifications, 1)
+
+	err = store.SetQualityProfileFormats(ctx, 1, []model.QualityProfileFormat{
+		{CustomFormatID: int32(hdrID), Score: 100},
+		{CustomFormatID: int32(camID), Score: -1000},
+	})
+	require.NoError(t, err)
+
+	profile, err := store.GetQualityProfile(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, profile.Formats, 2)
+	assert.Equal(t, "CAM", profile.Formats[0].Format.Name)
+	assert.Equal(t, int32(-1000), profile.Formats[0].Score)
+	assert.Equal(t, "HDR Any", profile.Formats[1].Format.Name)
+	assert.Equal(t, int32(100), profile.Formats[1].Score)
+
+	profiles, err := store.ListQualityProfiles(ctx)
+	require.NoError(t, err)
+	for _, p := range profiles {
+		if p.ID == 1 {
+			assert.Len(t, p.Formats, 2)
+		} else {
+			assert.Empty(t, p.Formats)
+		}
+	}
+
+	require.NoError(t, store.DeleteCustomFormat(ctx, camID))
+
+	_, err = store.GetCustomFormat(ctx, camID)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	profile, err = store.GetQualityProfile(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, profile.Formats, 1)
+	assert.Equal(t, "HDR Any", profile.Formats[0].Format.Name)
+
+	require.NoError(t, store.SetQualityProfileFormats(ctx, 1, nil))
+	profile, err = store.GetQualityProfile(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, profile.Formats)
+}
@@ -229,6 +229,12 @@ func (s *SQLite) UpdateEpisodeState(ctx context.Context, id int64, state storage
 		if metadata.IsEntireSeasonDownload != nil {
 			transition.IsEntireSeasonDownload = metadata.IsEntireSeasonDownload
 		}
+		transition.ReleaseGUID = metadata.ReleaseGUID
+		transition.ReleaseTitle = metadata.ReleaseTitle
+		transition.ReleaseInfoHash = metadata.ReleaseInfoHash
+		transition.IsUpgrade = metadata.IsUpgrade
+		transition.UpgradeReason = metadata.UpgradeReason
+		transition.ReplacedFile = metadata.ReplacedFile
 	}
 
 	newTransitionStmt := table.EpisodeTransition.
@@ -243,6 +249,12 @@ func (s *SQLite) UpdateEpisodeState(ctx context.Context, id int64, state storage
 		return err
 	}
 
+	err = clearPendingReleases(ctx, tx, table.PendingRelease.EpisodeID.EQ(sqlite.Int64(id)))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -55,6 +55,43 @@ func (s *SQLite) UpdateEpisodeFile(ctx context.Context, id int32, file model.Epi
 	return err
 }
 
+// UpdateSeriesFilePaths sets a series' path and the relative paths of its episode files and their extra files, by id, in one
+// transaction
+func (s *SQLite) UpdateSeriesFilePaths(ctx context.Context, seriesID int64, path string, relativePaths, extraPaths map[int32]string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	stmt := table.Series.UPDATE().
+		SET(table.Series.Path.SET(sqlite.String(path))).
+		WHERE(table.Series.ID.EQ(sqlite.Int64(seriesID)))
+	_, err = stmt.ExecContext(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for id, relativePath := range relativePaths {
+		stmt := table.EpisodeFile.UPDATE().
+			SET(table.EpisodeFile.RelativePath.SET(sqlite.String(relativePath))).
+			WHERE(table.EpisodeFile.ID.EQ(sqlite.Int32(id)))
+		_, err = stmt.ExecContext(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = updateExtraFilePaths(ctx, tx, extraPaths)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // CreateEpisodeFile stores an episode file
 func (s *SQLite) CreateEpisodeFile(ctx context.Context, file model.EpisodeFile) (int64, error) {
 	setColumns := make([]sqlite.Expression, len(table.EpisodeFile.MutableColumns))
@@ -86,18 +123,14 @@ func (s *SQLite) CreateEpisodeFile(ctx context.Context, file model.EpisodeFile)
 	return inserted, nil
 }
 
-// DeleteEpisodeFile removes an episode file by id
+// DeleteEpisodeFile removes an episode file and its extra files by id
 func (s *SQLite) DeleteEpisodeFile(ctx context.Context, id int64) error {
 	stmt := table.EpisodeFile.
 		DELETE().
 		WHERE(table.EpisodeFile.ID.EQ(sqlite.Int64(id))).
 		RETURNING(table.EpisodeFile.ID)
 
-	_, err := s.handleDelete(ctx, stmt)
-	if err != nil {
-		return err
-	}
-	return nil
+	return s.deleteWithExtraFiles(ctx, table.ExtraFile.DELETE().WHERE(table.ExtraFile.EpisodeFileID.EQ(sqlite.Int64(id))), stmt)
 }
 
 // ListEpisodeFiles lists all episode files
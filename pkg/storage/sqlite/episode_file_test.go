@@ -268,3 +268,25 @@ func TestUpdateEpisodeFile(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestSQLite_UpdateSeriesFilePaths(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	seriesID, err := store.CreateSeries(ctx, storage.Series{Series: model.Series{Path: ptr.To("Series"), Monitored: 1}}, storage.SeriesStateMissing)
+	require.NoError(t, err)
+
+	fileID, err := store.CreateEpisodeFile(ctx, model.EpisodeFile{RelativePath: ptr.To("Series/Season 01/series.s01e01.mkv"), Size: 1})
+	require.NoError(t, err)
+
+	err = store.UpdateSeriesFilePaths(ctx, seriesID, "Series (2020)", map[int32]string{int32(fileID): "Series (2020)/Season 01/Series - S01E01.mkv"}, nil)
+	require.NoError(t, err)
+
+	series, err := store.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int64(seriesID)))
+	require.NoError(t, err)
+	assert.Equal(t, "Series (2020)", *series.Path)
+
+	file, err := store.GetEpisodeFile(ctx, int32(fileID))
+	require.NoError(t, err)
+	assert.Equal(t, "Series (2020)/Season 01/Series - S01E01.mkv", *file.RelativePath)
+}
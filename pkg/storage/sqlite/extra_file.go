@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+)
+
+// CreateExtraFile stores a companion file that was imported along with a movie or episode file
+func (s *SQLite) CreateExtraFile(ctx context.Context, file model.ExtraFile) (int64, error) {
+	stmt := table.ExtraFile.
+		INSERT(table.ExtraFile.AllColumns.Except(table.ExtraFile.ID, table.ExtraFile.Added)).
+		MODEL(file).
+		RETURNING(table.ExtraFile.ID)
+
+	result, err := s.handleInsert(ctx, stmt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create extra file: %w", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// ListExtraFiles lists the companion files matching the conditions
+func (s *SQLite) ListExtraFiles(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ExtraFile, error) {
+	stmt := table.ExtraFile.
+		SELECT(table.ExtraFile.AllColumns).
+		FROM(table.ExtraFile)
+
+	for _, w := range where {
+		stmt = stmt.WHERE(w)
+	}
+
+	stmt = stmt.ORDER_BY(table.ExtraFile.ID.ASC())
+
+	files := make([]*model.ExtraFile, 0)
+	err := stmt.QueryContext(ctx, s.db, &files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extra files: %w", err)
+	}
+
+	return files, nil
+}
+
+// DeleteExtraFiles removes the companion files matching the condition
+func (s *SQLite) DeleteExtraFiles(ctx context.Context, where sqlite.BoolExpression) error {
+	stmt := table.ExtraFile.
+		DELETE().
+		WHERE(where)
+
+	_, err := s.handleDelete(ctx, stmt)
+	return err
+}
+
+// deleteWithExtraFiles removes a movie or episode file along with its companion files. Foreign keys aren't enforced, so
+// the companion files are removed explicitly in the same transaction.
+func (s *SQLite) deleteWithExtraFiles(ctx context.Context, extraFiles, file sqlite.DeleteStatement) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range []sqlite.DeleteStatement{extraFiles, file} {
+		_, err = stmt.ExecContext(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// updateExtraFilePaths sets the relative paths of companion files, by id, in the transaction
+func updateExtraFilePaths(ctx context.Context, tx *sql.Tx, relativePaths map[int32]string) error {
+	for id, relativePath := range relativePaths {
+		stmt := table.ExtraFile.UPDATE().
+			SET(table.ExtraFile.RelativePath.SET(sqlite.String(relativePath))).
+			WHERE(table.ExtraFile.ID.EQ(sqlite.Int32(id)))
+		_, err := stmt.ExecContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtraFileStorage(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	movieFileID, err := store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("Movie/Movie.mkv"), Size: 100})
+	require.NoError(t, err)
+	episodeFileID, err := store.CreateEpisodeFile(ctx, model.EpisodeFile{RelativePath: ptr.To("Series/Season 01/Series - S01E01.mkv"), Size: 100})
+	require.NoError(t, err)
+
+	subtitle := model.ExtraFile{MovieFileID: ptr.To(int32(movieFileID)), RelativePath: "Movie/Movie.en.forced.srt", Size: 10}
+	nfo := model.ExtraFile{MovieFileID: ptr.To(int32(movieFileID)), RelativePath: "Movie/Movie.nfo", Size: 20}
+	episodeSubtitle := model.ExtraFile{EpisodeFileID: ptr.To(int32(episodeFileID)), RelativePath: "Series/Season 01/Series - S01E01.srt", Size: 30}
+	for _, f := range []model.ExtraFile{subtitle, nfo, episodeSubtitle} {
+		_, err := store.CreateExtraFile(ctx, f)
+		require.NoError(t, err)
+	}
+
+	files, err := store.ListExtraFiles(ctx, table.ExtraFile.MovieFileID.EQ(sqlite.Int64(movieFileID)))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, subtitle.RelativePath, files[0].RelativePath)
+	assert.Equal(t, subtitle.Size, files[0].Size)
+	assert.False(t, files[0].Added.IsZero())
+	assert.Equal(t, nfo.RelativePath, files[1].RelativePath)
+
+	files, err = store.ListExtraFiles(ctx, table.ExtraFile.EpisodeFileID.EQ(sqlite.Int64(episodeFileID)))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, episodeSubtitle.RelativePath, files[0].RelativePath)
+
+	err = store.DeleteExtraFiles(ctx, table.ExtraFile.MovieFileID.EQ(sqlite.Int64(movieFileID)))
+	require.NoError(t, err)
+
+	files, err = store.ListExtraFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, episodeSubtitle.RelativePath, files[0].RelativePath)
+
+	err = store.DeleteEpisodeFile(ctx, episodeFileID)
+	require.NoError(t, err)
+
+	files, err = store.ListExtraFiles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files, "deleting an episode file deletes its extra files")
+}
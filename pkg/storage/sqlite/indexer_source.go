@@ -106,6 +106,17 @@ func (s *SQLite) DeleteIndexerSourceCascade(ctx context.Context, id int64) error
 		return err
 	}
 
+	// Delete the category settings of those indexers.
+	deleteCategories := table.IndexerCategory.DELETE().WHERE(table.IndexerCategory.IndexerSourceID.EQ(sqlite.Int64(id)))
+	_, err = deleteCategories.ExecContext(ctx, tx)
+	if err != nil {
+		log.Errorw("failed to delete indexer categories", "id", id, zap.String("query", deleteCategories.DebugSql()), "error", err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Errorw("failed to rollback", "id", id, "error", rbErr)
+		}
+		return err
+	}
+
 	// Delete the source row itself.
 	deleteSource := table.IndexerSource.DELETE().WHERE(table.IndexerSource.ID.EQ(sqlite.Int64(id)))
 	_, err = deleteSource.ExecContext(ctx, tx)
@@ -120,3 +131,33 @@ func (s *SQLite) DeleteIndexerSourceCascade(ctx context.Context, id int64) error
 	log.Debugw("deleted indexer source and child indexers", "id", id)
 	return tx.Commit()
 }
+
+// ListIndexerCategories lists the stored category settings of source indexers
+func (s *SQLite) ListIndexerCategories(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.IndexerCategory, error) {
+	items := make([]*model.IndexerCategory, 0)
+
+	stmt := table.IndexerCategory.SELECT(table.IndexerCategory.AllColumns).FROM(table.IndexerCategory)
+	if len(where) > 0 {
+		stmt = stmt.WHERE(sqlite.AND(where...))
+	}
+
+	stmt = stmt.ORDER_BY(table.IndexerCategory.IndexerSourceID.ASC(), table.IndexerCategory.IndexerID.ASC())
+
+	err := stmt.QueryContext(ctx, s.db, &items)
+	return items, err
+}
+
+// UpsertIndexerCategories stores the category settings of a source indexer
+func (s *SQLite) UpsertIndexerCategories(ctx context.Context, categories model.IndexerCategory) error {
+	stmt := table.IndexerCategory.
+		INSERT(table.IndexerCategory.AllColumns.Except(table.IndexerCategory.ID)).
+		MODEL(categories).
+		ON_CONFLICT(table.IndexerCategory.IndexerSourceID, table.IndexerCategory.IndexerID).
+		DO_UPDATE(sqlite.SET(
+			table.IndexerCategory.MovieCategories.SET(table.IndexerCategory.EXCLUDED.MovieCategories),
+			table.IndexerCategory.TvCategories.SET(table.IndexerCategory.EXCLUDED.TvCategories),
+		))
+
+	_, err := s.handleInsert(ctx, stmt)
+	return err
+}
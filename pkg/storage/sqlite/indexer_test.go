@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-jet/jet/v2/sqlite"
 	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIndexerStorage(t *testing.T) {
@@ -42,3 +45,51 @@ func TestIndexerStorage(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Empty(t, ix)
 }
+
+func TestIndexerCategoryStorage(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	sourceID, err := store.CreateIndexerSource(ctx, model.IndexerSource{
+		Name:           "prowlarr",
+		Implementation: "prowlarr",
+		Scheme:         "http",
+		Host:           "localhost",
+		Enabled:        true,
+	})
+	require.NoError(t, err)
+
+	categories, err := store.ListIndexerCategories(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, categories)
+
+	err = store.UpsertIndexerCategories(ctx, model.IndexerCategory{
+		IndexerSourceID: int32(sourceID),
+		IndexerID:       5,
+		MovieCategories: "[2000]",
+		TvCategories:    "[5000]",
+	})
+	require.NoError(t, err)
+
+	err = store.UpsertIndexerCategories(ctx, model.IndexerCategory{
+		IndexerSourceID: int32(sourceID),
+		IndexerID:       5,
+		MovieCategories: "[2040,2045]",
+		TvCategories:    "[5070]",
+	})
+	require.NoError(t, err)
+
+	categories, err = store.ListIndexerCategories(ctx, table.IndexerCategory.IndexerSourceID.EQ(sqlite.Int64(sourceID)))
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	assert.Equal(t, int32(5), categories[0].IndexerID)
+	assert.Equal(t, "[2040,2045]", categories[0].MovieCategories)
+	assert.Equal(t, "[5070]", categories[0].TvCategories)
+
+	err = store.DeleteIndexerSourceCascade(ctx, sourceID)
+	require.NoError(t, err)
+
+	categories, err = store.ListIndexerCategories(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, categories)
+}
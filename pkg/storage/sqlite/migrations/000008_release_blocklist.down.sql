@@ -0,0 +1,12 @@
+ALTER TABLE "episode_transition" DROP COLUMN "release_info_hash";
+ALTER TABLE "episode_transition" DROP COLUMN "release_title";
+ALTER TABLE "episode_transition" DROP COLUMN "release_guid";
+
+ALTER TABLE "movie_transition" DROP COLUMN "release_info_hash";
+ALTER TABLE "movie_transition" DROP COLUMN "release_title";
+ALTER TABLE "movie_transition" DROP COLUMN "release_guid";
+
+DROP INDEX IF EXISTS "idx_release_blocklist_episode";
+DROP INDEX IF EXISTS "idx_release_blocklist_movie";
+
+DROP TABLE IF EXISTS "release_blocklist";
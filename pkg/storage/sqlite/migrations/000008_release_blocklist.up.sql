@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "release_blocklist" (
+    "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "movie_id" INTEGER REFERENCES "movie"("id") ON DELETE CASCADE,
+    "episode_id" INTEGER REFERENCES "episode"("id") ON DELETE CASCADE,
+    "guid" TEXT,
+    "info_hash" TEXT,
+    "title" TEXT NOT NULL,
+    "protocol" TEXT,
+    "indexer" TEXT,
+    "reason" TEXT NOT NULL,
+    "created_at" DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_release_blocklist_movie" ON "release_blocklist" ("movie_id");
+CREATE INDEX IF NOT EXISTS "idx_release_blocklist_episode" ON "release_blocklist" ("episode_id");
+
+ALTER TABLE "movie_transition" ADD COLUMN "release_guid" TEXT;
+ALTER TABLE "movie_transition" ADD COLUMN "release_title" TEXT;
+ALTER TABLE "movie_transition" ADD COLUMN "release_info_hash" TEXT;
+
+ALTER TABLE "episode_transition" ADD COLUMN "release_guid" TEXT;
+ALTER TABLE "episode_transition" ADD COLUMN "release_title" TEXT;
+ALTER TABLE "episode_transition" ADD COLUMN "release_info_hash" TEXT;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_indexer_category_source_indexer";
+
+DROP TABLE IF EXISTS "indexer_category";
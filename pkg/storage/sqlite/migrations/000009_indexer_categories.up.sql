@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "indexer_category" (
+    "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "indexer_source_id" INTEGER NOT NULL REFERENCES "indexer_source"("id") ON DELETE CASCADE,
+    "indexer_id" INTEGER NOT NULL,
+    "movie_categories" TEXT NOT NULL DEFAULT '[]',
+    "tv_categories" TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_indexer_category_source_indexer" ON "indexer_category" ("indexer_source_id", "indexer_id");
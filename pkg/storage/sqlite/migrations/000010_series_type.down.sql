@@ -0,0 +1 @@
+ALTER TABLE "series" DROP COLUMN "series_type";
@@ -0,0 +1,2 @@
+-- standard series are numbered by season and episode, anime series by absolute episode number
+ALTER TABLE "series" ADD COLUMN "series_type" TEXT NOT NULL DEFAULT 'standard';
@@ -0,0 +1,3 @@
+ALTER TABLE "quality_profile" DROP COLUMN "min_format_score";
+DROP TABLE IF EXISTS "quality_profile_format";
+DROP TABLE IF EXISTS "custom_format";
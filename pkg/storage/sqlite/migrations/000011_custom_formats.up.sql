@@ -0,0 +1,18 @@
+-- custom formats are named sets of conditions on a release title, scored per quality profile
+CREATE TABLE IF NOT EXISTS "custom_format" (
+    "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "name" TEXT NOT NULL UNIQUE,
+    "specifications" TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE IF NOT EXISTS "quality_profile_format" (
+    "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "profile_id" INTEGER NOT NULL REFERENCES "quality_profile"("id") ON DELETE CASCADE,
+    "custom_format_id" INTEGER NOT NULL REFERENCES "custom_format"("id") ON DELETE CASCADE,
+    "score" INTEGER NOT NULL DEFAULT 0,
+    UNIQUE ("profile_id", "custom_format_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_quality_profile_format_profile" ON "quality_profile_format" ("profile_id");
+
+ALTER TABLE "quality_profile" ADD COLUMN "min_format_score" INTEGER NOT NULL DEFAULT 0;
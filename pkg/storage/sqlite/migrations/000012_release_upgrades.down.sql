@@ -0,0 +1,4 @@
+ALTER TABLE "episode_file" DROP COLUMN "scene_name";
+
+ALTER TABLE "episode_transition" DROP COLUMN "is_upgrade";
+ALTER TABLE "movie_transition" DROP COLUMN "is_upgrade";
@@ -0,0 +1,4 @@
+ALTER TABLE "movie_transition" ADD COLUMN "is_upgrade" BOOLEAN;
+ALTER TABLE "episode_transition" ADD COLUMN "is_upgrade" BOOLEAN;
+
+ALTER TABLE "episode_file" ADD COLUMN "scene_name" TEXT;
@@ -0,0 +1,5 @@
+ALTER TABLE "episode_transition" DROP COLUMN "replaced_file";
+ALTER TABLE "episode_transition" DROP COLUMN "upgrade_reason";
+
+ALTER TABLE "movie_transition" DROP COLUMN "replaced_file";
+ALTER TABLE "movie_transition" DROP COLUMN "upgrade_reason";
@@ -0,0 +1,5 @@
+ALTER TABLE "movie_transition" ADD COLUMN "upgrade_reason" TEXT;
+ALTER TABLE "movie_transition" ADD COLUMN "replaced_file" TEXT;
+
+ALTER TABLE "episode_transition" ADD COLUMN "upgrade_reason" TEXT;
+ALTER TABLE "episode_transition" ADD COLUMN "replaced_file" TEXT;
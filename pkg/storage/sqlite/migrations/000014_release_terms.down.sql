@@ -0,0 +1,3 @@
+ALTER TABLE "quality_profile" DROP COLUMN "preferred_terms";
+ALTER TABLE "quality_profile" DROP COLUMN "ignored_terms";
+ALTER TABLE "quality_profile" DROP COLUMN "required_terms";
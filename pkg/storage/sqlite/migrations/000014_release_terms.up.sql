@@ -0,0 +1,4 @@
+-- terms are JSON arrays matched against release titles, see storage.ReleaseTerms
+ALTER TABLE "quality_profile" ADD COLUMN "required_terms" TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE "quality_profile" ADD COLUMN "ignored_terms" TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE "quality_profile" ADD COLUMN "preferred_terms" TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,9 @@
+ALTER TABLE "episode_file" DROP COLUMN "languages";
+
+ALTER TABLE "quality_profile" DROP COLUMN "require_language";
+ALTER TABLE "quality_profile" DROP COLUMN "languages";
+
+ALTER TABLE "series_metadata" DROP COLUMN "original_language";
+
+ALTER TABLE "movie_metadata" DROP COLUMN "original_language";
+ALTER TABLE "movie_metadata" ADD COLUMN "original_language" INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+-- original_language was never populated, store it as an ISO 639-1 code instead
+ALTER TABLE "movie_metadata" DROP COLUMN "original_language";
+ALTER TABLE "movie_metadata" ADD COLUMN "original_language" TEXT;
+
+ALTER TABLE "series_metadata" ADD COLUMN "original_language" TEXT;
+
+-- languages is a JSON array of ISO 639-1 codes, or "original" for the language of the movie or series
+ALTER TABLE "quality_profile" ADD COLUMN "languages" TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE "quality_profile" ADD COLUMN "require_language" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- languages detected from the release name, comma separated ISO 639-1 codes
+ALTER TABLE "episode_file" ADD COLUMN "languages" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE "quality_definition" DROP COLUMN "source";
+ALTER TABLE "quality_definition" DROP COLUMN "resolution";
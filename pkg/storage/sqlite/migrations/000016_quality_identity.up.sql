@@ -0,0 +1,13 @@
+-- resolution and source identify which releases a quality definition describes, empty matches any
+ALTER TABLE "quality_definition" ADD COLUMN "resolution" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "quality_definition" ADD COLUMN "source" TEXT NOT NULL DEFAULT '';
+
+UPDATE "quality_definition" SET "resolution" = '720p' WHERE "name" LIKE '%-720p';
+UPDATE "quality_definition" SET "resolution" = '1080p' WHERE "name" LIKE '%-1080p';
+UPDATE "quality_definition" SET "resolution" = '2160p' WHERE "name" LIKE '%-2160p';
+
+UPDATE "quality_definition" SET "source" = 'HDTV' WHERE "name" LIKE 'HDTV-%';
+UPDATE "quality_definition" SET "source" = 'WEB-DL' WHERE "name" LIKE 'WEBDL-%';
+UPDATE "quality_definition" SET "source" = 'WEBRip' WHERE "name" LIKE 'WEBRip-%';
+UPDATE "quality_definition" SET "source" = 'BluRay' WHERE "name" LIKE 'Bluray-%';
+UPDATE "quality_definition" SET "source" = 'Remux' WHERE "name" LIKE 'Remux-%';
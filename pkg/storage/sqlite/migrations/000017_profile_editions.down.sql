@@ -0,0 +1,2 @@
+ALTER TABLE "quality_profile" DROP COLUMN "require_edition";
+ALTER TABLE "quality_profile" DROP COLUMN "editions";
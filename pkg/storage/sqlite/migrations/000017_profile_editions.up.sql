@@ -0,0 +1,3 @@
+-- editions is a JSON array of the preferred movie editions, best first. Empty accepts any edition.
+ALTER TABLE "quality_profile" ADD COLUMN "editions" TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE "quality_profile" ADD COLUMN "require_edition" BOOLEAN NOT NULL DEFAULT FALSE;
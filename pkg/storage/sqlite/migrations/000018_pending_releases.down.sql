@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_pending_release_season";
+DROP INDEX IF EXISTS "idx_pending_release_episode";
+DROP INDEX IF EXISTS "idx_pending_release_movie";
+
+DROP TABLE IF EXISTS "pending_release";
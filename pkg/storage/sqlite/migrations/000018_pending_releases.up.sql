@@ -0,0 +1,18 @@
+-- pending_release holds the releases found for a movie, episode or season pack while a delay profile waits for a
+-- better one to show up
+CREATE TABLE IF NOT EXISTS "pending_release" (
+    "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "movie_id" INTEGER REFERENCES "movie"("id") ON DELETE CASCADE,
+    "episode_id" INTEGER REFERENCES "episode"("id") ON DELETE CASCADE,
+    "season_id" INTEGER REFERENCES "season"("id") ON DELETE CASCADE,
+    "guid" TEXT,
+    "title" TEXT NOT NULL,
+    "protocol" TEXT NOT NULL,
+    "indexer" TEXT,
+    "first_seen" DATETIME NOT NULL,
+    "grab_after" DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "idx_pending_release_movie" ON "pending_release" ("movie_id");
+CREATE INDEX IF NOT EXISTS "idx_pending_release_episode" ON "pending_release" ("episode_id");
+CREATE INDEX IF NOT EXISTS "idx_pending_release_season" ON "pending_release" ("season_id");
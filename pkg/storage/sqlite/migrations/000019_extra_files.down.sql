@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_extra_file_episode_file";
+DROP INDEX IF EXISTS "idx_extra_file_movie_file";
+
+DROP TABLE IF EXISTS "extra_file";
@@ -0,0 +1,13 @@
+-- extra_file tracks the subtitles, nfo files and artwork imported along with a movie or episode file so they're removed
+-- with it
+CREATE TABLE IF NOT EXISTS "extra_file" (
+    "id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    "movie_file_id" INTEGER REFERENCES "movie_file"("id") ON DELETE CASCADE,
+    "episode_file_id" INTEGER REFERENCES "episode_file"("id") ON DELETE CASCADE,
+    "relative_path" TEXT NOT NULL,
+    "size" BIGINT NOT NULL DEFAULT 0,
+    "added" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_extra_file_movie_file" ON "extra_file" ("movie_file_id");
+CREATE INDEX IF NOT EXISTS "idx_extra_file_episode_file" ON "extra_file" ("episode_file_id");
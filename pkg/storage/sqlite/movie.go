@@ -152,7 +152,11 @@ func (s *SQLite) ListMoviesByState(ctx context.Context, state storage.MovieState
 			table.Movie.AllColumns,
 			table.MovieTransition.ToState,
 			table.MovieTransition.DownloadClientID,
-			table.MovieTransition.DownloadID).
+			table.MovieTransition.DownloadID,
+			table.MovieTransition.ReleaseGUID,
+			table.MovieTransition.ReleaseTitle,
+			table.MovieTransition.ReleaseInfoHash,
+			table.MovieTransition.IsUpgrade).
 		FROM(
 			table.Movie.INNER_JOIN(
 				table.MovieTransition,
@@ -185,14 +189,20 @@ func (s *SQLite) UpdateMovieMovieFileID(ctx context.Context, id int64, fileID in
 	return err
 }
 
-// UpdateMovie updates fields on a movie
+// UpdateMovie updates fields on a movie. Unmonitored movies lose their pending releases.
 func (s *SQLite) UpdateMovie(ctx context.Context, movie model.Movie, where ...sqlite.BoolExpression) error {
 	stmt := table.Movie.UPDATE(table.Movie.Monitored).MODEL(movie)
+	movies := table.Movie.SELECT(table.Movie.ID).FROM(table.Movie)
 	for _, w := range where {
 		stmt = stmt.WHERE(w)
+		movies = movies.WHERE(w)
 	}
 	_, err := stmt.ExecContext(ctx, s.db)
-	return err
+	if err != nil || movie.Monitored != 0 {
+		return err
+	}
+
+	return clearPendingReleases(ctx, s.db, table.PendingRelease.MovieID.IN(movies))
 }
 
 // UpdateMovieQualityProfile updates only the quality profile id for a movie
@@ -250,6 +260,12 @@ func (s *SQLite) UpdateMovieState(ctx context.Context, id int64, state storage.M
 			transition.DownloadClientID = metadata.DownloadClientID
 			transition.DownloadID = metadata.DownloadID
 		}
+		transition.ReleaseGUID = metadata.ReleaseGUID
+		transition.ReleaseTitle = metadata.ReleaseTitle
+		transition.ReleaseInfoHash = metadata.ReleaseInfoHash
+		transition.IsUpgrade = metadata.IsUpgrade
+		transition.UpgradeReason = metadata.UpgradeReason
+		transition.ReplacedFile = metadata.ReplacedFile
 	}
 
 	newTransitionStmt := table.MovieTransition.
@@ -264,6 +280,12 @@ func (s *SQLite) UpdateMovieState(ctx context.Context, id int64, state storage.M
 		return err
 	}
 
+	err = clearPendingReleases(ctx, tx, table.PendingRelease.MovieID.EQ(sqlite.Int64(id)))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -51,14 +51,57 @@ func (s *SQLite) CreateMovieFile(ctx context.Context, file model.MovieFile) (int
 	return inserted, nil
 }
 
-// DeleteMovieFile removes a movie file by id
-func (s *SQLite) DeleteMovieFile(ctx context.Context, id int64) error {
-	stmt := table.MovieFile.DELETE().WHERE(table.MovieFile.ID.EQ(sqlite.Int64(id))).RETURNING(table.MovieFile.ID)
-	_, err := s.handleDelete(ctx, stmt)
+// UpdateMovieFileQuality sets the quality a movie file was identified as
+func (s *SQLite) UpdateMovieFileQuality(ctx context.Context, id int64, quality string) error {
+	stmt := table.MovieFile.UPDATE().
+		SET(table.MovieFile.Quality.SET(sqlite.String(quality))).
+		WHERE(table.MovieFile.ID.EQ(sqlite.Int64(id)))
+	_, err := s.handleStatement(ctx, stmt)
+	return err
+}
+
+// UpdateMovieFilePaths sets a movie's path and the relative paths of its files and their extra files, by id, in one transaction
+func (s *SQLite) UpdateMovieFilePaths(ctx context.Context, movieID int64, path string, relativePaths, extraPaths map[int32]string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	stmt := table.Movie.UPDATE().
+		SET(table.Movie.Path.SET(sqlite.String(path))).
+		WHERE(table.Movie.ID.EQ(sqlite.Int64(movieID)))
+	_, err = stmt.ExecContext(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for id, relativePath := range relativePaths {
+		stmt := table.MovieFile.UPDATE().
+			SET(table.MovieFile.RelativePath.SET(sqlite.String(relativePath))).
+			WHERE(table.MovieFile.ID.EQ(sqlite.Int32(id)))
+		_, err = stmt.ExecContext(ctx, tx)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = updateExtraFilePaths(ctx, tx, extraPaths)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteMovieFile removes a movie file and its extra files by id
+func (s *SQLite) DeleteMovieFile(ctx context.Context, id int64) error {
+	return s.deleteWithExtraFiles(ctx,
+		table.ExtraFile.DELETE().WHERE(table.ExtraFile.MovieFileID.EQ(sqlite.Int64(id))),
+		table.MovieFile.DELETE().WHERE(table.MovieFile.ID.EQ(sqlite.Int64(id))).RETURNING(table.MovieFile.ID),
+	)
 }
 
 // ListMovieFiles lists all movie files
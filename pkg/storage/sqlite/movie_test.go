@@ -162,6 +162,27 @@ func TestSQLite_UpdateMovieMovieFileID(t *testing.T) {
 	})
 }
 
+func TestSQLite_UpdateMovieFileQuality(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+	require.NotNil(t, store)
+
+	id, err := store.CreateMovieFile(ctx, model.MovieFile{
+		Size:         1_000_000_000,
+		RelativePath: ptr.To("Title/Title.1080p.BluRay.mkv"),
+	})
+	require.NoError(t, err)
+
+	err = store.UpdateMovieFileQuality(ctx, id, "Bluray-1080p")
+	require.NoError(t, err)
+
+	files, err := store.ListMovieFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "Bluray-1080p", files[0].Quality)
+	assert.Equal(t, "Title/Title.1080p.BluRay.mkv", *files[0].RelativePath)
+}
+
 func TestSQLite_UpdateMovieQualityProfile(t *testing.T) {
 	t.Run("updates only quality profile id", func(t *testing.T) {
 		ctx := context.Background()
@@ -235,3 +256,35 @@ func TestSQLite_GetMovieByMovieFileID(t *testing.T) {
 		assert.Equal(t, int32(1), int32(*movie.MovieFileID))
 	})
 }
+
+func TestSQLite_UpdateMovieFilePaths(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Path: ptr.To("Title"), Monitored: 1}}, storage.MovieStateMissing)
+	require.NoError(t, err)
+
+	fileID, err := store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("Title/title.mkv"), Size: 1})
+	require.NoError(t, err)
+	extraID, err := store.CreateExtraFile(ctx, model.ExtraFile{MovieFileID: ptr.To(int32(fileID)), RelativePath: "Title/title.en.srt", Size: 1})
+	require.NoError(t, err)
+
+	err = store.UpdateMovieFilePaths(ctx, movieID, "Title (2020)",
+		map[int32]string{int32(fileID): "Title (2020)/Title (2020).mkv"},
+		map[int32]string{int32(extraID): "Title (2020)/Title (2020).en.srt"})
+	require.NoError(t, err)
+
+	movie, err := store.GetMovie(ctx, movieID)
+	require.NoError(t, err)
+	assert.Equal(t, "Title (2020)", *movie.Path)
+
+	files, err := store.GetMovieFilesByMovieName(ctx, "Title (2020)")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "Title (2020)/Title (2020).mkv", *files[0].RelativePath)
+
+	extras, err := store.ListExtraFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, extras, 1)
+	assert.Equal(t, "Title (2020)/Title (2020).en.srt", extras[0].RelativePath)
+}
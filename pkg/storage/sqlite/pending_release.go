@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+)
+
+// CreatePendingRelease stores a release that is waiting out a delay profile
+func (s *SQLite) CreatePendingRelease(ctx context.Context, release model.PendingRelease) (int64, error) {
+	stmt := table.PendingRelease.
+		INSERT(table.PendingRelease.AllColumns.Except(table.PendingRelease.ID)).
+		MODEL(release).
+		RETURNING(table.PendingRelease.ID)
+
+	result, err := s.handleInsert(ctx, stmt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create pending release: %w", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// ListPendingReleases lists pending releases, the ones that can be grabbed soonest first
+func (s *SQLite) ListPendingReleases(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.PendingRelease, error) {
+	stmt := table.PendingRelease.
+		SELECT(table.PendingRelease.AllColumns).
+		FROM(table.PendingRelease)
+
+	for _, w := range where {
+		stmt = stmt.WHERE(w)
+	}
+
+	stmt = stmt.ORDER_BY(table.PendingRelease.GrabAfter.ASC(), table.PendingRelease.ID.ASC())
+
+	releases := make([]*model.PendingRelease, 0)
+	err := stmt.QueryContext(ctx, s.db, &releases)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// DeletePendingReleases removes the pending releases matching the condition
+func (s *SQLite) DeletePendingReleases(ctx context.Context, where sqlite.BoolExpression) error {
+	stmt := table.PendingRelease.
+		DELETE().
+		WHERE(where)
+
+	_, err := s.handleDelete(ctx, stmt)
+	return err
+}
+
+// clearPendingReleases removes the pending releases matching the condition as part of another statement. Releases
+// pending for a movie, episode or season were found for its previous state, and aren't wanted once it's unmonitored.
+func clearPendingReleases(ctx context.Context, db qrm.Executable, where sqlite.BoolExpression) error {
+	_, err := table.PendingRelease.DELETE().WHERE(where).ExecContext(ctx, db)
+	return err
+}
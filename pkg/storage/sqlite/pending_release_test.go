@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingReleaseStorage(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	movieID, err := store.CreateMovie(ctx, storage.Movie{
+		Movie: model.Movie{
+			Path:             ptr.To("Movie (2024)"),
+			Monitored:        1,
+			QualityProfileID: 1,
+		},
+	}, storage.MovieStateMissing)
+	require.NoError(t, err)
+
+	firstSeen := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := model.PendingRelease{
+		MovieID:   ptr.To(int32(movieID)),
+		GUID:      ptr.To("guid-1"),
+		Title:     "Movie.2024.1080p.WEB-DL-GRP",
+		Protocol:  "torrent",
+		Indexer:   ptr.To("indexer"),
+		FirstSeen: firstSeen,
+		GrabAfter: firstSeen.Add(6 * time.Hour),
+	}
+	sooner := model.PendingRelease{
+		MovieID:   ptr.To(int32(movieID)),
+		Title:     "Movie.2024.1080p.BluRay-GRP",
+		Protocol:  "usenet",
+		FirstSeen: firstSeen,
+		GrabAfter: firstSeen.Add(time.Hour),
+	}
+
+	_, err = store.CreatePendingRelease(ctx, later)
+	require.NoError(t, err)
+	_, err = store.CreatePendingRelease(ctx, sooner)
+	require.NoError(t, err)
+
+	releases, err := store.ListPendingReleases(ctx, table.PendingRelease.MovieID.EQ(sqlite.Int64(movieID)))
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+	assert.Equal(t, sooner.Title, releases[0].Title, "releases that can be grabbed sooner are listed first")
+	assert.Equal(t, later.Title, releases[1].Title)
+	assert.Equal(t, later.GUID, releases[1].GUID)
+	assert.True(t, later.FirstSeen.Equal(releases[1].FirstSeen))
+	assert.True(t, later.GrabAfter.Equal(releases[1].GrabAfter))
+
+	err = store.DeletePendingReleases(ctx, table.PendingRelease.MovieID.EQ(sqlite.Int64(movieID)))
+	require.NoError(t, err)
+
+	releases, err = store.ListPendingReleases(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+}
+
+func TestPendingReleaseStorage_Cleared(t *testing.T) {
+	ctx := context.Background()
+	firstSeen := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pending := func(movieID, episodeID, seasonID *int32) model.PendingRelease {
+		return model.PendingRelease{
+			MovieID:   movieID,
+			EpisodeID: episodeID,
+			SeasonID:  seasonID,
+			Title:     "Release.1080p.WEB-DL-GRP",
+			Protocol:  "torrent",
+			FirstSeen: firstSeen,
+			GrabAfter: firstSeen.Add(time.Hour),
+		}
+	}
+
+	t.Run("movie changes state", func(t *testing.T) {
+		store := initSqlite(t, ctx)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Monitored: 1, QualityProfileID: 1}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		_, err = store.CreatePendingRelease(ctx, pending(ptr.To(int32(movieID)), nil, nil))
+		require.NoError(t, err)
+
+		err = store.UpdateMovieState(ctx, movieID, storage.MovieStateDownloading, nil)
+		require.NoError(t, err)
+
+		releases, err := store.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, releases)
+	})
+
+	t.Run("movie is unmonitored", func(t *testing.T) {
+		store := initSqlite(t, ctx)
+		movieID, err := store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{Monitored: 1, QualityProfileID: 1}}, storage.MovieStateMissing)
+		require.NoError(t, err)
+		_, err = store.CreatePendingRelease(ctx, pending(ptr.To(int32(movieID)), nil, nil))
+		require.NoError(t, err)
+
+		err = store.UpdateMovie(ctx, model.Movie{Monitored: 1}, table.Movie.ID.EQ(sqlite.Int64(movieID)))
+		require.NoError(t, err)
+		releases, err := store.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Len(t, releases, 1, "monitored movies keep their pending releases")
+
+		err = store.UpdateMovie(ctx, model.Movie{Monitored: 0}, table.Movie.ID.EQ(sqlite.Int64(movieID)))
+		require.NoError(t, err)
+		releases, err = store.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, releases)
+	})
+
+	t.Run("episode changes state and series is unmonitored", func(t *testing.T) {
+		store := initSqlite(t, ctx)
+		seriesID, err := store.CreateSeries(ctx, storage.Series{Series: model.Series{Monitored: 1, QualityProfileID: 1}}, storage.SeriesStateMissing)
+		require.NoError(t, err)
+		seasonID, err := store.CreateSeason(ctx, storage.Season{Season: model.Season{SeriesID: int32(seriesID)}}, storage.SeasonStateMissing)
+		require.NoError(t, err)
+		first, err := store.CreateEpisode(ctx, storage.Episode{Episode: model.Episode{SeasonID: int32(seasonID), EpisodeNumber: 1, Monitored: 1}}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+		second, err := store.CreateEpisode(ctx, storage.Episode{Episode: model.Episode{SeasonID: int32(seasonID), EpisodeNumber: 2, Monitored: 1}}, storage.EpisodeStateMissing)
+		require.NoError(t, err)
+
+		for _, release := range []model.PendingRelease{
+			pending(nil, ptr.To(int32(first)), nil),
+			pending(nil, ptr.To(int32(second)), nil),
+			pending(nil, nil, ptr.To(int32(seasonID))),
+		} {
+			_, err = store.CreatePendingRelease(ctx, release)
+			require.NoError(t, err)
+		}
+
+		err = store.UpdateEpisodeState(ctx, first, storage.EpisodeStateDownloading, nil)
+		require.NoError(t, err)
+		releases, err := store.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Len(t, releases, 2)
+
+		err = store.UpdateSeries(ctx, model.Series{Monitored: 0}, table.Series.ID.EQ(sqlite.Int64(seriesID)))
+		require.NoError(t, err)
+		releases, err = store.ListPendingReleases(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, releases)
+	})
+}
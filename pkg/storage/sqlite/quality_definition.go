@@ -48,6 +48,8 @@ func (s *SQLite) UpdateQualityDefinition(ctx context.Context, id int64, definiti
 		table.QualityDefinition.MinSize,
 		table.QualityDefinition.MaxSize,
 		table.QualityDefinition.MediaType,
+		table.QualityDefinition.Resolution,
+		table.QualityDefinition.Source,
 	).MODEL(definition).WHERE(table.QualityDefinition.ID.EQ(sqlite.Int64(id)))
 	_, err := stmt.ExecContext(ctx, s.db)
 	return err
@@ -44,6 +44,11 @@ func (s *SQLite) GetQualityProfile(ctx context.Context, id int64) (storage.Quali
 
 	var result storage.QualityProfile
 	err := stmt.QueryContext(ctx, s.db, &result)
+	if err != nil {
+		return result, err
+	}
+
+	err = s.attachQualityProfileFormats(ctx, &result)
 	return result, err
 }
 
@@ -72,6 +77,11 @@ func (s *SQLite) ListQualityProfiles(ctx context.Context, where ...sqlite.BoolEx
 
 	result := make([]*storage.QualityProfile, 0)
 	err := stmt.QueryContext(ctx, s.db, &result)
+	if err != nil {
+		return result, err
+	}
+
+	err = s.attachQualityProfileFormats(ctx, result...)
 	return result, err
 }
 
@@ -81,6 +91,14 @@ func (s *SQLite) UpdateQualityProfile(ctx context.Context, id int64, profile mod
 		table.QualityProfile.Name,
 		table.QualityProfile.CutoffQualityID,
 		table.QualityProfile.UpgradeAllowed,
+		table.QualityProfile.MinFormatScore,
+		table.QualityProfile.RequiredTerms,
+		table.QualityProfile.IgnoredTerms,
+		table.QualityProfile.PreferredTerms,
+		table.QualityProfile.Languages,
+		table.QualityProfile.RequireLanguage,
+		table.QualityProfile.Editions,
+		table.QualityProfile.RequireEdition,
 	).MODEL(profile).WHERE(table.QualityProfile.ID.EQ(sqlite.Int64(id)))
 	_, err := stmt.ExecContext(ctx, s.db)
 	return err
@@ -106,6 +124,16 @@ func (s *SQLite) DeleteQualityProfile(ctx context.Context, id int64) error {
 		return err
 	}
 
+	deleteFormats := table.QualityProfileFormat.DELETE().WHERE(table.QualityProfileFormat.ProfileID.EQ(sqlite.Int64(id)))
+	_, err = deleteFormats.ExecContext(ctx, tx)
+	if err != nil {
+		log.Errorw("failed to delete profile formats", "id", id, zap.String("query", deleteFormats.DebugSql()), "error", err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Errorw("failed to rollback", "id", id, "error", rbErr)
+		}
+		return err
+	}
+
 	stmt := table.QualityProfile.DELETE().WHERE(table.QualityProfile.ID.EQ(sqlite.Int64(id))).RETURNING(table.QualityProfile.AllColumns)
 	_, err = stmt.ExecContext(ctx, tx)
 	if err != nil {
@@ -123,3 +123,50 @@ func TestGetQualityStorage(t *testing.T) {
 	err = store.DeleteQualityProfile(ctx, profileID)
 	assert.Nil(t, err)
 }
+
+func TestQualityProfileTerms(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+
+	profileID, err := store.CreateQualityProfile(ctx, model.QualityProfile{
+		Name:           "terms",
+		RequiredTerms:  `["1080p"]`,
+		IgnoredTerms:   `["/\\b3d\\b/", "hc"]`,
+		PreferredTerms: `[{"term":"flux","score":10}]`,
+	})
+	assert.Nil(t, err)
+
+	definitionID, err := store.CreateQualityDefinition(ctx, model.QualityDefinition{Name: "terms definition", MinSize: 1, MaxSize: 10, PreferredSize: 5, MediaType: "movie"})
+	assert.Nil(t, err)
+	_, err = store.CreateQualityProfileItem(ctx, model.QualityProfileItem{ProfileID: int32(profileID), QualityID: int32(definitionID)})
+	assert.Nil(t, err)
+
+	profile, err := store.GetQualityProfile(ctx, profileID)
+	assert.Nil(t, err)
+	assert.Equal(t, storage.ReleaseTerms{"1080p"}, profile.RequiredTerms)
+	assert.Equal(t, storage.ReleaseTerms{`/\b3d\b/`, "hc"}, profile.IgnoredTerms)
+	assert.Equal(t, storage.PreferredTerms{{Term: "flux", Score: 10}}, profile.PreferredTerms)
+
+	err = store.UpdateQualityProfile(ctx, profileID, model.QualityProfile{
+		Name:           "terms",
+		RequiredTerms:  `[]`,
+		IgnoredTerms:   `["hc"]`,
+		PreferredTerms: `[]`,
+	})
+	assert.Nil(t, err)
+
+	profiles, err := store.ListQualityProfiles(ctx)
+	assert.Nil(t, err)
+
+	var updated *storage.QualityProfile
+	for _, p := range profiles {
+		if int64(p.ID) == profileID {
+			updated = p
+		}
+	}
+	if assert.NotNil(t, updated) {
+		assert.Empty(t, updated.RequiredTerms)
+		assert.Equal(t, storage.ReleaseTerms{"hc"}, updated.IgnoredTerms)
+		assert.Empty(t, updated.PreferredTerms)
+	}
+}
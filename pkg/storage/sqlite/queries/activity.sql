@@ -304,7 +304,11 @@ SELECT
     CAST(JSON_OBJECT(
         'downloadClient',
         JSON_OBJECT('id', dc.id, 'host', dc.host, 'port', dc.port),
-        'downloadID', mt.download_id
+        'downloadID', mt.download_id,
+        'releaseTitle', mt.release_title,
+        'isUpgrade', CASE WHEN mt.is_upgrade THEN JSON('true') ELSE JSON('false') END,
+        'upgradeReason', mt.upgrade_reason,
+        'replacedFile', mt.replaced_file
     ) AS TEXT) AS metadata
 FROM movie AS m
 INNER JOIN movie_transition AS mt ON m.id = mt.movie_id
@@ -355,12 +359,22 @@ SELECT
     et.to_state,
     et.from_state,
     et.created_at,
-    et.sort_key
+    et.sort_key,
+    CAST(JSON_OBJECT(
+        'downloadClient',
+        JSON_OBJECT('id', dc.id, 'host', dc.host, 'port', dc.port),
+        'downloadID', et.download_id,
+        'releaseTitle', et.release_title,
+        'isUpgrade', CASE WHEN et.is_upgrade THEN JSON('true') ELSE JSON('false') END,
+        'upgradeReason', et.upgrade_reason,
+        'replacedFile', et.replaced_file
+    ) AS TEXT) AS metadata
 FROM episode AS e
 INNER JOIN episode_transition AS et ON e.id = et.episode_id
 INNER JOIN season AS s ON e.season_id = s.id
 INNER JOIN series AS ser ON s.series_id = ser.id
 LEFT JOIN series_metadata AS sm ON ser.series_metadata_id = sm.id
+LEFT JOIN download_client AS dc ON et.download_client_id = dc.id
 WHERE e.id = ?
 ORDER BY et.sort_key ASC;
 
@@ -18,4 +18,6 @@ type EpisodeFile struct {
 	Added            time.Time
 	RelativePath     *string
 	OriginalFilePath *string
+	SceneName        *string
+	Languages        string
 }
@@ -23,4 +23,10 @@ type EpisodeTransition struct {
 	IsEntireSeasonDownload *bool
 	CreatedAt              *time.Time
 	UpdatedAt              *time.Time
+	ReleaseGUID            *string
+	ReleaseTitle           *string
+	ReleaseInfoHash        *string
+	IsUpgrade              *bool
+	UpgradeReason          *string
+	ReplacedFile           *string
 }
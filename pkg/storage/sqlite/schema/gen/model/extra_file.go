@@ -0,0 +1,21 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type ExtraFile struct {
+	ID            int32 `sql:"primary_key"`
+	MovieFileID   *int32
+	EpisodeFileID *int32
+	RelativePath  string
+	Size          int64
+	Added         time.Time
+}
@@ -22,7 +22,6 @@ type MovieMetadata struct {
 	CleanTitle         *string
 	OriginalTitle      *string
 	CleanOriginalTitle *string
-	OriginalLanguage   int32
 	Status             int32
 	LastInfoSync       *time.Time
 	Runtime            int32
@@ -40,4 +39,5 @@ type MovieMetadata struct {
 	Popularity         *float64
 	CollectionTmdbID   *int32
 	CollectionTitle    *string
+	OriginalLanguage   *string
 }
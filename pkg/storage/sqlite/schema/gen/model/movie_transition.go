@@ -22,4 +22,10 @@ type MovieTransition struct {
 	DownloadID       *string
 	CreatedAt        *time.Time
 	UpdatedAt        *time.Time
+	ReleaseGUID      *string
+	ReleaseTitle     *string
+	ReleaseInfoHash  *string
+	IsUpgrade        *bool
+	UpgradeReason    *string
+	ReplacedFile     *string
 }
@@ -0,0 +1,25 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+import (
+	"time"
+)
+
+type PendingRelease struct {
+	ID        int32 `sql:"primary_key"`
+	MovieID   *int32
+	EpisodeID *int32
+	SeasonID  *int32
+	GUID      *string
+	Title     string
+	Protocol  string
+	Indexer   *string
+	FirstSeen time.Time
+	GrabAfter time.Time
+}
@@ -15,4 +15,6 @@ type QualityDefinition struct {
 	MinSize       float64
 	MaxSize       float64
 	MediaType     string
+	Resolution    string
+	Source        string
 }
@@ -12,4 +12,12 @@ type QualityProfile struct {
 	Name            string
 	CutoffQualityID *int32
 	UpgradeAllowed  bool
+	MinFormatScore  int32
+	RequiredTerms   string
+	IgnoredTerms    string
+	PreferredTerms  string
+	Languages       string
+	RequireLanguage bool
+	Editions        string
+	RequireEdition  bool
 }
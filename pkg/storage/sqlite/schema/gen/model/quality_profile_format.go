@@ -0,0 +1,15 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package model
+
+type QualityProfileFormat struct {
+	ID             int32 `sql:"primary_key"`
+	ProfileID      int32
+	CustomFormatID int32
+	Score          int32
+}
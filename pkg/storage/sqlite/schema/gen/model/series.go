@@ -20,4 +20,5 @@ type Series struct {
 	SeriesMetadataID  *int32
 	LastSearchTime    *time.Time
 	MonitorNewSeasons int32
+	SeriesType        string
 }
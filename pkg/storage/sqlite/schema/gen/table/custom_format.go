@@ -0,0 +1,81 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var CustomFormat = newCustomFormatTable("", "custom_format", "")
+
+type customFormatTable struct {
+	sqlite.Table
+
+	// Columns
+	ID             sqlite.ColumnInteger
+	Name           sqlite.ColumnString
+	Specifications sqlite.ColumnString
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type CustomFormatTable struct {
+	customFormatTable
+
+	EXCLUDED customFormatTable
+}
+
+// AS creates new CustomFormatTable with assigned alias
+func (a CustomFormatTable) AS(alias string) *CustomFormatTable {
+	return newCustomFormatTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new CustomFormatTable with assigned schema name
+func (a CustomFormatTable) FromSchema(schemaName string) *CustomFormatTable {
+	return newCustomFormatTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new CustomFormatTable with assigned table prefix
+func (a CustomFormatTable) WithPrefix(prefix string) *CustomFormatTable {
+	return newCustomFormatTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new CustomFormatTable with assigned table suffix
+func (a CustomFormatTable) WithSuffix(suffix string) *CustomFormatTable {
+	return newCustomFormatTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newCustomFormatTable(schemaName, tableName, alias string) *CustomFormatTable {
+	return &CustomFormatTable{
+		customFormatTable: newCustomFormatTableImpl(schemaName, tableName, alias),
+		EXCLUDED:          newCustomFormatTableImpl("", "excluded", ""),
+	}
+}
+
+func newCustomFormatTableImpl(schemaName, tableName, alias string) customFormatTable {
+	var (
+		IDColumn             = sqlite.IntegerColumn("id")
+		NameColumn           = sqlite.StringColumn("name")
+		SpecificationsColumn = sqlite.StringColumn("specifications")
+		allColumns           = sqlite.ColumnList{IDColumn, NameColumn, SpecificationsColumn}
+		mutableColumns       = sqlite.ColumnList{NameColumn, SpecificationsColumn}
+	)
+
+	return customFormatTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:             IDColumn,
+		Name:           NameColumn,
+		Specifications: SpecificationsColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
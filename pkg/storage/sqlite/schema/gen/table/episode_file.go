@@ -23,6 +23,8 @@ type episodeFileTable struct {
 	Added            sqlite.ColumnTimestamp
 	RelativePath     sqlite.ColumnString
 	OriginalFilePath sqlite.ColumnString
+	SceneName        sqlite.ColumnString
+	Languages        sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -69,8 +71,10 @@ func newEpisodeFileTableImpl(schemaName, tableName, alias string) episodeFileTab
 		AddedColumn            = sqlite.TimestampColumn("added")
 		RelativePathColumn     = sqlite.StringColumn("relative_path")
 		OriginalFilePathColumn = sqlite.StringColumn("original_file_path")
-		allColumns             = sqlite.ColumnList{IDColumn, QualityColumn, SizeColumn, AddedColumn, RelativePathColumn, OriginalFilePathColumn}
-		mutableColumns         = sqlite.ColumnList{QualityColumn, SizeColumn, AddedColumn, RelativePathColumn, OriginalFilePathColumn}
+		SceneNameColumn        = sqlite.StringColumn("scene_name")
+		LanguagesColumn        = sqlite.StringColumn("languages")
+		allColumns             = sqlite.ColumnList{IDColumn, QualityColumn, SizeColumn, AddedColumn, RelativePathColumn, OriginalFilePathColumn, SceneNameColumn, LanguagesColumn}
+		mutableColumns         = sqlite.ColumnList{QualityColumn, SizeColumn, AddedColumn, RelativePathColumn, OriginalFilePathColumn, SceneNameColumn, LanguagesColumn}
 	)
 
 	return episodeFileTable{
@@ -83,6 +87,8 @@ func newEpisodeFileTableImpl(schemaName, tableName, alias string) episodeFileTab
 		Added:            AddedColumn,
 		RelativePath:     RelativePathColumn,
 		OriginalFilePath: OriginalFilePathColumn,
+		SceneName:        SceneNameColumn,
+		Languages:        LanguagesColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -28,6 +28,12 @@ type episodeTransitionTable struct {
 	IsEntireSeasonDownload sqlite.ColumnBool
 	CreatedAt              sqlite.ColumnTimestamp
 	UpdatedAt              sqlite.ColumnTimestamp
+	ReleaseGUID            sqlite.ColumnString
+	ReleaseTitle           sqlite.ColumnString
+	ReleaseInfoHash        sqlite.ColumnString
+	IsUpgrade              sqlite.ColumnBool
+	UpgradeReason          sqlite.ColumnString
+	ReplacedFile           sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -79,8 +85,14 @@ func newEpisodeTransitionTableImpl(schemaName, tableName, alias string) episodeT
 		IsEntireSeasonDownloadColumn = sqlite.BoolColumn("is_entire_season_download")
 		CreatedAtColumn              = sqlite.TimestampColumn("created_at")
 		UpdatedAtColumn              = sqlite.TimestampColumn("updated_at")
-		allColumns                   = sqlite.ColumnList{IDColumn, EpisodeIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, IsEntireSeasonDownloadColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns               = sqlite.ColumnList{EpisodeIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, IsEntireSeasonDownloadColumn, CreatedAtColumn, UpdatedAtColumn}
+		ReleaseGUIDColumn            = sqlite.StringColumn("release_guid")
+		ReleaseTitleColumn           = sqlite.StringColumn("release_title")
+		ReleaseInfoHashColumn        = sqlite.StringColumn("release_info_hash")
+		IsUpgradeColumn              = sqlite.BoolColumn("is_upgrade")
+		UpgradeReasonColumn          = sqlite.StringColumn("upgrade_reason")
+		ReplacedFileColumn           = sqlite.StringColumn("replaced_file")
+		allColumns                   = sqlite.ColumnList{IDColumn, EpisodeIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, IsEntireSeasonDownloadColumn, CreatedAtColumn, UpdatedAtColumn, ReleaseGUIDColumn, ReleaseTitleColumn, ReleaseInfoHashColumn, IsUpgradeColumn, UpgradeReasonColumn, ReplacedFileColumn}
+		mutableColumns               = sqlite.ColumnList{EpisodeIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, IsEntireSeasonDownloadColumn, CreatedAtColumn, UpdatedAtColumn, ReleaseGUIDColumn, ReleaseTitleColumn, ReleaseInfoHashColumn, IsUpgradeColumn, UpgradeReasonColumn, ReplacedFileColumn}
 	)
 
 	return episodeTransitionTable{
@@ -98,6 +110,12 @@ func newEpisodeTransitionTableImpl(schemaName, tableName, alias string) episodeT
 		IsEntireSeasonDownload: IsEntireSeasonDownloadColumn,
 		CreatedAt:              CreatedAtColumn,
 		UpdatedAt:              UpdatedAtColumn,
+		ReleaseGUID:            ReleaseGUIDColumn,
+		ReleaseTitle:           ReleaseTitleColumn,
+		ReleaseInfoHash:        ReleaseInfoHashColumn,
+		IsUpgrade:              IsUpgradeColumn,
+		UpgradeReason:          UpgradeReasonColumn,
+		ReplacedFile:           ReplacedFileColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -0,0 +1,90 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var ExtraFile = newExtraFileTable("", "extra_file", "")
+
+type extraFileTable struct {
+	sqlite.Table
+
+	// Columns
+	ID            sqlite.ColumnInteger
+	MovieFileID   sqlite.ColumnInteger
+	EpisodeFileID sqlite.ColumnInteger
+	RelativePath  sqlite.ColumnString
+	Size          sqlite.ColumnInteger
+	Added         sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type ExtraFileTable struct {
+	extraFileTable
+
+	EXCLUDED extraFileTable
+}
+
+// AS creates new ExtraFileTable with assigned alias
+func (a ExtraFileTable) AS(alias string) *ExtraFileTable {
+	return newExtraFileTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ExtraFileTable with assigned schema name
+func (a ExtraFileTable) FromSchema(schemaName string) *ExtraFileTable {
+	return newExtraFileTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ExtraFileTable with assigned table prefix
+func (a ExtraFileTable) WithPrefix(prefix string) *ExtraFileTable {
+	return newExtraFileTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ExtraFileTable with assigned table suffix
+func (a ExtraFileTable) WithSuffix(suffix string) *ExtraFileTable {
+	return newExtraFileTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newExtraFileTable(schemaName, tableName, alias string) *ExtraFileTable {
+	return &ExtraFileTable{
+		extraFileTable: newExtraFileTableImpl(schemaName, tableName, alias),
+		EXCLUDED:       newExtraFileTableImpl("", "excluded", ""),
+	}
+}
+
+func newExtraFileTableImpl(schemaName, tableName, alias string) extraFileTable {
+	var (
+		IDColumn            = sqlite.IntegerColumn("id")
+		MovieFileIDColumn   = sqlite.IntegerColumn("movie_file_id")
+		EpisodeFileIDColumn = sqlite.IntegerColumn("episode_file_id")
+		RelativePathColumn  = sqlite.StringColumn("relative_path")
+		SizeColumn          = sqlite.IntegerColumn("size")
+		AddedColumn         = sqlite.TimestampColumn("added")
+		allColumns          = sqlite.ColumnList{IDColumn, MovieFileIDColumn, EpisodeFileIDColumn, RelativePathColumn, SizeColumn, AddedColumn}
+		mutableColumns      = sqlite.ColumnList{MovieFileIDColumn, EpisodeFileIDColumn, RelativePathColumn, SizeColumn, AddedColumn}
+	)
+
+	return extraFileTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:            IDColumn,
+		MovieFileID:   MovieFileIDColumn,
+		EpisodeFileID: EpisodeFileIDColumn,
+		RelativePath:  RelativePathColumn,
+		Size:          SizeColumn,
+		Added:         AddedColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
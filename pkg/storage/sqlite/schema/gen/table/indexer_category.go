@@ -0,0 +1,87 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var IndexerCategory = newIndexerCategoryTable("", "indexer_category", "")
+
+type indexerCategoryTable struct {
+	sqlite.Table
+
+	// Columns
+	ID              sqlite.ColumnInteger
+	IndexerSourceID sqlite.ColumnInteger
+	IndexerID       sqlite.ColumnInteger
+	MovieCategories sqlite.ColumnString
+	TvCategories    sqlite.ColumnString
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type IndexerCategoryTable struct {
+	indexerCategoryTable
+
+	EXCLUDED indexerCategoryTable
+}
+
+// AS creates new IndexerCategoryTable with assigned alias
+func (a IndexerCategoryTable) AS(alias string) *IndexerCategoryTable {
+	return newIndexerCategoryTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new IndexerCategoryTable with assigned schema name
+func (a IndexerCategoryTable) FromSchema(schemaName string) *IndexerCategoryTable {
+	return newIndexerCategoryTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new IndexerCategoryTable with assigned table prefix
+func (a IndexerCategoryTable) WithPrefix(prefix string) *IndexerCategoryTable {
+	return newIndexerCategoryTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new IndexerCategoryTable with assigned table suffix
+func (a IndexerCategoryTable) WithSuffix(suffix string) *IndexerCategoryTable {
+	return newIndexerCategoryTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newIndexerCategoryTable(schemaName, tableName, alias string) *IndexerCategoryTable {
+	return &IndexerCategoryTable{
+		indexerCategoryTable: newIndexerCategoryTableImpl(schemaName, tableName, alias),
+		EXCLUDED:             newIndexerCategoryTableImpl("", "excluded", ""),
+	}
+}
+
+func newIndexerCategoryTableImpl(schemaName, tableName, alias string) indexerCategoryTable {
+	var (
+		IDColumn              = sqlite.IntegerColumn("id")
+		IndexerSourceIDColumn = sqlite.IntegerColumn("indexer_source_id")
+		IndexerIDColumn       = sqlite.IntegerColumn("indexer_id")
+		MovieCategoriesColumn = sqlite.StringColumn("movie_categories")
+		TvCategoriesColumn    = sqlite.StringColumn("tv_categories")
+		allColumns            = sqlite.ColumnList{IDColumn, IndexerSourceIDColumn, IndexerIDColumn, MovieCategoriesColumn, TvCategoriesColumn}
+		mutableColumns        = sqlite.ColumnList{IndexerSourceIDColumn, IndexerIDColumn, MovieCategoriesColumn, TvCategoriesColumn}
+	)
+
+	return indexerCategoryTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:              IDColumn,
+		IndexerSourceID: IndexerSourceIDColumn,
+		IndexerID:       IndexerIDColumn,
+		MovieCategories: MovieCategoriesColumn,
+		TvCategories:    TvCategoriesColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
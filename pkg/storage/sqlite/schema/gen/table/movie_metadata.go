@@ -27,7 +27,6 @@ type movieMetadataTable struct {
 	CleanTitle         sqlite.ColumnString
 	OriginalTitle      sqlite.ColumnString
 	CleanOriginalTitle sqlite.ColumnString
-	OriginalLanguage   sqlite.ColumnInteger
 	Status             sqlite.ColumnInteger
 	LastInfoSync       sqlite.ColumnTimestamp
 	Runtime            sqlite.ColumnInteger
@@ -45,6 +44,7 @@ type movieMetadataTable struct {
 	Popularity         sqlite.ColumnFloat
 	CollectionTmdbID   sqlite.ColumnInteger
 	CollectionTitle    sqlite.ColumnString
+	OriginalLanguage   sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -95,7 +95,6 @@ func newMovieMetadataTableImpl(schemaName, tableName, alias string) movieMetadat
 		CleanTitleColumn         = sqlite.StringColumn("clean_title")
 		OriginalTitleColumn      = sqlite.StringColumn("original_title")
 		CleanOriginalTitleColumn = sqlite.StringColumn("clean_original_title")
-		OriginalLanguageColumn   = sqlite.IntegerColumn("original_language")
 		StatusColumn             = sqlite.IntegerColumn("status")
 		LastInfoSyncColumn       = sqlite.TimestampColumn("last_info_sync")
 		RuntimeColumn            = sqlite.IntegerColumn("runtime")
@@ -113,8 +112,9 @@ func newMovieMetadataTableImpl(schemaName, tableName, alias string) movieMetadat
 		PopularityColumn         = sqlite.FloatColumn("popularity")
 		CollectionTmdbIDColumn   = sqlite.IntegerColumn("collection_tmdb_id")
 		CollectionTitleColumn    = sqlite.StringColumn("collection_title")
-		allColumns               = sqlite.ColumnList{IDColumn, TmdbIDColumn, ImdbIDColumn, ImagesColumn, GenresColumn, TitleColumn, SortTitleColumn, CleanTitleColumn, OriginalTitleColumn, CleanOriginalTitleColumn, OriginalLanguageColumn, StatusColumn, LastInfoSyncColumn, RuntimeColumn, InCinemasColumn, ReleaseDateColumn, YearColumn, SecondaryYearColumn, RatingsColumn, RecommendationsColumn, CertificationColumn, YoutubeTrailerIDColumn, StudioColumn, OverviewColumn, WebsiteColumn, PopularityColumn, CollectionTmdbIDColumn, CollectionTitleColumn}
-		mutableColumns           = sqlite.ColumnList{TmdbIDColumn, ImdbIDColumn, ImagesColumn, GenresColumn, TitleColumn, SortTitleColumn, CleanTitleColumn, OriginalTitleColumn, CleanOriginalTitleColumn, OriginalLanguageColumn, StatusColumn, LastInfoSyncColumn, RuntimeColumn, InCinemasColumn, ReleaseDateColumn, YearColumn, SecondaryYearColumn, RatingsColumn, RecommendationsColumn, CertificationColumn, YoutubeTrailerIDColumn, StudioColumn, OverviewColumn, WebsiteColumn, PopularityColumn, CollectionTmdbIDColumn, CollectionTitleColumn}
+		OriginalLanguageColumn   = sqlite.StringColumn("original_language")
+		allColumns               = sqlite.ColumnList{IDColumn, TmdbIDColumn, ImdbIDColumn, ImagesColumn, GenresColumn, TitleColumn, SortTitleColumn, CleanTitleColumn, OriginalTitleColumn, CleanOriginalTitleColumn, StatusColumn, LastInfoSyncColumn, RuntimeColumn, InCinemasColumn, ReleaseDateColumn, YearColumn, SecondaryYearColumn, RatingsColumn, RecommendationsColumn, CertificationColumn, YoutubeTrailerIDColumn, StudioColumn, OverviewColumn, WebsiteColumn, PopularityColumn, CollectionTmdbIDColumn, CollectionTitleColumn, OriginalLanguageColumn}
+		mutableColumns           = sqlite.ColumnList{TmdbIDColumn, ImdbIDColumn, ImagesColumn, GenresColumn, TitleColumn, SortTitleColumn, CleanTitleColumn, OriginalTitleColumn, CleanOriginalTitleColumn, StatusColumn, LastInfoSyncColumn, RuntimeColumn, InCinemasColumn, ReleaseDateColumn, YearColumn, SecondaryYearColumn, RatingsColumn, RecommendationsColumn, CertificationColumn, YoutubeTrailerIDColumn, StudioColumn, OverviewColumn, WebsiteColumn, PopularityColumn, CollectionTmdbIDColumn, CollectionTitleColumn, OriginalLanguageColumn}
 	)
 
 	return movieMetadataTable{
@@ -131,7 +131,6 @@ func newMovieMetadataTableImpl(schemaName, tableName, alias string) movieMetadat
 		CleanTitle:         CleanTitleColumn,
 		OriginalTitle:      OriginalTitleColumn,
 		CleanOriginalTitle: CleanOriginalTitleColumn,
-		OriginalLanguage:   OriginalLanguageColumn,
 		Status:             StatusColumn,
 		LastInfoSync:       LastInfoSyncColumn,
 		Runtime:            RuntimeColumn,
@@ -149,6 +148,7 @@ func newMovieMetadataTableImpl(schemaName, tableName, alias string) movieMetadat
 		Popularity:         PopularityColumn,
 		CollectionTmdbID:   CollectionTmdbIDColumn,
 		CollectionTitle:    CollectionTitleColumn,
+		OriginalLanguage:   OriginalLanguageColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
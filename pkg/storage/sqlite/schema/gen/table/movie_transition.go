@@ -27,6 +27,12 @@ type movieTransitionTable struct {
 	DownloadID       sqlite.ColumnString
 	CreatedAt        sqlite.ColumnTimestamp
 	UpdatedAt        sqlite.ColumnTimestamp
+	ReleaseGUID      sqlite.ColumnString
+	ReleaseTitle     sqlite.ColumnString
+	ReleaseInfoHash  sqlite.ColumnString
+	IsUpgrade        sqlite.ColumnBool
+	UpgradeReason    sqlite.ColumnString
+	ReplacedFile     sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -77,8 +83,14 @@ func newMovieTransitionTableImpl(schemaName, tableName, alias string) movieTrans
 		DownloadIDColumn       = sqlite.StringColumn("download_id")
 		CreatedAtColumn        = sqlite.TimestampColumn("created_at")
 		UpdatedAtColumn        = sqlite.TimestampColumn("updated_at")
-		allColumns             = sqlite.ColumnList{IDColumn, MovieIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, CreatedAtColumn, UpdatedAtColumn}
-		mutableColumns         = sqlite.ColumnList{MovieIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, CreatedAtColumn, UpdatedAtColumn}
+		ReleaseGUIDColumn      = sqlite.StringColumn("release_guid")
+		ReleaseTitleColumn     = sqlite.StringColumn("release_title")
+		ReleaseInfoHashColumn  = sqlite.StringColumn("release_info_hash")
+		IsUpgradeColumn        = sqlite.BoolColumn("is_upgrade")
+		UpgradeReasonColumn    = sqlite.StringColumn("upgrade_reason")
+		ReplacedFileColumn     = sqlite.StringColumn("replaced_file")
+		allColumns             = sqlite.ColumnList{IDColumn, MovieIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, CreatedAtColumn, UpdatedAtColumn, ReleaseGUIDColumn, ReleaseTitleColumn, ReleaseInfoHashColumn, IsUpgradeColumn, UpgradeReasonColumn, ReplacedFileColumn}
+		mutableColumns         = sqlite.ColumnList{MovieIDColumn, ToStateColumn, FromStateColumn, MostRecentColumn, SortKeyColumn, DownloadClientIDColumn, DownloadIDColumn, CreatedAtColumn, UpdatedAtColumn, ReleaseGUIDColumn, ReleaseTitleColumn, ReleaseInfoHashColumn, IsUpgradeColumn, UpgradeReasonColumn, ReplacedFileColumn}
 	)
 
 	return movieTransitionTable{
@@ -95,6 +107,12 @@ func newMovieTransitionTableImpl(schemaName, tableName, alias string) movieTrans
 		DownloadID:       DownloadIDColumn,
 		CreatedAt:        CreatedAtColumn,
 		UpdatedAt:        UpdatedAtColumn,
+		ReleaseGUID:      ReleaseGUIDColumn,
+		ReleaseTitle:     ReleaseTitleColumn,
+		ReleaseInfoHash:  ReleaseInfoHashColumn,
+		IsUpgrade:        IsUpgradeColumn,
+		UpgradeReason:    UpgradeReasonColumn,
+		ReplacedFile:     ReplacedFileColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
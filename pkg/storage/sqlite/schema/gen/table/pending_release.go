@@ -0,0 +1,102 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var PendingRelease = newPendingReleaseTable("", "pending_release", "")
+
+type pendingReleaseTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	MovieID   sqlite.ColumnInteger
+	EpisodeID sqlite.ColumnInteger
+	SeasonID  sqlite.ColumnInteger
+	GUID      sqlite.ColumnString
+	Title     sqlite.ColumnString
+	Protocol  sqlite.ColumnString
+	Indexer   sqlite.ColumnString
+	FirstSeen sqlite.ColumnTimestamp
+	GrabAfter sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type PendingReleaseTable struct {
+	pendingReleaseTable
+
+	EXCLUDED pendingReleaseTable
+}
+
+// AS creates new PendingReleaseTable with assigned alias
+func (a PendingReleaseTable) AS(alias string) *PendingReleaseTable {
+	return newPendingReleaseTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new PendingReleaseTable with assigned schema name
+func (a PendingReleaseTable) FromSchema(schemaName string) *PendingReleaseTable {
+	return newPendingReleaseTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new PendingReleaseTable with assigned table prefix
+func (a PendingReleaseTable) WithPrefix(prefix string) *PendingReleaseTable {
+	return newPendingReleaseTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new PendingReleaseTable with assigned table suffix
+func (a PendingReleaseTable) WithSuffix(suffix string) *PendingReleaseTable {
+	return newPendingReleaseTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newPendingReleaseTable(schemaName, tableName, alias string) *PendingReleaseTable {
+	return &PendingReleaseTable{
+		pendingReleaseTable: newPendingReleaseTableImpl(schemaName, tableName, alias),
+		EXCLUDED:            newPendingReleaseTableImpl("", "excluded", ""),
+	}
+}
+
+func newPendingReleaseTableImpl(schemaName, tableName, alias string) pendingReleaseTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		MovieIDColumn   = sqlite.IntegerColumn("movie_id")
+		EpisodeIDColumn = sqlite.IntegerColumn("episode_id")
+		SeasonIDColumn  = sqlite.IntegerColumn("season_id")
+		GUIDColumn      = sqlite.StringColumn("guid")
+		TitleColumn     = sqlite.StringColumn("title")
+		ProtocolColumn  = sqlite.StringColumn("protocol")
+		IndexerColumn   = sqlite.StringColumn("indexer")
+		FirstSeenColumn = sqlite.TimestampColumn("first_seen")
+		GrabAfterColumn = sqlite.TimestampColumn("grab_after")
+		allColumns      = sqlite.ColumnList{IDColumn, MovieIDColumn, EpisodeIDColumn, SeasonIDColumn, GUIDColumn, TitleColumn, ProtocolColumn, IndexerColumn, FirstSeenColumn, GrabAfterColumn}
+		mutableColumns  = sqlite.ColumnList{MovieIDColumn, EpisodeIDColumn, SeasonIDColumn, GUIDColumn, TitleColumn, ProtocolColumn, IndexerColumn, FirstSeenColumn, GrabAfterColumn}
+	)
+
+	return pendingReleaseTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		MovieID:   MovieIDColumn,
+		EpisodeID: EpisodeIDColumn,
+		SeasonID:  SeasonIDColumn,
+		GUID:      GUIDColumn,
+		Title:     TitleColumn,
+		Protocol:  ProtocolColumn,
+		Indexer:   IndexerColumn,
+		FirstSeen: FirstSeenColumn,
+		GrabAfter: GrabAfterColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
@@ -24,6 +24,8 @@ type qualityDefinitionTable struct {
 	MinSize       sqlite.ColumnFloat
 	MaxSize       sqlite.ColumnFloat
 	MediaType     sqlite.ColumnString
+	Resolution    sqlite.ColumnString
+	Source        sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -71,8 +73,10 @@ func newQualityDefinitionTableImpl(schemaName, tableName, alias string) qualityD
 		MinSizeColumn       = sqlite.FloatColumn("min_size")
 		MaxSizeColumn       = sqlite.FloatColumn("max_size")
 		MediaTypeColumn     = sqlite.StringColumn("media_type")
-		allColumns          = sqlite.ColumnList{IDColumn, QualityIDColumn, NameColumn, PreferredSizeColumn, MinSizeColumn, MaxSizeColumn, MediaTypeColumn}
-		mutableColumns      = sqlite.ColumnList{QualityIDColumn, NameColumn, PreferredSizeColumn, MinSizeColumn, MaxSizeColumn, MediaTypeColumn}
+		ResolutionColumn    = sqlite.StringColumn("resolution")
+		SourceColumn        = sqlite.StringColumn("source")
+		allColumns          = sqlite.ColumnList{IDColumn, QualityIDColumn, NameColumn, PreferredSizeColumn, MinSizeColumn, MaxSizeColumn, MediaTypeColumn, ResolutionColumn, SourceColumn}
+		mutableColumns      = sqlite.ColumnList{QualityIDColumn, NameColumn, PreferredSizeColumn, MinSizeColumn, MaxSizeColumn, MediaTypeColumn, ResolutionColumn, SourceColumn}
 	)
 
 	return qualityDefinitionTable{
@@ -86,6 +90,8 @@ func newQualityDefinitionTableImpl(schemaName, tableName, alias string) qualityD
 		MinSize:       MinSizeColumn,
 		MaxSize:       MaxSizeColumn,
 		MediaType:     MediaTypeColumn,
+		Resolution:    ResolutionColumn,
+		Source:        SourceColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
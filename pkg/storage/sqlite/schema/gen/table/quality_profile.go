@@ -21,6 +21,14 @@ type qualityProfileTable struct {
 	Name            sqlite.ColumnString
 	CutoffQualityID sqlite.ColumnInteger
 	UpgradeAllowed  sqlite.ColumnBool
+	MinFormatScore  sqlite.ColumnInteger
+	RequiredTerms   sqlite.ColumnString
+	IgnoredTerms    sqlite.ColumnString
+	PreferredTerms  sqlite.ColumnString
+	Languages       sqlite.ColumnString
+	RequireLanguage sqlite.ColumnBool
+	Editions        sqlite.ColumnString
+	RequireEdition  sqlite.ColumnBool
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -65,8 +73,16 @@ func newQualityProfileTableImpl(schemaName, tableName, alias string) qualityProf
 		NameColumn            = sqlite.StringColumn("name")
 		CutoffQualityIDColumn = sqlite.IntegerColumn("cutoff_quality_id")
 		UpgradeAllowedColumn  = sqlite.BoolColumn("upgrade_allowed")
-		allColumns            = sqlite.ColumnList{IDColumn, NameColumn, CutoffQualityIDColumn, UpgradeAllowedColumn}
-		mutableColumns        = sqlite.ColumnList{NameColumn, CutoffQualityIDColumn, UpgradeAllowedColumn}
+		MinFormatScoreColumn  = sqlite.IntegerColumn("min_format_score")
+		RequiredTermsColumn   = sqlite.StringColumn("required_terms")
+		IgnoredTermsColumn    = sqlite.StringColumn("ignored_terms")
+		PreferredTermsColumn  = sqlite.StringColumn("preferred_terms")
+		LanguagesColumn       = sqlite.StringColumn("languages")
+		RequireLanguageColumn = sqlite.BoolColumn("require_language")
+		EditionsColumn        = sqlite.StringColumn("editions")
+		RequireEditionColumn  = sqlite.BoolColumn("require_edition")
+		allColumns            = sqlite.ColumnList{IDColumn, NameColumn, CutoffQualityIDColumn, UpgradeAllowedColumn, MinFormatScoreColumn, RequiredTermsColumn, IgnoredTermsColumn, PreferredTermsColumn, LanguagesColumn, RequireLanguageColumn, EditionsColumn, RequireEditionColumn}
+		mutableColumns        = sqlite.ColumnList{NameColumn, CutoffQualityIDColumn, UpgradeAllowedColumn, MinFormatScoreColumn, RequiredTermsColumn, IgnoredTermsColumn, PreferredTermsColumn, LanguagesColumn, RequireLanguageColumn, EditionsColumn, RequireEditionColumn}
 	)
 
 	return qualityProfileTable{
@@ -77,6 +93,14 @@ func newQualityProfileTableImpl(schemaName, tableName, alias string) qualityProf
 		Name:            NameColumn,
 		CutoffQualityID: CutoffQualityIDColumn,
 		UpgradeAllowed:  UpgradeAllowedColumn,
+		MinFormatScore:  MinFormatScoreColumn,
+		RequiredTerms:   RequiredTermsColumn,
+		IgnoredTerms:    IgnoredTermsColumn,
+		PreferredTerms:  PreferredTermsColumn,
+		Languages:       LanguagesColumn,
+		RequireLanguage: RequireLanguageColumn,
+		Editions:        EditionsColumn,
+		RequireEdition:  RequireEditionColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
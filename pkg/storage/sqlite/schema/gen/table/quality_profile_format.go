@@ -0,0 +1,84 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var QualityProfileFormat = newQualityProfileFormatTable("", "quality_profile_format", "")
+
+type qualityProfileFormatTable struct {
+	sqlite.Table
+
+	// Columns
+	ID             sqlite.ColumnInteger
+	ProfileID      sqlite.ColumnInteger
+	CustomFormatID sqlite.ColumnInteger
+	Score          sqlite.ColumnInteger
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type QualityProfileFormatTable struct {
+	qualityProfileFormatTable
+
+	EXCLUDED qualityProfileFormatTable
+}
+
+// AS creates new QualityProfileFormatTable with assigned alias
+func (a QualityProfileFormatTable) AS(alias string) *QualityProfileFormatTable {
+	return newQualityProfileFormatTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new QualityProfileFormatTable with assigned schema name
+func (a QualityProfileFormatTable) FromSchema(schemaName string) *QualityProfileFormatTable {
+	return newQualityProfileFormatTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new QualityProfileFormatTable with assigned table prefix
+func (a QualityProfileFormatTable) WithPrefix(prefix string) *QualityProfileFormatTable {
+	return newQualityProfileFormatTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new QualityProfileFormatTable with assigned table suffix
+func (a QualityProfileFormatTable) WithSuffix(suffix string) *QualityProfileFormatTable {
+	return newQualityProfileFormatTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newQualityProfileFormatTable(schemaName, tableName, alias string) *QualityProfileFormatTable {
+	return &QualityProfileFormatTable{
+		qualityProfileFormatTable: newQualityProfileFormatTableImpl(schemaName, tableName, alias),
+		EXCLUDED:                  newQualityProfileFormatTableImpl("", "excluded", ""),
+	}
+}
+
+func newQualityProfileFormatTableImpl(schemaName, tableName, alias string) qualityProfileFormatTable {
+	var (
+		IDColumn             = sqlite.IntegerColumn("id")
+		ProfileIDColumn      = sqlite.IntegerColumn("profile_id")
+		CustomFormatIDColumn = sqlite.IntegerColumn("custom_format_id")
+		ScoreColumn          = sqlite.IntegerColumn("score")
+		allColumns           = sqlite.ColumnList{IDColumn, ProfileIDColumn, CustomFormatIDColumn, ScoreColumn}
+		mutableColumns       = sqlite.ColumnList{ProfileIDColumn, CustomFormatIDColumn, ScoreColumn}
+	)
+
+	return qualityProfileFormatTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:             IDColumn,
+		ProfileID:      ProfileIDColumn,
+		CustomFormatID: CustomFormatIDColumn,
+		Score:          ScoreColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
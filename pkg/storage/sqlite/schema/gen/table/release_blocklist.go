@@ -0,0 +1,102 @@
+//
+// Code generated by go-jet DO NOT EDIT.
+//
+// WARNING: Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated
+//
+
+package table
+
+import (
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+var ReleaseBlocklist = newReleaseBlocklistTable("", "release_blocklist", "")
+
+type releaseBlocklistTable struct {
+	sqlite.Table
+
+	// Columns
+	ID        sqlite.ColumnInteger
+	MovieID   sqlite.ColumnInteger
+	EpisodeID sqlite.ColumnInteger
+	GUID      sqlite.ColumnString
+	InfoHash  sqlite.ColumnString
+	Title     sqlite.ColumnString
+	Protocol  sqlite.ColumnString
+	Indexer   sqlite.ColumnString
+	Reason    sqlite.ColumnString
+	CreatedAt sqlite.ColumnTimestamp
+
+	AllColumns     sqlite.ColumnList
+	MutableColumns sqlite.ColumnList
+}
+
+type ReleaseBlocklistTable struct {
+	releaseBlocklistTable
+
+	EXCLUDED releaseBlocklistTable
+}
+
+// AS creates new ReleaseBlocklistTable with assigned alias
+func (a ReleaseBlocklistTable) AS(alias string) *ReleaseBlocklistTable {
+	return newReleaseBlocklistTable(a.SchemaName(), a.TableName(), alias)
+}
+
+// Schema creates new ReleaseBlocklistTable with assigned schema name
+func (a ReleaseBlocklistTable) FromSchema(schemaName string) *ReleaseBlocklistTable {
+	return newReleaseBlocklistTable(schemaName, a.TableName(), a.Alias())
+}
+
+// WithPrefix creates new ReleaseBlocklistTable with assigned table prefix
+func (a ReleaseBlocklistTable) WithPrefix(prefix string) *ReleaseBlocklistTable {
+	return newReleaseBlocklistTable(a.SchemaName(), prefix+a.TableName(), a.TableName())
+}
+
+// WithSuffix creates new ReleaseBlocklistTable with assigned table suffix
+func (a ReleaseBlocklistTable) WithSuffix(suffix string) *ReleaseBlocklistTable {
+	return newReleaseBlocklistTable(a.SchemaName(), a.TableName()+suffix, a.TableName())
+}
+
+func newReleaseBlocklistTable(schemaName, tableName, alias string) *ReleaseBlocklistTable {
+	return &ReleaseBlocklistTable{
+		releaseBlocklistTable: newReleaseBlocklistTableImpl(schemaName, tableName, alias),
+		EXCLUDED:              newReleaseBlocklistTableImpl("", "excluded", ""),
+	}
+}
+
+func newReleaseBlocklistTableImpl(schemaName, tableName, alias string) releaseBlocklistTable {
+	var (
+		IDColumn        = sqlite.IntegerColumn("id")
+		MovieIDColumn   = sqlite.IntegerColumn("movie_id")
+		EpisodeIDColumn = sqlite.IntegerColumn("episode_id")
+		GUIDColumn      = sqlite.StringColumn("guid")
+		InfoHashColumn  = sqlite.StringColumn("info_hash")
+		TitleColumn     = sqlite.StringColumn("title")
+		ProtocolColumn  = sqlite.StringColumn("protocol")
+		IndexerColumn   = sqlite.StringColumn("indexer")
+		ReasonColumn    = sqlite.StringColumn("reason")
+		CreatedAtColumn = sqlite.TimestampColumn("created_at")
+		allColumns      = sqlite.ColumnList{IDColumn, MovieIDColumn, EpisodeIDColumn, GUIDColumn, InfoHashColumn, TitleColumn, ProtocolColumn, IndexerColumn, ReasonColumn, CreatedAtColumn}
+		mutableColumns  = sqlite.ColumnList{MovieIDColumn, EpisodeIDColumn, GUIDColumn, InfoHashColumn, TitleColumn, ProtocolColumn, IndexerColumn, ReasonColumn, CreatedAtColumn}
+	)
+
+	return releaseBlocklistTable{
+		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
+
+		//Columns
+		ID:        IDColumn,
+		MovieID:   MovieIDColumn,
+		EpisodeID: EpisodeIDColumn,
+		GUID:      GUIDColumn,
+		InfoHash:  InfoHashColumn,
+		Title:     TitleColumn,
+		Protocol:  ProtocolColumn,
+		Indexer:   IndexerColumn,
+		Reason:    ReasonColumn,
+		CreatedAt: CreatedAtColumn,
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
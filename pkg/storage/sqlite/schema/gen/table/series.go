@@ -25,6 +25,7 @@ type seriesTable struct {
 	SeriesMetadataID  sqlite.ColumnInteger
 	LastSearchTime    sqlite.ColumnTimestamp
 	MonitorNewSeasons sqlite.ColumnInteger
+	SeriesType        sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -73,8 +74,9 @@ func newSeriesTableImpl(schemaName, tableName, alias string) seriesTable {
 		SeriesMetadataIDColumn  = sqlite.IntegerColumn("series_metadata_id")
 		LastSearchTimeColumn    = sqlite.TimestampColumn("last_search_time")
 		MonitorNewSeasonsColumn = sqlite.IntegerColumn("monitor_new_seasons")
-		allColumns              = sqlite.ColumnList{IDColumn, PathColumn, MonitoredColumn, AddedColumn, QualityProfileIDColumn, SeriesMetadataIDColumn, LastSearchTimeColumn, MonitorNewSeasonsColumn}
-		mutableColumns          = sqlite.ColumnList{PathColumn, MonitoredColumn, AddedColumn, QualityProfileIDColumn, SeriesMetadataIDColumn, LastSearchTimeColumn, MonitorNewSeasonsColumn}
+		SeriesTypeColumn        = sqlite.StringColumn("series_type")
+		allColumns              = sqlite.ColumnList{IDColumn, PathColumn, MonitoredColumn, AddedColumn, QualityProfileIDColumn, SeriesMetadataIDColumn, LastSearchTimeColumn, MonitorNewSeasonsColumn, SeriesTypeColumn}
+		mutableColumns          = sqlite.ColumnList{PathColumn, MonitoredColumn, AddedColumn, QualityProfileIDColumn, SeriesMetadataIDColumn, LastSearchTimeColumn, MonitorNewSeasonsColumn, SeriesTypeColumn}
 	)
 
 	return seriesTable{
@@ -89,6 +91,7 @@ func newSeriesTableImpl(schemaName, tableName, alias string) seriesTable {
 		SeriesMetadataID:  SeriesMetadataIDColumn,
 		LastSearchTime:    LastSearchTimeColumn,
 		MonitorNewSeasons: MonitorNewSeasonsColumn,
+		SeriesType:        SeriesTypeColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -10,12 +10,15 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	CustomFormat = CustomFormat.FromSchema(schema)
 	DownloadClient = DownloadClient.FromSchema(schema)
 	Episode = Episode.FromSchema(schema)
 	EpisodeFile = EpisodeFile.FromSchema(schema)
 	EpisodeMetadata = EpisodeMetadata.FromSchema(schema)
 	EpisodeTransition = EpisodeTransition.FromSchema(schema)
+	ExtraFile = ExtraFile.FromSchema(schema)
 	Indexer = Indexer.FromSchema(schema)
+	IndexerCategory = IndexerCategory.FromSchema(schema)
 	IndexerSource = IndexerSource.FromSchema(schema)
 	Job = Job.FromSchema(schema)
 	JobTransition = JobTransition.FromSchema(schema)
@@ -23,9 +26,12 @@ func UseSchema(schema string) {
 	MovieFile = MovieFile.FromSchema(schema)
 	MovieMetadata = MovieMetadata.FromSchema(schema)
 	MovieTransition = MovieTransition.FromSchema(schema)
+	PendingRelease = PendingRelease.FromSchema(schema)
 	QualityDefinition = QualityDefinition.FromSchema(schema)
 	QualityProfile = QualityProfile.FromSchema(schema)
+	QualityProfileFormat = QualityProfileFormat.FromSchema(schema)
 	QualityProfileItem = QualityProfileItem.FromSchema(schema)
+	ReleaseBlocklist = ReleaseBlocklist.FromSchema(schema)
 	SchemaMigrations = SchemaMigrations.FromSchema(schema)
 	Season = Season.FromSchema(schema)
 	SeasonMetadata = SeasonMetadata.FromSchema(schema)
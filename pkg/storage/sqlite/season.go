@@ -221,5 +221,11 @@ func (s *SQLite) UpdateSeasonState(ctx context.Context, id int64, state storage.
 		return err
 	}
 
+	err = clearPendingReleases(ctx, tx, table.PendingRelease.SeasonID.EQ(sqlite.Int64(id)))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit()
 }
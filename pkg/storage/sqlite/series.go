@@ -16,6 +16,9 @@ func (s *SQLite) CreateSeries(ctx context.Context, series storage.Series, initia
 	if series.State == "" {
 		series.State = storage.SeriesStateNew
 	}
+	if series.SeriesType == "" {
+		series.SeriesType = storage.SeriesTypeStandard
+	}
 
 	err := series.Machine().ToState(initialState)
 	if err != nil {
@@ -185,13 +185,28 @@ func (s *SQLite) UpdateSeriesState(ctx context.Context, id int64, state storage.
 	return tx.Commit()
 }
 
-// UpdateSeries updates fields on a series
+// UpdateSeries updates fields on a series. The episodes and seasons of an unmonitored series lose their pending releases.
 func (s *SQLite) UpdateSeries(ctx context.Context, series model.Series, where ...sqlite.BoolExpression) error {
 	stmt := table.Series.UPDATE(table.Series.Monitored).MODEL(series)
+	seriesIDs := table.Series.SELECT(table.Series.ID).FROM(table.Series)
 	for _, w := range where {
 		stmt = stmt.WHERE(w)
+		seriesIDs = seriesIDs.WHERE(w)
 	}
 	_, err := stmt.ExecContext(ctx, s.db)
+	if err != nil || series.Monitored != 0 {
+		return err
+	}
+
+	seasons := table.Season.SELECT(table.Season.ID).FROM(table.Season).WHERE(table.Season.SeriesID.IN(seriesIDs))
+	episodes := table.Episode.SELECT(table.Episode.ID).FROM(table.Episode).WHERE(table.Episode.SeasonID.IN(seasons))
+	return clearPendingReleases(ctx, s.db, table.PendingRelease.SeasonID.IN(seasons).OR(table.PendingRelease.EpisodeID.IN(episodes)))
+}
+
+// UpdateSeriesType sets whether a series uses standard, anime or daily numbering
+func (s *SQLite) UpdateSeriesType(ctx context.Context, seriesID int64, seriesType string) error {
+	stmt := table.Series.UPDATE(table.Series.SeriesType).SET(seriesType).WHERE(table.Series.ID.EQ(sqlite.Int64(seriesID)))
+	_, err := stmt.ExecContext(ctx, s.db)
 	return err
 }
 
@@ -227,3 +227,29 @@ func TestSQLite_UpdateSeriesState(t *testing.T) {
 		assert.Equal(t, storage.SeriesStateDownloading, foundSeries.State)
 	})
 }
+
+func TestSQLite_UpdateSeriesType(t *testing.T) {
+	ctx := context.Background()
+	store := initSqlite(t, ctx)
+	require.NotNil(t, store)
+
+	seriesID, err := store.CreateSeries(ctx, storage.Series{
+		Series: model.Series{
+			Monitored:        1,
+			QualityProfileID: 1,
+		},
+	}, storage.SeriesStateMissing)
+	require.NoError(t, err)
+
+	series, err := store.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int64(seriesID)))
+	require.NoError(t, err)
+	assert.Equal(t, storage.SeriesTypeStandard, series.SeriesType)
+
+	err = store.UpdateSeriesType(ctx, seriesID, storage.SeriesTypeAnime)
+	require.NoError(t, err)
+
+	series, err = store.GetSeries(ctx, table.Series.ID.EQ(sqlite.Int64(seriesID)))
+	require.NoError(t, err)
+	assert.Equal(t, storage.SeriesTypeAnime, series.SeriesType)
+	assert.Equal(t, int32(1), series.Monitored)
+}
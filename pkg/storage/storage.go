@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -33,6 +34,9 @@ type Storage interface {
 	SeriesMetadataStorage
 	StatisticsStorage
 	ActivityStorage
+	BlocklistStorage
+	PendingReleaseStorage
+	ExtraFileStorage
 }
 
 type IndexerStorage interface {
@@ -52,6 +56,9 @@ type IndexerSourceStorage interface {
 	// indexers in a single transaction. If any operation fails the transaction
 	// is rolled back and no rows are removed.
 	DeleteIndexerSourceCascade(ctx context.Context, id int64) error
+	ListIndexerCategories(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.IndexerCategory, error)
+	// UpsertIndexerCategories stores the categories searched for an indexer, replacing any existing ones
+	UpsertIndexerCategories(ctx context.Context, categories model.IndexerCategory) error
 }
 
 type QualityStorage interface {
@@ -73,6 +80,15 @@ type QualityStorage interface {
 	ListQualityDefinitions(ctx context.Context) ([]*model.QualityDefinition, error)
 	UpdateQualityDefinition(ctx context.Context, id int64, definition model.QualityDefinition) error
 	DeleteQualityDefinition(ctx context.Context, id int64) error
+
+	CreateCustomFormat(ctx context.Context, format CustomFormat) (int64, error)
+	GetCustomFormat(ctx context.Context, id int64) (CustomFormat, error)
+	ListCustomFormats(ctx context.Context, where ...sqlite.BoolExpression) ([]*CustomFormat, error)
+	UpdateCustomFormat(ctx context.Context, id int64, format CustomFormat) error
+	// DeleteCustomFormat deletes a custom format and removes it from every quality profile
+	DeleteCustomFormat(ctx context.Context, id int64) error
+	// SetQualityProfileFormats replaces the custom format scores of a quality profile
+	SetQualityProfileFormats(ctx context.Context, profileID int64, formats []model.QualityProfileFormat) error
 }
 
 type MovieState string
@@ -90,13 +106,30 @@ type TransitionStateMetadata struct {
 	DownloadID             *string
 	DownloadClientID       *int32
 	IsEntireSeasonDownload *bool // applicable only to episodes
+	ReleaseGUID            *string
+	ReleaseTitle           *string
+	ReleaseInfoHash        *string
+	IsUpgrade              *bool   // the download replaces a file already in the library
+	UpgradeReason          *string // why an upgrade was grabbed, see UpgradeReasonCutoff and UpgradeReasonProper
+	ReplacedFile           *string // the library path of the file an upgrade replaced
 }
 
+const (
+	// UpgradeReasonCutoff is a release of a better quality for a file below the quality profile cutoff
+	UpgradeReasonCutoff = "cutoff"
+	// UpgradeReasonProper is a PROPER or REPACK of the quality the file already has
+	UpgradeReasonProper = "proper"
+)
+
 type Movie struct {
 	model.Movie
 	State            MovieState `alias:"movie_transition.to_state" json:"state"`
 	DownloadID       string     `alias:"movie_transition.download_id" json:"-"`
 	DownloadClientID int32      `alias:"movie_transition.download_client_id" json:"-"`
+	ReleaseGUID      *string    `alias:"movie_transition.release_guid" json:"-"`
+	ReleaseTitle     *string    `alias:"movie_transition.release_title" json:"-"`
+	ReleaseInfoHash  *string    `alias:"movie_transition.release_info_hash" json:"-"`
+	IsUpgrade        bool       `alias:"movie_transition.is_upgrade" json:"-"`
 }
 
 type MovieTransition model.MovieTransition
@@ -105,8 +138,9 @@ func (m Movie) Machine() *machine.StateMachine[MovieState] {
 	return machine.New(m.State,
 		machine.From(MovieStateNew).To(MovieStateUnreleased, MovieStateMissing, MovieStateDiscovered),
 		machine.From(MovieStateMissing).To(MovieStateDiscovered, MovieStateDownloading, MovieStateDownloaded),
-		machine.From(MovieStateUnreleased).To(MovieStateDiscovered, MovieStateMissing),
-		machine.From(MovieStateDownloading).To(MovieStateDownloaded),
+		machine.From(MovieStateUnreleased).To(MovieStateDiscovered, MovieStateMissing, MovieStateDownloaded),
+		machine.From(MovieStateDownloading).To(MovieStateDownloaded, MovieStateMissing),
+		machine.From(MovieStateDownloaded).To(MovieStateDownloading),
 	)
 }
 
@@ -126,6 +160,8 @@ type MovieStorage interface {
 
 	GetMovieFilesByMovieName(ctx context.Context, name string) ([]*model.MovieFile, error)
 	CreateMovieFile(ctx context.Context, movieFile model.MovieFile) (int64, error)
+	UpdateMovieFileQuality(ctx context.Context, id int64, quality string) error
+	UpdateMovieFilePaths(ctx context.Context, movieID int64, path string, relativePaths, extraPaths map[int32]string) error
 	DeleteMovieFile(ctx context.Context, id int64) error
 	ListMovieFiles(ctx context.Context) ([]*model.MovieFile, error)
 	LinkMovieMetadata(ctx context.Context, movieID int64, metadataID int32) error
@@ -191,6 +227,124 @@ type QualityProfile struct {
 	ID              int32               `sql:"primary_key" json:"id"`
 	CutoffQualityID *int32              `alias:"cutoff_quality_id" json:"cutoff_quality_id,omitempty"`
 	UpgradeAllowed  bool                `json:"upgradeAllowed"`
+	// MinFormatScore is the lowest custom format score a release needs to be grabbed
+	MinFormatScore int32 `json:"minFormatScore"`
+	// Formats are the custom formats scored by this profile. They're loaded separately from the qualities.
+	Formats []QualityProfileFormat `json:"formats,omitempty"`
+	// RequiredTerms rejects releases that don't contain at least one of the terms
+	RequiredTerms ReleaseTerms `json:"requiredTerms"`
+	// IgnoredTerms rejects releases that contain any of the terms
+	IgnoredTerms ReleaseTerms `json:"ignoredTerms"`
+	// PreferredTerms add their score to releases that contain them
+	PreferredTerms PreferredTerms `json:"preferredTerms"`
+	// Languages are the audio languages wanted. Releases tagged with other languages are ranked below the rest.
+	Languages ProfileLanguages `json:"languages"`
+	// RequireLanguage rejects releases tagged with other languages instead
+	RequireLanguage bool `json:"requireLanguage"`
+	// Editions are the movie editions wanted, best first. Releases of other editions are ranked below the rest and an
+	// empty list accepts any edition.
+	Editions ProfileEditions `json:"editions"`
+	// RequireEdition rejects releases of other editions instead
+	RequireEdition bool `json:"requireEdition"`
+}
+
+// OriginalLanguage in a profile's languages stands for the original language of the movie or series
+const OriginalLanguage = "original"
+
+// JSONList is a list stored as a JSON array column
+type JSONList[T any] []T
+
+// Scan implements sql.Scanner. An empty list scans to nil.
+func (l *JSONList[T]) Scan(src any) error {
+	if err := scanJSON(src, (*[]T)(l)); err != nil {
+		return err
+	}
+	if len(*l) == 0 {
+		*l = nil
+	}
+	return nil
+}
+
+// MarshalJSON encodes nil as an empty list
+func (l JSONList[T]) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]T(l))
+}
+
+// ProfileLanguages are ISO 639-1 codes or OriginalLanguage
+type ProfileLanguages = JSONList[string]
+
+// TheatricalEdition is the edition of releases that aren't tagged with one
+const TheatricalEdition = "Theatrical"
+
+// ProfileEditions are edition names like "Extended" or "Director's Cut"
+type ProfileEditions = JSONList[string]
+
+// ReleaseTerms are matched case insensitively against release titles. A term wrapped in slashes, like `/\b3d\b/`,
+// is a regular expression, anything else is a plain word.
+type ReleaseTerms = JSONList[string]
+
+// PreferredTerm is a release term and the score releases containing it get
+type PreferredTerm struct {
+	Term  string `json:"term" validate:"required"`
+	Score int32  `json:"score"`
+}
+
+// PreferredTerms are the profile's preferred terms and their scores
+type PreferredTerms = JSONList[PreferredTerm]
+
+func scanJSON(src any, dest any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported type %T for JSON column", src)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+// QualityProfileFormat is the score a quality profile gives releases matching a custom format
+type QualityProfileFormat struct {
+	Format CustomFormat `json:"format"`
+	Score  int32        `json:"score"`
+}
+
+const (
+	CustomFormatSpecTitle        = "title"
+	CustomFormatSpecReleaseGroup = "releaseGroup"
+	CustomFormatSpecSource       = "source"
+	CustomFormatSpecResolution   = "resolution"
+	CustomFormatSpecCodec        = "codec"
+	CustomFormatSpecEdition      = "edition"
+	CustomFormatSpecLanguage     = "language"
+)
+
+// CustomFormatSpecification is a single condition on a parsed release. Value is a case insensitive regex
+// matched against the release attribute named by Type.
+type CustomFormatSpecification struct {
+	Type     string `json:"type" validate:"required,oneof=title releaseGroup source resolution codec edition language"`
+	Value    string `json:"value" validate:"required"`
+	Negate   bool   `json:"negate"`
+	Required bool   `json:"required"`
+}
+
+// CustomFormat is a named set of conditions used to score releases
+type CustomFormat struct {
+	ID             int32                       `json:"id"`
+	Name           string                      `json:"name"`
+	Specifications []CustomFormatSpecification `json:"specifications"`
 }
 
 type QualityDefinition struct {
@@ -200,6 +354,9 @@ type QualityDefinition struct {
 	PreferredSize float64 `alias:"quality_definition.preferred_size" json:"preferredSize"`
 	MinSize       float64 `alias:"quality_definition.min_size" json:"minSize"`
 	MaxSize       float64 `alias:"quality_definition.max_size" json:"maxSize"`
+	// Resolution and Source identify the releases the definition describes, empty matches any
+	Resolution string `alias:"quality_definition.resolution" json:"resolution"`
+	Source     string `alias:"quality_definition.source" json:"source"`
 }
 
 type (
@@ -234,6 +391,15 @@ const (
 	EpisodeStateCompleted   EpisodeState = "completed"
 )
 
+const (
+	// SeriesTypeStandard series are numbered by season and episode
+	SeriesTypeStandard = "standard"
+	// SeriesTypeAnime series are released with absolute episode numbers
+	SeriesTypeAnime = "anime"
+	// SeriesTypeDaily series are released by air date, like talk shows and news
+	SeriesTypeDaily = "daily"
+)
+
 type Series struct {
 	model.Series
 	State SeriesState `alias:"series_transition.to_state" json:"state"`
@@ -268,7 +434,7 @@ func (s Season) Machine() *machine.StateMachine[SeasonState] {
 		machine.From(SeasonStateDiscovered).To(SeasonStateMissing, SeasonStateContinuing, SeasonStateCompleted),
 		machine.From(SeasonStateMissing).To(SeasonStateDiscovered, SeasonStateDownloading),
 		machine.From(SeasonStateUnreleased).To(SeasonStateDiscovered, SeasonStateMissing),
-		machine.From(SeasonStateDownloading).To(SeasonStateContinuing, SeasonStateCompleted),
+		machine.From(SeasonStateDownloading).To(SeasonStateContinuing, SeasonStateCompleted, SeasonStateMissing),
 		machine.From(SeasonStateContinuing).To(SeasonStateCompleted, SeasonStateMissing),
 		machine.From(SeasonStateCompleted).To(SeasonStateContinuing),
 	)
@@ -280,6 +446,10 @@ type Episode struct {
 	DownloadID             string       `alias:"episode_transition.download_id" json:"-"`
 	DownloadClientID       int32        `alias:"episode_transition.download_client_id" json:"-"`
 	IsEntireSeasonDownload bool         `alias:"episode_transition.is_entire_season_download" json:"-"`
+	ReleaseGUID            *string      `alias:"episode_transition.release_guid" json:"-"`
+	ReleaseTitle           *string      `alias:"episode_transition.release_title" json:"-"`
+	ReleaseInfoHash        *string      `alias:"episode_transition.release_info_hash" json:"-"`
+	IsUpgrade              bool         `alias:"episode_transition.is_upgrade" json:"-"`
 }
 
 type EpisodeTransition model.EpisodeTransition
@@ -288,10 +458,11 @@ func (e Episode) Machine() *machine.StateMachine[EpisodeState] {
 	return machine.New(e.State,
 		machine.From(EpisodeStateNew).To(EpisodeStateUnreleased, EpisodeStateMissing, EpisodeStateDiscovered),
 		machine.From(EpisodeStateDiscovered).To(EpisodeStateCompleted),
-		machine.From(EpisodeStateMissing).To(EpisodeStateDiscovered, EpisodeStateDownloading, EpisodeStateUnreleased),
-		machine.From(EpisodeStateUnreleased).To(EpisodeStateDiscovered, EpisodeStateMissing),
-		machine.From(EpisodeStateDownloading).To(EpisodeStateDownloaded),
-		machine.From(EpisodeStateDownloaded).To(EpisodeStateCompleted),
+		machine.From(EpisodeStateMissing).To(EpisodeStateDiscovered, EpisodeStateDownloading, EpisodeStateUnreleased, EpisodeStateDownloaded),
+		machine.From(EpisodeStateUnreleased).To(EpisodeStateDiscovered, EpisodeStateMissing, EpisodeStateDownloaded),
+		machine.From(EpisodeStateDownloading).To(EpisodeStateDownloaded, EpisodeStateMissing),
+		machine.From(EpisodeStateDownloaded).To(EpisodeStateCompleted, EpisodeStateDownloading),
+		machine.From(EpisodeStateCompleted).To(EpisodeStateDownloading),
 	)
 }
 
@@ -303,6 +474,7 @@ type SeriesStorage interface {
 	UpdateSeriesState(ctx context.Context, id int64, state SeriesState, metadata *TransitionStateMetadata) error
 	LinkSeriesMetadata(ctx context.Context, seriesID int64, metadataID int32) error
 	UpdateSeries(ctx context.Context, series model.Series, where ...sqlite.BoolExpression) error
+	UpdateSeriesType(ctx context.Context, seriesID int64, seriesType string) error
 
 	GetSeason(ctx context.Context, where sqlite.BoolExpression) (*Season, error)
 	CreateSeason(ctx context.Context, season Season, initialState SeasonState) (int64, error)
@@ -324,6 +496,7 @@ type SeriesStorage interface {
 	GetEpisodeFileByID(ctx context.Context, id int64) ([]*model.EpisodeFile, error)
 	CreateEpisodeFile(ctx context.Context, episodeFile model.EpisodeFile) (int64, error)
 	UpdateEpisodeFile(ctx context.Context, id int32, episodeFile model.EpisodeFile) error
+	UpdateSeriesFilePaths(ctx context.Context, seriesID int64, path string, relativePaths, extraPaths map[int32]string) error
 	DeleteEpisodeFile(ctx context.Context, id int64) error
 	ListEpisodeFiles(ctx context.Context) ([]*model.EpisodeFile, error)
 }
@@ -346,6 +519,25 @@ type SeriesMetadataStorage interface {
 	GetEpisodeMetadata(ctx context.Context, where sqlite.BoolExpression) (*model.EpisodeMetadata, error)
 }
 
+type BlocklistStorage interface {
+	CreateBlocklistEntry(ctx context.Context, entry model.ReleaseBlocklist) (int64, error)
+	GetBlocklistEntry(ctx context.Context, id int64) (*model.ReleaseBlocklist, error)
+	ListBlocklistEntries(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ReleaseBlocklist, error)
+	DeleteBlocklistEntry(ctx context.Context, id int64) error
+}
+
+type PendingReleaseStorage interface {
+	CreatePendingRelease(ctx context.Context, release model.PendingRelease) (int64, error)
+	ListPendingReleases(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.PendingRelease, error)
+	DeletePendingReleases(ctx context.Context, where sqlite.BoolExpression) error
+}
+
+type ExtraFileStorage interface {
+	CreateExtraFile(ctx context.Context, file model.ExtraFile) (int64, error)
+	ListExtraFiles(ctx context.Context, where ...sqlite.BoolExpression) ([]*model.ExtraFile, error)
+	DeleteExtraFiles(ctx context.Context, where sqlite.BoolExpression) error
+}
+
 func ReadSchemaFiles(files ...string) ([]string, error) {
 	var schemas []string
 	for _, f := range files {
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kasuboski/mediaz/pkg/manager"
+)
+
+// ListBlocklist lists blocklisted releases, optionally filtered by movieId or episodeId
+func (s Server) ListBlocklist() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		movieID, err := parseOptionalQueryInt32(r, "movieId")
+		if err != nil {
+			s.respondError(r, w, http.StatusBadRequest, err)
+			return
+		}
+
+		episodeID, err := parseOptionalQueryInt32(r, "episodeId")
+		if err != nil {
+			s.respondError(r, w, http.StatusBadRequest, err)
+			return
+		}
+
+		entries, err := s.manager.ListBlocklist(r.Context(), movieID, episodeID)
+		if err != nil {
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, entries)
+	}
+}
+
+// CreateBlocklistEntry manually blocklists a release
+func (s Server) CreateBlocklistEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req manager.AddBlocklistEntryRequest
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		entry, err := s.manager.AddBlocklistEntry(r.Context(), req)
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusCreated, entry)
+	}
+}
+
+// DeleteBlocklistEntry removes a release from the blocklist by ID from the URL
+func (s Server) DeleteBlocklistEntry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		if err := s.manager.DeleteBlocklistEntry(r.Context(), id); err != nil {
+			if isNotFound(err) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, map[string]any{"id": id})
+	}
+}
+
+func parseOptionalQueryInt32(r *http.Request, key string) (*int32, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	val, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameter: must be integer", key)
+	}
+
+	v := int32(val)
+	return &v, nil
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/manager"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	tmdbMocks "github.com/kasuboski/mediaz/pkg/tmdb/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlocklistManager(t *testing.T) (manager.MediaManager, int32) {
+	t.Helper()
+	ctrl := gomockController(t)
+	tmdbMock := tmdbMocks.NewMockITmdb(ctrl)
+	store := newInMemoryStore(t)
+
+	movieID, err := store.CreateMovie(context.Background(), storage.Movie{
+		Movie: model.Movie{
+			Path:             ptr.To("Blocked Movie (2024)"),
+			Monitored:        1,
+			QualityProfileID: 1,
+		},
+	}, storage.MovieStateMissing)
+	require.NoError(t, err)
+
+	return manager.New(tmdbMock, nil, nil, store, nil, config.Manager{}, config.Config{}), int32(movieID)
+}
+
+func TestServer_CreateBlocklistEntry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mgr, movieID := newBlocklistManager(t)
+		s := newTestServer(withManager(mgr))
+
+		body, err := json.Marshal(manager.AddBlocklistEntryRequest{
+			MovieID: &movieID,
+			GUID:    ptr.To("guid-1"),
+			Title:   "Blocked.Movie.2024.1080p.WEB-DL-GRP",
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/blocklist", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.CreateBlocklistEntry().ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+
+		var response GenericResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		entry, ok := response.Response.(map[string]any)
+		require.True(t, ok, "Response should be a map")
+		assert.Equal(t, "Blocked.Movie.2024.1080p.WEB-DL-GRP", entry["Title"])
+		assert.Equal(t, "guid-1", entry["GUID"])
+		assert.Equal(t, manager.BlocklistReasonManual, entry["Reason"])
+		assert.Equal(t, float64(movieID), entry["MovieID"])
+	})
+
+	t.Run("missing media reference", func(t *testing.T) {
+		mgr, _ := newBlocklistManager(t)
+		s := newTestServer(withManager(mgr))
+
+		req, err := http.NewRequest("POST", "/blocklist", bytes.NewReader([]byte(`{"title":"Some.Release"}`)))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.CreateBlocklistEntry().ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "movieId or episodeId")
+	})
+
+	t.Run("missing title", func(t *testing.T) {
+		mgr, movieID := newBlocklistManager(t)
+		s := newTestServer(withManager(mgr))
+
+		body, err := json.Marshal(manager.AddBlocklistEntryRequest{MovieID: &movieID})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/blocklist", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.CreateBlocklistEntry().ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "title is required")
+	})
+}
+
+func TestServer_ListBlocklist(t *testing.T) {
+	mgr, movieID := newBlocklistManager(t)
+	ctx := context.Background()
+
+	_, err := mgr.AddBlocklistEntry(ctx, manager.AddBlocklistEntryRequest{
+		MovieID: &movieID,
+		Title:   "Blocked.Movie.2024.720p.HDTV-GRP",
+	})
+	require.NoError(t, err)
+
+	s := newTestServer(withManager(mgr))
+
+	t.Run("filtered by movie", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/blocklist?movieId="+itoa(movieID), nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ListBlocklist().ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response GenericResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		entries, ok := response.Response.([]any)
+		require.True(t, ok, "Response should be an array")
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Blocked.Movie.2024.720p.HDTV-GRP", entries[0].(map[string]any)["Title"])
+	})
+
+	t.Run("filtered by other movie", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/blocklist?movieId=999", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ListBlocklist().ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response GenericResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		entries, ok := response.Response.([]any)
+		require.True(t, ok, "Response should be an array")
+		assert.Empty(t, entries)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/blocklist?episodeId=abc", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ListBlocklist().ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "invalid episodeId")
+	})
+}
+
+func TestServer_DeleteBlocklistEntry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mgr, movieID := newBlocklistManager(t)
+		ctx := context.Background()
+
+		entry, err := mgr.AddBlocklistEntry(ctx, manager.AddBlocklistEntryRequest{
+			MovieID: &movieID,
+			Title:   "Blocked.Movie.2024.720p.HDTV-GRP",
+		})
+		require.NoError(t, err)
+
+		s := newTestServer(withManager(mgr))
+
+		req, err := http.NewRequest("DELETE", "/blocklist/"+itoa(entry.ID), nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		router.HandleFunc("/blocklist/{id}", s.DeleteBlocklistEntry()).Methods("DELETE")
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		entries, err := mgr.ListBlocklist(ctx, &movieID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mgr, _ := newBlocklistManager(t)
+		s := newTestServer(withManager(mgr))
+
+		req, err := http.NewRequest("DELETE", "/blocklist/42", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		router := mux.NewRouter()
+		router.HandleFunc("/blocklist/{id}", s.DeleteBlocklistEntry()).Methods("DELETE")
+		router.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
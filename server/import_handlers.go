@@ -0,0 +1,45 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kasuboski/mediaz/pkg/manager"
+)
+
+// ScanImport lists the video files in the directory from the query and the movie or episodes each is matched to
+func (s Server) ScanImport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		files, err := s.manager.ScanImport(r.Context(), r.URL.Query().Get("directory"))
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, files)
+	}
+}
+
+// ImportFiles imports files into the library by hand as they're matched in the request
+func (s Server) ImportFiles() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req manager.ImportRequest
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		files, err := s.manager.ImportFiles(r.Context(), req)
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, files)
+	}
+}
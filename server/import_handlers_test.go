@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ScanImport(t *testing.T) {
+	mgr := manager.New(nil, nil, nil, newInMemoryStore(t), nil, config.Manager{}, config.Config{})
+	s := newTestServer(withManager(mgr))
+
+	t.Run("lists the directory's video files", func(t *testing.T) {
+		dir := t.TempDir()
+		episode := filepath.Join(dir, "Show.S01E01.mkv")
+		require.NoError(t, os.WriteFile(episode, []byte("video"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o644))
+
+		req, err := http.NewRequest("GET", "/import?directory="+url.QueryEscape(dir), nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ScanImport().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Response []manager.ImportFile `json:"response"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		require.Len(t, response.Response, 1)
+		assert.Equal(t, episode, response.Response[0].Path)
+		assert.Empty(t, response.Response[0].MediaType)
+		assert.Equal(t, "no series in the library is named Show", response.Response[0].Error)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/import?directory="+url.QueryEscape(filepath.Join(t.TempDir(), "missing")), nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ScanImport().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestServer_ImportFiles(t *testing.T) {
+	mgr := manager.New(nil, nil, nil, newInMemoryStore(t), nil, config.Manager{}, config.Config{})
+	s := newTestServer(withManager(mgr))
+
+	t.Run("reports files that aren't matched", func(t *testing.T) {
+		body, err := json.Marshal(manager.ImportRequest{Files: []manager.ImportFile{{Path: "/import/unknown.mkv"}}})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/import", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ImportFiles().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Response []manager.ImportFile `json:"response"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Response, 1)
+		assert.Equal(t, "the file isn't matched to a movie or episode", response.Response[0].Error)
+	})
+
+	t.Run("nothing to import", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/import", bytes.NewReader([]byte("{}")))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ImportFiles().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
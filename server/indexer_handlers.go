@@ -82,3 +82,33 @@ func (s Server) UpdateIndexer() http.HandlerFunc {
 		s.respond(r, w, http.StatusOK, indexer)
 	}
 }
+
+// UpdateIndexerCategories sets the movie and tv categories searched on an indexer from an indexer source
+func (s Server) UpdateIndexerCategories() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		var req manager.UpdateIndexerCategoriesRequest
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		indexer, err := s.manager.UpdateIndexerCategories(r.Context(), int32(id), req)
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			if isNotFound(err) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, indexer)
+	}
+}
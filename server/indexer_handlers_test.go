@@ -375,3 +375,31 @@ func TestServer_UpdateIndexer(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 	})
 }
+
+func TestServer_UpdateIndexerCategories(t *testing.T) {
+	serve := func(s Server, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("PUT", "/indexers/5/categories", strings.NewReader(body))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/indexers/{id}/categories", s.UpdateIndexerCategories()).Methods("PUT")
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("error - validation error (missing categories)", func(t *testing.T) {
+		s := newTestServer(withManager(newIndexerManager(t)))
+
+		rr := serve(s, `{"sourceId":1,"movieCategories":[2000],"tvCategories":[]}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("error - indexer source not found", func(t *testing.T) {
+		s := newTestServer(withManager(newIndexerManager(t)))
+
+		rr := serve(s, `{"sourceId":1,"movieCategories":[2000],"tvCategories":[5000]}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+)
+
+// PreviewOrganizeLibrary lists where tracked library files would be moved to match the naming formats without moving them
+func (s Server) PreviewOrganizeLibrary() http.HandlerFunc {
+	return s.organizeLibrary(true)
+}
+
+// OrganizeLibrary moves tracked library files to match the naming formats
+func (s Server) OrganizeLibrary() http.HandlerFunc {
+	return s.organizeLibrary(false)
+}
+
+func (s Server) organizeLibrary(dryRun bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := s.manager.OrganizeLibrary(r.Context(), dryRun)
+		if err != nil {
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, result)
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/manager"
+	"github.com/kasuboski/mediaz/pkg/ptr"
+	"github.com/kasuboski/mediaz/pkg/storage"
+	"github.com/kasuboski/mediaz/pkg/storage/sqlite/schema/gen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_PreviewOrganizeLibrary(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryStore(t)
+
+	metadataID, err := store.CreateMovieMetadata(ctx, model.MovieMetadata{TmdbID: 1, Title: "Movie", Runtime: 100, Year: ptr.To(int32(2020))})
+	require.NoError(t, err)
+	_, err = store.CreateMovie(ctx, storage.Movie{Movie: model.Movie{
+		Path:            ptr.To("Movie"),
+		Monitored:       1,
+		MovieMetadataID: ptr.To(int32(metadataID)),
+	}}, storage.MovieStateMissing)
+	require.NoError(t, err)
+	_, err = store.CreateMovieFile(ctx, model.MovieFile{RelativePath: ptr.To("Movie/movie.mkv"), Size: 100})
+	require.NoError(t, err)
+
+	mgr := manager.New(nil, nil, nil, store, nil, config.Manager{}, config.Config{Library: config.Library{
+		MovieFolderFormat: "{Title} ({Year})",
+		MovieFileFormat:   "{Title} ({Year})",
+	}})
+	s := newTestServer(withManager(mgr))
+
+	req, err := http.NewRequest("GET", "/library/organize", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	s.PreviewOrganizeLibrary().ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Response manager.OrganizeResult `json:"response"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	assert.True(t, response.Response.DryRun)
+	require.Len(t, response.Response.Renames, 1)
+	assert.Equal(t, "Movie/movie.mkv", response.Response.Renames[0].From)
+	assert.Equal(t, "Movie (2020)/Movie (2020).mkv", response.Response.Renames[0].To)
+}
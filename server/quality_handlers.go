@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/kasuboski/mediaz/pkg/manager"
@@ -46,6 +47,10 @@ func (s Server) CreateQualityDefinition() http.HandlerFunc {
 
 		definition, err := s.manager.AddQualityDefinition(r.Context(), req)
 		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
 			s.respondError(r, w, http.StatusInternalServerError, err)
 			return
 		}
@@ -84,6 +89,10 @@ func (s Server) UpdateQualityDefinition() http.HandlerFunc {
 
 		definition, err := s.manager.UpdateQualityDefinition(r.Context(), id, req)
 		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
 			s.respondError(r, w, http.StatusInternalServerError, err)
 			return
 		}
@@ -142,6 +151,10 @@ func (s Server) CreateQualityProfile() http.HandlerFunc {
 
 		profile, err := s.manager.AddQualityProfile(r.Context(), req)
 		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
 			s.respondError(r, w, http.StatusInternalServerError, err)
 			return
 		}
@@ -163,6 +176,10 @@ func (s Server) UpdateQualityProfile() http.HandlerFunc {
 
 		profile, err := s.manager.UpdateQualityProfile(r.Context(), id, req)
 		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
 			s.respondError(r, w, http.StatusInternalServerError, err)
 			return
 		}
@@ -189,3 +206,106 @@ func (s Server) DeleteQualityProfile() http.HandlerFunc {
 		s.respond(r, w, http.StatusOK, req)
 	}
 }
+
+// ListCustomFormats lists all custom formats
+func (s Server) ListCustomFormats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		formats, err := s.manager.ListCustomFormats(r.Context())
+		if err != nil {
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, formats)
+	}
+}
+
+// GetCustomFormat gets a custom format by ID
+func (s Server) GetCustomFormat() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		format, err := s.manager.GetCustomFormat(r.Context(), id)
+		if err != nil {
+			if isNotFound(err) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, format)
+	}
+}
+
+// CreateCustomFormat creates a custom format
+func (s Server) CreateCustomFormat() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req manager.AddCustomFormatRequest
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		format, err := s.manager.AddCustomFormat(r.Context(), req)
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusCreated, format)
+	}
+}
+
+// UpdateCustomFormat replaces the name and specifications of a custom format
+func (s Server) UpdateCustomFormat() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		var req manager.UpdateCustomFormatRequest
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		format, err := s.manager.UpdateCustomFormat(r.Context(), id, req)
+		if err != nil {
+			switch {
+			case errors.Is(err, manager.ErrValidation):
+				s.respondError(r, w, http.StatusBadRequest, err)
+			case isNotFound(err):
+				s.respondError(r, w, http.StatusNotFound, err)
+			default:
+				s.respondError(r, w, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		s.respond(r, w, http.StatusOK, format)
+	}
+}
+
+// DeleteCustomFormat deletes a custom format by ID from the URL
+func (s Server) DeleteCustomFormat() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		if err := s.manager.DeleteCustomFormat(r.Context(), id); err != nil {
+			if isNotFound(err) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, map[string]any{"id": id})
+	}
+}
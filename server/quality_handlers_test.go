@@ -193,7 +193,7 @@ func TestServer_CreateQualityDefinition(t *testing.T) {
 
 		s := newTestServer(withManager(mgr))
 
-		requestBody := `{"name":"TestCustom1080p","type":"movie","preferredSize":40,"minSize":20,"maxSize":60}`
+		requestBody := `{"name":"TestCustom1080p","type":"movie","preferredSize":40,"minSize":20,"maxSize":60,"resolution":"1080p","source":"WEB-DL"}`
 		req, err := http.NewRequest("POST", "/quality/definitions", strings.NewReader(requestBody))
 		require.NoError(t, err)
 
@@ -217,6 +217,26 @@ func TestServer_CreateQualityDefinition(t *testing.T) {
 		assert.Equal(t, float64(40), def["PreferredSize"])
 		assert.Equal(t, float64(20), def["MinSize"])
 		assert.Equal(t, float64(60), def["MaxSize"])
+		assert.Equal(t, "1080p", def["Resolution"])
+		assert.Equal(t, "WEB-DL", def["Source"])
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		mgr := newQualityManager(t)
+
+		s := newTestServer(withManager(mgr))
+
+		requestBody := `{"name":"TestCustom1080p","type":"movie","preferredSize":40,"minSize":20,"maxSize":60,"source":"Betamax"}`
+		req, err := http.NewRequest("POST", "/quality/definitions", strings.NewReader(requestBody))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		handler := s.CreateQualityDefinition()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "unknown source")
 	})
 
 	t.Run("invalid request body - malformed json", func(t *testing.T) {
@@ -769,3 +789,66 @@ func TestServer_DeleteQualityProfile(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "invalid id")
 	})
 }
+
+// --- Custom format tests ---
+
+func TestServer_CustomFormats(t *testing.T) {
+	mgr := newQualityManager(t)
+	s := newTestServer(withManager(mgr))
+
+	router := mux.NewRouter()
+	router.HandleFunc("/quality/formats", s.ListCustomFormats()).Methods("GET")
+	router.HandleFunc("/quality/formats", s.CreateCustomFormat()).Methods("POST")
+	router.HandleFunc("/quality/formats/{id}", s.GetCustomFormat()).Methods("GET")
+	router.HandleFunc("/quality/formats/{id}", s.UpdateCustomFormat()).Methods("PUT")
+	router.HandleFunc("/quality/formats/{id}", s.DeleteCustomFormat()).Methods("DELETE")
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("POST", "/quality/formats", `{"name":"HDR","specifications":[{"type":"title","value":"\\bHDR\\b"}]}`)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var response GenericResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	created, ok := response.Response.(map[string]any)
+	require.True(t, ok, "Response should be a map")
+	assert.Equal(t, "HDR", created["name"])
+	id := itoa(int64(created["id"].(float64)))
+
+	rr = serve("POST", "/quality/formats", `{"name":"HDR","specifications":[{"type":"title","value":"HDR"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "duplicate name")
+
+	rr = serve("POST", "/quality/formats", `{"name":"Bad","specifications":[{"type":"bitrate","value":"x"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "unknown specification type")
+
+	rr = serve("GET", "/quality/formats", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response.Response, 1)
+
+	rr = serve("PUT", "/quality/formats/"+id, `{"name":"HDR10","specifications":[{"type":"title","value":"HDR10"}]}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serve("GET", "/quality/formats/"+id, "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "HDR10", response.Response.(map[string]any)["name"])
+
+	rr = serve("PUT", "/quality/formats/9999", `{"name":"Missing","specifications":[{"type":"title","value":"x"}]}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serve("DELETE", "/quality/formats/"+id, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serve("GET", "/quality/formats/"+id, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serve("DELETE", "/quality/formats/"+id, "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
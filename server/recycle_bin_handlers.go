@@ -0,0 +1,41 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kasuboski/mediaz/pkg/manager"
+)
+
+// ListRecycleBin lists the files in the recycle bin
+func (s Server) ListRecycleBin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		files, err := s.manager.ListRecycleBin(r.Context())
+		if err != nil {
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, files)
+	}
+}
+
+// RestoreRecycledFiles moves files from the recycle bin back into their library
+func (s Server) RestoreRecycledFiles() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req manager.RestoreRecycledFilesRequest
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		files, err := s.manager.RestoreRecycledFiles(r.Context(), req)
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, files)
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RecycleBin(t *testing.T) {
+	movieDir := t.TempDir()
+	binDir := t.TempDir()
+	recycled := filepath.Join(binDir, "movies", "20240101T000000Z", "Movie", "Movie.mkv")
+	require.NoError(t, os.MkdirAll(filepath.Dir(recycled), os.ModePerm))
+	require.NoError(t, os.WriteFile(recycled, []byte("movie"), 0o644))
+
+	lib := library.New(library.FileSystem{FS: os.DirFS(movieDir), Path: movieDir}, library.FileSystem{}, &io.MediaFileSystem{}, false)
+	mgr := manager.New(nil, nil, lib, newInMemoryStore(t), nil, config.Manager{}, config.Config{Library: config.Library{RecycleBin: binDir}})
+	s := newTestServer(withManager(mgr))
+
+	t.Run("lists the recycle bin", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/recyclebin", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.ListRecycleBin().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Response []library.RecycledFile `json:"response"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		require.Len(t, response.Response, 1)
+		assert.Equal(t, "movies/20240101T000000Z/Movie/Movie.mkv", response.Response[0].ID)
+		assert.Equal(t, "Movie/Movie.mkv", response.Response[0].Path)
+	})
+
+	t.Run("restores files", func(t *testing.T) {
+		body, err := json.Marshal(manager.RestoreRecycledFilesRequest{IDs: []string{"movies/20240101T000000Z/Movie/Movie.mkv", "movies/invalid"}})
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/recyclebin/restore", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.RestoreRecycledFiles().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Response []manager.RestoredFile `json:"response"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		require.Len(t, response.Response, 2)
+		assert.Equal(t, "Movie/Movie.mkv", response.Response[0].Path)
+		assert.Empty(t, response.Response[0].Error)
+		assert.NotEmpty(t, response.Response[1].Error)
+		assert.FileExists(t, filepath.Join(movieDir, "Movie", "Movie.mkv"))
+	})
+
+	t.Run("restore without ids", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/recyclebin/restore", bytes.NewReader([]byte(`{}`)))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		s.RestoreRecycledFiles().ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
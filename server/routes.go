@@ -18,6 +18,7 @@ func (s *Server) registerRoutes(rtr *mux.Router) {
 	v1.HandleFunc("/library/movies/{id}/monitored", s.UpdateMovieMonitored()).Methods("PATCH")
 	v1.HandleFunc("/library/movies/{id}/quality", s.UpdateMovieQualityProfile()).Methods("PATCH")
 	v1.HandleFunc("/library/movies/{id}/search", s.SearchForMovie()).Methods("POST")
+	v1.HandleFunc("/library/movies/{id}/releases", s.ListMovieReleases()).Methods("GET")
 
 	// Movie details
 	v1.HandleFunc("/movie/{tmdbID}", s.GetMovieDetailByTMDBID()).Methods("GET")
@@ -30,9 +31,11 @@ func (s *Server) registerRoutes(rtr *mux.Router) {
 	v1.HandleFunc("/library/tv", s.AddSeriesToLibrary()).Methods("POST")
 	v1.HandleFunc("/library/tv/{id}", s.DeleteSeriesFromLibrary()).Methods("DELETE")
 	v1.HandleFunc("/library/tv/{id}/monitored", s.UpdateSeriesMonitored()).Methods("PATCH")
+	v1.HandleFunc("/library/tv/{id}/type", s.UpdateSeriesType()).Methods("PATCH")
 	v1.HandleFunc("/library/tv/{id}/search", s.SearchForSeries()).Methods("POST")
 	v1.HandleFunc("/season/{id}/search", s.SearchForSeason()).Methods("POST")
 	v1.HandleFunc("/episode/{id}/search", s.SearchForEpisode()).Methods("POST")
+	v1.HandleFunc("/episode/{id}/releases", s.ListEpisodeReleases()).Methods("GET")
 
 	// Refresh
 	v1.HandleFunc("/tv/refresh", s.RefreshSeriesMetadata()).Methods("POST")
@@ -46,6 +49,7 @@ func (s *Server) registerRoutes(rtr *mux.Router) {
 	v1.HandleFunc("/indexers", s.ListIndexers()).Methods("GET")
 	v1.HandleFunc("/indexers", s.CreateIndexer()).Methods("POST")
 	v1.HandleFunc("/indexers/{id}", s.UpdateIndexer()).Methods("PUT")
+	v1.HandleFunc("/indexers/{id}/categories", s.UpdateIndexerCategories()).Methods("PUT")
 	v1.HandleFunc("/indexers/{id}", s.DeleteIndexer()).Methods("DELETE")
 
 	// Indexer sources
@@ -79,9 +83,34 @@ func (s *Server) registerRoutes(rtr *mux.Router) {
 	v1.HandleFunc("/quality/profiles/{id}", s.UpdateQualityProfile()).Methods("PUT")
 	v1.HandleFunc("/quality/profiles/{id}", s.DeleteQualityProfile()).Methods("DELETE")
 
+	// Custom formats
+	v1.HandleFunc("/quality/formats", s.ListCustomFormats()).Methods("GET")
+	v1.HandleFunc("/quality/formats/{id}", s.GetCustomFormat()).Methods("GET")
+	v1.HandleFunc("/quality/formats", s.CreateCustomFormat()).Methods("POST")
+	v1.HandleFunc("/quality/formats/{id}", s.UpdateCustomFormat()).Methods("PUT")
+	v1.HandleFunc("/quality/formats/{id}", s.DeleteCustomFormat()).Methods("DELETE")
+
+	// Release blocklist
+	v1.HandleFunc("/blocklist", s.ListBlocklist()).Methods("GET")
+	v1.HandleFunc("/blocklist", s.CreateBlocklistEntry()).Methods("POST")
+	v1.HandleFunc("/blocklist/{id}", s.DeleteBlocklistEntry()).Methods("DELETE")
+
 	// Config & stats
 	v1.HandleFunc("/config", s.GetConfig()).Methods("GET")
 	v1.HandleFunc("/library/stats", s.GetLibraryStats()).Methods("GET")
+	v1.HandleFunc("/system/disk", s.GetDiskSpace()).Methods("GET")
+
+	// Library organize
+	v1.HandleFunc("/library/organize", s.PreviewOrganizeLibrary()).Methods("GET")
+	v1.HandleFunc("/library/organize", s.OrganizeLibrary()).Methods("POST")
+
+	// Manual import
+	v1.HandleFunc("/import", s.ScanImport()).Methods("GET")
+	v1.HandleFunc("/import", s.ImportFiles()).Methods("POST")
+
+	// Recycle bin
+	v1.HandleFunc("/recyclebin", s.ListRecycleBin()).Methods("GET")
+	v1.HandleFunc("/recyclebin/restore", s.RestoreRecycledFiles()).Methods("POST")
 
 	// Jobs
 	v1.HandleFunc("/jobs", s.ListJobs()).Methods("GET")
@@ -116,3 +116,47 @@ func (s Server) SearchForEpisode() http.HandlerFunc {
 		w.WriteHeader(http.StatusAccepted)
 	}
 }
+
+// ListMovieReleases searches the indexers for a movie and lists the releases that could be grabbed with their scores,
+// and the rejected ones with why with ?rejected=true
+func (s Server) ListMovieReleases() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		releases, err := s.manager.ListMovieReleases(r.Context(), id, r.URL.Query().Get("rejected") == "true")
+		if err != nil {
+			if isNotFound(err) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, releases)
+	}
+}
+
+// ListEpisodeReleases searches the indexers for an episode and lists the releases that could be grabbed with their
+// scores, and the rejected ones with why with ?rejected=true
+func (s Server) ListEpisodeReleases() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		releases, err := s.manager.ListEpisodeReleases(r.Context(), id, r.URL.Query().Get("rejected") == "true")
+		if err != nil {
+			if isNotFound(err) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, releases)
+	}
+}
@@ -267,3 +267,81 @@ func TestServer_SearchForEpisode(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	})
 }
+
+func TestServer_ListMovieReleases(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		s := newTestServer()
+
+		req, err := http.NewRequest("GET", "/library/movies/invalid/releases", nil)
+		require.NoError(t, err)
+
+		req = mux.SetURLVars(req, map[string]string{"id": "invalid"})
+
+		rr := httptest.NewRecorder()
+		handler := s.ListMovieReleases()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("movie not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := storeMocks.NewMockStorage(ctrl)
+		store.EXPECT().GetMovie(gomock.Any(), int64(1)).Return(nil, storage.ErrNotFound)
+
+		mgr := manager.New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		s := newTestServer(withManager(mgr))
+
+		req, err := http.NewRequest("GET", "/library/movies/1/releases", nil)
+		require.NoError(t, err)
+
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+		handler := s.ListMovieReleases()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestServer_ListEpisodeReleases(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		s := newTestServer()
+
+		req, err := http.NewRequest("GET", "/episode/invalid/releases", nil)
+		require.NoError(t, err)
+
+		req = mux.SetURLVars(req, map[string]string{"id": "invalid"})
+
+		rr := httptest.NewRecorder()
+		handler := s.ListEpisodeReleases()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("episode not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := storeMocks.NewMockStorage(ctrl)
+		store.EXPECT().GetEpisode(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+
+		mgr := manager.New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		s := newTestServer(withManager(mgr))
+
+		req, err := http.NewRequest("GET", "/episode/1/releases", nil)
+		require.NoError(t, err)
+
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		rr := httptest.NewRecorder()
+		handler := s.ListEpisodeReleases()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
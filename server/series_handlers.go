@@ -115,6 +115,38 @@ func (s Server) UpdateSeriesMonitored() http.HandlerFunc {
 	}
 }
 
+// UpdateSeriesType sets whether a series is standard, anime or daily
+func (s Server) UpdateSeriesType() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.parseURLInt64(w, r, "id")
+		if !ok {
+			return
+		}
+
+		var req struct {
+			SeriesType string `json:"seriesType" validate:"required"`
+		}
+		if !s.decodeJSON(w, r, &req) {
+			return
+		}
+
+		series, err := s.manager.UpdateSeriesType(r.Context(), id, req.SeriesType)
+		if err != nil {
+			if errors.Is(err, manager.ErrValidation) {
+				s.respondError(r, w, http.StatusBadRequest, err)
+				return
+			}
+			if errors.Is(err, storage.ErrNotFound) {
+				s.respondError(r, w, http.StatusNotFound, err)
+				return
+			}
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, series)
+	}
+}
+
 // RefreshSeriesMetadata refreshes metadata for the given TMDB IDs
 func (s Server) RefreshSeriesMetadata() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
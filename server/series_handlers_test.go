@@ -305,3 +305,67 @@ func TestServer_GetTVDetailByTMDBID(t *testing.T) {
 		assert.Contains(t, responseBody, "null") // response should be null when there's an error
 	})
 }
+
+func TestServer_UpdateSeriesType(t *testing.T) {
+	newRouter := func(s Server) *mux.Router {
+		router := mux.NewRouter()
+		router.HandleFunc("/library/tv/{id}/type", s.UpdateSeriesType()).Methods("PATCH")
+		return router
+	}
+
+	t.Run("updates series type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := storeMocks.NewMockStorage(ctrl)
+
+		store.EXPECT().UpdateSeriesType(gomock.Any(), int64(1), storage.SeriesTypeAnime).Return(nil)
+		store.EXPECT().GetSeries(gomock.Any(), gomock.Any()).Return(&storage.Series{
+			Series: model.Series{ID: 1, SeriesType: storage.SeriesTypeAnime},
+		}, nil)
+
+		mgr := manager.New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		s := newTestServer(withManager(mgr))
+
+		req := httptest.NewRequest(http.MethodPatch, "/library/tv/1/type", strings.NewReader(`{"seriesType":"anime"}`))
+		rr := httptest.NewRecorder()
+		newRouter(s).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response GenericResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		series, ok := response.Response.(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, storage.SeriesTypeAnime, series["SeriesType"])
+	})
+
+	t.Run("rejects unknown series type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := storeMocks.NewMockStorage(ctrl)
+
+		mgr := manager.New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		s := newTestServer(withManager(mgr))
+
+		req := httptest.NewRequest(http.MethodPatch, "/library/tv/1/type", strings.NewReader(`{"seriesType":"weekly"}`))
+		rr := httptest.NewRecorder()
+		newRouter(s).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("returns not found for unknown series", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := storeMocks.NewMockStorage(ctrl)
+
+		store.EXPECT().UpdateSeriesType(gomock.Any(), int64(2), storage.SeriesTypeStandard).Return(nil)
+		store.EXPECT().GetSeries(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+
+		mgr := manager.New(nil, nil, nil, store, nil, config.Manager{}, config.Config{})
+		s := newTestServer(withManager(mgr))
+
+		req := httptest.NewRequest(http.MethodPatch, "/library/tv/2/type", strings.NewReader(`{"seriesType":"standard"}`))
+		rr := httptest.NewRecorder()
+		newRouter(s).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -42,3 +42,15 @@ func (s Server) GetLibraryStats() http.HandlerFunc {
 		s.respond(r, w, http.StatusOK, stats)
 	}
 }
+
+// GetDiskSpace returns the total and free space of the movie, TV and download directories
+func (s Server) GetDiskSpace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		spaces, err := s.manager.GetDiskSpace(r.Context())
+		if err != nil {
+			s.respondError(r, w, http.StatusInternalServerError, err)
+			return
+		}
+		s.respond(r, w, http.StatusOK, spaces)
+	}
+}
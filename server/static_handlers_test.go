@@ -4,9 +4,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/kasuboski/mediaz/config"
+	"github.com/kasuboski/mediaz/pkg/io"
+	"github.com/kasuboski/mediaz/pkg/library"
+	"github.com/kasuboski/mediaz/pkg/manager"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServer_Healthz(t *testing.T) {
@@ -32,3 +39,35 @@ func TestServer_Healthz(t *testing.T) {
 		assert.Equal(t, "ok", response.Response)
 	})
 }
+
+func TestServer_GetDiskSpace(t *testing.T) {
+	movieDir := t.TempDir()
+	lib := library.New(library.FileSystem{FS: os.DirFS(movieDir), Path: movieDir}, library.FileSystem{}, &io.MediaFileSystem{}, false)
+	mgr := manager.New(nil, nil, lib, newInMemoryStore(t), nil, config.Manager{}, config.Config{Library: config.Library{
+		MovieDir:         movieDir,
+		DownloadMountDir: filepath.Join(t.TempDir(), "missing"),
+		MinimumFreeSpace: 100,
+	}})
+	s := newTestServer(withManager(mgr))
+
+	req, err := http.NewRequest("GET", "/system/disk", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	s.GetDiskSpace().ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Response []manager.DirectoryDiskSpace `json:"response"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	require.Len(t, response.Response, 2)
+	assert.Equal(t, "movies", response.Response[0].Name)
+	assert.Positive(t, response.Response[0].Total)
+	assert.Equal(t, int64(100<<20), response.Response[0].MinimumFree)
+	assert.Empty(t, response.Response[0].Error)
+
+	assert.Equal(t, "downloads", response.Response[1].Name)
+	assert.NotEmpty(t, response.Response[1].Error)
+}